@@ -38,6 +38,12 @@ func main() {
 	configFlag := false
 	flaggy.Bool(&configFlag, "c", "config", "Print the current default config")
 
+	resolveFlag := false
+	flaggy.Bool(&resolveFlag, "r", "resolve", "Start by resolving merge conflicts, beginning with the file given, if any. Set as git's mergetool to use with 'git mergetool'")
+
+	readOnlyFlag := false
+	flaggy.Bool(&readOnlyFlag, "o", "read-only", "Browse the repo without changing anything, e.g. to look around a production checkout")
+
 	flaggy.Parse()
 
 	if versionFlag {
@@ -60,6 +66,13 @@ func main() {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+	appConfig.Resolve = resolveFlag
+	appConfig.ReadOnly = readOnlyFlag
+	if resolveFlag {
+		// when git runs us as its mergetool the positional argument is the
+		// conflicted file rather than a rebase todo
+		appConfig.ResolveFile = dump
+	}
 
 	app, err := app.NewApp(appConfig)
 
@@ -121,6 +121,15 @@ func NewApp(config config.AppConfigurer) (*App, error) {
 	if err != nil {
 		return app, err
 	}
+
+	repoContext, err := app.GitCommand.GetRepoContext()
+	if err != nil {
+		return app, err
+	}
+	if err := app.Config.SetRepoContext(repoContext); err != nil {
+		return app, err
+	}
+
 	app.Gui, err = gui.NewGui(app.Log, app.GitCommand, app.OSCommand, app.Tr, config, app.Updater)
 	if err != nil {
 		return app, err
@@ -0,0 +1,35 @@
+package commands
+
+import "strings"
+
+// When git has nothing to say about two refs it exits with status 1 and no
+// output, e.g. for two commits that aren't related, whereas it complains when
+// it can't answer at all, e.g. for a ref that doesn't exist.
+
+// IsAncestor tells us whether ancestor is in descendant's history, counting a
+// commit as being in its own history like git does
+func (c *GitCommand) IsAncestor(ancestor string, descendant string) (bool, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git merge-base --is-ancestor %s %s", c.OSCommand.Quote(ancestor), c.OSCommand.Quote(descendant))
+	if err != nil {
+		if output == "" {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetMergeBaseOf returns the best common ancestor of the two refs, i.e. where
+// they diverged, or nil if they don't have one
+func (c *GitCommand) GetMergeBaseOf(ref string, otherRef string) (*Commit, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git merge-base %s %s", c.OSCommand.Quote(ref), c.OSCommand.Quote(otherRef))
+	if err != nil {
+		if output == "" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	sha := strings.TrimSpace(output)
+	return &Commit{Sha: sha, Name: c.getCommitSubject(sha)}, nil
+}
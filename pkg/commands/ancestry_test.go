@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandIsAncestor is a function.
+func TestGitCommandIsAncestor(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func(bool, error)
+	}
+
+	expect := `git merge-base --is-ancestor "abc" "master"`
+	scenarios := []scenario{
+		{
+			"ancestor",
+			test.CreateMockCommand(t, []*test.CommandSwapper{{Expect: expect, Replace: "echo"}}),
+			func(isAncestor bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, isAncestor)
+			},
+		},
+		{
+			"not an ancestor",
+			test.CreateMockCommand(t, []*test.CommandSwapper{{Expect: expect, Replace: "false"}}),
+			func(isAncestor bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, isAncestor)
+			},
+		},
+		{
+			"unknown ref",
+			test.CreateMockCommand(t, []*test.CommandSwapper{{Expect: expect, Replace: "bash -c 'echo fatal: Not a valid commit name abc; exit 128'"}}),
+			func(isAncestor bool, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.IsAncestor("abc", "master"))
+		})
+	}
+}
+
+// TestGitCommandGetMergeBaseOf is a function.
+func TestGitCommandGetMergeBaseOf(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git merge-base "feature" "master"`, Replace: "echo abc123"},
+		{Expect: "git show -s --format=%s abc123", Replace: "echo Add login"},
+	})
+
+	commit, err := gitCmd.GetMergeBaseOf("feature", "master")
+	assert.NoError(t, err)
+	assert.EqualValues(t, &Commit{Sha: "abc123", Name: "Add login"}, commit)
+
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git merge-base "feature" "orphan"`, Replace: "false"},
+	})
+
+	commit, err = gitCmd.GetMergeBaseOf("feature", "orphan")
+	assert.NoError(t, err)
+	assert.Nil(t, commit)
+}
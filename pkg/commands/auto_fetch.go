@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"time"
+)
+
+// defaultAutoFetchInterval is how often we fetch in the background when
+// git.autoFetchInterval isn't set to something sensible
+const defaultAutoFetchInterval = 60 * time.Second
+
+// AutoFetcher fetches in the background every so often. The interval is
+// counted from the end of the last fetch, so a slow fetch never has the next
+// one piling up behind it.
+type AutoFetcher struct {
+	interval time.Duration
+	fetch    func() error
+}
+
+// NewAutoFetcher returns a fetcher that calls fetch every interval once it's
+// running
+func NewAutoFetcher(interval time.Duration, fetch func() error) *AutoFetcher {
+	return &AutoFetcher{interval: interval, fetch: fetch}
+}
+
+// Run fetches every interval until stop is closed, handing each fetch's error
+// to onFetched. It blocks, so it's meant to be run in a goroutine.
+func (f *AutoFetcher) Run(stop chan struct{}, onFetched func(error)) {
+	timer := time.NewTimer(f.interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			err := f.fetch()
+			if onFetched != nil {
+				onFetched(err)
+			}
+			timer.Reset(f.interval)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// GetAutoFetchInterval returns how often to fetch in the background, going by
+// git.autoFetchInterval, in seconds
+func (c *GitCommand) GetAutoFetchInterval() time.Duration {
+	seconds := c.Config.GetUserConfig().GetInt("git.autoFetchInterval")
+	if seconds <= 0 {
+		return defaultAutoFetchInterval
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetAutoFetchInterval is a function.
+func TestGitCommandGetAutoFetchInterval(t *testing.T) {
+	type scenario struct {
+		testName string
+		seconds  int
+		expected time.Duration
+	}
+
+	scenarios := []scenario{
+		{
+			"configured",
+			300,
+			300 * time.Second,
+		},
+		{
+			"zero falls back to the default",
+			0,
+			60 * time.Second,
+		},
+		{
+			"negative falls back to the default",
+			-5,
+			60 * time.Second,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.Config.GetUserConfig().Set("git.autoFetchInterval", s.seconds)
+			assert.EqualValues(t, s.expected, gitCmd.GetAutoFetchInterval())
+		})
+	}
+}
+
+// TestAutoFetcherRun is a function.
+func TestAutoFetcherRun(t *testing.T) {
+	mutex := sync.Mutex{}
+	fetches := 0
+	errs := []error{}
+
+	fetcher := NewAutoFetcher(time.Millisecond*10, func() error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		fetches++
+		if fetches == 2 {
+			return errors.New("could not resolve host")
+		}
+		return nil
+	})
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		fetcher.Run(stop, func(err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			errs = append(errs, err)
+		})
+		close(stopped)
+	}()
+
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return fetches >= 3
+	}, time.Second, time.Millisecond)
+
+	close(stop)
+	<-stopped
+
+	// Run has returned, so nothing's fetching anymore
+	assert.Len(t, errs, fetches)
+	assert.NoError(t, errs[0])
+	assert.EqualError(t, errs[1], "could not resolve host")
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Autosaves are like snapshots but taken automatically, every so often, of the
+// uncommitted changes only. Each is a stash commit made with `git stash create`
+// kept under refs/lazygit/autosaves/<time taken> so that they're out of the way
+// of the stash list. Untracked files aren't included.
+
+const autoSavesRefPrefix = "refs/lazygit/autosaves/"
+
+// autoSaveTimeFormat sorts the same way as the times do
+const autoSaveTimeFormat = "20060102-150405"
+
+// AutoSave is a stash commit of the uncommitted changes at the time it was taken
+type AutoSave struct {
+	Name string
+	Sha  string
+}
+
+// Ref returns the ref the autosave is kept under
+func (a *AutoSave) Ref() string {
+	return autoSavesRefPrefix + a.Name
+}
+
+// Time returns when the autosave was taken
+func (a *AutoSave) Time() time.Time {
+	t, _ := time.ParseInLocation(autoSaveTimeFormat, a.Name, time.Local)
+	return t
+}
+
+// GetAutoSaves returns the autosaves, newest first
+func (c *GitCommand) GetAutoSaves() ([]*AutoSave, error) {
+	output, err := c.OSCommand.RunCommandWithOutput(`git for-each-ref --sort=-refname --format="%%(refname)|%%(objectname)" %s`, autoSavesRefPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	autoSaves := []*AutoSave{}
+	for _, line := range utils.SplitLines(output) {
+		split := strings.SplitN(line, "|", 2)
+		if len(split) != 2 {
+			continue
+		}
+		autoSaves = append(autoSaves, &AutoSave{Name: strings.TrimPrefix(split[0], autoSavesRefPrefix), Sha: split[1]})
+	}
+	return autoSaves, nil
+}
+
+// CreateAutoSave saves the uncommitted changes unless there aren't any or
+// they haven't changed since the last autosave, returning whether it did. Only
+// the newest keep autosaves are kept.
+func (c *GitCommand) CreateAutoSave(keep int) (bool, error) {
+	autoSaves, err := c.GetAutoSaves()
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	sha, err := c.OSCommand.RunCommandWithOutput("git stash create %s", c.OSCommand.Quote("lazygit autosave "+now.Format(time.RFC3339)))
+	if err != nil {
+		return false, err
+	}
+	sha = strings.TrimSpace(sha)
+	if sha == "" {
+		return false, nil
+	}
+
+	if len(autoSaves) > 0 {
+		// each stash commit is new, so we compare what's in them instead
+		trees, err := c.OSCommand.RunCommandWithOutput("git rev-parse %s^{tree} %s^{tree}", sha, autoSaves[0].Sha)
+		if err != nil {
+			return false, err
+		}
+		if lines := utils.SplitLines(trees); len(lines) == 2 && lines[0] == lines[1] {
+			return false, nil
+		}
+	}
+
+	if err := c.OSCommand.RunCommand("git update-ref %s %s", autoSavesRefPrefix+now.Format(autoSaveTimeFormat), sha); err != nil {
+		return false, err
+	}
+
+	// the one we just made takes up a spot
+	for i := keep - 1; i >= 0 && i < len(autoSaves); i++ {
+		if err := c.OSCommand.RunCommand("git update-ref -d %s", autoSaves[i].Ref()); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// RestoreAutoSave applies the saved changes to the working tree
+func (c *GitCommand) RestoreAutoSave(autoSave *AutoSave) error {
+	return c.OSCommand.RunCommand("git stash apply %s", autoSave.Sha)
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetAutoSaves is a function.
+func TestGitCommandGetAutoSaves(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  `git for-each-ref --sort=-refname --format="%(refname)|%(objectname)" refs/lazygit/autosaves/`,
+			Replace: "echo 'refs/lazygit/autosaves/20201016-153000|bbb\nrefs/lazygit/autosaves/20201016-152000|aaa'",
+		},
+	})
+
+	autoSaves, err := gitCmd.GetAutoSaves()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*AutoSave{
+		{Name: "20201016-153000", Sha: "bbb"},
+		{Name: "20201016-152000", Sha: "aaa"},
+	}, autoSaves)
+	assert.EqualValues(t, "refs/lazygit/autosaves/20201016-153000", autoSaves[0].Ref())
+	assert.EqualValues(t, time.Date(2020, 10, 16, 15, 30, 0, 0, time.Local), autoSaves[0].Time())
+}
+
+// TestGitCommandCreateAutoSave is a function.
+func TestGitCommandCreateAutoSave(t *testing.T) {
+	type scenario struct {
+		testName      string
+		autoSaves     string
+		stashSha      string
+		trees         string
+		keep          int
+		expectCreated bool
+		expectDeleted []string
+	}
+
+	scenarios := []scenario{
+		{
+			"nothing to save",
+			"",
+			"",
+			"",
+			10,
+			false,
+			nil,
+		},
+		{
+			"first autosave",
+			"",
+			"ccc",
+			"",
+			10,
+			true,
+			nil,
+		},
+		{
+			"unchanged since the last autosave",
+			"refs/lazygit/autosaves/20201016-153000|bbb",
+			"ccc",
+			"tree1\ntree1",
+			10,
+			false,
+			nil,
+		},
+		{
+			"too many autosaves",
+			"refs/lazygit/autosaves/20201016-153000|bbb\nrefs/lazygit/autosaves/20201016-152000|aaa\nrefs/lazygit/autosaves/20201016-151000|999",
+			"ccc",
+			"tree2\ntree1",
+			2,
+			true,
+			[]string{"refs/lazygit/autosaves/20201016-152000", "refs/lazygit/autosaves/20201016-151000"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			created := ""
+			deleted := []string{}
+			gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				switch args[0] {
+				case "for-each-ref":
+					return exec.Command("echo", s.autoSaves)
+				case "stash":
+					assert.EqualValues(t, "create", args[1])
+					return exec.Command("echo", s.stashSha)
+				case "rev-parse":
+					assert.EqualValues(t, []string{s.stashSha + "^{tree}", "bbb^{tree}"}, args[1:])
+					return exec.Command("echo", s.trees)
+				case "update-ref":
+					if args[1] == "-d" {
+						deleted = append(deleted, args[2])
+					} else {
+						assert.EqualValues(t, s.stashSha, args[2])
+						created = args[1]
+					}
+					return exec.Command("echo")
+				}
+				t.Errorf("unexpected command: git %s", strings.Join(args, " "))
+				return exec.Command("false")
+			}
+
+			ok, err := gitCmd.CreateAutoSave(s.keep)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectCreated, ok)
+			if s.expectCreated {
+				assert.Regexp(t, regexp.MustCompile(`^refs/lazygit/autosaves/\d{8}-\d{6}$`), created)
+			} else {
+				assert.EqualValues(t, "", created)
+			}
+			if s.expectDeleted == nil {
+				s.expectDeleted = []string{}
+			}
+			assert.EqualValues(t, s.expectDeleted, deleted)
+		})
+	}
+}
+
+// TestGitCommandRestoreAutoSave is a function.
+func TestGitCommandRestoreAutoSave(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git stash apply bbb",
+			Replace: "echo",
+		},
+	})
+	assert.NoError(t, gitCmd.RestoreAutoSave(&AutoSave{Name: "20201016-153000", Sha: "bbb"}))
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// When backups are turned on, discarding a file's changes first copies the
+// file into a backup dir outside of the repo, so that it can be restored if
+// the discard was a mistake. Each discard gets its own dir named after the
+// time it happened, holding the discarded files at their paths in the repo.
+
+const backupTimeFormat = "20060102-150405.000000000"
+
+// Backup is a copy of a file taken before its changes were discarded
+type Backup struct {
+	FileName string // the path of the file in the repo
+	Path     string // the path of the copy
+	Time     time.Time
+}
+
+func (c *GitCommand) backupsDir() string {
+	return c.repoDataDir("backups")
+}
+
+// repoDataDir returns a dir in the config dir for keeping things that belong
+// to the current repo. Two checkouts can have the same name, so the dir is
+// keyed on the repo's absolute path as well.
+func (c *GitCommand) repoDataDir(kind string) string {
+	repoPath, err := os.Getwd()
+	if err != nil {
+		c.Log.Error(err)
+		return filepath.Join(c.Config.GetUserConfigDir(), kind, utils.GetCurrentRepoName())
+	}
+	sum := sha256.Sum256([]byte(repoPath))
+	return filepath.Join(c.Config.GetUserConfigDir(), kind, fmt.Sprintf("%s-%x", filepath.Base(repoPath), sum[:6]))
+}
+
+// BackupFile copies a file, or a directory of untracked files, from the
+// working tree into a new backup. Deleted files have nothing to back up.
+func (c *GitCommand) BackupFile(fileName string) error {
+	if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		return nil
+	}
+
+	dir := filepath.Join(c.backupsDir(), time.Now().Format(backupTimeFormat))
+	return WrapError(filepath.Walk(fileName, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return copyFile(path, filepath.Join(dir, path), info.Mode())
+	}))
+}
+
+// GetBackups returns every backup for the current repo, newest first
+func (c *GitCommand) GetBackups() ([]*Backup, error) {
+	backups := []*Backup{}
+
+	dirs, err := ioutil.ReadDir(c.backupsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return backups, nil
+		}
+		return nil, WrapError(err)
+	}
+
+	for _, dir := range dirs {
+		backupTime, err := time.Parse(backupTimeFormat, dir.Name())
+		if err != nil || !dir.IsDir() {
+			continue
+		}
+
+		root := filepath.Join(c.backupsDir(), dir.Name())
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			fileName, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			backups = append(backups, &Backup{
+				FileName: filepath.ToSlash(fileName),
+				Path:     path,
+				Time:     backupTime,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, WrapError(err)
+		}
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+
+	return backups, nil
+}
+
+// RestoreBackup puts a backed up file back into the working tree, overwriting
+// whatever is there now, and then deletes the backup
+func (c *GitCommand) RestoreBackup(backup *Backup) error {
+	if err := c.OSCommand.CheckWritable(backup.FileName); err != nil {
+		return err
+	}
+	if rel, err := filepath.Rel(c.backupsDir(), backup.Path); err != nil || strings.HasPrefix(rel, "..") {
+		return errors.New("not restoring a backup from another repo: " + backup.Path)
+	}
+	info, err := os.Stat(backup.Path)
+	if err != nil {
+		return WrapError(err)
+	}
+	if err := copyFile(backup.Path, filepath.FromSlash(backup.FileName), info.Mode()); err != nil {
+		return err
+	}
+	if err := os.Remove(backup.Path); err != nil {
+		return WrapError(err)
+	}
+
+	// tidy up the backup's dir once there's nothing left in it
+	dir := filepath.Dir(backup.Path)
+	for dir != c.backupsDir() && dir != "." {
+		if os.Remove(dir) != nil {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	return nil
+}
+
+func copyFile(src string, dest string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return WrapError(err)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return WrapError(err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return WrapError(err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
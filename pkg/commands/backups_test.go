@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandBackupAndRestore is a function.
+func TestGitCommandBackupAndRestore(t *testing.T) {
+	actual, err := os.Getwd()
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Chdir(actual))
+	}()
+
+	repoDir, err := ioutil.TempDir("", "lazygit-backup-repo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(repoDir)
+	configDir, err := ioutil.TempDir("", "lazygit-backup-config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(configDir)
+
+	assert.NoError(t, os.Chdir(repoDir))
+	assert.NoError(t, os.MkdirAll("dir", os.ModePerm))
+	assert.NoError(t, ioutil.WriteFile("file.txt", []byte("file"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join("dir", "other.txt"), []byte("other"), 0644))
+
+	appConfig := NewDummyAppConfig()
+	appConfig.UserConfigDir = configDir
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config = appConfig
+
+	backups, err := gitCmd.GetBackups()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 0)
+
+	assert.NoError(t, gitCmd.BackupFile("file.txt"))
+	assert.NoError(t, gitCmd.BackupFile("dir/"))
+	// deleted files have nothing to back up
+	assert.NoError(t, gitCmd.BackupFile("deleted.txt"))
+
+	backups, err = gitCmd.GetBackups()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 2)
+	// newest first
+	assert.Equal(t, "dir/other.txt", backups[0].FileName)
+	assert.Equal(t, "file.txt", backups[1].FileName)
+
+	assert.NoError(t, ioutil.WriteFile("file.txt", []byte("discarded"), 0644))
+	assert.NoError(t, gitCmd.RestoreBackup(backups[1]))
+
+	content, err := ioutil.ReadFile("file.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "file", string(content))
+
+	backups, err = gitCmd.GetBackups()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+
+	// another repo with the same name has backups of its own
+	otherParent, err := ioutil.TempDir("", "lazygit-backup-other")
+	assert.NoError(t, err)
+	defer os.RemoveAll(otherParent)
+	otherRepoDir := filepath.Join(otherParent, filepath.Base(repoDir))
+	assert.NoError(t, os.MkdirAll(otherRepoDir, os.ModePerm))
+	assert.NoError(t, os.Chdir(otherRepoDir))
+
+	otherBackups, err := gitCmd.GetBackups()
+	assert.NoError(t, err)
+	assert.Len(t, otherBackups, 0)
+	assert.Error(t, gitCmd.RestoreBackup(backups[0]))
+	_, err = os.Stat(filepath.Join(otherRepoDir, "dir", "other.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
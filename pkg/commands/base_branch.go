@@ -0,0 +1,26 @@
+package commands
+
+import "strings"
+
+// GetBaseBranch returns the branch that the given branch is meant to be merged
+// into: its stack parent if it's stacked, otherwise the first of the
+// configured base branches that exists. It returns an empty string if there
+// isn't one.
+func (c *GitCommand) GetBaseBranch(branchName string) string {
+	if parent := c.GetStackParents()[branchName]; parent != "" {
+		return parent
+	}
+
+	for _, candidate := range c.Config.GetUserConfig().GetStringSlice("git.baseBranches") {
+		if candidate != branchName && c.IsCommitRef(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// GetMergeBase returns the commit where the given branch and HEAD diverged
+func (c *GitCommand) GetMergeBase(branchName string) (string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git merge-base %s HEAD", c.OSCommand.Quote(branchName))
+	return strings.TrimSpace(output), err
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetBaseBranch is a function.
+func TestGitCommandGetBaseBranch(t *testing.T) {
+	type scenario struct {
+		testName string
+		branch   string
+		steps    []*test.CommandSwapper
+		expected string
+	}
+
+	stackParents := func(output string) *test.CommandSwapper {
+		return &test.CommandSwapper{
+			Expect:  `git config --local --get-regexp '^branch\..*\.lazygitstackparent$'`,
+			Replace: output,
+		}
+	}
+
+	scenarios := []scenario{
+		{
+			"stacked branch",
+			"feature",
+			[]*test.CommandSwapper{stackParents("echo 'branch.feature.lazygitstackparent parent'")},
+			"parent",
+		},
+		{
+			"falls back to the first base branch that exists",
+			"feature",
+			[]*test.CommandSwapper{
+				stackParents("false"),
+				{Expect: "git rev-parse --verify --quiet main^{commit}", Replace: "false"},
+				{Expect: "git rev-parse --verify --quiet master^{commit}", Replace: "echo abc123"},
+			},
+			"master",
+		},
+		{
+			"the base branch itself",
+			"main",
+			[]*test.CommandSwapper{
+				stackParents("false"),
+				{Expect: "git rev-parse --verify --quiet master^{commit}", Replace: "false"},
+			},
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.Config.GetUserConfig().Set("git.baseBranches", []string{"main", "master"})
+			gitCmd.OSCommand.command = test.CreateMockCommand(t, s.steps)
+			assert.EqualValues(t, s.expected, gitCmd.GetBaseBranch(s.branch))
+		})
+	}
+}
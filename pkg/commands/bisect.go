@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Bisect runs happen in a review worktree, so that git can check out commit
+// after commit without touching the user's own working tree.
+
+// StartBisect starts bisecting in the worktree between the good commit and the
+// worktree's HEAD, which is taken to be bad
+func (c *GitCommand) StartBisect(worktree *ReviewWorktree, good string) error {
+	return c.OSCommand.RunCommand("git -C %s bisect start HEAD %s", c.OSCommand.Quote(worktree.Path), c.OSCommand.Quote(good))
+}
+
+// BisectRun prepares `git bisect run`, which keeps checking out commits in the
+// worktree and running the command on them until it's found the first one the
+// command fails on
+func (c *GitCommand) BisectRun(worktree *ReviewWorktree, command string) *exec.Cmd {
+	platform := c.OSCommand.Platform
+	cmd := c.OSCommand.PrepareSubProcess("git", "bisect", "run", platform.shell, platform.shellArg, command)
+	cmd.Dir = worktree.Path
+	return cmd
+}
+
+// FirstBadCommit returns the sha of the commit a finished bisect run found
+func (c *GitCommand) FirstBadCommit(worktree *ReviewWorktree) (string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git -C %s rev-parse refs/bisect/bad", c.OSCommand.Quote(worktree.Path))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
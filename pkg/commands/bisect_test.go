@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandStartBisect is a function.
+func TestGitCommandStartBisect(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git -C /tmp/lazygit-review123 bisect start HEAD abc123",
+			Replace: "echo",
+		},
+	})
+
+	assert.NoError(t, gitCmd.StartBisect(&ReviewWorktree{Path: "/tmp/lazygit-review123"}, "abc123"))
+}
+
+// TestGitCommandBisectRun is a function.
+func TestGitCommandBisectRun(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"bisect", "run", "bash", "-c", "make test"}, args)
+		return exec.Command("echo")
+	}
+
+	cmd := gitCmd.BisectRun(&ReviewWorktree{Path: "/tmp/lazygit-review123"}, "make test")
+	assert.EqualValues(t, "/tmp/lazygit-review123", cmd.Dir)
+}
+
+// TestGitCommandFirstBadCommit is a function.
+func TestGitCommandFirstBadCommit(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func(string, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"bisect run finished",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git -C /tmp/lazygit-review123 rev-parse refs/bisect/bad",
+					Replace: "echo abc123",
+				},
+			}),
+			func(sha string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "abc123", sha)
+			},
+		},
+		{
+			"no bisect",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git -C /tmp/lazygit-review123 rev-parse refs/bisect/bad",
+					Replace: "false",
+				},
+			}),
+			func(sha string, err error) {
+				assert.Error(t, err)
+				assert.EqualValues(t, "", sha)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.FirstBadCommit(&ReviewWorktree{Path: "/tmp/lazygit-review123"}))
+		})
+	}
+}
@@ -9,4 +9,6 @@ type Branch struct {
 	Pullables    string
 	UpstreamName string
 	Head         bool
+	// StackParent is the branch this one is stacked on, if any
+	StackParent string
 }
@@ -139,6 +139,11 @@ outer:
 		branches = append([]*Branch{{Name: currentBranchName, Head: true, Recency: "  *"}}, branches...)
 	}
 
+	stackParents := b.GitCommand.GetStackParents()
+	for _, branch := range branches {
+		branch.StackParent = stackParents[branch.Name]
+	}
+
 	return branches
 }
 
@@ -4,12 +4,27 @@ package commands
 type Commit struct {
 	Sha           string
 	Name          string
-	Status        string // one of "unpushed", "pushed", "merged", "rebasing" or "selected"
+	Status        string // one of "unpushed", "pushed", "merged", "rebasing", "applying" or "selected"
 	DisplayString string
 	Action        string // one of "", "pick", "edit", "squash", "reword", "drop", "fixup"
 	Copied        bool   // to know if this commit is ready to be cherry-picked somewhere
 	Tags          []string
 	ExtraInfo     string // something like 'HEAD -> master, tag: v0.15.2'
+	Decorations   []Decoration
 	Author        string
 	Date          string
+	UnixTimestamp int64 // only set for reflog entries
+	// StepCount is how many reflog entries a collapsed group of rebase steps
+	// holds, or zero for entries that aren't a collapsed group
+	StepCount int
+	// Stat is the commit's size, once it's been loaded
+	Stat *CommitStat
+}
+
+// ShortSha returns the abbreviated sha we show in lists
+func (c *Commit) ShortSha() string {
+	if len(c.Sha) < 8 {
+		return c.Sha
+	}
+	return c.Sha[:8]
 }
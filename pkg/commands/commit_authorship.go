@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+// CommitAuthorshipFlags returns the flags for making a commit with the given
+// author and/or date rather than the user's own and the current time
+func (c *GitCommand) CommitAuthorshipFlags(author string, date string) string {
+	flags := ""
+	if author != "" {
+		flags += " --author=" + c.OSCommand.Quote(author)
+	}
+	if date != "" {
+		flags += " --date=" + c.OSCommand.Quote(date)
+	}
+	return flags
+}
+
+// GetLatestModTime returns when the most recently modified of the files was
+// last modified, e.g. for backdating a commit to when the work was done.
+// Deleted files are skipped.
+func GetLatestModTime(fileNames []string) (time.Time, error) {
+	latest := time.Time{}
+	for _, fileName := range fileNames {
+		info, err := os.Stat(fileName)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return latest, WrapError(err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// ResetCommitAuthor sets the author of the commit at the given index to the
+// user and its author date to now, as if they'd only just written it
+func (c *GitCommand) ResetCommitAuthor(commits []*Commit, index int) error {
+	return c.amendCommits(commits, []int{index}, "--reset-author")
+}
+
+// SetCommitsAuthor rewrites the commits at the given indexes to be authored by
+// the given 'Name <email>', keeping their author dates
+func (c *GitCommand) SetCommitsAuthor(commits []*Commit, indexes []int, author string) error {
+	return c.amendCommits(commits, indexes, "--author="+c.OSCommand.Quote(author))
+}
+
+// amendCommits amends each of the commits at the given indexes (with 0 the
+// newest) with the given flags and no other changes. HEAD on its own we can
+// amend directly, otherwise we rebase with an exec line after each.
+func (c *GitCommand) amendCommits(commits []*Commit, indexes []int, flags string) error {
+	command := "git commit --amend --no-edit --allow-empty --no-verify " + flags
+	amending := map[int]bool{}
+	last := 0
+	for _, index := range indexes {
+		amending[index] = true
+		if index > last {
+			last = index
+		}
+	}
+	if last == 0 {
+		return c.OSCommand.RunCommand(command)
+	}
+	if len(commits) <= last+1 {
+		return errors.New(c.Tr.SLocalize("CannotRebaseOntoFirstCommit"))
+	}
+
+	todo := ""
+	for i, commit := range commits[0 : last+1] {
+		lines := "pick " + commit.Sha + " " + commit.Name + "\n"
+		if amending[i] {
+			lines += "exec " + command + "\n"
+		}
+		todo = lines + todo
+	}
+
+	cmd, err := c.PrepareInteractiveRebaseCommand(commits[last+1].Sha, todo, true)
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
+
+// GetAuthorIdentity returns who commits are authored by by default, as
+// 'Name <email>'
+func (c *GitCommand) GetAuthorIdentity() (string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git var GIT_AUTHOR_IDENT")
+	if err != nil {
+		return "", err
+	}
+	// the identity ends with the current time e.g. '1600000000 +1000'
+	fields := strings.Fields(output)
+	if len(fields) < 2 {
+		return strings.TrimSpace(output), nil
+	}
+	return strings.Join(fields[:len(fields)-2], " "), nil
+}
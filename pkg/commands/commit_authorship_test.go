@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandCommitAuthorshipFlags is a function.
+func TestGitCommandCommitAuthorshipFlags(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	assert.EqualValues(t, "", gitCmd.CommitAuthorshipFlags("", ""))
+	assert.EqualValues(t, " --author='Jesse <jesse@example.com>' --date='2020-01-02 03:04:05'", gitCmd.CommitAuthorshipFlags("Jesse <jesse@example.com>", "2020-01-02 03:04:05"))
+}
+
+// TestGitCommandGetAuthorIdentity is a function.
+func TestGitCommandGetAuthorIdentity(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git var GIT_AUTHOR_IDENT",
+			Replace: "echo 'Jesse Duffield <jesse@example.com> 1600000000 +1000'",
+		},
+	})
+
+	identity, err := gitCmd.GetAuthorIdentity()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "Jesse Duffield <jesse@example.com>", identity)
+}
+
+// TestGetLatestModTime is a function.
+func TestGetLatestModTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-mtime")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	older := filepath.Join(dir, "older")
+	newer := filepath.Join(dir, "newer")
+	expected := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+	for path, modTime := range map[string]time.Time{older: expected.Add(-time.Hour), newer: expected} {
+		assert.NoError(t, ioutil.WriteFile(path, []byte(""), 0644))
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	latest, err := GetLatestModTime([]string{older, newer, filepath.Join(dir, "deleted")})
+	assert.NoError(t, err)
+	assert.True(t, expected.Equal(latest))
+}
+
+// TestGitCommandResetCommitAuthor is a function.
+func TestGitCommandResetCommitAuthor(t *testing.T) {
+	commits := []*Commit{
+		{Sha: "ccc", Name: "third"},
+		{Sha: "bbb", Name: "second"},
+		{Sha: "aaa", Name: "first"},
+	}
+
+	t.Run("HEAD", func(t *testing.T) {
+		gitCmd := NewDummyGitCommand()
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+			{
+				Expect:  "git commit --amend --no-edit --allow-empty --no-verify --reset-author",
+				Replace: "echo",
+			},
+		})
+		assert.NoError(t, gitCmd.ResetCommitAuthor(commits, 0))
+	})
+
+	t.Run("further down", func(t *testing.T) {
+		gitCmd := NewDummyGitCommand()
+		var cmd *exec.Cmd
+		gitCmd.OSCommand.command = func(name string, args ...string) *exec.Cmd {
+			assert.EqualValues(t, []string{"rebase", "--interactive", "--autostash", "--keep-empty", "--rebase-merges", "aaa"}, args)
+			cmd = exec.Command("echo")
+			return cmd
+		}
+		assert.NoError(t, gitCmd.ResetCommitAuthor(commits, 1))
+
+		todo := ""
+		for _, env := range cmd.Env {
+			if strings.HasPrefix(env, "LAZYGIT_REBASE_TODO=") {
+				todo = strings.TrimPrefix(env, "LAZYGIT_REBASE_TODO=")
+			}
+		}
+		assert.EqualValues(t, "pick bbb second\nexec git commit --amend --no-edit --allow-empty --no-verify --reset-author\npick ccc third\n", todo)
+	})
+
+	t.Run("root commit", func(t *testing.T) {
+		gitCmd := NewDummyGitCommand()
+		assert.Error(t, gitCmd.ResetCommitAuthor(commits, 2))
+	})
+}
+
+// TestGitCommandSetCommitsAuthor is a function.
+func TestGitCommandSetCommitsAuthor(t *testing.T) {
+	commits := []*Commit{
+		{Sha: "ddd", Name: "fourth"},
+		{Sha: "ccc", Name: "third"},
+		{Sha: "bbb", Name: "second"},
+		{Sha: "aaa", Name: "first"},
+	}
+
+	gitCmd := NewDummyGitCommand()
+	var cmd *exec.Cmd
+	gitCmd.OSCommand.command = func(name string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, []string{"rebase", "--interactive", "--autostash", "--keep-empty", "--rebase-merges", "aaa"}, args)
+		cmd = exec.Command("echo")
+		return cmd
+	}
+	assert.NoError(t, gitCmd.SetCommitsAuthor(commits, []int{0, 2}, "Jesse <jesse@example.com>"))
+
+	todo := ""
+	for _, env := range cmd.Env {
+		if strings.HasPrefix(env, "LAZYGIT_REBASE_TODO=") {
+			todo = strings.TrimPrefix(env, "LAZYGIT_REBASE_TODO=")
+		}
+	}
+	amend := "exec git commit --amend --no-edit --allow-empty --no-verify --author='Jesse <jesse@example.com>'\n"
+	assert.EqualValues(t, "pick bbb second\n"+amend+"pick ccc third\npick ddd fourth\n"+amend, todo)
+}
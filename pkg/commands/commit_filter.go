@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// CommitFilter narrows down the commits panel. It's made up of authors like
+// 'author:jesse', paths like 'path:pkg/gui', a base branch like 'ahead:main'
+// and any other words, which are looked for in the commit's message or at the
+// start of its sha, separated by spaces. Double quotes keep spaces in a term
+// e.g. 'author:"Jesse Duffield"'. A commit needs to match one of the authors,
+// if there are any, touch one of the paths, if there are any, not be on the
+// base branch, if there is one, and contain every word.
+type CommitFilter struct {
+	Authors []string
+	Paths   []string
+	Words   []string
+	// AheadOf is the base branch for only showing the commits that a pull
+	// request from the checked out branch would have
+	AheadOf string
+	// PathShas holds the commits that touch one of the paths. It's loaded by
+	// LoadCommitFilterPaths given that we need git to work it out.
+	PathShas map[string]bool
+	// AheadShas holds the commits that aren't on AheadOf. It's loaded by
+	// LoadCommitFilterAhead.
+	AheadShas map[string]bool
+}
+
+// NewCommitFilter parses a filter as typed in by the user
+func NewCommitFilter(filter string) *CommitFilter {
+	commitFilter := &CommitFilter{Authors: []string{}, Paths: []string{}, Words: []string{}}
+	for _, term := range splitFilterTerms(filter) {
+		switch {
+		case strings.HasPrefix(term, "author:"):
+			commitFilter.Authors = append(commitFilter.Authors, strings.ToLower(strings.TrimPrefix(term, "author:")))
+		case strings.HasPrefix(term, "path:"):
+			commitFilter.Paths = append(commitFilter.Paths, strings.TrimPrefix(term, "path:"))
+		case strings.HasPrefix(term, "ahead:"):
+			commitFilter.AheadOf = strings.TrimPrefix(term, "ahead:")
+		default:
+			commitFilter.Words = append(commitFilter.Words, strings.ToLower(term))
+		}
+	}
+	return commitFilter
+}
+
+// splitFilterTerms splits a filter on spaces, except for those in double
+// quotes, and drops the quotes
+func splitFilterTerms(filter string) []string {
+	terms := []string{}
+	var term strings.Builder
+	inQuotes := false
+	for _, r := range filter {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case unicode.IsSpace(r) && !inQuotes:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
+
+// CommitFilterTerm returns a term for a filter, e.g. 'author:jesse', quoting
+// the value if it has spaces in it
+func CommitFilterTerm(key string, value string) string {
+	if strings.ContainsAny(value, " \t") {
+		value = "\"" + value + "\""
+	}
+	return key + ":" + value
+}
+
+// IsEmpty tells us whether the filter lets every commit through
+func (f *CommitFilter) IsEmpty() bool {
+	return f == nil || (len(f.Authors) == 0 && len(f.Paths) == 0 && len(f.Words) == 0 && f.AheadOf == "")
+}
+
+// Matches tells us whether a commit gets through the filter
+func (f *CommitFilter) Matches(commit *Commit) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	if len(f.Authors) > 0 {
+		author := strings.ToLower(commit.Author)
+		matched := false
+		for _, filterAuthor := range f.Authors {
+			if strings.Contains(author, filterAuthor) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Paths) > 0 && !f.PathShas[commit.Sha] {
+		return false
+	}
+
+	if f.AheadOf != "" && !f.AheadShas[commit.Sha] {
+		return false
+	}
+
+	name := strings.ToLower(commit.Name)
+	for _, word := range f.Words {
+		if !strings.Contains(name, word) && !strings.HasPrefix(commit.Sha, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the commits that get through the filter, in the same order
+func (f *CommitFilter) Filter(commits []*Commit) []*Commit {
+	if f.IsEmpty() {
+		return commits
+	}
+
+	result := make([]*Commit, 0, len(commits))
+	for _, commit := range commits {
+		if f.Matches(commit) {
+			result = append(result, commit)
+		}
+	}
+	return result
+}
+
+// LoadCommitFilterPaths works out which commits touch the filter's paths
+func (c *GitCommand) LoadCommitFilterPaths(filter *CommitFilter) error {
+	if filter.IsEmpty() || len(filter.Paths) == 0 {
+		return nil
+	}
+
+	output, err := c.OSCommand.RunCommandWithOutput("git log --format=%%H -- %s", c.quoteFileNames(filter.Paths))
+	if err != nil {
+		return err
+	}
+	filter.PathShas = map[string]bool{}
+	for _, sha := range utils.SplitLines(output) {
+		filter.PathShas[sha] = true
+	}
+	return nil
+}
+
+// LoadCommitFilterAhead works out which commits aren't on the filter's base
+// branch
+func (c *GitCommand) LoadCommitFilterAhead(filter *CommitFilter) error {
+	if filter.IsEmpty() || filter.AheadOf == "" {
+		return nil
+	}
+
+	output, err := c.OSCommand.RunCommandWithOutput("git rev-list %s..HEAD", c.OSCommand.Quote(filter.AheadOf))
+	if err != nil {
+		return err
+	}
+	filter.AheadShas = map[string]bool{}
+	for _, sha := range utils.SplitLines(output) {
+		filter.AheadShas[sha] = true
+	}
+	return nil
+}
+
+// FormatPatches writes a patch file for each of the given commits into dir,
+// numbered oldest first. The commits are expected newest first, as they are in
+// the commits panel, and needn't be next to each other in history.
+func (c *GitCommand) FormatPatches(commits []*Commit, dir string) error {
+	for i := len(commits) - 1; i >= 0; i-- {
+		number := len(commits) - i
+		if err := c.OSCommand.RunCommand("git format-patch --start-number %d -o %s -1 %s", number, c.OSCommand.Quote(dir), commits[i].Sha); err != nil {
+			return err
+		}
+	}
+	return nil
+}
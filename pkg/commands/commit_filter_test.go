@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewCommitFilter is a function.
+func TestNewCommitFilter(t *testing.T) {
+	commitFilter := NewCommitFilter("author:Jesse path:pkg/gui Fix  typo")
+	assert.EqualValues(t, []string{"jesse"}, commitFilter.Authors)
+	assert.EqualValues(t, []string{"pkg/gui"}, commitFilter.Paths)
+	assert.EqualValues(t, []string{"fix", "typo"}, commitFilter.Words)
+	assert.False(t, commitFilter.IsEmpty())
+	assert.True(t, NewCommitFilter(" ").IsEmpty())
+
+	aheadFilter := NewCommitFilter("ahead:main")
+	assert.EqualValues(t, "main", aheadFilter.AheadOf)
+	assert.False(t, aheadFilter.IsEmpty())
+}
+
+// TestNewCommitFilterQuoted is a function.
+func TestNewCommitFilterQuoted(t *testing.T) {
+	commitFilter := NewCommitFilter(`author:"Jesse Duffield" path:"docs/My Notes.md" fix`)
+	assert.EqualValues(t, []string{"jesse duffield"}, commitFilter.Authors)
+	assert.EqualValues(t, []string{"docs/My Notes.md"}, commitFilter.Paths)
+	assert.EqualValues(t, []string{"fix"}, commitFilter.Words)
+}
+
+// TestCommitFilterTerm is a function.
+func TestCommitFilterTerm(t *testing.T) {
+	assert.EqualValues(t, "author:jesse", CommitFilterTerm("author", "jesse"))
+	assert.EqualValues(t, `author:"Jesse Duffield"`, CommitFilterTerm("author", "Jesse Duffield"))
+	assert.EqualValues(t, []string{"jesse duffield"}, NewCommitFilter(CommitFilterTerm("author", "Jesse Duffield")).Authors)
+}
+
+// TestCommitFilterMatches is a function.
+func TestCommitFilterMatches(t *testing.T) {
+	commit := &Commit{Sha: "abc123", Name: "Fix a typo in the README", Author: "Jesse Duffield"}
+
+	type scenario struct {
+		testName string
+		filter   *CommitFilter
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{
+			"nil filter",
+			nil,
+			true,
+		},
+		{
+			"matching author and words",
+			NewCommitFilter("author:duffield fix readme"),
+			true,
+		},
+		{
+			"other author",
+			NewCommitFilter("author:someone"),
+			false,
+		},
+		{
+			"sha prefix",
+			NewCommitFilter("abc"),
+			true,
+		},
+		{
+			"missing word",
+			NewCommitFilter("fix bug"),
+			false,
+		},
+		{
+			"path touched",
+			&CommitFilter{Paths: []string{"README.md"}, PathShas: map[string]bool{"abc123": true}},
+			true,
+		},
+		{
+			"path not touched",
+			&CommitFilter{Paths: []string{"main.go"}, PathShas: map[string]bool{}},
+			false,
+		},
+		{
+			"ahead of the base branch",
+			&CommitFilter{AheadOf: "main", AheadShas: map[string]bool{"abc123": true}},
+			true,
+		},
+		{
+			"on the base branch",
+			&CommitFilter{AheadOf: "main", AheadShas: map[string]bool{}},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.Equal(t, s.expected, s.filter.Matches(commit))
+		})
+	}
+}
+
+// TestGitCommandLoadCommitFilterPaths is a function.
+func TestGitCommandLoadCommitFilterPaths(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git log --format=%H -- "pkg/gui" "README.md"`, Replace: "echo 'abc\ndef'"},
+	})
+
+	commitFilter := NewCommitFilter("path:pkg/gui path:README.md")
+	assert.NoError(t, gitCmd.LoadCommitFilterPaths(commitFilter))
+	assert.EqualValues(t, map[string]bool{"abc": true, "def": true}, commitFilter.PathShas)
+}
+
+// TestGitCommandLoadCommitFilterAhead is a function.
+func TestGitCommandLoadCommitFilterAhead(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git rev-list "main"..HEAD`, Replace: "echo 'abc\ndef'"},
+	})
+
+	commitFilter := NewCommitFilter("ahead:main")
+	assert.NoError(t, gitCmd.LoadCommitFilterAhead(commitFilter))
+	assert.EqualValues(t, map[string]bool{"abc": true, "def": true}, commitFilter.AheadShas)
+}
+
+// TestGitCommandFormatPatches is a function.
+func TestGitCommandFormatPatches(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git format-patch --start-number 1 -o "patches" -1 ccc`, Replace: "echo"},
+		{Expect: `git format-patch --start-number 2 -o "patches" -1 aaa`, Replace: "echo"},
+	})
+
+	commits := []*Commit{{Sha: "aaa"}, {Sha: "ccc"}}
+	assert.NoError(t, gitCmd.FormatPatches(commits, "patches"))
+}
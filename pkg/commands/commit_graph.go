@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The commit-graph file caches the shape of the commit history, so that git can
+// walk it without parsing every commit object along the way. In repos with
+// hundreds of thousands of commits it makes the difference between rendering
+// the branch graph and counting ahead/behind commits instantly and waiting
+// seconds each time. git uses it automatically once it exists.
+
+// HasCommitGraph tells us whether the repo has a commit-graph, either as a
+// single file or as a chain of incremental ones
+func (c *GitCommand) HasCommitGraph() bool {
+	for _, path := range []string{"objects/info/commit-graph", "objects/info/commit-graphs/commit-graph-chain"} {
+		// --git-path takes care of worktrees, whose objects live in the main repo
+		fullPath, err := c.OSCommand.RunCommandWithOutput("git rev-parse --git-path %s", path)
+		if err != nil {
+			continue
+		}
+		if exists, _ := c.OSCommand.FileExists(strings.TrimSpace(fullPath)); exists {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCommitCount returns the number of commits reachable from HEAD
+func (c *GitCommand) GetCommitCount() (int, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git rev-list --count HEAD")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(output))
+}
+
+// WriteCommitGraph writes a commit-graph for everything reachable from our
+// refs. The changed-path filters also speed up logs limited to a path.
+func (c *GitCommand) WriteCommitGraph() error {
+	return c.OSCommand.RunCommand("git commit-graph write --reachable --changed-paths")
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandHasCommitGraph is a function.
+func TestGitCommandHasCommitGraph(t *testing.T) {
+	file, err := ioutil.TempFile("", "commit-graph")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	file.Close()
+
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{
+			"single file",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse --git-path objects/info/commit-graph", Replace: "echo " + file.Name()},
+			}),
+			true,
+		},
+		{
+			"chain of incremental files",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse --git-path objects/info/commit-graph", Replace: "echo /does/not/exist"},
+				{Expect: "git rev-parse --git-path objects/info/commit-graphs/commit-graph-chain", Replace: "echo " + file.Name()},
+			}),
+			true,
+		},
+		{
+			"no commit-graph",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse --git-path objects/info/commit-graph", Replace: "echo /does/not/exist"},
+				{Expect: "git rev-parse --git-path objects/info/commit-graphs/commit-graph-chain", Replace: "echo /does/not/exist"},
+			}),
+			false,
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			assert.EqualValues(t, s.expected, gitCmd.HasCommitGraph())
+		})
+	}
+}
@@ -33,6 +33,8 @@ type CommitListBuilder struct {
 	Tr                  *i18n.Localizer
 	CherryPickedCommits []*Commit
 	DiffEntries         []*Commit
+	// LogSettings says how much of the history to load
+	LogSettings LogSettings
 }
 
 // NewCommitListBuilder builds a new commit list builder
@@ -61,11 +63,10 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *Commit {
 	message := strings.Join(split[4:], SEPARATION_CHAR)
 	tags := []string{}
 
-	if extraInfo != "" {
-		re := regexp.MustCompile(`tag: ([^,\)]+)`)
-		tagMatch := re.FindStringSubmatch(extraInfo)
-		if len(tagMatch) > 1 {
-			tags = append(tags, tagMatch[1])
+	decorations := parseDecorations(extraInfo)
+	for _, decoration := range decorations {
+		if decoration.Kind == "tag" {
+			tags = append(tags, decoration.Name)
 		}
 	}
 
@@ -74,7 +75,8 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *Commit {
 		Name:          message,
 		DisplayString: line,
 		Tags:          tags,
-		ExtraInfo:     extraInfo,
+		ExtraInfo:     shortDecorations(extraInfo),
+		Decorations:   decorations,
 		Date:          date,
 		Author:        author,
 	}
@@ -141,6 +143,9 @@ func (c *CommitListBuilder) GetCommits(limit bool) ([]*Commit, error) {
 func (c *CommitListBuilder) getRebasingCommits(rebaseMode string) ([]*Commit, error) {
 	switch rebaseMode {
 	case "normal":
+		if c.GitCommand.IsApplyingMailbox() {
+			return c.getMailboxCommits(), nil
+		}
 		return c.getNormalRebasingCommits()
 	case "interactive":
 		return c.getInteractiveRebasingCommits()
@@ -190,6 +195,20 @@ func (c *CommitListBuilder) getNormalRebasingCommits() ([]*Commit, error) {
 	return commits, nil
 }
 
+// getMailboxCommits returns the patches `git am` has yet to apply, including
+// the one it's stopped at. Unlike the commits of a rebase, there's no todo
+// list to edit, so they get a status of their own.
+func (c *CommitListBuilder) getMailboxCommits() []*Commit {
+	commits := []*Commit{}
+	for _, patch := range c.GitCommand.GetMailboxPatches() {
+		if patch.Status == "applied" {
+			continue
+		}
+		commits = append([]*Commit{{Sha: patch.Sha, Name: patch.Subject, Status: "applying", Action: "patch"}}, commits...)
+	}
+	return commits
+}
+
 // git-rebase-todo example:
 // pick ac446ae94ee560bdb8d1d057278657b251aaef17 ac446ae
 // pick afb893148791a2fbd8091aeb81deba4930c73031 afb8931
@@ -214,18 +233,21 @@ func (c *CommitListBuilder) getInteractiveRebasingCommits() ([]*Commit, error) {
 	lines := strings.Split(string(bytesContent), "\n")
 	for _, line := range lines {
 		if line == "" || line == "noop" {
-			return commits, nil
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
 		}
-		splitLine := strings.Split(line, " ")
-		commits = append([]*Commit{{
-			Sha:    splitLine[1],
-			Name:   strings.Join(splitLine[2:], " "),
-			Status: "rebasing",
-			Action: splitLine[0],
-		}}, commits...)
+		commits = append([]*Commit{parseRebaseTodoLine(line)}, commits...)
 	}
 
-	return nil, nil
+	// if an exec failed we show it beneath the todo items so that it's clear
+	// why the rebase has stopped
+	if command, ok := c.GitCommand.RebaseStoppedAtExec(); ok {
+		commits = append(commits, &Commit{Name: command, Status: "stopped", Action: "exec"})
+	}
+
+	return commits, nil
 }
 
 // assuming the file starts like this:
@@ -316,7 +338,7 @@ func (c *CommitListBuilder) getLog(limit bool) string {
 		limitFlag = "-30"
 	}
 
-	result, err := c.OSCommand.RunCommandWithOutput(fmt.Sprintf("git log --oneline --pretty=format:\"%%H%s%%ar%s%%aN%s%%d%s%%s\" %s --abbrev=%d", SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, limitFlag, 20))
+	result, err := c.OSCommand.RunCommandWithOutput(fmt.Sprintf("git log --oneline --pretty=format:\"%%H%s%%ar%s%%aN%s%%d%s%%s\" %s%s --decorate=full --abbrev=%d", SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, limitFlag, c.LogSettings.flags(), 20))
 
 	if err != nil {
 		// assume if there is an error there are no commits yet for this branch
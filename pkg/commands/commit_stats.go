@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// CommitStat is how big a commit is
+type CommitStat struct {
+	Files      int
+	Insertions int
+	Deletions  int
+}
+
+var (
+	filesChangedRegexp = regexp.MustCompile(`(\d+) files? changed`)
+	insertionsRegexp   = regexp.MustCompile(`(\d+) insertions?\(\+\)`)
+	deletionsRegexp    = regexp.MustCompile(`(\d+) deletions?\(-\)`)
+)
+
+// GetCommitStats returns the size of each of the given commits, by sha. Merge
+// commits come out empty because git doesn't diff them by default.
+func (c *GitCommand) GetCommitStats(shas []string) (map[string]*CommitStat, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git log --no-walk=unsorted --format=%%H --shortstat %s", strings.Join(shas, " "))
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitStats(output), nil
+}
+
+func parseCommitStats(output string) map[string]*CommitStat {
+	stats := map[string]*CommitStat{}
+	var stat *CommitStat
+	for _, line := range utils.SplitLines(output) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.Contains(line, " changed") {
+			stat = &CommitStat{}
+			stats[line] = stat
+			continue
+		}
+		if stat == nil {
+			continue
+		}
+		stat.Files = firstNumber(filesChangedRegexp, line)
+		stat.Insertions = firstNumber(insertionsRegexp, line)
+		stat.Deletions = firstNumber(deletionsRegexp, line)
+	}
+	return stats
+}
+
+func firstNumber(re *regexp.Regexp, str string) int {
+	match := re.FindStringSubmatch(str)
+	if match == nil {
+		return 0
+	}
+	number, _ := strconv.Atoi(match[1])
+	return number
+}
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetCommitStats is a function.
+func TestGitCommandGetCommitStats(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git log --no-walk=unsorted --format=%H --shortstat aaa bbb ccc ddd",
+			Replace: "echo 'aaa\n\n 3 files changed, 10 insertions(+), 2 deletions(-)\nbbb\n\n 1 file changed, 1 insertion(+)\nccc\nddd\n\n 1 file changed, 4 deletions(-)'",
+		},
+	})
+
+	stats, err := gitCmd.GetCommitStats([]string{"aaa", "bbb", "ccc", "ddd"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]*CommitStat{
+		"aaa": {Files: 3, Insertions: 10, Deletions: 2},
+		"bbb": {Files: 1, Insertions: 1},
+		"ccc": {},
+		"ddd": {Files: 1, Deletions: 4},
+	}, stats)
+}
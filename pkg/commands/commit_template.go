@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultCommitTemplatePath is where we look for a template when the user
+// hasn't set commit.template, relative to the root of the repo
+const defaultCommitTemplatePath = ".gitmessage"
+
+// getGitConfigValue returns the repo's value for the key, falling back to the
+// user's global value
+func (c *GitCommand) getGitConfigValue(key string) string {
+	value, _ := c.getLocalGitConfig(key)
+	if value == "" {
+		value, _ = c.getGlobalGitConfig(key)
+	}
+	return strings.TrimSpace(value)
+}
+
+// GetCommitTemplate returns the content of the template that commit.template
+// points to, or of a .gitmessage file at the root of the repo if it isn't set.
+// If there's no template we return an empty string.
+func (c *GitCommand) GetCommitTemplate() (string, error) {
+	path := c.getGitConfigValue("commit.template")
+	if path == "" {
+		if _, err := os.Stat(defaultCommitTemplatePath); err != nil {
+			return "", nil
+		}
+		path = defaultCommitTemplatePath
+	}
+
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", WrapError(err)
+	}
+	return strings.TrimRight(string(content), "\n"), nil
+}
+
+// GetBranchSubjectPrefix returns what to start the commit subject with on the
+// given branch, going by git.commit.subjectFromBranch: the first match of its
+// pattern, expanded into its template, e.g. 'PROJ-123: ' for the branch
+// feature/PROJ-123-login. Without a template we use the whole match followed
+// by ': '. If the pattern isn't set or doesn't match we return an empty string.
+func (c *GitCommand) GetBranchSubjectPrefix(branchName string) (string, error) {
+	config := c.Config.GetUserConfig()
+	pattern := config.GetString("git.commit.subjectFromBranch.pattern")
+	if pattern == "" {
+		return "", nil
+	}
+
+	branchRegexp, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", WrapError(err)
+	}
+	match := branchRegexp.FindStringSubmatchIndex(branchName)
+	if match == nil {
+		return "", nil
+	}
+
+	template := config.GetString("git.commit.subjectFromBranch.template")
+	if template == "" {
+		template = "${0}: "
+	}
+	return string(branchRegexp.ExpandString(nil, template, branchName, match)), nil
+}
+
+// GetCommentChar returns the character that starts a comment line in a commit
+// message, as set by core.commentChar
+func (c *GitCommand) GetCommentChar() string {
+	commentChar := c.getGitConfigValue("core.commentChar")
+	// 'auto' picks a character the message doesn't use, which only makes sense
+	// for messages git writes itself, so we treat it like the default
+	if commentChar == "" || commentChar == "auto" {
+		return "#"
+	}
+	return commentChar
+}
+
+// CleanupCommitMessage does what git does to a message written in an editor:
+// it drops comment lines, trailing whitespace, and repeated or surrounding
+// blank lines
+func CleanupCommitMessage(message string, commentChar string) string {
+	lines := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, commentChar) {
+			continue
+		}
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetCommitTemplate is a function.
+func TestGitCommandGetCommitTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-template")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	templatePath := filepath.Join(dir, "template.txt")
+	assert.NoError(t, ioutil.WriteFile(templatePath, []byte("Subject\n\n# Explain why\n"), 0644))
+
+	type scenario struct {
+		testName           string
+		getLocalGitConfig  func(string) (string, error)
+		getGlobalGitConfig func(string) (string, error)
+		test               func(string, error)
+	}
+
+	noConfig := func(string) (string, error) { return "", nil }
+	configured := func(key string) (string, error) {
+		if key == "commit.template" {
+			return templatePath + "\n", nil
+		}
+		return "", nil
+	}
+
+	scenarios := []scenario{
+		{
+			"no template",
+			noConfig,
+			noConfig,
+			func(template string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "", template)
+			},
+		},
+		{
+			"local template",
+			configured,
+			noConfig,
+			func(template string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "Subject\n\n# Explain why", template)
+			},
+		},
+		{
+			"global template",
+			noConfig,
+			configured,
+			func(template string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "Subject\n\n# Explain why", template)
+			},
+		},
+		{
+			"missing template",
+			func(string) (string, error) { return filepath.Join(dir, "missing.txt"), nil },
+			noConfig,
+			func(template string, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getLocalGitConfig = s.getLocalGitConfig
+			gitCmd.getGlobalGitConfig = s.getGlobalGitConfig
+			s.test(gitCmd.GetCommitTemplate())
+		})
+	}
+}
+
+// TestGitCommandGetCommentChar is a function.
+func TestGitCommandGetCommentChar(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	assert.EqualValues(t, "#", gitCmd.GetCommentChar())
+
+	gitCmd.getGlobalGitConfig = func(string) (string, error) { return ";", nil }
+	assert.EqualValues(t, ";", gitCmd.GetCommentChar())
+
+	gitCmd.getLocalGitConfig = func(string) (string, error) { return "auto", nil }
+	assert.EqualValues(t, "#", gitCmd.GetCommentChar())
+}
+
+// TestGitCommandGetBranchSubjectPrefix is a function.
+func TestGitCommandGetBranchSubjectPrefix(t *testing.T) {
+	type scenario struct {
+		testName   string
+		pattern    string
+		template   string
+		branchName string
+		test       func(string, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"not configured",
+			"",
+			"",
+			"feature/PROJ-123-login",
+			func(prefix string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "", prefix)
+			},
+		},
+		{
+			"whole match without a template",
+			`[A-Z]+-\d+`,
+			"",
+			"feature/PROJ-123-login",
+			func(prefix string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "PROJ-123: ", prefix)
+			},
+		},
+		{
+			"groups expanded into the template",
+			`^(\w+)/([A-Z]+-\d+)`,
+			"[$2] ${1}: ",
+			"fix/PROJ-123-login",
+			func(prefix string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "[PROJ-123] fix: ", prefix)
+			},
+		},
+		{
+			"branch doesn't match",
+			`[A-Z]+-\d+`,
+			"",
+			"master",
+			func(prefix string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "", prefix)
+			},
+		},
+		{
+			"invalid pattern",
+			`[A-Z`,
+			"",
+			"master",
+			func(prefix string, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.Config.GetUserConfig().Set("git.commit.subjectFromBranch.pattern", s.pattern)
+			gitCmd.Config.GetUserConfig().Set("git.commit.subjectFromBranch.template", s.template)
+			s.test(gitCmd.GetBranchSubjectPrefix(s.branchName))
+		})
+	}
+}
+
+// TestCleanupCommitMessage is a function.
+func TestCleanupCommitMessage(t *testing.T) {
+	type scenario struct {
+		testName    string
+		message     string
+		commentChar string
+		expected    string
+	}
+
+	scenarios := []scenario{
+		{
+			"plain message",
+			"Fix typo\n\nIt was bugging me",
+			"#",
+			"Fix typo\n\nIt was bugging me",
+		},
+		{
+			"comment lines",
+			"# Subject\nFix typo\n\n# Why?\nIt was bugging me\n# end",
+			"#",
+			"Fix typo\n\nIt was bugging me",
+		},
+		{
+			"surrounding and repeated blank lines",
+			"\n\nFix typo  \n\n\n\nIt was bugging me\n\n",
+			"#",
+			"Fix typo\n\nIt was bugging me",
+		},
+		{
+			"custom comment char",
+			"#123 Fix typo\n; a comment",
+			";",
+			"#123 Fix typo",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, CleanupCommitMessage(s.message, s.commentChar))
+		})
+	}
+}
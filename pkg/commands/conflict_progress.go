@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// SequenceProgress says where a rebase, cherry-pick or revert has got to, so
+// that while resolving conflicts we can show which commit is being applied
+type SequenceProgress struct {
+	// Action is 'rebase', 'cherry-pick' or 'revert'
+	Action string
+	Sha    string
+	Name   string
+	// Current and Total count the commits of a rebase, with the current one
+	// being the one that's stopped. Cherry-picks and reverts don't record how
+	// many commits they started with so these are zero and Queued says how many
+	// are still to come instead.
+	Current int
+	Total   int
+	Queued  int
+}
+
+func (c *GitCommand) readGitDirFile(path string) (string, error) {
+	bytes, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", c.DotGitDir, path))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(bytes)), nil
+}
+
+func (c *GitCommand) readGitDirNumber(path string) int {
+	content, err := c.readGitDirFile(path)
+	if err != nil {
+		return 0
+	}
+	number, _ := strconv.Atoi(content)
+	return number
+}
+
+// GetSequenceProgress returns what the rebase, cherry-pick or revert in
+// progress is up to, or nil if there isn't one
+func (c *GitCommand) GetSequenceProgress() *SequenceProgress {
+	if done, err := c.readGitDirFile("rebase-merge/done"); err == nil {
+		lines := strings.Split(done, "\n")
+		commit := parseRebaseTodoLine(lines[len(lines)-1])
+		return &SequenceProgress{
+			Action:  "rebase",
+			Sha:     commit.Sha,
+			Name:    commit.Name,
+			Current: c.readGitDirNumber("rebase-merge/msgnum"),
+			Total:   c.readGitDirNumber("rebase-merge/end"),
+		}
+	}
+
+	if next := c.readGitDirNumber("rebase-apply/next"); next > 0 {
+		action := "rebase"
+		if c.IsApplyingMailbox() {
+			action = "am"
+		}
+		progress := &SequenceProgress{
+			Action:  action,
+			Current: next,
+			Total:   c.readGitDirNumber("rebase-apply/last"),
+		}
+		if sha, err := c.readGitDirFile("rebase-apply/original-commit"); err == nil {
+			progress.Sha = sha
+			progress.Name = c.getCommitSubject(sha)
+		}
+		return progress
+	}
+
+	for action, headFile := range map[string]string{"cherry-pick": "CHERRY_PICK_HEAD", "revert": "REVERT_HEAD"} {
+		sha, err := c.readGitDirFile(headFile)
+		if err != nil {
+			continue
+		}
+		progress := &SequenceProgress{Action: action, Sha: sha, Name: c.getCommitSubject(sha)}
+		if todo, err := c.readGitDirFile("sequencer/todo"); err == nil {
+			// the todo starts with the commit we're stopped at
+			progress.Queued = c.getTodoCommitCount(strings.Split(todo, "\n")) - 1
+		}
+		return progress
+	}
+
+	return nil
+}
+
+func (c *GitCommand) getCommitSubject(sha string) string {
+	output, err := c.OSCommand.RunCommandWithOutput("git show -s --format=%%s %s", sha)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// ConflictsFirst moves the files with merge conflicts to the top, otherwise
+// keeping the files in the order they were in
+func ConflictsFirst(files []*File) []*File {
+	result := append([]*File{}, files...)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].HasMergeConflicts && !result[j].HasMergeConflicts
+	})
+	return result
+}
+
+// ConflictResolution sums up how a file that had conflicts ended up, as the
+// lines added and deleted in the staged version compared to our side and to
+// their side. Matching one side exactly is worth a second look, given that it
+// means every change from the other side was thrown away.
+type ConflictResolution struct {
+	Name          string
+	OursAdded     int
+	OursDeleted   int
+	TheirsAdded   int
+	TheirsDeleted int
+}
+
+// MatchesOurs tells us whether the file was resolved by taking our side
+func (r *ConflictResolution) MatchesOurs() bool {
+	return r.OursAdded == 0 && r.OursDeleted == 0
+}
+
+// MatchesTheirs tells us whether the file was resolved by taking their side
+func (r *ConflictResolution) MatchesTheirs() bool {
+	return r.TheirsAdded == 0 && r.TheirsDeleted == 0
+}
+
+// theirsRef returns the ref for the commit being merged or applied, or ""
+// if there isn't one
+func (c *GitCommand) theirsRef() string {
+	for _, ref := range []string{"MERGE_HEAD", "REBASE_HEAD", "CHERRY_PICK_HEAD", "REVERT_HEAD"} {
+		if _, err := c.readGitDirFile(ref); err == nil {
+			return ref
+		}
+	}
+	return ""
+}
+
+// GetConflictResolutions compares the staged version of each of the given
+// files with our side and their side of the merge or rebase in progress
+func (c *GitCommand) GetConflictResolutions(fileNames []string) ([]*ConflictResolution, error) {
+	theirs := c.theirsRef()
+	if theirs == "" || len(fileNames) == 0 {
+		return nil, nil
+	}
+
+	ours, err := c.diffStagedNumstat("HEAD", fileNames)
+	if err != nil {
+		return nil, err
+	}
+	theirsStats, err := c.diffStagedNumstat(theirs, fileNames)
+	if err != nil {
+		return nil, err
+	}
+
+	resolutions := make([]*ConflictResolution, len(fileNames))
+	for i, fileName := range fileNames {
+		resolutions[i] = &ConflictResolution{
+			Name:          fileName,
+			OursAdded:     ours[fileName][0],
+			OursDeleted:   ours[fileName][1],
+			TheirsAdded:   theirsStats[fileName][0],
+			TheirsDeleted: theirsStats[fileName][1],
+		}
+	}
+	return resolutions, nil
+}
+
+// diffStagedNumstat returns the lines added and deleted in each staged file
+// compared to ref. Files that are the same as ref are left out. A binary file
+// that differs counts as one line added and deleted.
+func (c *GitCommand) diffStagedNumstat(ref string, fileNames []string) (map[string][2]int, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git diff --cached --numstat %s -- %s", ref, c.quoteFileNames(fileNames))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := map[string][2]int{}
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		if fields[0] == "-" {
+			stats[fields[2]] = [2]int{1, 1}
+			continue
+		}
+		added, _ := strconv.Atoi(fields[0])
+		deleted, _ := strconv.Atoi(fields[1])
+		stats[fields[2]] = [2]int{added, deleted}
+	}
+	return stats, nil
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetSequenceProgress is a function.
+func TestGitCommandGetSequenceProgress(t *testing.T) {
+	type scenario struct {
+		testName string
+		files    map[string]string
+		command  func(string, ...string) *exec.Cmd
+		expected *SequenceProgress
+	}
+
+	scenarios := []scenario{
+		{
+			"nothing in progress",
+			map[string]string{},
+			nil,
+			nil,
+		},
+		{
+			"interactive rebase",
+			map[string]string{
+				"rebase-merge/done":   "pick abc first\npick def second commit\n",
+				"rebase-merge/msgnum": "2\n",
+				"rebase-merge/end":    "5\n",
+			},
+			nil,
+			&SequenceProgress{Action: "rebase", Sha: "def", Name: "second commit", Current: 2, Total: 5},
+		},
+		{
+			"normal rebase",
+			map[string]string{
+				"rebase-apply/next":            "3\n",
+				"rebase-apply/last":            "4\n",
+				"rebase-apply/original-commit": "abc\n",
+			},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git show -s --format=%s abc", Replace: "echo 'third commit'"},
+			}),
+			&SequenceProgress{Action: "rebase", Sha: "abc", Name: "third commit", Current: 3, Total: 4},
+		},
+		{
+			"cherry-pick with more to come",
+			map[string]string{
+				"CHERRY_PICK_HEAD": "abc\n",
+				"sequencer/todo":   "pick abc first\npick def second\npick ghi third\n",
+			},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git show -s --format=%s abc", Replace: "echo first"},
+			}),
+			&SequenceProgress{Action: "cherry-pick", Sha: "abc", Name: "first", Queued: 2},
+		},
+		{
+			"single revert",
+			map[string]string{
+				"REVERT_HEAD": "abc\n",
+			},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git show -s --format=%s abc", Replace: "echo first"},
+			}),
+			&SequenceProgress{Action: "revert", Sha: "abc", Name: "first"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "lazygit-conflict-progress")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			for path, content := range s.files {
+				path = filepath.Join(dir, path)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+			}
+
+			gitCmd := NewDummyGitCommand()
+			gitCmd.DotGitDir = dir
+			if s.command != nil {
+				gitCmd.OSCommand.command = s.command
+			}
+			assert.EqualValues(t, s.expected, gitCmd.GetSequenceProgress())
+		})
+	}
+}
+
+// TestConflictsFirst is a function.
+func TestConflictsFirst(t *testing.T) {
+	files := []*File{
+		{Name: "a"},
+		{Name: "b", HasMergeConflicts: true},
+		{Name: "c"},
+		{Name: "d", HasMergeConflicts: true},
+	}
+
+	names := []string{}
+	for _, file := range ConflictsFirst(files) {
+		names = append(names, file.Name)
+	}
+	assert.EqualValues(t, []string{"b", "d", "a", "c"}, names)
+	assert.Equal(t, "a", files[0].Name)
+}
+
+// TestGitCommandGetConflictResolutions is a function.
+func TestGitCommandGetConflictResolutions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-conflict-progress")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "MERGE_HEAD"), []byte("abc\n"), 0644))
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dir
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git diff --cached --numstat HEAD -- "a.txt" "b.txt" "c.png"`, Replace: "echo '3\t1\ta.txt\n-\t-\tc.png'"},
+		{Expect: `git diff --cached --numstat MERGE_HEAD -- "a.txt" "b.txt" "c.png"`, Replace: "echo '2\t2\tb.txt'"},
+	})
+
+	resolutions, err := gitCmd.GetConflictResolutions([]string{"a.txt", "b.txt", "c.png"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*ConflictResolution{
+		{Name: "a.txt", OursAdded: 3, OursDeleted: 1},
+		{Name: "b.txt", TheirsAdded: 2, TheirsDeleted: 2},
+		{Name: "c.png", OursAdded: 1, OursDeleted: 1},
+	}, resolutions)
+	assert.True(t, resolutions[0].MatchesTheirs())
+	assert.True(t, resolutions[1].MatchesOurs())
+}
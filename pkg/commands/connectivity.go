@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// connectivityTimeout is how long we'll wait on a remote's host before
+// deciding that we're still offline
+const connectivityTimeout = 5 * time.Second
+
+var defaultPorts = map[string]string{
+	"ssh":   "22",
+	"git":   "9418",
+	"http":  "80",
+	"https": "443",
+}
+
+// remoteAddress returns the host:port a remote url connects to, or an empty
+// string for remotes that live on this machine
+func remoteAddress(remoteURL string) string {
+	if strings.Contains(remoteURL, "://") {
+		parsed, err := url.Parse(remoteURL)
+		if err != nil || parsed.Hostname() == "" {
+			return ""
+		}
+		port := parsed.Port()
+		if port == "" {
+			port = defaultPorts[strings.TrimPrefix(parsed.Scheme, "git+")]
+		}
+		if port == "" {
+			return ""
+		}
+		return net.JoinHostPort(parsed.Hostname(), port)
+	}
+
+	// scp-like syntax e.g. git@github.com:jesseduffield/lazygit.git. A path
+	// with a slash before the first colon is a local path
+	colonIndex := strings.Index(remoteURL, ":")
+	if colonIndex == -1 || strings.Contains(remoteURL[:colonIndex], "/") {
+		return ""
+	}
+	host := remoteURL[:colonIndex]
+	if atIndex := strings.LastIndex(host, "@"); atIndex != -1 {
+		host = host[atIndex+1:]
+	}
+	return net.JoinHostPort(host, defaultPorts["ssh"])
+}
+
+// CanReachRemote tells us whether we can open a connection to the remote's
+// host (or the checked out branch's remote if remoteName is empty), which is
+// a cheap way of telling whether we're online without fetching anything
+func (c *GitCommand) CanReachRemote(remoteName string) bool {
+	if remoteName == "" {
+		remoteName = c.getBranchRemote("")
+	}
+	remoteURL, _ := c.getLocalGitConfig("remote." + remoteName + ".url")
+	address := remoteAddress(strings.TrimSpace(remoteURL))
+	if address == "" {
+		return true
+	}
+
+	conn, err := net.DialTimeout("tcp", address, connectivityTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRemoteAddress is a function.
+func TestRemoteAddress(t *testing.T) {
+	type scenario struct {
+		remoteURL string
+		expected  string
+	}
+
+	scenarios := []scenario{
+		{"git@github.com:jesseduffield/lazygit.git", "github.com:22"},
+		{"github.com:jesseduffield/lazygit.git", "github.com:22"},
+		{"ssh://git@gitlab.example.com:2222/team/lazygit.git", "gitlab.example.com:2222"},
+		{"https://github.com/jesseduffield/lazygit.git", "github.com:443"},
+		{"http://user@[::1]/lazygit.git", "[::1]:80"},
+		{"git://example.com/lazygit.git", "example.com:9418"},
+		{"file:///home/jesse/lazygit.git", ""},
+		{"/home/jesse/lazygit.git", ""},
+		{"../lazy:git", ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.remoteURL, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, remoteAddress(s.remoteURL))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package commands
+
+import "strings"
+
+// Decoration is a ref pointing at a commit, like the checked out branch, a
+// tag, or where a remote branch is
+type Decoration struct {
+	// Name is the ref's short name, e.g. 'origin/master'
+	Name string
+	// Kind is one of "head", "branch", "remote", "tag" or "other". A "head"
+	// decoration is the checked out branch, or HEAD itself when it's detached
+	Kind string
+}
+
+var decorationPrefixes = []struct {
+	prefix string
+	kind   string
+}{
+	{"refs/heads/", "branch"},
+	{"refs/remotes/", "remote"},
+	{"tag: refs/tags/", "tag"},
+}
+
+// parseDecorations reads the refs out of a commit's full decoration, as in
+// '(HEAD -> refs/heads/master, tag: refs/tags/v1, refs/remotes/origin/master)'
+func parseDecorations(extraInfo string) []Decoration {
+	extraInfo = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(extraInfo), "("), ")")
+	if extraInfo == "" {
+		return nil
+	}
+
+	decorations := []Decoration{}
+	for _, ref := range strings.Split(extraInfo, ", ") {
+		if ref == "HEAD" {
+			decorations = append(decorations, Decoration{Name: "HEAD", Kind: "head"})
+			continue
+		}
+		if strings.HasPrefix(ref, "HEAD -> ") {
+			decorations = append(decorations, Decoration{Name: strings.TrimPrefix(ref, "HEAD -> refs/heads/"), Kind: "head"})
+			continue
+		}
+		// a remote's HEAD just says which of its branches is the default one,
+		// which is only noise next to that branch
+		if strings.HasPrefix(ref, "refs/remotes/") && strings.HasSuffix(ref, "/HEAD") {
+			continue
+		}
+
+		decoration := Decoration{Name: strings.TrimPrefix(ref, "refs/"), Kind: "other"}
+		for _, p := range decorationPrefixes {
+			if strings.HasPrefix(ref, p.prefix) {
+				decoration = Decoration{Name: strings.TrimPrefix(ref, p.prefix), Kind: p.kind}
+				break
+			}
+		}
+		decorations = append(decorations, decoration)
+	}
+	return decorations
+}
+
+// shortDecorations turns a full decoration back into the one git shows by
+// default, as in '(HEAD -> master, tag: v1, origin/master)'
+func shortDecorations(extraInfo string) string {
+	return strings.NewReplacer("refs/heads/", "", "refs/remotes/", "", "refs/tags/", "").Replace(extraInfo)
+}
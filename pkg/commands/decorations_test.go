@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseDecorations is a function.
+func TestParseDecorations(t *testing.T) {
+	type scenario struct {
+		testName  string
+		extraInfo string
+		expected  []Decoration
+	}
+
+	scenarios := []scenario{
+		{
+			"no decorations",
+			"",
+			nil,
+		},
+		{
+			"checked out branch with tags and remotes",
+			"(HEAD -> refs/heads/master, tag: refs/tags/v1, refs/remotes/origin/master, refs/remotes/origin/HEAD, refs/heads/feat/x)",
+			[]Decoration{
+				{Name: "master", Kind: "head"},
+				{Name: "v1", Kind: "tag"},
+				{Name: "origin/master", Kind: "remote"},
+				{Name: "feat/x", Kind: "branch"},
+			},
+		},
+		{
+			"detached head and stash",
+			"(HEAD, refs/stash)",
+			[]Decoration{
+				{Name: "HEAD", Kind: "head"},
+				{Name: "stash", Kind: "other"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, parseDecorations(s.extraInfo))
+		})
+	}
+}
+
+// TestShortDecorations is a function.
+func TestShortDecorations(t *testing.T) {
+	assert.EqualValues(t,
+		"(HEAD -> master, tag: v1, origin/master)",
+		shortDecorations("(HEAD -> refs/heads/master, tag: refs/tags/v1, refs/remotes/origin/master)"),
+	)
+}
+
+// TestCommitListBuilderExtractCommitFromLineDecorations is a function.
+func TestCommitListBuilderExtractCommitFromLineDecorations(t *testing.T) {
+	c := NewDummyCommitListBuilder()
+
+	commit := c.extractCommitFromLine("abc|2 days ago|Jesse|(tag: refs/tags/v1, tag: refs/tags/v2, refs/remotes/origin/master)|fix it")
+	assert.EqualValues(t, []string{"v1", "v2"}, commit.Tags)
+	assert.EqualValues(t, "(tag: v1, tag: v2, origin/master)", commit.ExtraInfo)
+	assert.Len(t, commit.Decorations, 3)
+	assert.EqualValues(t, "fix it", commit.Name)
+}
@@ -5,6 +5,7 @@ package commands
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"strings"
 	"unicode/utf8"
 
@@ -17,8 +18,14 @@ import (
 // Output is a function that executes by every word that gets read by bufio
 // As return of output you need to give a string that will be written to stdin
 // NOTE: If the return data is empty it won't written anything to stdin
-func RunCommandWithOutputLiveWrapper(c *OSCommand, command string, output func(string) string) error {
-	cmd := c.ExecutableFromString(command)
+// env is added to the command's environment, and the command is killed along
+// with anything it started once ctx is done
+func RunCommandWithOutputLiveWrapper(c *OSCommand, ctx context.Context, command string, env []string, output func(string) string) error {
+	cmd := c.executableWithContext(ctx, command)
+	// pty.Start gives the command a session of its own, which makes it the
+	// leader of its own process group anyway
+	cmd.SysProcAttr.Setpgid = false
+	cmd.Env = append(cmd.Env, env...)
 	cmd.Env = append(cmd.Env, "LANG=en_US.UTF-8", "LC_ALL=en_US.UTF-8")
 
 	var stderr bytes.Buffer
@@ -39,7 +46,7 @@ func RunCommandWithOutputLiveWrapper(c *OSCommand, command string, output func(s
 		}
 	}()
 
-	err = cmd.Wait()
+	err = waitWithContext(ctx, cmd)
 	ptmx.Close()
 	if err != nil {
 		return errors.New(stderr.String())
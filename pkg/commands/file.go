@@ -13,4 +13,8 @@ type File struct {
 	DisplayString           string
 	Type                    string // one of 'file', 'directory', and 'other'
 	ShortStatus             string // e.g. 'AD', ' A', 'M ', '??'
+	WhitespaceOnly          bool   // if the unstaged changes only touch whitespace
+	// Submodule is the path of the submodule the file's in, when we're showing
+	// the status across submodules, or "" for the superproject's own files
+	Submodule string
 }
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// fileStatusFilters are the statuses that a file filter can ask for, with
+// 'is:', e.g. 'is:staged'
+var fileStatusFilters = map[string]func(*File) bool{
+	"staged":     func(file *File) bool { return file.HasStagedChanges },
+	"unstaged":   func(file *File) bool { return file.HasUnstagedChanges },
+	"untracked":  func(file *File) bool { return file.ShortStatus == "??" },
+	"conflicted": func(file *File) bool { return file.HasMergeConflicts },
+}
+
+// FileFilter narrows down the files panel. It's made up of globs like '*.go'
+// or 'src/**' and statuses like 'is:staged', separated by spaces. A file needs
+// to match one of the globs, if there are any, and one of the statuses, if
+// there are any.
+type FileFilter struct {
+	Globs    []string
+	Statuses []string
+}
+
+// NewFileFilter parses a filter as typed in by the user
+func NewFileFilter(filter string) (*FileFilter, error) {
+	fileFilter := &FileFilter{Globs: []string{}, Statuses: []string{}}
+	for _, term := range strings.Fields(filter) {
+		if strings.HasPrefix(term, "is:") {
+			status := strings.TrimPrefix(term, "is:")
+			if _, ok := fileStatusFilters[status]; !ok {
+				return nil, errors.New("unknown status filter: " + term)
+			}
+			fileFilter.Statuses = append(fileFilter.Statuses, status)
+		} else {
+			fileFilter.Globs = append(fileFilter.Globs, term)
+		}
+	}
+	return fileFilter, nil
+}
+
+// IsEmpty tells us whether the filter lets every file through
+func (f *FileFilter) IsEmpty() bool {
+	return f == nil || (len(f.Globs) == 0 && len(f.Statuses) == 0)
+}
+
+// Matches tells us whether a file gets through the filter
+func (f *FileFilter) Matches(file *File) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	if len(f.Statuses) > 0 {
+		matched := false
+		for _, status := range f.Statuses {
+			if fileStatusFilters[status](file) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Globs) == 0 {
+		return true
+	}
+	// renamed files show up as 'old -> new' and we match on either name
+	for _, name := range strings.Split(file.Name, " -> ") {
+		for _, glob := range f.Globs {
+			if matchesFileGlob(glob, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Filter returns the files that get through the filter
+func (f *FileFilter) Filter(files []*File) []*File {
+	if f.IsEmpty() {
+		return files
+	}
+
+	result := []*File{}
+	for _, file := range files {
+		if f.Matches(file) {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// FilesMatchingPattern returns the files with a name matching the pattern,
+// which is either a glob like those in a FileFilter or, if it's wrapped in
+// slashes, a regex, e.g. '/_test\.go$/'
+func FilesMatchingPattern(files []*File, pattern string) ([]*File, error) {
+	matches := func(name string) bool {
+		return matchesFileGlob(pattern, name)
+	}
+
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, err
+		}
+		matches = re.MatchString
+	}
+
+	result := []*File{}
+	for _, file := range files {
+		// renamed files show up as 'old -> new' and we match on the new name
+		split := strings.Split(file.Name, " -> ")
+		if matches(split[len(split)-1]) {
+			result = append(result, file)
+		}
+	}
+	return result, nil
+}
+
+// matchesFileGlob works like a .gitignore pattern: a glob without a '/' is
+// matched against the file's base name, so '*.go' matches Go files anywhere,
+// and a glob ending in '/' matches everything in that dir
+func matchesFileGlob(glob string, name string) bool {
+	if strings.HasSuffix(glob, "/") {
+		glob += "**"
+	}
+	if !strings.Contains(glob, "/") {
+		name = path.Base(strings.TrimSuffix(name, "/"))
+	}
+	return utils.MatchesGlob(glob, name)
+}
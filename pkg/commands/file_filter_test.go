@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewFileFilter is a function.
+func TestNewFileFilter(t *testing.T) {
+	type scenario struct {
+		testName string
+		filter   string
+		test     func(*FileFilter, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"globs and statuses",
+			"*.go  is:staged src/** is:untracked",
+			func(fileFilter *FileFilter, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []string{"*.go", "src/**"}, fileFilter.Globs)
+				assert.EqualValues(t, []string{"staged", "untracked"}, fileFilter.Statuses)
+			},
+		},
+		{
+			"empty",
+			"  ",
+			func(fileFilter *FileFilter, err error) {
+				assert.NoError(t, err)
+				assert.True(t, fileFilter.IsEmpty())
+			},
+		},
+		{
+			"unknown status",
+			"is:modified",
+			func(fileFilter *FileFilter, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			s.test(NewFileFilter(s.filter))
+		})
+	}
+}
+
+// TestFileFilterFilter is a function.
+func TestFileFilterFilter(t *testing.T) {
+	files := []*File{
+		{Name: "main.go", HasStagedChanges: true, Tracked: true, ShortStatus: "M "},
+		{Name: "pkg/gui/gui.go", HasUnstagedChanges: true, Tracked: true, ShortStatus: " M"},
+		{Name: "src/a.txt", HasUnstagedChanges: true, ShortStatus: "??"},
+		{Name: "src/new/", HasUnstagedChanges: true, ShortStatus: "??"},
+		{Name: "old.txt -> renamed.go", HasStagedChanges: true, Tracked: true, ShortStatus: "R "},
+		{Name: "conflict.txt", HasMergeConflicts: true, HasStagedChanges: true, HasUnstagedChanges: true, Tracked: true, ShortStatus: "UU"},
+	}
+
+	type scenario struct {
+		filter   string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{"", []string{"main.go", "pkg/gui/gui.go", "src/a.txt", "src/new/", "old.txt -> renamed.go", "conflict.txt"}},
+		{"*.go", []string{"main.go", "pkg/gui/gui.go", "old.txt -> renamed.go"}},
+		{"src/**", []string{"src/a.txt", "src/new/"}},
+		{"pkg/", []string{"pkg/gui/gui.go"}},
+		{"new", []string{"src/new/"}},
+		{"is:staged", []string{"main.go", "old.txt -> renamed.go", "conflict.txt"}},
+		{"is:untracked", []string{"src/a.txt", "src/new/"}},
+		{"is:conflicted", []string{"conflict.txt"}},
+		{"*.go is:staged", []string{"main.go", "old.txt -> renamed.go"}},
+		{"*.txt is:unstaged is:conflicted", []string{"src/a.txt", "conflict.txt"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.filter, func(t *testing.T) {
+			fileFilter, err := NewFileFilter(s.filter)
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, file := range fileFilter.Filter(files) {
+				names = append(names, file.Name)
+			}
+			assert.EqualValues(t, s.expected, names)
+		})
+	}
+}
+
+// TestFilesMatchingPattern is a function.
+func TestFilesMatchingPattern(t *testing.T) {
+	files := []*File{
+		{Name: "main.go"},
+		{Name: "main_test.go"},
+		{Name: "pkg/gui/gui.go"},
+		{Name: "pkg/gui/gui_test.go"},
+		{Name: "old_test.go -> README.md"},
+	}
+
+	type scenario struct {
+		pattern  string
+		expected []string
+		hasError bool
+	}
+
+	scenarios := []scenario{
+		{"*.go", []string{"main.go", "main_test.go", "pkg/gui/gui.go", "pkg/gui/gui_test.go"}, false},
+		{"pkg/**", []string{"pkg/gui/gui.go", "pkg/gui/gui_test.go"}, false},
+		{"*.md", []string{"old_test.go -> README.md"}, false},
+		{`/_test\.go$/`, []string{"main_test.go", "pkg/gui/gui_test.go"}, false},
+		{`/^main/`, []string{"main.go", "main_test.go"}, false},
+		{"/(/", nil, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.pattern, func(t *testing.T) {
+			matches, err := FilesMatchingPattern(files, s.pattern)
+			if s.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, file := range matches {
+				names = append(names, file.Name)
+			}
+			assert.EqualValues(t, s.expected, names)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Formatter is a formatter from the user's config, for formatting files just
+// before staging them:
+//
+//   git:
+//     formatters:
+//       - pattern: '*.go'
+//         command: 'gofmt -w {{filenames}}'
+//
+// {{filenames}} is replaced with the files that match the pattern
+type Formatter struct {
+	Pattern string `yaml:"pattern"`
+	Command string `yaml:"command"`
+}
+
+func (c *GitCommand) getFormatters() []Formatter {
+	rawFormatters := c.Config.GetUserConfig().Get("git.formatters")
+	if rawFormatters == nil {
+		return nil
+	}
+	formattersYaml, err := yaml.Marshal(rawFormatters)
+	if err != nil {
+		return nil
+	}
+	formatters := []Formatter{}
+	if err := yaml.Unmarshal(formattersYaml, &formatters); err != nil {
+		c.Log.Error(err)
+		return nil
+	}
+	return formatters
+}
+
+// FormatFiles runs each formatter on the files that match its pattern,
+// returning the files that some formatter was run on
+func (c *GitCommand) FormatFiles(fileNames []string) ([]string, error) {
+	formatted := []string{}
+	for _, formatter := range c.getFormatters() {
+		if formatter.Pattern == "" || formatter.Command == "" {
+			continue
+		}
+
+		matching := []string{}
+		for _, fileName := range fileNames {
+			if matchesPathPattern(formatter.Pattern, fileName) {
+				matching = append(matching, fileName)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		command := utils.ResolvePlaceholderString(formatter.Command, map[string]string{
+			"filenames": c.quoteFileNames(matching),
+		})
+		if err := c.OSCommand.RunCommand(command); err != nil {
+			return nil, err
+		}
+		for _, fileName := range matching {
+			if !utils.IncludesString(formatted, fileName) {
+				formatted = append(formatted, fileName)
+			}
+		}
+	}
+	return formatted, nil
+}
+
+// matchesPathPattern tells us whether a file matches a glob. Globs without a
+// slash in them can match the file's name in any directory.
+func matchesPathPattern(pattern string, fileName string) bool {
+	fileName = filepath.ToSlash(fileName)
+	if utils.MatchesGlob(pattern, fileName) {
+		return true
+	}
+	return !strings.Contains(pattern, "/") && utils.MatchesGlob(pattern, path.Base(fileName))
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandFormatFiles is a function.
+func TestGitCommandFormatFiles(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config.GetUserConfig().Set("git.formatters", []interface{}{
+		map[string]interface{}{"pattern": "*.go", "command": "gofmt -w {{filenames}}"},
+		map[string]interface{}{"pattern": "docs/*.md", "command": "prettier --write {{filenames}}"},
+		map[string]interface{}{"pattern": "*.rs", "command": "rustfmt {{filenames}}"},
+	})
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `gofmt -w "main.go" "pkg/a.go"`, Replace: "echo"},
+		{Expect: `prettier --write "docs/README.md"`, Replace: "echo"},
+	})
+
+	formatted, err := gitCmd.FormatFiles([]string{"main.go", "pkg/a.go", "docs/README.md", "README.md"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"main.go", "pkg/a.go", "docs/README.md"}, formatted)
+}
+
+// TestGitCommandFormatFilesNoFormatters is a function.
+func TestGitCommandFormatFilesNoFormatters(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{})
+
+	formatted, err := gitCmd.FormatFiles([]string{"main.go"})
+	assert.NoError(t, err)
+	assert.Len(t, formatted, 0)
+}
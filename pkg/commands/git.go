@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -178,12 +179,22 @@ func stashEntryFromLine(line string, index int) *StashEntry {
 
 // GetStashEntryDiff stash diff
 func (c *GitCommand) ShowStashEntryCmdStr(index int) string {
-	return fmt.Sprintf("git stash show -p --color=%s stash@{%d}", c.colorArg(), index)
+	return fmt.Sprintf("git stash show%s -p --color=%s stash@{%d}", c.submoduleDiffArg(), c.colorArg(PagingContextStash), index)
 }
 
 // GetStatusFiles git status files
 func (c *GitCommand) GetStatusFiles() []*File {
 	statusOutput, _ := c.GitStatus()
+	files := c.parseStatusFiles(statusOutput, "")
+	if c.RecursesSubmodules() {
+		files = append(files, c.getSubmoduleStatusFiles("")...)
+	}
+	return files
+}
+
+// parseStatusFiles parses the output of `git status --porcelain`, run in the
+// given submodule, or in the superproject if that's ""
+func (c *GitCommand) parseStatusFiles(statusOutput string, submodule string) []*File {
 	statusStrings := utils.SplitLines(statusOutput)
 	files := []*File{}
 
@@ -192,6 +203,10 @@ func (c *GitCommand) GetStatusFiles() []*File {
 		stagedChange := change[0:1]
 		unstagedChange := statusString[1:2]
 		filename := c.OSCommand.Unquote(statusString[3:])
+		if submodule != "" {
+			filename = inSubmodule(submodule, filename)
+			statusString = change + " " + filename
+		}
 		untracked := utils.IncludesString([]string{"??", "A ", "AM"}, change)
 		hasNoStagedChanges := utils.IncludesString([]string{" ", "U", "?"}, stagedChange)
 		hasMergeConflicts := utils.IncludesString([]string{"DD", "AA", "UU", "AU", "UA", "UD", "DU"}, change)
@@ -208,6 +223,7 @@ func (c *GitCommand) GetStatusFiles() []*File {
 			HasInlineMergeConflicts: hasInlineMergeConflicts,
 			Type:                    c.OSCommand.FileType(filename),
 			ShortStatus:             change,
+			Submodule:               submodule,
 		}
 		files = append(files, file)
 	}
@@ -311,9 +327,41 @@ func (c *GitCommand) RebaseBranch(branchName string) error {
 	return c.OSCommand.RunPreparedCommand(cmd)
 }
 
+// RebaseBranchUpdatingRefs rebases like RebaseBranch, but with --update-refs
+// so that any other branches pointing at the commits being rebased are moved
+// to the rebased commits. Because git puts 'update-ref' lines in the todo for
+// this, it only works for rebases where we don't write the todo ourselves.
+func (c *GitCommand) RebaseBranchUpdatingRefs(branchName string) error {
+	cmd, err := c.prepareInteractiveRebaseCommand(branchName, "", false, "--update-refs")
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
+
+// GetBranchHeads returns the commit each local branch points at, so that we
+// can tell which branches a rebase has moved
+func (c *GitCommand) GetBranchHeads() (map[string]string, error) {
+	cmdStr := `git for-each-ref --format="%(refname:short)|%(objectname)" refs/heads`
+	output, err := c.OSCommand.RunCommandWithOutput(cmdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	heads := map[string]string{}
+	for _, line := range utils.SplitLines(output) {
+		split := strings.SplitN(line, "|", 2)
+		if len(split) == 2 {
+			heads[split[0]] = split[1]
+		}
+	}
+	return heads, nil
+}
+
 // Fetch fetch git repo
-func (c *GitCommand) Fetch(unamePassQuestion func(string) string, canAskForCredentials bool) error {
-	return c.OSCommand.DetectUnamePass("git fetch", func(question string) string {
+func (c *GitCommand) Fetch(ctx context.Context, unamePassQuestion func(string) string, canAskForCredentials bool) error {
+	return c.OSCommand.DetectUnamePassWithEnvContext(ctx, "git fetch", c.remoteEnv(""), func(question string) string {
 		if canAskForCredentials {
 			return unamePassQuestion(question)
 		}
@@ -406,12 +454,12 @@ func (c *GitCommand) AmendHead() (*exec.Cmd, error) {
 
 // Pull pulls from repo
 func (c *GitCommand) Pull(args string, ask func(string) string) error {
-	return c.OSCommand.DetectUnamePass("git pull --no-edit "+args, ask)
+	return c.OSCommand.DetectUnamePassWithEnv("git pull --no-edit "+args, c.remoteEnv(remoteFromArgs(args)), ask)
 }
 
 // PullWithoutPasswordCheck assumes that the pull will not prompt the user for a password
 func (c *GitCommand) PullWithoutPasswordCheck(args string) error {
-	return c.OSCommand.RunCommand("git pull --no-edit " + args)
+	return c.OSCommand.RunCommandWithEnv("git pull --no-edit "+args, c.remoteEnv(remoteFromArgs(args)))
 }
 
 // Push pushes to a branch
@@ -426,8 +474,13 @@ func (c *GitCommand) Push(branchName string, force bool, upstream string, args s
 		setUpstreamArg = "--set-upstream " + upstream
 	}
 
+	remoteName := remoteFromArgs(upstream)
+	if remoteName == "" {
+		remoteName = remoteFromArgs(args)
+	}
+
 	cmd := fmt.Sprintf("git push --follow-tags %s %s %s", forceFlag, setUpstreamArg, args)
-	return c.OSCommand.DetectUnamePass(cmd, ask)
+	return c.OSCommand.DetectUnamePassWithEnv(cmd, c.remoteEnv(remoteName), ask)
 }
 
 // CatFile obtains the content of a file
@@ -440,11 +493,27 @@ func (c *GitCommand) StageFile(fileName string) error {
 	return c.OSCommand.RunCommand("git add %s", c.OSCommand.Quote(fileName))
 }
 
+// StageFiles stages several files at once
+func (c *GitCommand) StageFiles(fileNames []string) error {
+	quotedFileNames := make([]string, len(fileNames))
+	for i, fileName := range fileNames {
+		quotedFileNames[i] = c.OSCommand.Quote(fileName)
+	}
+	return c.OSCommand.RunCommand("git add -- %s", strings.Join(quotedFileNames, " "))
+}
+
 // StageAll stages all files
 func (c *GitCommand) StageAll() error {
 	return c.OSCommand.RunCommand("git add -A")
 }
 
+// RefreshIndex re-reads every tracked file rather than trusting the stat info
+// cached in the index, for when something has changed files without changing
+// their modification times
+func (c *GitCommand) RefreshIndex() error {
+	return c.OSCommand.RunCommand("git update-index -q --really-refresh")
+}
+
 // UnstageAll stages all files
 func (c *GitCommand) UnstageAll() error {
 	return c.OSCommand.RunCommand("git reset")
@@ -469,7 +538,7 @@ func (c *GitCommand) UnStageFile(fileName string, tracked bool) error {
 
 // GitStatus returns the plaintext short status of the repo
 func (c *GitCommand) GitStatus() (string, error) {
-	return c.OSCommand.RunCommandWithOutput("git status --untracked-files=all --porcelain")
+	return c.OSCommand.RunCommandWithOutput("git status --untracked-files=%s --porcelain", c.untrackedFilesMode())
 }
 
 // IsInMergeState states whether we are still mid-merge
@@ -502,9 +571,9 @@ func (c *GitCommand) RebaseMode() (string, error) {
 // DiscardAllFileChanges directly
 func (c *GitCommand) DiscardAllFileChanges(file *File) error {
 	// if the file isn't tracked, we assume you want to delete it
-	quotedFileName := c.OSCommand.Quote(file.Name)
+	quotedFileName := c.OSCommand.Quote(file.NameInRepo())
 	if file.HasStagedChanges || file.HasMergeConflicts {
-		if err := c.OSCommand.RunCommand("git reset -- %s", quotedFileName); err != nil {
+		if err := c.OSCommand.RunCommand("git %sreset -- %s", c.repoArg(file), quotedFileName); err != nil {
 			return err
 		}
 	}
@@ -517,8 +586,8 @@ func (c *GitCommand) DiscardAllFileChanges(file *File) error {
 
 // DiscardUnstagedFileChanges directly
 func (c *GitCommand) DiscardUnstagedFileChanges(file *File) error {
-	quotedFileName := c.OSCommand.Quote(file.Name)
-	return c.OSCommand.RunCommand("git checkout -- %s", quotedFileName)
+	quotedFileName := c.OSCommand.Quote(file.NameInRepo())
+	return c.OSCommand.RunCommand("git %scheckout -- %s", c.repoArg(file), quotedFileName)
 }
 
 // Checkout checks out a branch (or commit), with --force if you set the force arg to true
@@ -558,8 +627,14 @@ func (c *GitCommand) Ignore(filename string) error {
 	return c.OSCommand.AppendLineToFile(".gitignore", filename)
 }
 
-func (c *GitCommand) ShowCmdStr(sha string) string {
-	return fmt.Sprintf("git show --color=%s --no-renames --stat -p %s", c.colorArg(), sha)
+// ShowCmdStr shows a commit's diff, with a summary of the files changed above
+// it. The summary is made to fit the given width, if it's known.
+func (c *GitCommand) ShowCmdStr(sha string, pagingContext string, width int) string {
+	stat := "--stat"
+	if width > 0 {
+		stat = fmt.Sprintf("--stat=%d", width)
+	}
+	return fmt.Sprintf("git show%s --color=%s --no-renames %s -p %s", c.submoduleDiffArg(), c.colorArg(pagingContext), stat, sha)
 }
 
 func (c *GitCommand) GetBranchGraphCmdStr(branchName string) string {
@@ -592,8 +667,9 @@ func (c *GitCommand) Diff(file *File, plain bool, cached bool) string {
 func (c *GitCommand) DiffCmdStr(file *File, plain bool, cached bool) string {
 	cachedArg := ""
 	trackedArg := "--"
-	colorArg := c.colorArg()
-	split := strings.Split(file.Name, " -> ") // in case of a renamed file we get the new filename
+	colorArg := c.colorArg(PagingContextFiles)
+	submoduleArg := c.submoduleDiffArg()
+	split := strings.Split(file.NameInRepo(), " -> ") // in case of a renamed file we get the new filename
 	fileName := c.OSCommand.Quote(split[len(split)-1])
 	if cached {
 		cachedArg = "--cached"
@@ -603,9 +679,17 @@ func (c *GitCommand) DiffCmdStr(file *File, plain bool, cached bool) string {
 	}
 	if plain {
 		colorArg = "never"
+		// a plain diff is for building patches out of
+		submoduleArg = ""
 	}
 
-	return fmt.Sprintf("git diff --color=%s %s %s %s", colorArg, cachedArg, trackedArg, fileName)
+	return fmt.Sprintf("git %sdiff%s --color=%s %s %s %s", c.repoArg(file), submoduleArg, colorArg, cachedArg, trackedArg, fileName)
+}
+
+// PathDiffCmdStr diffs a file, or everything under a directory, in the working
+// tree against a ref
+func (c *GitCommand) PathDiffCmdStr(ref string, path string) string {
+	return fmt.Sprintf("git diff%s --color=%s %s -- %s", c.submoduleDiffArg(), c.colorArg(PagingContextFiles), ref, c.OSCommand.Quote(path))
 }
 
 func (c *GitCommand) ApplyPatch(patch string, flags ...string) error {
@@ -624,7 +708,8 @@ func (c *GitCommand) ApplyPatch(patch string, flags ...string) error {
 }
 
 func (c *GitCommand) FastForward(branchName string, remoteName string, remoteBranchName string) error {
-	return c.OSCommand.RunCommand("git fetch %s %s:%s", remoteName, remoteBranchName, branchName)
+	command := fmt.Sprintf("git fetch %s %s:%s", remoteName, remoteBranchName, branchName)
+	return c.OSCommand.RunCommandWithEnv(command, c.remoteEnv(remoteName))
 }
 
 func (c *GitCommand) RunSkipEditorCommand(command string) error {
@@ -676,6 +761,54 @@ func (c *GitCommand) RewordCommit(commits []*Commit, index int) (*exec.Cmd, erro
 	return c.PrepareInteractiveRebaseCommand(sha, todo, false)
 }
 
+// GetCommitMessage returns the full message of a commit
+func (c *GitCommand) GetCommitMessage(sha string) (string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git log -1 --format=%%B %s", sha)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(output, "\n"), nil
+}
+
+// RewordCommits gives each of the commits at the given indexes a new message
+// in a single rebase. Each message is written to a temp file which an exec
+// line after the commit amends it with.
+func (c *GitCommand) RewordCommits(commits []*Commit, messages map[int]string) error {
+	oldest := -1
+	for index := range messages {
+		if index > oldest {
+			oldest = index
+		}
+	}
+	if oldest == -1 {
+		return nil
+	}
+	if len(commits) <= oldest+1 {
+		return errors.New(c.Tr.SLocalize("CannotRebaseOntoFirstCommit"))
+	}
+
+	todo := ""
+	for i, commit := range commits[0 : oldest+1] {
+		lines := "pick " + commit.Sha + " " + commit.Name + "\n"
+		if message, ok := messages[i]; ok {
+			path, err := c.OSCommand.CreateTempFile("lazygit-reword-"+commit.Sha, message)
+			if err != nil {
+				return err
+			}
+			quotedPath := c.OSCommand.Quote(path)
+			lines += fmt.Sprintf("exec git commit --allow-empty --amend --no-verify -F %s && rm %s\n", quotedPath, quotedPath)
+		}
+		todo = lines + todo
+	}
+
+	cmd, err := c.PrepareInteractiveRebaseCommand(commits[oldest+1].Sha, todo, true)
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
+
 func (c *GitCommand) MoveCommitDown(commits []*Commit, index int) error {
 	// we must ensure that we have at least two commits after the selected one
 	if len(commits) <= index+2 {
@@ -715,6 +848,10 @@ func (c *GitCommand) InteractiveRebase(commits []*Commit, index int, action stri
 // we tell git to run lazygit to edit the todo list, and we pass the client
 // lazygit a todo string to write to the todo file
 func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string, overrideEditor bool) (*exec.Cmd, error) {
+	return c.prepareInteractiveRebaseCommand(baseSha, todo, overrideEditor)
+}
+
+func (c *GitCommand) prepareInteractiveRebaseCommand(baseSha string, todo string, overrideEditor bool, extraArgs ...string) (*exec.Cmd, error) {
 	ex := c.OSCommand.GetLazygitPath()
 
 	debug := "FALSE"
@@ -722,7 +859,8 @@ func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string
 		debug = "TRUE"
 	}
 
-	splitCmd := str.ToArgv(fmt.Sprintf("git rebase --interactive --autostash --keep-empty --rebase-merges %s", baseSha))
+	args := append([]string{"--interactive", "--autostash", "--keep-empty", "--rebase-merges"}, extraArgs...)
+	splitCmd := str.ToArgv(fmt.Sprintf("git rebase %s %s", strings.Join(args, " "), baseSha))
 
 	cmd := c.OSCommand.command(splitCmd[0], splitCmd[1:]...)
 
@@ -731,7 +869,7 @@ func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string
 		gitSequenceEditor = "true"
 	}
 
-	cmd.Env = os.Environ()
+	cmd.Env = c.OSCommand.addGitEnv(os.Environ())
 	cmd.Env = append(
 		cmd.Env,
 		"LAZYGIT_CLIENT_COMMAND=INTERACTIVE_REBASE",
@@ -796,6 +934,9 @@ func (c *GitCommand) AmendTo(sha string) error {
 // EditRebaseTodo sets the action at a given index in the git-rebase-todo file
 func (c *GitCommand) EditRebaseTodo(index int, action string) error {
 	fileName := fmt.Sprintf("%s/rebase-merge/git-rebase-todo", c.DotGitDir)
+	if err := c.OSCommand.CheckWritable(fileName); err != nil {
+		return err
+	}
 	bytes, err := ioutil.ReadFile(fileName)
 	if err != nil {
 		return err
@@ -828,6 +969,9 @@ func (c *GitCommand) getTodoCommitCount(content []string) int {
 // MoveTodoDown moves a rebase todo item down by one position
 func (c *GitCommand) MoveTodoDown(index int) error {
 	fileName := fmt.Sprintf("%s/rebase-merge/git-rebase-todo", c.DotGitDir)
+	if err := c.OSCommand.CheckWritable(fileName); err != nil {
+		return err
+	}
 	bytes, err := ioutil.ReadFile(fileName)
 	if err != nil {
 		return err
@@ -897,12 +1041,14 @@ func (c *GitCommand) ShowCommitFile(commitSha, fileName string, plain bool) (str
 }
 
 func (c *GitCommand) ShowCommitFileCmdStr(commitSha, fileName string, plain bool) string {
-	colorArg := c.colorArg()
+	colorArg := c.colorArg(PagingContextCommitFiles)
+	submoduleArg := c.submoduleDiffArg()
 	if plain {
 		colorArg = "never"
+		submoduleArg = ""
 	}
 
-	return fmt.Sprintf("git show --no-renames --color=%s %s -- %s", colorArg, commitSha, fileName)
+	return fmt.Sprintf("git show%s --no-renames --color=%s %s -- %s", submoduleArg, colorArg, commitSha, fileName)
 }
 
 // CheckoutFile checks out the file for the given commit
@@ -968,7 +1114,46 @@ func (c *GitCommand) ResetSoft(ref string) error {
 
 // DiffCommits show diff between commits
 func (c *GitCommand) DiffCommits(sha1, sha2 string) (string, error) {
-	return c.OSCommand.RunCommandWithOutput("git diff --color=%s --stat -p %s %s", c.colorArg(), sha1, sha2)
+	return c.OSCommand.RunCommandWithOutput("git diff%s --color=%s --stat -p %s %s", c.submoduleDiffArg(), c.colorArg(PagingContextCommits), sha1, sha2)
+}
+
+// GetRefNames returns the names of the branches, remote branches and tags
+func (c *GitCommand) GetRefNames() ([]string, error) {
+	cmdStr := `git for-each-ref --format="%(refname:short)" refs/heads refs/remotes refs/tags`
+	output, err := c.OSCommand.RunCommandWithOutput(cmdStr)
+	if err != nil {
+		return nil, err
+	}
+	return utils.SplitLines(output), nil
+}
+
+// IsCommitRef tells us whether the given ref, sha or revision names a commit
+func (c *GitCommand) IsCommitRef(ref string) bool {
+	return c.OSCommand.RunCommand("git rev-parse --verify --quiet %s^{commit}", ref) == nil
+}
+
+// GetComparisonFiles returns the files that differ between two refs
+func (c *GitCommand) GetComparisonFiles(from string, to string) ([]*CommitFile, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git diff --name-only --no-renames %s %s", from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	commitFiles := []*CommitFile{}
+	for _, file := range utils.SplitLines(output) {
+		commitFiles = append(commitFiles, &CommitFile{
+			Sha:           to,
+			Name:          file,
+			DisplayString: file,
+			Status:        UNSELECTED,
+		})
+	}
+	return commitFiles, nil
+}
+
+// ComparisonFileCmdStr diffs a file between two refs
+func (c *GitCommand) ComparisonFileCmdStr(from string, to string, fileName string) string {
+	return fmt.Sprintf("git diff%s --no-renames --color=%s %s %s -- %s", c.submoduleDiffArg(), c.colorArg(PagingContextCommitFiles), from, to, fileName)
 }
 
 // CreateFixupCommit creates a commit that fixes up a previous commit
@@ -1074,7 +1259,8 @@ func (c *GitCommand) IsHeadDetached() bool {
 }
 
 func (c *GitCommand) DeleteRemoteBranch(remoteName string, branchName string) error {
-	return c.OSCommand.RunCommand("git push %s --delete %s", remoteName, branchName)
+	command := fmt.Sprintf("git push %s --delete %s", remoteName, branchName)
+	return c.OSCommand.RunCommandWithEnv(command, c.remoteEnv(remoteName))
 }
 
 func (c *GitCommand) SetBranchUpstream(remoteName string, remoteBranchName string, branchName string) error {
@@ -1098,15 +1284,17 @@ func (c *GitCommand) DeleteTag(tagName string) error {
 }
 
 func (c *GitCommand) PushTag(remoteName string, tagName string) error {
-	return c.OSCommand.RunCommand("git push %s %s", remoteName, tagName)
+	command := fmt.Sprintf("git push %s %s", remoteName, tagName)
+	return c.OSCommand.RunCommandWithEnv(command, c.remoteEnv(remoteName))
 }
 
 func (c *GitCommand) FetchRemote(remoteName string) error {
-	return c.OSCommand.RunCommand("git fetch %s", remoteName)
+	return c.OSCommand.RunCommandWithEnv("git fetch "+remoteName, c.remoteEnv(remoteName))
 }
 
 func (c *GitCommand) GetReflogCommits() ([]*Commit, error) {
-	output, err := c.OSCommand.RunCommandWithOutput("git reflog --abbrev=20")
+	// with a date format, the HEAD@{n} selector holds the entry's time
+	output, err := c.OSCommand.RunCommandWithOutput("git reflog --abbrev=20 --date=unix")
 	if err != nil {
 		// assume error means we have no reflog
 		return []*Commit{}, nil
@@ -1114,17 +1302,19 @@ func (c *GitCommand) GetReflogCommits() ([]*Commit, error) {
 
 	lines := strings.Split(strings.TrimSpace(output), "\n")
 	commits := make([]*Commit, 0)
-	re := regexp.MustCompile(`(\w+).*HEAD@\{\d+\}: (.*)`)
+	re := regexp.MustCompile(`(\w+).*HEAD@\{(\d+)\}: (.*)`)
 	for _, line := range lines {
 		match := re.FindStringSubmatch(line)
 		if len(match) <= 1 {
 			continue
 		}
 
+		unixTimestamp, _ := strconv.ParseInt(match[2], 10, 64)
 		commit := &Commit{
-			Sha:    match[1],
-			Name:   match[2],
-			Status: "reflog",
+			Sha:           match[1],
+			Name:          match[3],
+			Status:        "reflog",
+			UnixTimestamp: unixTimestamp,
 		}
 
 		commits = append(commits, commit)
@@ -1148,23 +1338,51 @@ func (c *GitCommand) ConfiguredPager() string {
 	return strings.Split(trimmedOutput, "\n")[0]
 }
 
-func (c *GitCommand) GetPager(width int) string {
-	useConfig := c.Config.GetUserConfig().GetBool("git.paging.useConfig")
-	if useConfig {
-		pager := c.ConfiguredPager()
-		return strings.Split(pager, "| less")[0]
+// Each kind of diff we show has a paging context, so that it can have its own
+// pager and colorArg under git.paging.contexts. Anything not set for a context
+// comes from git.paging itself.
+const (
+	PagingContextFiles       = "files"
+	PagingContextCommits     = "commits"
+	PagingContextCommitFiles = "commitFiles"
+	PagingContextReflog      = "reflog"
+	PagingContextStash       = "stash"
+)
+
+// getPagingSetting returns a git.paging setting for the given paging context,
+// and whether the context sets it itself
+func (c *GitCommand) getPagingSetting(context string, key string) (string, bool) {
+	userConfig := c.Config.GetUserConfig()
+	contextKey := fmt.Sprintf("git.paging.contexts.%s.%s", context, key)
+	if userConfig.IsSet(contextKey) {
+		return userConfig.GetString(contextKey), true
+	}
+	return userConfig.GetString("git.paging." + key), false
+}
+
+func (c *GitCommand) GetPager(width int, context string) string {
+	pagerTemplate, contextHasPager := c.getPagingSetting(context, "pager")
+	// a context's own pager wins out over the global useConfig, so that e.g.
+	// commits can use difftastic while everything else uses git's pager. An
+	// empty pager for a context means it doesn't get one.
+	if !contextHasPager {
+		useConfig, _ := c.getPagingSetting(context, "useConfig")
+		if useConfig == "true" {
+			pager := c.ConfiguredPager()
+			return strings.Split(pager, "| less")[0]
+		}
 	}
 
 	templateValues := map[string]string{
 		"columnWidth": strconv.Itoa(width/2 - 6),
 	}
 
-	pagerTemplate := c.Config.GetUserConfig().GetString("git.paging.pager")
 	return utils.ResolvePlaceholderString(pagerTemplate, templateValues)
 }
 
-func (c *GitCommand) colorArg() string {
-	return c.Config.GetUserConfig().GetString("git.paging.colorArg")
+func (c *GitCommand) colorArg(context string) string {
+	colorArg, _ := c.getPagingSetting(context, "colorArg")
+	return colorArg
 }
 
 func (c *GitCommand) RenameBranch(oldName string, newName string) error {
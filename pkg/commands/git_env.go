@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os"
+	"strings"
+)
+
+// The user can set environment variables for the git commands we run, e.g. to
+// use a particular ssh key or proxy, and per repo via conditionalConfigs:
+//
+//   git:
+//     env:
+//       - GIT_SSH_COMMAND=ssh -i ~/.ssh/work_key
+//       - HTTPS_PROXY=http://proxy.example.com:3128
+//
+// They're a list rather than a map because viper lowercases map keys, and
+// environment variable names are case sensitive.
+
+// SetGitTraceFile turns on GIT_TRACE for the git commands we run from here on,
+// with the trace going to the given file. An empty path turns it off again
+func (c *OSCommand) SetGitTraceFile(path string) {
+	c.gitTraceMutex.Lock()
+	defer c.gitTraceMutex.Unlock()
+	c.gitTraceFile = path
+}
+
+// GetGitTraceFile returns the file git commands are tracing to, if any
+func (c *OSCommand) GetGitTraceFile() string {
+	c.gitTraceMutex.Lock()
+	defer c.gitTraceMutex.Unlock()
+	return c.gitTraceFile
+}
+
+// addGitEnv adds the user's git.env to a git command's environment, along
+// with GIT_TRACE if we're tracing
+func (c *OSCommand) addGitEnv(env []string) []string {
+	for _, variable := range c.Config.GetUserConfig().GetStringSlice("git.env") {
+		if !strings.Contains(variable, "=") {
+			c.Log.Warnf("ignoring git.env entry without an '=': %s", variable)
+			continue
+		}
+		env = append(env, variable)
+	}
+	if traceFile := c.GetGitTraceFile(); traceFile != "" {
+		env = append(env, "GIT_TRACE="+traceFile)
+	}
+	return env
+}
+
+func (c *OSCommand) commandEnv(cmdName string) []string {
+	env := append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	if cmdName == "git" {
+		env = c.addGitEnv(env)
+	}
+	return env
+}
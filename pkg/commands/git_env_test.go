@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandGitEnv is a function.
+func TestOSCommandGitEnv(t *testing.T) {
+	type scenario struct {
+		testName  string
+		command   string
+		gitEnv    []interface{}
+		traceFile string
+		included  []string
+		excluded  []string
+	}
+
+	scenarios := []scenario{
+		{
+			"git command gets git.env",
+			"git fetch",
+			[]interface{}{"GIT_SSH_COMMAND=ssh -i ~/.ssh/work_key", "HTTPS_PROXY=http://proxy:3128"},
+			"",
+			[]string{"GIT_OPTIONAL_LOCKS=0", "GIT_SSH_COMMAND=ssh -i ~/.ssh/work_key", "HTTPS_PROXY=http://proxy:3128"},
+			[]string{"GIT_TRACE=/tmp/trace"},
+		},
+		{
+			"entries without an equals sign are ignored",
+			"git status",
+			[]interface{}{"GIT_SSH_COMMAND"},
+			"",
+			[]string{"GIT_OPTIONAL_LOCKS=0"},
+			[]string{"GIT_SSH_COMMAND"},
+		},
+		{
+			"tracing",
+			"git status",
+			[]interface{}{},
+			"/tmp/trace",
+			[]string{"GIT_TRACE=/tmp/trace"},
+			nil,
+		},
+		{
+			"other commands don't get git.env",
+			"echo hello",
+			[]interface{}{"GIT_SSH_COMMAND=ssh -i ~/.ssh/work_key"},
+			"/tmp/trace",
+			[]string{"GIT_OPTIONAL_LOCKS=0"},
+			[]string{"GIT_SSH_COMMAND=ssh -i ~/.ssh/work_key", "GIT_TRACE=/tmp/trace"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			osCommand := NewDummyOSCommand()
+			osCommand.Config.GetUserConfig().Set("git.env", s.gitEnv)
+			osCommand.SetGitTraceFile(s.traceFile)
+
+			env := osCommand.ExecutableFromString(s.command).Env
+			for _, variable := range s.included {
+				assert.Contains(t, env, variable)
+			}
+			for _, variable := range s.excluded {
+				assert.NotContains(t, env, variable)
+			}
+		})
+	}
+}
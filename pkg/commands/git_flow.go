@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// GitFlowBranchTypes are the kinds of branch that git-flow deals in
+var GitFlowBranchTypes = []string{"feature", "release", "hotfix"}
+
+// GitFlowConfig is how git-flow has been set up in a repo. We do the merging
+// and tagging ourselves rather than shelling out to the git-flow extension, so
+// that it works without the extension installed and so that trunk-based repos
+// get the same commands.
+type GitFlowConfig struct {
+	MainBranch string
+	// DevelopBranch is empty for trunk-based repos, where everything is
+	// finished straight into the main branch
+	DevelopBranch string
+	// Prefixes maps each branch type to the prefix of its branch names
+	Prefixes         map[string]string
+	VersionTagPrefix string
+}
+
+// NewGitFlowConfig returns git-flow's default config
+func NewGitFlowConfig(mainBranch string, trunkBased bool) *GitFlowConfig {
+	config := &GitFlowConfig{
+		MainBranch:    mainBranch,
+		DevelopBranch: "develop",
+		Prefixes: map[string]string{
+			"feature": "feature/",
+			"release": "release/",
+			"hotfix":  "hotfix/",
+		},
+	}
+	if trunkBased {
+		config.DevelopBranch = ""
+	}
+	return config
+}
+
+// parseGitFlowConfig overlays the output of `git config --get-regexp gitflow`
+// onto the default config
+func parseGitFlowConfig(output string, config *GitFlowConfig) {
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		split := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if len(split) < 2 {
+			continue
+		}
+		key, value := split[0], split[1]
+
+		switch {
+		case key == "gitflow.branch.master" || key == "gitflow.branch.main":
+			config.MainBranch = value
+		case key == "gitflow.branch.develop":
+			if config.DevelopBranch != "" {
+				config.DevelopBranch = value
+			}
+		case key == "gitflow.prefix.versiontag":
+			config.VersionTagPrefix = value
+		case strings.HasPrefix(key, "gitflow.prefix."):
+			branchType := strings.TrimPrefix(key, "gitflow.prefix.")
+			if _, ok := config.Prefixes[branchType]; ok {
+				config.Prefixes[branchType] = value
+			}
+		}
+	}
+}
+
+// GetGitFlowConfig returns the repo's git-flow config, and whether git-flow has
+// actually been initialised in the repo. If it hasn't, we return the defaults.
+func (c *GitCommand) GetGitFlowConfig(trunkBased bool) (*GitFlowConfig, bool) {
+	mainBranch := "master"
+	if err := c.OSCommand.RunCommand("git rev-parse --verify --quiet refs/heads/main"); err == nil {
+		mainBranch = "main"
+	}
+	config := NewGitFlowConfig(mainBranch, trunkBased)
+
+	output, err := c.OSCommand.RunCommandWithOutput("git config --local --get-regexp gitflow")
+	if err != nil {
+		return config, false
+	}
+	parseGitFlowConfig(output, config)
+	return config, true
+}
+
+// BranchType returns the git-flow type of a branch and its name without the
+// prefix, or ok = false if it isn't a git-flow branch
+func (config *GitFlowConfig) BranchType(branchName string) (branchType string, name string, ok bool) {
+	for _, branchType := range GitFlowBranchTypes {
+		prefix := config.Prefixes[branchType]
+		if prefix != "" && strings.HasPrefix(branchName, prefix) {
+			return branchType, strings.TrimPrefix(branchName, prefix), true
+		}
+	}
+	return "", "", false
+}
+
+// baseBranch is where a branch of the given type starts from
+func (config *GitFlowConfig) baseBranch(branchType string) string {
+	if branchType == "hotfix" || config.DevelopBranch == "" {
+		return config.MainBranch
+	}
+	return config.DevelopBranch
+}
+
+// GitFlowStart creates and checks out a new git-flow branch
+func (c *GitCommand) GitFlowStart(config *GitFlowConfig, branchType string, name string) error {
+	return c.OSCommand.RunCommand("git checkout -b %s%s %s", config.Prefixes[branchType], name, config.baseBranch(branchType))
+}
+
+// GitFlowFinish merges a git-flow branch back to where it belongs and deletes
+// it. Features go into the develop branch. Releases and hotfixes go into the
+// main branch, get tagged, and then go into the develop branch too. If a merge
+// hits conflicts we stop there, and once the user has resolved them they can
+// finish the branch again to do the rest.
+func (c *GitCommand) GitFlowFinish(config *GitFlowConfig, branchName string) error {
+	branchType, name, ok := config.BranchType(branchName)
+	if !ok {
+		return errors.New(c.Tr.SLocalize("NotAGitFlowBranch"))
+	}
+
+	merge := func(into string) error {
+		if err := c.OSCommand.RunCommand("git checkout %s", into); err != nil {
+			return err
+		}
+		return c.OSCommand.RunCommand("git merge --no-ff --no-edit %s", branchName)
+	}
+
+	if branchType == "feature" {
+		if err := merge(config.baseBranch(branchType)); err != nil {
+			return err
+		}
+	} else {
+		if err := merge(config.MainBranch); err != nil {
+			return err
+		}
+
+		tag := config.VersionTagPrefix + name
+		// the tag will already be there if we're finishing again after conflicts
+		if err := c.OSCommand.RunCommand("git rev-parse --verify --quiet refs/tags/%s", tag); err != nil {
+			if err := c.OSCommand.RunCommand("git tag -a %s -m %s", tag, tag); err != nil {
+				return err
+			}
+		}
+
+		if config.DevelopBranch != "" {
+			if err := merge(config.DevelopBranch); err != nil {
+				return err
+			}
+		}
+	}
+
+	return c.OSCommand.RunCommand("git branch -d %s", branchName)
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseGitFlowConfig is a function.
+func TestParseGitFlowConfig(t *testing.T) {
+	type scenario struct {
+		testName   string
+		trunkBased bool
+		output     string
+		expected   *GitFlowConfig
+	}
+
+	scenarios := []scenario{
+		{
+			"no config",
+			false,
+			"",
+			NewGitFlowConfig("master", false),
+		},
+		{
+			"custom branches and prefixes",
+			false,
+			"gitflow.branch.master main\ngitflow.branch.develop dev\ngitflow.prefix.feature feat/\ngitflow.prefix.versiontag v\ngitflow.prefix.support support/\n",
+			&GitFlowConfig{
+				MainBranch:    "main",
+				DevelopBranch: "dev",
+				Prefixes: map[string]string{
+					"feature": "feat/",
+					"release": "release/",
+					"hotfix":  "hotfix/",
+				},
+				VersionTagPrefix: "v",
+			},
+		},
+		{
+			"trunk-based ignores the develop branch",
+			true,
+			"gitflow.branch.develop dev\n",
+			NewGitFlowConfig("master", true),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			config := NewGitFlowConfig("master", s.trunkBased)
+			parseGitFlowConfig(s.output, config)
+			assert.EqualValues(t, s.expected, config)
+		})
+	}
+}
+
+// TestGitCommandGitFlowFinish is a function.
+func TestGitCommandGitFlowFinish(t *testing.T) {
+	type scenario struct {
+		testName   string
+		config     *GitFlowConfig
+		branchName string
+		command    func(string, ...string) *exec.Cmd
+		test       func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"feature",
+			NewGitFlowConfig("master", false),
+			"feature/foo",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git checkout develop", Replace: "echo"},
+				{Expect: "git merge --no-ff --no-edit feature/foo", Replace: "echo"},
+				{Expect: "git branch -d feature/foo", Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"release",
+			NewGitFlowConfig("master", false),
+			"release/1.0",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git checkout master", Replace: "echo"},
+				{Expect: "git merge --no-ff --no-edit release/1.0", Replace: "echo"},
+				{Expect: "git rev-parse --verify --quiet refs/tags/1.0", Replace: "test"},
+				{Expect: "git tag -a 1.0 -m 1.0", Replace: "echo"},
+				{Expect: "git checkout develop", Replace: "echo"},
+				{Expect: "git merge --no-ff --no-edit release/1.0", Replace: "echo"},
+				{Expect: "git branch -d release/1.0", Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"trunk-based hotfix that was already tagged",
+			NewGitFlowConfig("main", true),
+			"hotfix/1.0.1",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git checkout main", Replace: "echo"},
+				{Expect: "git merge --no-ff --no-edit hotfix/1.0.1", Replace: "echo"},
+				{Expect: "git rev-parse --verify --quiet refs/tags/1.0.1", Replace: "echo"},
+				{Expect: "git branch -d hotfix/1.0.1", Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"merge conflicts",
+			NewGitFlowConfig("master", false),
+			"feature/foo",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git checkout develop", Replace: "echo"},
+				{Expect: "git merge --no-ff --no-edit feature/foo", Replace: "test"},
+			}),
+			func(err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"not a git-flow branch",
+			NewGitFlowConfig("master", false),
+			"foo",
+			test.CreateMockCommand(t, []*test.CommandSwapper{}),
+			func(err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.GitFlowFinish(s.config, s.branchName))
+		})
+	}
+}
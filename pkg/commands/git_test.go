@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -1052,6 +1053,19 @@ func TestGitCommandStageFile(t *testing.T) {
 	assert.NoError(t, gitCmd.StageFile("test.txt"))
 }
 
+// TestGitCommandStageFiles is a function.
+func TestGitCommandStageFiles(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"add", "--", "test.txt", "dir/other file.txt"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.StageFiles([]string{"test.txt", "dir/other file.txt"}))
+}
+
 // TestGitCommandUnstageFile is a function.
 func TestGitCommandUnstageFile(t *testing.T) {
 	type scenario struct {
@@ -1657,7 +1671,17 @@ func TestGitCommandApplyPatch(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.testName, func(t *testing.T) {
+			// ApplyPatch writes the patch into the user config dir, so we point
+			// that at a temp dir to avoid leaving files behind in the repo
+			configDir, err := ioutil.TempDir("", "lazygit-test")
+			assert.NoError(t, err)
+			defer os.RemoveAll(configDir)
+
+			appConfig := NewDummyAppConfig()
+			appConfig.UserConfigDir = configDir
+
 			gitCmd := NewDummyGitCommand()
+			gitCmd.Config = appConfig
 			gitCmd.OSCommand.command = s.command
 			s.test(gitCmd.ApplyPatch("test", "cached"))
 		})
@@ -1712,6 +1736,34 @@ func TestGitCommandRebaseBranch(t *testing.T) {
 	}
 }
 
+// TestGitCommandRebaseBranchUpdatingRefs is a function.
+func TestGitCommandRebaseBranchUpdatingRefs(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git rebase --interactive --autostash --keep-empty --rebase-merges --update-refs master",
+			Replace: "echo",
+		},
+	})
+
+	assert.NoError(t, gitCmd.RebaseBranchUpdatingRefs("master"))
+}
+
+// TestGitCommandGetBranchHeads is a function.
+func TestGitCommandGetBranchHeads(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  `git for-each-ref --format="%(refname:short)|%(objectname)" refs/heads`,
+			Replace: "echo 'master|abc123\nfeature|def456'",
+		},
+	})
+
+	heads, err := gitCmd.GetBranchHeads()
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]string{"master": "abc123", "feature": "def456"}, heads)
+}
+
 // TestGitCommandCheckoutFile is a function.
 func TestGitCommandCheckoutFile(t *testing.T) {
 	type scenario struct {
@@ -2032,6 +2084,39 @@ func TestGitCommandRemoveUntrackedFiles(t *testing.T) {
 	}
 }
 
+// TestGitCommandRefreshIndex is a function.
+func TestGitCommandRefreshIndex(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"valid case",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git update-index -q --really-refresh`,
+					Replace: "echo",
+				},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.RefreshIndex())
+		})
+	}
+}
+
 // TestGitCommandResetHard is a function.
 func TestGitCommandResetHard(t *testing.T) {
 	type scenario struct {
@@ -2207,3 +2292,204 @@ func TestFindDotGitDir(t *testing.T) {
 		})
 	}
 }
+
+// TestGitCommandGetReflogCommits is a function.
+func TestGitCommandGetReflogCommits(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git reflog --abbrev=20 --date=unix",
+			Replace: "echo 'd7d6a9c45caecf639954 HEAD@{1583452800}: commit: c\ne3ab32c4ba88a88ba7b8 HEAD@{1583366400}: checkout: moving from a to b'",
+		},
+	})
+
+	commits, err := gitCmd.GetReflogCommits()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*Commit{
+		{Sha: "d7d6a9c45caecf639954", Name: "commit: c", Status: "reflog", UnixTimestamp: 1583452800},
+		{Sha: "e3ab32c4ba88a88ba7b8", Name: "checkout: moving from a to b", Status: "reflog", UnixTimestamp: 1583366400},
+	}, commits)
+}
+
+// TestGitCommandGetPager is a function.
+func TestGitCommandGetPager(t *testing.T) {
+	type scenario struct {
+		testName string
+		context  string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{"context with its own pager", PagingContextCommits, "difft --width=44"},
+		{"context with no pager", PagingContextStash, ""},
+		{"context falling back to the global pager", PagingContextFiles, "delta"},
+	}
+
+	gitCmd := NewDummyGitCommand()
+	userConfig := gitCmd.Config.GetUserConfig()
+	userConfig.Set("git.paging.pager", "delta")
+	userConfig.Set("git.paging.contexts", map[string]interface{}{
+		"commits": map[string]interface{}{"pager": "difft --width={{columnWidth}}"},
+		"stash":   map[string]interface{}{"pager": ""},
+	})
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, gitCmd.GetPager(100, s.context))
+		})
+	}
+}
+
+// TestGitCommandShowCmdStr is a function.
+func TestGitCommandShowCmdStr(t *testing.T) {
+	type scenario struct {
+		testName string
+		width    int
+		expected string
+	}
+
+	scenarios := []scenario{
+		{"stat fitted to the width", 80, "git show --color=always --no-renames --stat=80 -p 1234567890"},
+		{"width unknown", 0, "git show --color=always --no-renames --stat -p 1234567890"},
+	}
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config.GetUserConfig().Set("git.paging.colorArg", "always")
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, gitCmd.ShowCmdStr("1234567890", PagingContextCommits, s.width))
+		})
+	}
+}
+
+// TestGitCommandGetRefNames is a function.
+func TestGitCommandGetRefNames(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  `git for-each-ref --format="%(refname:short)" refs/heads refs/remotes refs/tags`,
+			Replace: "echo 'master\norigin/master\nv1.0'",
+		},
+	})
+
+	refNames, err := gitCmd.GetRefNames()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"master", "origin/master", "v1.0"}, refNames)
+}
+
+// TestGitCommandGetComparisonFiles is a function.
+func TestGitCommandGetComparisonFiles(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func([]*CommitFile, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"files differ",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git diff --name-only --no-renames master feature",
+					Replace: "echo 'a.txt\nb/c.txt'",
+				},
+			}),
+			func(files []*CommitFile, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*CommitFile{
+					{Sha: "feature", Name: "a.txt", DisplayString: "a.txt", Status: UNSELECTED},
+					{Sha: "feature", Name: "b/c.txt", DisplayString: "b/c.txt", Status: UNSELECTED},
+				}, files)
+			},
+		},
+		{
+			"no files differ",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git diff --name-only --no-renames master feature",
+					Replace: "echo",
+				},
+			}),
+			func(files []*CommitFile, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, files, 0)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.GetComparisonFiles("master", "feature"))
+		})
+	}
+}
+
+// TestGitCommandPathDiffCmdStr is a function.
+func TestGitCommandPathDiffCmdStr(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config.GetUserConfig().Set("git.paging.colorArg", "always")
+
+	assert.EqualValues(t, "git diff --color=always master -- 'pkg/gui'", gitCmd.PathDiffCmdStr("master", "pkg/gui"))
+	assert.EqualValues(t, "git diff --color=always origin/master -- 'test.txt'", gitCmd.PathDiffCmdStr("origin/master", "test.txt"))
+}
+
+// TestGitCommandGetCommitMessage is a function.
+func TestGitCommandGetCommitMessage(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git log -1 --format=%B abc123",
+			Replace: "echo 'Fix typo\n\nIt was bugging me\n'",
+		},
+	})
+
+	message, err := gitCmd.GetCommitMessage("abc123")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "Fix typo\n\nIt was bugging me", message)
+}
+
+// TestGitCommandRewordCommits is a function.
+func TestGitCommandRewordCommits(t *testing.T) {
+	commits := []*Commit{
+		{Sha: "ddd", Name: "fourth"},
+		{Sha: "ccc", Name: "third"},
+		{Sha: "bbb", Name: "second"},
+		{Sha: "aaa", Name: "first"},
+	}
+
+	gitCmd := NewDummyGitCommand()
+	var cmd *exec.Cmd
+	gitCmd.OSCommand.command = func(name string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, []string{"rebase", "--interactive", "--autostash", "--keep-empty", "--rebase-merges", "aaa"}, args)
+		cmd = exec.Command("echo")
+		return cmd
+	}
+
+	assert.NoError(t, gitCmd.RewordCommits(commits, map[int]string{0: "Fourth", 2: "Second\n\nWith a body"}))
+
+	todo := ""
+	for _, env := range cmd.Env {
+		if strings.HasPrefix(env, "LAZYGIT_REBASE_TODO=") {
+			todo = strings.TrimPrefix(env, "LAZYGIT_REBASE_TODO=")
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(todo), "\n")
+	assert.Len(t, lines, 5)
+	assert.EqualValues(t, "pick bbb second", lines[0])
+	assert.EqualValues(t, "pick ccc third", lines[2])
+	assert.EqualValues(t, "pick ddd fourth", lines[3])
+
+	execRegexp := regexp.MustCompile(`^exec git commit --allow-empty --amend --no-verify -F '(.*)' && rm '.*'$`)
+	for i, expectedMessage := range map[int]string{1: "Second\n\nWith a body", 4: "Fourth"} {
+		match := execRegexp.FindStringSubmatch(lines[i])
+		if assert.NotNil(t, match, lines[i]) {
+			content, err := ioutil.ReadFile(match[1])
+			assert.NoError(t, err)
+			assert.EqualValues(t, expectedMessage, string(content))
+			_ = os.Remove(match[1])
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ServiceConnection is how to connect to a hosting service's web or API
+// endpoints, for networks that intercept TLS with their own certificate
+// authority:
+//
+//   serviceConnections:
+//     - host: 'github.example.com'
+//       caBundle: '~/certs/corp-ca.pem'
+//     - host: 'gitlab.internal'
+//       insecureSkipVerify: true
+//
+// Proxies are taken from HTTP_PROXY, HTTPS_PROXY and NO_PROXY as usual.
+type ServiceConnection struct {
+	Host string `yaml:"host"`
+	// CABundle is a PEM file of certificates to trust on top of the system's
+	CABundle           string `yaml:"caBundle"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+}
+
+func (c *OSCommand) getServiceConnections() []ServiceConnection {
+	rawConnections := c.Config.GetUserConfig().Get("serviceConnections")
+	if rawConnections == nil {
+		return nil
+	}
+	connectionsYaml, err := yaml.Marshal(rawConnections)
+	if err != nil {
+		return nil
+	}
+	connections := []ServiceConnection{}
+	if err := yaml.Unmarshal(connectionsYaml, &connections); err != nil {
+		c.Log.Error(err)
+		return nil
+	}
+	return connections
+}
+
+// NewHTTPClient returns a client for talking to hosting services, set up with
+// the user's serviceConnections entry for each host it connects to, if any
+func (c *OSCommand) NewHTTPClient() (*http.Client, error) {
+	return c.newHTTPClient(true)
+}
+
+// NewVerifyingHTTPClient is NewHTTPClient except that it always verifies
+// certificates, whatever insecureSkipVerify says, for downloading something
+// we're going to run, like a new lazygit binary
+func (c *OSCommand) NewVerifyingHTTPClient() (*http.Client, error) {
+	return c.newHTTPClient(false)
+}
+
+func (c *OSCommand) newHTTPClient(allowInsecure bool) (*http.Client, error) {
+	transport := &hostTransport{
+		transports: map[string]*http.Transport{},
+		fallback:   newTransport(&tls.Config{}),
+	}
+	for _, connection := range c.getServiceConnections() {
+		host := strings.ToLower(connection.Host)
+		if _, ok := transport.transports[host]; ok {
+			continue
+		}
+		tlsConfig := &tls.Config{}
+		if connection.CABundle != "" {
+			rootCAs, err := loadCABundle(connection.CABundle)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = rootCAs
+		}
+		if connection.InsecureSkipVerify && allowInsecure {
+			c.Log.Warnf("not verifying TLS certificates for %s", connection.Host)
+			tlsConfig.InsecureSkipVerify = true
+		}
+		transport.transports[host] = newTransport(tlsConfig)
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// hostTransport picks the TLS settings for each request by its own host, so
+// that a redirect to another host, e.g. from github.com to where release
+// assets are stored, doesn't carry over the settings of the first one
+type hostTransport struct {
+	transports map[string]*http.Transport
+	fallback   *http.Transport
+}
+
+func (t *hostTransport) transportFor(host string) *http.Transport {
+	if transport, ok := t.transports[strings.ToLower(host)]; ok {
+		return transport
+	}
+	return t.fallback
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transportFor(req.URL.Hostname()).RoundTrip(req)
+}
+
+// loadCABundle returns the system's certificate pool with the certificates in
+// the given PEM file added
+func loadCABundle(path string) (*x509.CertPool, error) {
+	if strings.HasPrefix(path, "~") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+		}
+	}
+
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificates found in " + path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCABundle writes a self-signed certificate to a PEM file in dir
+func writeTestCABundle(t *testing.T, dir string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Corp CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	path := filepath.Join(dir, "corp-ca.pem")
+	assert.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	return path
+}
+
+// tlsConfigFor returns the TLS settings the client uses for the given host
+func tlsConfigFor(client *http.Client, host string) *tls.Config {
+	return client.Transport.(*hostTransport).transportFor(host).TLSClientConfig
+}
+
+// TestOSCommandNewHTTPClient is a function.
+func TestOSCommandNewHTTPClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	caBundle := writeTestCABundle(t, dir)
+	notABundle := filepath.Join(dir, "not-a-bundle.pem")
+	assert.NoError(t, ioutil.WriteFile(notABundle, []byte("hello"), 0644))
+
+	type scenario struct {
+		testName    string
+		connections []interface{}
+		test        func(*http.Client, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"no matching connection",
+			[]interface{}{
+				map[string]interface{}{"host": "github.example.com", "insecureSkipVerify": true},
+			},
+			func(client *http.Client, err error) {
+				assert.NoError(t, err)
+				transport := client.Transport.(*hostTransport).transportFor("github.com")
+				assert.NotNil(t, transport.Proxy)
+				assert.Nil(t, transport.TLSClientConfig.RootCAs)
+				assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+			},
+		},
+		{
+			"ca bundle",
+			[]interface{}{
+				map[string]interface{}{"host": "github.example.com", "caBundle": caBundle},
+			},
+			func(client *http.Client, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, tlsConfigFor(client, "GitHub.example.com").RootCAs)
+				assert.False(t, tlsConfigFor(client, "GitHub.example.com").InsecureSkipVerify)
+				assert.Nil(t, tlsConfigFor(client, "objects.githubusercontent.com").RootCAs)
+			},
+		},
+		{
+			"insecure skip verify only applies to its own host",
+			[]interface{}{
+				map[string]interface{}{"host": "github.com", "insecureSkipVerify": true},
+			},
+			func(client *http.Client, err error) {
+				assert.NoError(t, err)
+				assert.True(t, tlsConfigFor(client, "github.com").InsecureSkipVerify)
+				assert.False(t, tlsConfigFor(client, "objects.githubusercontent.com").InsecureSkipVerify)
+			},
+		},
+		{
+			"ca bundle without certificates",
+			[]interface{}{
+				map[string]interface{}{"host": "github.example.com", "caBundle": notABundle},
+			},
+			func(client *http.Client, err error) {
+				assert.EqualError(t, err, "no certificates found in "+notABundle)
+			},
+		},
+		{
+			"missing ca bundle",
+			[]interface{}{
+				map[string]interface{}{"host": "github.example.com", "caBundle": filepath.Join(dir, "missing.pem")},
+			},
+			func(client *http.Client, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			osCommand := NewDummyOSCommand()
+			osCommand.Config.GetUserConfig().Set("serviceConnections", s.connections)
+			s.test(osCommand.NewHTTPClient())
+		})
+	}
+}
+
+// TestOSCommandNewVerifyingHTTPClient is a function.
+func TestOSCommandNewVerifyingHTTPClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("serviceConnections", []interface{}{
+		map[string]interface{}{"host": "github.com", "insecureSkipVerify": true, "caBundle": writeTestCABundle(t, dir)},
+	})
+
+	client, err := osCommand.NewVerifyingHTTPClient()
+	assert.NoError(t, err)
+	assert.False(t, tlsConfigFor(client, "github.com").InsecureSkipVerify)
+	assert.NotNil(t, tlsConfigFor(client, "github.com").RootCAs)
+}
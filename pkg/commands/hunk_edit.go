@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Editing a hunk works like the `e` option of `git add -p`: we write the hunk
+// out to a file, let the user change it in their editor, and then apply
+// whatever they leave behind to the index. Lines starting with '#' are
+// instructions and get thrown away.
+
+// CreateHunkEditFile writes a patch to a file for the user to edit, with
+// instructions on how to edit it underneath, and returns the file's path
+func (c *GitCommand) CreateHunkEditFile(patch string) (string, error) {
+	path := filepath.Join(c.repoDataDir("hunk-edits"), time.Now().Format("Jan _2 15.04.05.000000000")+".edit.patch")
+
+	content := strings.TrimRight(patch, "\n") + "\n" + c.Tr.SLocalize("EditHunkInstructions") + "\n"
+	if err := c.OSCommand.CreateFileWithContent(path, content); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// stripHunkEditComments removes the instruction lines from an edited hunk. If
+// no added or removed lines are left there's nothing to apply, in which case
+// we return an empty string.
+func stripHunkEditComments(content string) string {
+	lines := []string{}
+	hasChanges := false
+	inHunk := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "@@") {
+			inHunk = true
+		} else if inHunk && (strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-")) {
+			hasChanges = true
+		}
+		lines = append(lines, line)
+	}
+
+	if !hasChanges {
+		return ""
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// ApplyEditedHunk applies the hunk the user has edited to the index, or takes
+// it out of the index if reverse is true. Returns false if the user removed
+// every change, meaning there was nothing to do.
+func (c *GitCommand) ApplyEditedHunk(path string, reverse bool) (bool, error) {
+	flags := []string{"cached", "recount"}
+	if reverse {
+		flags = append(flags, "reverse")
+	}
+	return c.applyEditedPatch(path, flags...)
+}
+
+// ApplyEditedPatch applies a patch the user has edited to the working tree,
+// returning false if there was nothing left to apply
+func (c *GitCommand) ApplyEditedPatch(path string) (bool, error) {
+	return c.applyEditedPatch(path, "recount")
+}
+
+// applyEditedPatch reads back a patch the user has edited and applies it. Line
+// counts in the hunk headers are recounted by git (with the 'recount' flag),
+// so the user only has to worry about the lines themselves. We check that the
+// patch applies before applying it so that a bad edit never leaves things half
+// updated.
+func (c *GitCommand) applyEditedPatch(path string, flags ...string) (bool, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, WrapError(err)
+	}
+	if err := c.OSCommand.Remove(path); err != nil {
+		return false, err
+	}
+
+	patch := stripHunkEditComments(string(content))
+	if patch == "" {
+		return false, nil
+	}
+
+	if err := c.ApplyPatch(patch, append(flags, "check")...); err != nil {
+		return false, err
+	}
+	return true, c.ApplyPatch(patch, flags...)
+}
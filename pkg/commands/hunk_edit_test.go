@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const editedHunkHeader = `diff --git a/filename b/filename
+index e69de29..c4a9ab1 100644
+--- a/filename
++++ b/filename
+`
+
+// TestStripHunkEditComments is a function.
+func TestStripHunkEditComments(t *testing.T) {
+	type scenario struct {
+		testName string
+		content  string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"instructions are removed",
+			editedHunkHeader + "@@ -1,2 +1,2 @@\n a\n-b\n+c\n# Lines starting with # will be removed.\n",
+			editedHunkHeader + "@@ -1,2 +1,2 @@\n a\n-b\n+c\n",
+		},
+		{
+			"every change removed",
+			editedHunkHeader + "@@ -1,2 +1,2 @@\n a\n b\n# Lines starting with # will be removed.\n",
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, stripHunkEditComments(s.content))
+		})
+	}
+}
+
+// TestGitCommandApplyEditedHunk is a function.
+func TestGitCommandApplyEditedHunk(t *testing.T) {
+	type scenario struct {
+		testName        string
+		content         string
+		reverse         bool
+		expectedFlags   [][]string
+		expectedApplied bool
+	}
+
+	scenarios := []scenario{
+		{
+			"stage an edited hunk",
+			editedHunkHeader + "@@ -1,2 +1,2 @@\n a\n+c\n# instructions\n",
+			false,
+			[][]string{
+				{"apply", "--cached", "--recount", "--check"},
+				{"apply", "--cached", "--recount"},
+			},
+			true,
+		},
+		{
+			"unstage an edited hunk",
+			editedHunkHeader + "@@ -1,2 +1,2 @@\n a\n+c\n# instructions\n",
+			true,
+			[][]string{
+				{"apply", "--cached", "--recount", "--reverse", "--check"},
+				{"apply", "--cached", "--recount", "--reverse"},
+			},
+			true,
+		},
+		{
+			"nothing left to apply",
+			editedHunkHeader + "@@ -1,2 +1,2 @@\n a\n# instructions\n",
+			false,
+			[][]string{},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "lazygit-hunk-edit")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "hunk.patch")
+			assert.NoError(t, ioutil.WriteFile(path, []byte(s.content), 0644))
+
+			// ApplyPatch writes the patch into the user config dir
+			appConfig := NewDummyAppConfig()
+			appConfig.UserConfigDir = dir
+
+			gitCmd := NewDummyGitCommand()
+			gitCmd.Config = appConfig
+			callCount := 0
+			gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+				assert.Equal(t, "git", cmd)
+				assert.Less(t, callCount, len(s.expectedFlags))
+				expected := s.expectedFlags[callCount]
+				assert.EqualValues(t, expected, args[:len(expected)])
+				callCount++
+				return exec.Command("echo")
+			}
+
+			applied, err := gitCmd.ApplyEditedHunk(path, s.reverse)
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedApplied, applied)
+			assert.Equal(t, len(s.expectedFlags), callCount)
+
+			_, err = os.Stat(path)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
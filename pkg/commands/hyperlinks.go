@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	urlRegex      = regexp.MustCompile(`^https?://[^\s<>"']+$`)
+	issueRefRegex = regexp.MustCompile(`^#([0-9]+)$`)
+	shaRegex      = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+// Hyperlinker turns the things we show that have a home on the web (URLs,
+// commit shas and issue references) into links
+type Hyperlinker struct {
+	service  *Service
+	repoInfo *RepoInformation
+}
+
+// NewHyperlinker returns a Hyperlinker for the service our origin remote lives
+// on. If we don't know the service we can still link URLs.
+func NewHyperlinker(gitCommand *GitCommand) *Hyperlinker {
+	repoURL := gitCommand.GetRemoteURL()
+	if repoURL == "" {
+		return &Hyperlinker{}
+	}
+
+	for _, service := range getServices(gitCommand.Config) {
+		if strings.Contains(repoURL, service.Name) {
+			return &Hyperlinker{
+				service:  service,
+				repoInfo: getRepoInfoFromURL(repoURL),
+			}
+		}
+	}
+
+	return &Hyperlinker{}
+}
+
+// GetLink returns the link for a word of text, if it has one. Surrounding
+// punctuation is ignored so that e.g. '(#123),' links to issue 123.
+func (h *Hyperlinker) GetLink(word string) (string, bool) {
+	word = strings.TrimRight(strings.TrimLeft(word, "(<[{'\""), ")>]}'\".,:;!?")
+
+	if urlRegex.MatchString(word) {
+		return word, true
+	}
+
+	if h.service == nil {
+		return "", false
+	}
+
+	if match := issueRefRegex.FindStringSubmatch(word); match != nil {
+		return fmt.Sprintf(h.service.IssueURL, h.repoInfo.Owner, h.repoInfo.Repository, match[1]), true
+	}
+
+	if shaRegex.MatchString(word) {
+		return fmt.Sprintf(h.service.CommitURL, h.repoInfo.Owner, h.repoInfo.Repository, word), true
+	}
+
+	return "", false
+}
+
+// GetCommitLink returns the link to a commit on the hosting service
+func (h *Hyperlinker) GetCommitLink(sha string) (string, bool) {
+	if h.service == nil {
+		return "", false
+	}
+	return fmt.Sprintf(h.service.CommitURL, h.repoInfo.Owner, h.repoInfo.Repository, sha), true
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHyperlinkerGetLink is a function.
+func TestHyperlinkerGetLink(t *testing.T) {
+	type scenario struct {
+		testName    string
+		hyperlinker *Hyperlinker
+		word        string
+		expected    string
+		found       bool
+	}
+
+	github := &Hyperlinker{
+		service:  NewService("github", "github.com", "github.com"),
+		repoInfo: &RepoInformation{Owner: "jesseduffield", Repository: "lazygit"},
+	}
+
+	scenarios := []scenario{
+		{
+			"links a URL",
+			github,
+			"https://example.com/some/page",
+			"https://example.com/some/page",
+			true,
+		},
+		{
+			"strips punctuation from a URL",
+			github,
+			"(https://example.com).",
+			"https://example.com",
+			true,
+		},
+		{
+			"links an issue reference",
+			github,
+			"(#123),",
+			"https://github.com/jesseduffield/lazygit/issues/123",
+			true,
+		},
+		{
+			"links a short sha",
+			github,
+			"abc1234",
+			"https://github.com/jesseduffield/lazygit/commit/abc1234",
+			true,
+		},
+		{
+			"ignores a word that looks nothing like a link",
+			github,
+			"hello",
+			"",
+			false,
+		},
+		{
+			"ignores a hex word that's too short to be a sha",
+			github,
+			"beef",
+			"",
+			false,
+		},
+		{
+			"still links URLs when we don't know the hosting service",
+			&Hyperlinker{},
+			"http://example.com",
+			"http://example.com",
+			true,
+		},
+		{
+			"can't link a sha when we don't know the hosting service",
+			&Hyperlinker{},
+			"abc1234",
+			"",
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			link, found := s.hyperlinker.GetLink(s.word)
+			assert.EqualValues(t, s.expected, link)
+			assert.EqualValues(t, s.found, found)
+		})
+	}
+}
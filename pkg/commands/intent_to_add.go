@@ -0,0 +1,33 @@
+package commands
+
+import "strings"
+
+// emptyBlobSha is the id of an empty file's content, which is what git puts in
+// the index for a file added with --intent-to-add
+const emptyBlobSha = "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"
+
+// AddIntentToAdd adds an untracked file to the index without any of its
+// content (`git add -N`), so that its lines can be staged one at a time like
+// those of any other file
+func (c *GitCommand) AddIntentToAdd(fileName string) error {
+	return c.OSCommand.RunCommand("git add --intent-to-add -- %s", c.OSCommand.Quote(fileName))
+}
+
+// RemoveIntentToAdd takes a file added with AddIntentToAdd back out of the
+// index so that it's untracked again, provided none of its content has been
+// staged since
+func (c *GitCommand) RemoveIntentToAdd(fileName string) error {
+	quotedFileName := c.OSCommand.Quote(fileName)
+	output, err := c.OSCommand.RunCommandWithOutput("git ls-files --stage -- %s", quotedFileName)
+	if err != nil {
+		return err
+	}
+
+	// output looks like '100644 e69de29bb2d1d6434b8b29ae775ad8c2e48c5391 0	file'
+	fields := strings.Fields(output)
+	if len(fields) < 2 || fields[1] != emptyBlobSha {
+		return nil
+	}
+
+	return c.OSCommand.RunCommand("git rm --cached --quiet -- %s", quotedFileName)
+}
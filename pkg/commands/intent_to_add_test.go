@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandAddIntentToAdd is a function.
+func TestGitCommandAddIntentToAdd(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git add --intent-to-add -- "test.txt"`, Replace: "echo"},
+	})
+
+	assert.NoError(t, gitCmd.AddIntentToAdd("test.txt"))
+}
+
+// TestGitCommandRemoveIntentToAdd is a function.
+func TestGitCommandRemoveIntentToAdd(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"nothing staged",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: `git ls-files --stage -- "test.txt"`, Replace: "echo 100644 e69de29bb2d1d6434b8b29ae775ad8c2e48c5391 0 test.txt"},
+				{Expect: `git rm --cached --quiet -- "test.txt"`, Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"some lines staged",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: `git ls-files --stage -- "test.txt"`, Replace: "echo 100644 de980441c3ab03a8c07dda1ad27b8a11f39deb1e 0 test.txt"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"no longer in the index",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: `git ls-files --stage -- "test.txt"`, Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.RemoveIntentToAdd("test.txt"))
+		})
+	}
+}
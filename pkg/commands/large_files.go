@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// LargeFile is a file that probably shouldn't be committed as is, either
+// because it's big or because it's the kind of file that's usually kept in LFS
+type LargeFile struct {
+	Name string
+	Size int64
+	// Pattern is the pattern from git.largeFiles.patterns the file matched,
+	// if any
+	Pattern string
+}
+
+// GetLargeFiles returns the files, or the files in the directories, that are
+// bigger than git.largeFiles.threshold (in MB) or match one of the patterns
+// in git.largeFiles.patterns, leaving out files that are already in LFS
+func (c *GitCommand) GetLargeFiles(fileNames []string) ([]*LargeFile, error) {
+	userConfig := c.Config.GetUserConfig()
+	threshold := int64(userConfig.GetFloat64("git.largeFiles.threshold") * 1024 * 1024)
+	patterns := userConfig.GetStringSlice("git.largeFiles.patterns")
+	if threshold <= 0 && len(patterns) == 0 {
+		return nil, nil
+	}
+
+	largeFiles := []*LargeFile{}
+	for _, fileName := range fileNames {
+		err := filepath.Walk(fileName, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// deleted files can't be large
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			pattern := matchingLargeFilePattern(patterns, path)
+			if pattern != "" || (threshold > 0 && info.Size() > threshold) {
+				largeFiles = append(largeFiles, &LargeFile{Name: filepath.ToSlash(path), Size: info.Size(), Pattern: pattern})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, WrapError(err)
+		}
+	}
+	if len(largeFiles) == 0 {
+		return largeFiles, nil
+	}
+
+	inLfs, err := c.filesInLfs(largeFiles)
+	if err != nil {
+		return nil, err
+	}
+	result := []*LargeFile{}
+	for _, largeFile := range largeFiles {
+		if !inLfs[largeFile.Name] {
+			result = append(result, largeFile)
+		}
+	}
+	return result, nil
+}
+
+// matchingLargeFilePattern returns the first pattern that matches the file.
+// As in .gitignore, a pattern without a slash matches the file's name in any
+// directory.
+func matchingLargeFilePattern(patterns []string, fileName string) string {
+	for _, pattern := range patterns {
+		if matchesPathPattern(pattern, fileName) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// filesInLfs tells us which of the files have the lfs filter set on them in
+// .gitattributes
+func (c *GitCommand) filesInLfs(files []*LargeFile) (map[string]bool, error) {
+	quotedNames := make([]string, len(files))
+	for i, file := range files {
+		quotedNames[i] = c.OSCommand.Quote(file.Name)
+	}
+	output, err := c.OSCommand.RunCommandWithOutput("git check-attr filter -- %s", strings.Join(quotedNames, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	inLfs := map[string]bool{}
+	for _, line := range utils.SplitLines(output) {
+		if strings.HasSuffix(line, ": filter: lfs") {
+			inLfs[strings.TrimSuffix(line, ": filter: lfs")] = true
+		}
+	}
+	return inLfs, nil
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMatchingLargeFilePattern is a function.
+func TestMatchingLargeFilePattern(t *testing.T) {
+	type scenario struct {
+		fileName string
+		expected string
+	}
+
+	patterns := []string{"*.zip", "assets/**"}
+	scenarios := []scenario{
+		{"release.zip", "*.zip"},
+		{"dist/release.zip", "*.zip"},
+		{"assets/images/logo.png", "assets/**"},
+		{"src/assets/logo.png", ""},
+		{"main.go", ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.fileName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, matchingLargeFilePattern(patterns, s.fileName))
+		})
+	}
+}
+
+// TestGitCommandGetLargeFiles is a function.
+func TestGitCommandGetLargeFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-large-files")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	files := map[string]int{
+		"small.txt":       10,
+		"big.bin":         2 * 1024 * 1024,
+		"in-lfs.bin":      2 * 1024 * 1024,
+		"dir/archive.zip": 10,
+	}
+	for name, size := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+		assert.NoError(t, ioutil.WriteFile(name, make([]byte, size), 0644))
+	}
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config.GetUserConfig().Set("git.largeFiles.threshold", 1)
+	gitCmd.Config.GetUserConfig().Set("git.largeFiles.patterns", []string{"*.zip"})
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git check-attr filter -- big.bin in-lfs.bin dir/archive.zip",
+			Replace: "echo 'big.bin: filter: unspecified\nin-lfs.bin: filter: lfs\ndir/archive.zip: filter: unspecified'",
+		},
+	})
+
+	largeFiles, err := gitCmd.GetLargeFiles([]string{"small.txt", "big.bin", "in-lfs.bin", "dir", "deleted.bin"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*LargeFile{
+		{Name: "big.bin", Size: 2 * 1024 * 1024},
+		{Name: "dir/archive.zip", Size: 10, Pattern: "*.zip"},
+	}, largeFiles)
+}
@@ -2,9 +2,12 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
 )
 
 func (c *GitCommand) GetRemotes() ([]*Remote, error) {
@@ -56,3 +59,24 @@ func (c *GitCommand) GetRemotes() ([]*Remote, error) {
 
 	return remotes, nil
 }
+
+// GetRepoContext returns the repo's path and remote urls, which are used to
+// decide which conditional config sections apply to the repo
+func (c *GitCommand) GetRepoContext() (config.RepoContext, error) {
+	repoPath, err := os.Getwd()
+	if err != nil {
+		return config.RepoContext{}, err
+	}
+
+	goGitRemotes, err := c.Repo.Remotes()
+	if err != nil {
+		return config.RepoContext{}, err
+	}
+
+	remoteURLs := []string{}
+	for _, goGitRemote := range goGitRemotes {
+		remoteURLs = append(remoteURLs, goGitRemote.Config().URLs...)
+	}
+
+	return config.RepoContext{Path: repoPath, RemoteURLs: remoteURLs}, nil
+}
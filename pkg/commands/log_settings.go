@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// LogSettings are how much of the history the commits panel shows. They're
+// saved to the repo's own git config so that they stick for that repo, e.g.
+// for following just the main line of a repo with lots of merges.
+type LogSettings struct {
+	// FirstParent only follows the first parent of merge commits, leaving out
+	// the commits that were merged in
+	FirstParent bool
+	// NoMerges leaves out merge commits
+	NoMerges bool
+	// Order is how the commits are sorted, one of LogOrders. When it's empty
+	// we leave it to git, which goes by commit date
+	Order string
+}
+
+const (
+	firstParentConfigKey = "lazygit.log.firstParent"
+	noMergesConfigKey    = "lazygit.log.noMerges"
+	orderConfigKey       = "lazygit.log.order"
+)
+
+// LogOrders are the orders the commits panel can sort commits in. Date order
+// can interleave the commits of branches that were worked on at the same time,
+// whereas topo order keeps each branch's commits together
+var LogOrders = []string{"", "date", "author-date", "topo"}
+
+// GetLogSettings returns the repo's log settings
+func (c *GitCommand) GetLogSettings() LogSettings {
+	firstParent, _ := c.getLocalGitConfig(firstParentConfigKey)
+	noMerges, _ := c.getLocalGitConfig(noMergesConfigKey)
+	order, _ := c.getLocalGitConfig(orderConfigKey)
+
+	order = strings.TrimSpace(order)
+	if !utils.IncludesString(LogOrders, order) {
+		order = ""
+	}
+
+	return LogSettings{
+		FirstParent: isTruthy(firstParent),
+		NoMerges:    isTruthy(noMerges),
+		Order:       order,
+	}
+}
+
+// SetFirstParent saves whether the commits panel only follows first parents
+func (c *GitCommand) SetFirstParent(value bool) error {
+	return c.SetLocalConfigValue(firstParentConfigKey, boolString(value))
+}
+
+// SetNoMerges saves whether the commits panel leaves out merge commits
+func (c *GitCommand) SetNoMerges(value bool) error {
+	return c.SetLocalConfigValue(noMergesConfigKey, boolString(value))
+}
+
+// SetLogOrder saves how the commits panel sorts commits
+func (c *GitCommand) SetLogOrder(order string) error {
+	return c.SetLocalConfigValue(orderConfigKey, order)
+}
+
+// IsEmpty tells us whether the settings leave the whole history in
+func (s LogSettings) IsEmpty() bool {
+	return !s.FirstParent && !s.NoMerges
+}
+
+func (s LogSettings) flags() string {
+	flags := ""
+	if s.FirstParent {
+		flags += " --first-parent"
+	}
+	if s.NoMerges {
+		flags += " --no-merges"
+	}
+	if s.Order != "" {
+		flags += " --" + s.Order + "-order"
+	}
+	return flags
+}
+
+func boolString(value bool) string {
+	if value {
+		return "true"
+	}
+	return "false"
+}
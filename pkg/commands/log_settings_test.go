@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetLogSettings is a function.
+func TestGitCommandGetLogSettings(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.getLocalGitConfig = func(key string) (string, error) {
+		switch key {
+		case "lazygit.log.firstParent":
+			return "true\n", nil
+		case "lazygit.log.order":
+			return "topo\n", nil
+		}
+		return "", nil
+	}
+
+	settings := gitCmd.GetLogSettings()
+	assert.EqualValues(t, LogSettings{FirstParent: true, Order: "topo"}, settings)
+	assert.False(t, settings.IsEmpty())
+	assert.True(t, LogSettings{}.IsEmpty())
+	assert.True(t, LogSettings{Order: "topo"}.IsEmpty())
+}
+
+// TestGitCommandGetLogSettingsUnknownOrder is a function.
+func TestGitCommandGetLogSettingsUnknownOrder(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.getLocalGitConfig = func(key string) (string, error) {
+		if key == "lazygit.log.order" {
+			return "sideways\n", nil
+		}
+		return "", nil
+	}
+
+	assert.EqualValues(t, LogSettings{}, gitCmd.GetLogSettings())
+}
+
+// TestGitCommandSetNoMerges is a function.
+func TestGitCommandSetNoMerges(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git config --local lazygit.log.noMerges "true"`, Replace: "echo"},
+	})
+
+	assert.NoError(t, gitCmd.SetNoMerges(true))
+}
+
+// TestCommitListBuilderGetLogWithSettings is a function.
+func TestCommitListBuilderGetLogWithSettings(t *testing.T) {
+	c := NewDummyCommitListBuilder()
+	c.LogSettings = LogSettings{FirstParent: true, NoMerges: true, Order: "author-date"}
+	c.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git log --oneline --pretty=format:"%H|%ar|%aN|%d|%s" -30 --first-parent --no-merges --author-date-order --decorate=full --abbrev=20`, Replace: "echo abc"},
+	})
+
+	assert.EqualValues(t, "abc\n", c.getLog(true))
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Dropping a stash, or resetting or rebasing a branch, leaves commits that
+// nothing points to any more. They stay in the repo until they're garbage
+// collected, which by default takes weeks, so they can usually be brought back.
+
+// lostCommitsBatchSize is how many commits we look up at once, to keep the
+// command line short enough for Windows
+const lostCommitsBatchSize = 100
+
+// LostCommit is a commit that's no longer on a branch, in the stash or in the
+// reflog, e.g. a dropped stash
+type LostCommit struct {
+	Sha  string
+	Name string
+	Time time.Time
+	// IsStash tells us whether it was made by `git stash`, going by its
+	// parents and the message git gives stashes
+	IsStash bool
+}
+
+// ShortSha returns the first 8 characters of the sha
+func (l *LostCommit) ShortSha() string {
+	if len(l.Sha) < 8 {
+		return l.Sha
+	}
+	return l.Sha[:8]
+}
+
+// GetLostCommits returns the newest limit commits that nothing points to,
+// according to `git fsck`, newest first. We ignore the reflogs so that
+// commits that only they point to count as lost too.
+func (c *GitCommand) GetLostCommits(limit int) ([]*LostCommit, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git fsck --no-reflogs --no-progress")
+	if err != nil {
+		return nil, err
+	}
+
+	shas := []string{}
+	for _, line := range utils.SplitLines(output) {
+		if strings.HasPrefix(line, "dangling commit ") {
+			shas = append(shas, strings.TrimPrefix(line, "dangling commit "))
+		}
+	}
+
+	lostCommits := []*LostCommit{}
+	for start := 0; start < len(shas); start += lostCommitsBatchSize {
+		end := utils.Min(start+lostCommitsBatchSize, len(shas))
+		output, err := c.OSCommand.RunCommandWithOutput(`git show -s --format="%%H|%%ct|%%P|%%s" %s`, strings.Join(shas[start:end], " "))
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range utils.SplitLines(output) {
+			if lostCommit := parseLostCommit(line); lostCommit != nil {
+				lostCommits = append(lostCommits, lostCommit)
+			}
+		}
+	}
+
+	sort.SliceStable(lostCommits, func(i, j int) bool {
+		return lostCommits[i].Time.After(lostCommits[j].Time)
+	})
+	if limit > 0 && len(lostCommits) > limit {
+		lostCommits = lostCommits[:limit]
+	}
+	return lostCommits, nil
+}
+
+func parseLostCommit(line string) *LostCommit {
+	split := strings.SplitN(line, "|", 4)
+	if len(split) != 4 {
+		return nil
+	}
+	unixTime, err := strconv.ParseInt(split[1], 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	name := split[3]
+	// a stash's first parent is the commit it was made on and the others
+	// hold the index and untracked files
+	isStash := len(strings.Fields(split[2])) >= 2 && (strings.HasPrefix(name, "WIP on ") || strings.HasPrefix(name, "On "))
+	return &LostCommit{
+		Sha:     split[0],
+		Name:    name,
+		Time:    time.Unix(unixTime, 0),
+		IsStash: isStash,
+	}
+}
+
+// ApplyLostStash applies a dropped stash to the working tree
+func (c *GitCommand) ApplyLostStash(sha string) error {
+	return c.OSCommand.RunCommand("git stash apply %s", sha)
+}
+
+// StoreLostStash puts a dropped stash back in the stash list
+func (c *GitCommand) StoreLostStash(lostCommit *LostCommit) error {
+	return c.OSCommand.RunCommand("git stash store -m %s %s", c.OSCommand.Quote(lostCommit.Name), lostCommit.Sha)
+}
+
+// CreateBranchAt creates a branch pointing at the given commit without
+// checking it out
+func (c *GitCommand) CreateBranchAt(name string, sha string) error {
+	return c.OSCommand.RunCommand("git branch %s %s", c.OSCommand.Quote(name), sha)
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetLostCommits is a function.
+func TestGitCommandGetLostCommits(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git fsck --no-reflogs --no-progress",
+			Replace: "echo 'dangling blob 111\ndangling commit aaa\ndangling commit bbb'",
+		},
+		{
+			Expect:  `git show -s --format="%H|%ct|%P|%s" aaa bbb`,
+			Replace: "echo 'aaa|1600000000|ccc|Fix a|b typo\nbbb|1600000100|ccc ddd|WIP on master: 123abc Add login'",
+		},
+	})
+
+	lostCommits, err := gitCmd.GetLostCommits(10)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*LostCommit{
+		{Sha: "bbb", Name: "WIP on master: 123abc Add login", Time: time.Unix(1600000100, 0), IsStash: true},
+		{Sha: "aaa", Name: "Fix a|b typo", Time: time.Unix(1600000000, 0), IsStash: false},
+	}, lostCommits)
+}
+
+// TestGitCommandGetLostCommitsLimit is a function.
+func TestGitCommandGetLostCommitsLimit(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: "git fsck --no-reflogs --no-progress", Replace: "echo 'dangling commit aaa\ndangling commit bbb'"},
+		{Expect: `git show -s --format="%H|%ct|%P|%s" aaa bbb`, Replace: "echo 'aaa|1600000000|ccc|Old\nbbb|1600000100|ccc|New'"},
+	})
+
+	lostCommits, err := gitCmd.GetLostCommits(1)
+	assert.NoError(t, err)
+	assert.Len(t, lostCommits, 1)
+	assert.EqualValues(t, "bbb", lostCommits[0].Sha)
+}
+
+// TestGitCommandStoreLostStash is a function.
+func TestGitCommandStoreLostStash(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git stash store -m "On master: wip" bbb`, Replace: "echo"},
+	})
+
+	assert.NoError(t, gitCmd.StoreLostStash(&LostCommit{Sha: "bbb", Name: "On master: wip"}))
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// `git am` applies patches from a mailbox (or from the files that `git
+// format-patch` writes) as commits. While it's going, it keeps each patch in
+// its own numbered file in .git/rebase-apply along with 'next' and 'last',
+// which tell us how far through it is.
+
+// MailboxPatch is one of the patches `git am` is applying
+type MailboxPatch struct {
+	Number  int
+	Sha     string // the sha the patch was made from, if it says
+	Subject string
+	// Status is one of 'applied', 'current' or 'pending'
+	Status string
+}
+
+var patchSubjectPrefixRegexp = regexp.MustCompile(`^(\[[^\]]*\]\s*)+`)
+
+// IsApplyingMailbox tells us whether we're part way through a `git am`
+func (c *GitCommand) IsApplyingMailbox() bool {
+	exists, _ := c.OSCommand.FileExists(filepath.Join(c.DotGitDir, "rebase-apply", "applying"))
+	return exists
+}
+
+// GetMailboxPatches returns the patches of the `git am` in progress
+func (c *GitCommand) GetMailboxPatches() []*MailboxPatch {
+	next := c.readGitDirNumber("rebase-apply/next")
+	last := c.readGitDirNumber("rebase-apply/last")
+
+	patches := []*MailboxPatch{}
+	for number := 1; number <= last; number++ {
+		patch := &MailboxPatch{Number: number, Status: "pending"}
+		if number < next {
+			patch.Status = "applied"
+		} else if number == next {
+			patch.Status = "current"
+		}
+		if content, err := c.readGitDirFile(fmt.Sprintf("rebase-apply/%04d", number)); err == nil {
+			patch.Sha, patch.Subject = parseMailHeaders(content)
+		}
+		patches = append(patches, patch)
+	}
+	return patches
+}
+
+// parseMailHeaders returns the sha from the 'From <sha> <date>' line that
+// `git format-patch` starts each mail with, and the subject without the
+// '[PATCH n/m]' prefix
+func parseMailHeaders(mail string) (string, string) {
+	sha := ""
+	subject := ""
+	inSubject := false
+	for i, line := range strings.Split(mail, "\n") {
+		if line == "" {
+			// the headers end at the first blank line
+			break
+		}
+		if i == 0 && strings.HasPrefix(line, "From ") {
+			if fields := strings.Fields(line); len(fields) > 1 && len(fields[1]) == 40 {
+				sha = fields[1]
+			}
+			continue
+		}
+		if inSubject && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			// long subjects carry on over indented lines
+			subject += " " + strings.TrimSpace(line)
+			continue
+		}
+		inSubject = strings.HasPrefix(line, "Subject: ")
+		if inSubject {
+			subject = strings.TrimPrefix(line, "Subject: ")
+		}
+	}
+	return sha, patchSubjectPrefixRegexp.ReplaceAllString(subject, "")
+}
+
+// mailboxPaths returns what to pass to `git am` for the path the user gave
+// us. git takes a directory to be a Maildir, so for a directory of patches
+// like the one `git format-patch -o` writes we pass its files in order.
+func mailboxPaths(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	if _, err := os.Stat(filepath.Join(path, "cur")); err == nil {
+		return []string{path}, nil
+	}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{}
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		paths = append(paths, filepath.Join(path, file.Name()))
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no patches in %s", path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// ApplyMailbox applies the patches in a mailbox file, Maildir, or directory of
+// patch files as commits. We use a three-way merge so that patches that don't
+// apply cleanly leave conflicts to resolve, as in a rebase.
+func (c *GitCommand) ApplyMailbox(path string) error {
+	paths, err := mailboxPaths(path)
+	if err != nil {
+		return err
+	}
+	quotedPaths := make([]string, len(paths))
+	for i, path := range paths {
+		quotedPaths[i] = c.OSCommand.Quote(path)
+	}
+	return c.OSCommand.RunCommand("git am --3way %s", strings.Join(quotedPaths, " "))
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPatchMail = `From e93d4193e6dd45ca9cf3a5a273d7ba6cd8b8fb20 Mon Sep 17 00:00:00 2001
+From: Lazygit Tester <test@example.com>
+Date: Wed, 5 Dec 2018 21:03:23 +1100
+Subject: [PATCH 2/3] [RFC] Fix a bug that was so hard to describe that its
+ subject needed two lines
+
+Subject: not a header
+---
+ file.txt | 2 +-
+`
+
+// TestParseMailHeaders is a function.
+func TestParseMailHeaders(t *testing.T) {
+	sha, subject := parseMailHeaders(testPatchMail)
+	assert.EqualValues(t, "e93d4193e6dd45ca9cf3a5a273d7ba6cd8b8fb20", sha)
+	assert.EqualValues(t, "Fix a bug that was so hard to describe that its subject needed two lines", subject)
+
+	sha, subject = parseMailHeaders("From: someone@example.com\nSubject: Plain mail\n\nbody")
+	assert.EqualValues(t, "", sha)
+	assert.EqualValues(t, "Plain mail", subject)
+}
+
+// TestGitCommandGetMailboxPatches is a function.
+func TestGitCommandGetMailboxPatches(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-am")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"next":     "2",
+		"last":     "3",
+		"applying": "",
+		"0001":     "From: a@example.com\nSubject: [PATCH 1/3] first\n",
+		"0002":     testPatchMail,
+		"0003":     "From: a@example.com\nSubject: [PATCH 3/3] third\n",
+	}
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "rebase-apply"), 0755))
+	for name, content := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "rebase-apply", name), []byte(content), 0644))
+	}
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dir
+
+	assert.True(t, gitCmd.IsApplyingMailbox())
+	assert.EqualValues(t, []*MailboxPatch{
+		{Number: 1, Subject: "first", Status: "applied"},
+		{Number: 2, Sha: "e93d4193e6dd45ca9cf3a5a273d7ba6cd8b8fb20", Subject: "Fix a bug that was so hard to describe that its subject needed two lines", Status: "current"},
+		{Number: 3, Subject: "third", Status: "pending"},
+	}, gitCmd.GetMailboxPatches())
+}
+
+// TestGitCommandApplyMailbox is a function.
+func TestGitCommandApplyMailbox(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-patches")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"0002-second.patch", "0001-first.patch", ".hidden"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(""), 0644))
+	}
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git am --3way " + filepath.Join(dir, "0001-first.patch") + " " + filepath.Join(dir, "0002-second.patch"),
+			Replace: "echo",
+		},
+	})
+	assert.NoError(t, gitCmd.ApplyMailbox(dir))
+
+	assert.Error(t, gitCmd.ApplyMailbox(filepath.Join(dir, "missing")))
+}
@@ -0,0 +1,37 @@
+package commands
+
+import "strings"
+
+// transientNetworkErrors are bits of the messages git and ssh give for
+// network failures that are worth trying again, as opposed to e.g. a rejected
+// push or bad credentials, which will fail the same way every time
+var transientNetworkErrors = []string{
+	"connection timed out",
+	"operation timed out",
+	"connection reset",
+	"could not resolve host",
+	"temporary failure in name resolution",
+	"the remote end hung up unexpectedly",
+	"early eof",
+	"rpc failed",
+	"network is unreachable",
+	"broken pipe",
+	"gnutls_handshake() failed",
+	"ssl_read",
+	"http2 stream",
+}
+
+// IsTransientNetworkError tells us whether a fetch, pull or push failed in a
+// way that might not happen if we try again in a bit
+func IsTransientNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, transientError := range transientNetworkErrors {
+		if strings.Contains(message, transientError) {
+			return true
+		}
+	}
+	return false
+}
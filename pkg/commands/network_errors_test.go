@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsTransientNetworkError is a function.
+func TestIsTransientNetworkError(t *testing.T) {
+	type scenario struct {
+		testName string
+		err      error
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{"no error", nil, false},
+		{"timeout", errors.New("ssh: connect to host github.com port 22: Connection timed out\nfatal: Could not read from remote repository."), true},
+		{"dns", errors.New("fatal: unable to access 'https://github.com/jesseduffield/lazygit.git/': Could not resolve host: github.com"), true},
+		{"hung up", errors.New("error: RPC failed; curl 56 GnuTLS recv error (-54)\nfatal: The remote end hung up unexpectedly"), true},
+		{"rejected push", errors.New("! [rejected]        master -> master (fetch first)\nerror: failed to push some refs"), false},
+		{"bad credentials", errors.New("remote: Invalid username or password."), false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, IsTransientNetworkError(s.err))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -39,10 +41,17 @@ type OSCommand struct {
 	beforeExecuteCmd   func(*exec.Cmd)
 	getGlobalGitConfig func(string) (string, error)
 	getenv             func(string) string
+	gitTraceFile       string
+	gitTraceMutex      sync.Mutex
+	// ctx is what the commands we run are tied to unless they're given a
+	// context of their own. It's cancelled when we quit.
+	ctx            context.Context
+	cancelCommands context.CancelFunc
 }
 
 // NewOSCommand os command runner
 func NewOSCommand(log *logrus.Entry, config config.AppConfigurer) *OSCommand {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &OSCommand{
 		Log:                log,
 		Platform:           getPlatform(),
@@ -51,9 +60,23 @@ func NewOSCommand(log *logrus.Entry, config config.AppConfigurer) *OSCommand {
 		beforeExecuteCmd:   func(*exec.Cmd) {},
 		getGlobalGitConfig: gitconfig.Global,
 		getenv:             os.Getenv,
+		ctx:                ctx,
+		cancelCommands:     cancel,
 	}
 }
 
+// Context returns the context that commands are run with by default, for
+// deriving contexts from, e.g. with a timeout
+func (c *OSCommand) Context() context.Context {
+	return c.ctx
+}
+
+// CancelCommands kills every command still running with the default
+// context, along with anything they started, for when we're quitting
+func (c *OSCommand) CancelCommands() {
+	c.cancelCommands()
+}
+
 // SetCommand sets the command function used by the struct.
 // To be used for testing only
 func (c *OSCommand) SetCommand(cmd func(string, ...string) *exec.Cmd) {
@@ -71,17 +94,29 @@ func (c *OSCommand) SetBeforeExecuteCmd(cmd func(*exec.Cmd)) {
 // in that case it's not. To get around that error you'll need to define the string
 // in a variable and pass the variable into RunCommandWithOutput.
 func (c *OSCommand) RunCommandWithOutput(formatString string, formatArgs ...interface{}) (string, error) {
+	return c.RunCommandWithOutputContext(c.ctx, formatString, formatArgs...)
+}
+
+// RunCommandWithOutputContext is RunCommandWithOutput, except that the
+// command and anything it starts are killed once ctx is done
+func (c *OSCommand) RunCommandWithOutputContext(ctx context.Context, formatString string, formatArgs ...interface{}) (string, error) {
 	command := formatString
 	if formatArgs != nil {
 		command = fmt.Sprintf(formatString, formatArgs...)
 	}
 	c.Log.WithField("command", command).Info("RunCommand")
-	cmd := c.ExecutableFromString(command)
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+	if err := c.CheckReadOnly(command); err != nil {
+		return "", err
+	}
+	cmd := c.executableWithContext(ctx, command)
+	return sanitisedCommandOutput(combinedOutputWithContext(ctx, cmd))
 }
 
 // RunExecutableWithOutput runs an executable file and returns its output
 func (c *OSCommand) RunExecutableWithOutput(cmd *exec.Cmd) (string, error) {
+	if err := c.checkReadOnlyCmd(cmd); err != nil {
+		return "", err
+	}
 	c.beforeExecuteCmd(cmd)
 	return sanitisedCommandOutput(cmd.CombinedOutput())
 }
@@ -96,21 +131,74 @@ func (c *OSCommand) RunExecutable(cmd *exec.Cmd) error {
 func (c *OSCommand) ExecutableFromString(commandStr string) *exec.Cmd {
 	splitCmd := str.ToArgv(commandStr)
 	cmd := c.command(splitCmd[0], splitCmd[1:]...)
-	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	cmd.Env = c.commandEnv(splitCmd[0])
 	return cmd
 }
 
+// executableWithContext is ExecutableFromString for a command that's killed,
+// along with its process group, once ctx is done
+func (c *OSCommand) executableWithContext(ctx context.Context, commandStr string) *exec.Cmd {
+	return CommandWithContext(ctx, c.ExecutableFromString(commandStr))
+}
+
+// combinedOutputWithContext is cmd.CombinedOutput for a command from
+// CommandWithContext. exec.CommandContext only kills the process itself when
+// ctx is done, so we kill its process group ourselves.
+func combinedOutputWithContext(ctx context.Context, cmd *exec.Cmd) ([]byte, error) {
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	err := waitWithContext(ctx, cmd)
+	return output.Bytes(), err
+}
+
+// waitWithContext waits for a started command, killing its process group if
+// ctx is done before it exits
+func waitWithContext(ctx context.Context, cmd *exec.Cmd) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = Kill(cmd)
+		case <-done:
+		}
+	}()
+	return cmd.Wait()
+}
+
 // RunCommandWithOutputLive runs RunCommandWithOutputLiveWrapper
 func (c *OSCommand) RunCommandWithOutputLive(command string, output func(string) string) error {
-	return RunCommandWithOutputLiveWrapper(c, command, output)
+	if err := c.CheckReadOnly(command); err != nil {
+		return err
+	}
+	return RunCommandWithOutputLiveWrapper(c, c.ctx, command, nil, output)
 }
 
 // DetectUnamePass detect a username / password question in a command
 // ask is a function that gets executen when this function detect you need to fillin a password
 // The ask argument will be "username" or "password" and expects the user's password or username back
 func (c *OSCommand) DetectUnamePass(command string, ask func(string) string) error {
+	return c.DetectUnamePassWithEnv(command, nil, ask)
+}
+
+// DetectUnamePassWithEnv is DetectUnamePass with env added to the command's
+// environment
+func (c *OSCommand) DetectUnamePassWithEnv(command string, env []string, ask func(string) string) error {
+	return c.DetectUnamePassWithEnvContext(c.ctx, command, env, ask)
+}
+
+// DetectUnamePassWithEnvContext is DetectUnamePassWithEnv, except that the
+// command and anything it starts are killed once ctx is done
+func (c *OSCommand) DetectUnamePassWithEnvContext(ctx context.Context, command string, env []string, ask func(string) string) error {
+	if err := c.CheckReadOnly(command); err != nil {
+		return err
+	}
 	ttyText := ""
-	errMessage := c.RunCommandWithOutputLive(command, func(word string) string {
+	errMessage := RunCommandWithOutputLiveWrapper(c, ctx, command, env, func(word string) string {
 		ttyText = ttyText + " " + word
 
 		prompts := map[string]string{
@@ -136,6 +224,25 @@ func (c *OSCommand) RunCommand(formatString string, formatArgs ...interface{}) e
 	return err
 }
 
+// RunCommandWithEnv runs a command with env added to its environment
+func (c *OSCommand) RunCommandWithEnv(command string, env []string) error {
+	return c.RunCommandWithEnvContext(c.ctx, command, env)
+}
+
+// RunCommandWithEnvContext is RunCommandWithEnv, except that the command and
+// anything it starts are killed once ctx is done
+func (c *OSCommand) RunCommandWithEnvContext(ctx context.Context, command string, env []string) error {
+	c.Log.WithField("command", command).Info("RunCommand")
+	cmd := c.executableWithContext(ctx, command)
+	cmd.Env = append(cmd.Env, env...)
+	if err := c.checkReadOnlyCmd(cmd); err != nil {
+		return err
+	}
+	c.beforeExecuteCmd(cmd)
+	_, err := sanitisedCommandOutput(combinedOutputWithContext(ctx, cmd))
+	return err
+}
+
 // FileType tells us if the file is a file, directory or other
 func (c *OSCommand) FileType(path string) string {
 	fileInfo, err := os.Stat(path)
@@ -151,6 +258,9 @@ func (c *OSCommand) FileType(path string) string {
 // RunDirectCommand wrapper around direct commands
 func (c *OSCommand) RunDirectCommand(command string) (string, error) {
 	c.Log.WithField("command", command).Info("RunDirectCommand")
+	if err := c.CheckReadOnly(command); err != nil {
+		return "", err
+	}
 
 	return sanitisedCommandOutput(
 		c.command(c.Platform.shell, c.Platform.shellArg, command).
@@ -191,13 +301,42 @@ func (c *OSCommand) OpenLink(link string) error {
 	}
 
 	command := utils.ResolvePlaceholderString(commandTemplate, templateValues)
-	err := c.RunCommand(command)
+	c.Log.WithField("command", command).Info("OpenLink")
+	// opening a link can't change anything, so this is allowed in read-only mode
+	_, err := sanitisedCommandOutput(c.ExecutableFromString(command).CombinedOutput())
 	return err
 }
 
 // EditFile opens a file in a subprocess using whatever editor is available,
 // falling back to core.editor, VISUAL, EDITOR, then vi
 func (c *OSCommand) EditFile(filename string) (*exec.Cmd, error) {
+	editor, err := c.getEditor()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PrepareSubProcess(editor, filename), nil
+}
+
+// editorsTakingLineNumbers are the editors that we know open a file at line n
+// when given +n before the filename
+var editorsTakingLineNumbers = []string{"vi", "vim", "nvim", "gvim", "nano", "emacs", "emacsclient", "micro", "kak", "joe", "ne"}
+
+// EditFileAtLine opens a file in the editor at the given line, if the editor
+// lets us tell it the line
+func (c *OSCommand) EditFileAtLine(filename string, lineNumber int) (*exec.Cmd, error) {
+	editor, err := c.getEditor()
+	if err != nil {
+		return nil, err
+	}
+
+	if utils.IncludesString(editorsTakingLineNumbers, filepath.Base(editor)) {
+		return c.PrepareSubProcess(editor, fmt.Sprintf("+%d", lineNumber), filename), nil
+	}
+	return c.PrepareSubProcess(editor, filename), nil
+}
+
+func (c *OSCommand) getEditor() (string, error) {
 	editor, _ := c.getGlobalGitConfig("core.editor")
 
 	if editor == "" {
@@ -212,10 +351,10 @@ func (c *OSCommand) EditFile(filename string) (*exec.Cmd, error) {
 		}
 	}
 	if editor == "" {
-		return nil, errors.New("No editor defined in $VISUAL, $EDITOR, or git config")
+		return "", errors.New("No editor defined in $VISUAL, $EDITOR, or git config")
 	}
 
-	return c.PrepareSubProcess(editor, filename), nil
+	return editor, nil
 }
 
 // PrepareSubProcess iniPrepareSubProcessrocess then tells the Gui to switch to it
@@ -223,7 +362,7 @@ func (c *OSCommand) EditFile(filename string) (*exec.Cmd, error) {
 func (c *OSCommand) PrepareSubProcess(cmdName string, commandArgs ...string) *exec.Cmd {
 	cmd := c.command(cmdName, commandArgs...)
 	if cmd != nil {
-		cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+		cmd.Env = c.commandEnv(cmdName)
 	}
 	return cmd
 }
@@ -246,6 +385,9 @@ func (c *OSCommand) Unquote(message string) string {
 
 // AppendLineToFile adds a new line in file
 func (c *OSCommand) AppendLineToFile(filename, line string) error {
+	if err := c.CheckWritable(filename); err != nil {
+		return err
+	}
 	f, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
 	if err != nil {
 		return WrapError(err)
@@ -281,6 +423,9 @@ func (c *OSCommand) CreateTempFile(filename, content string) (string, error) {
 
 // CreateFileWithContent creates a file with the given content
 func (c *OSCommand) CreateFileWithContent(path string, content string) error {
+	if err := c.CheckWritable(path); err != nil {
+		return err
+	}
 	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
 		c.Log.Error(err)
 		return err
@@ -296,6 +441,9 @@ func (c *OSCommand) CreateFileWithContent(path string, content string) error {
 
 // Remove removes a file or directory at the specified path
 func (c *OSCommand) Remove(filename string) error {
+	if err := c.CheckWritable(filename); err != nil {
+		return err
+	}
 	err := os.RemoveAll(filename)
 	return WrapError(err)
 }
@@ -315,6 +463,9 @@ func (c *OSCommand) FileExists(path string) (bool, error) {
 // this is useful if you need to give your command some environment variables
 // before running it
 func (c *OSCommand) RunPreparedCommand(cmd *exec.Cmd) error {
+	if err := c.checkReadOnlyCmd(cmd); err != nil {
+		return err
+	}
 	c.beforeExecuteCmd(cmd)
 	out, err := cmd.CombinedOutput()
 	outString := string(out)
@@ -344,6 +495,11 @@ func (c *OSCommand) RunCustomCommand(command string) *exec.Cmd {
 
 // PipeCommands runs a heap of commands and pipes their inputs/outputs together like A | B | C
 func (c *OSCommand) PipeCommands(commandStrings ...string) error {
+	for _, command := range commandStrings {
+		if err := c.CheckReadOnly(command); err != nil {
+			return err
+		}
+	}
 
 	cmds := make([]*exec.Cmd, len(commandStrings))
 
@@ -402,10 +558,29 @@ func (c *OSCommand) PipeCommands(commandStrings ...string) error {
 	return nil
 }
 
+// Kill kills the command, along with anything it started if it has a process
+// group of its own
 func Kill(cmd *exec.Cmd) error {
 	if cmd.Process == nil {
 		// somebody got to it before we were able to, poor bastard
 		return nil
 	}
-	return cmd.Process.Kill()
+	return killProcessGroup(cmd)
+}
+
+// CommandWithContext returns a copy of the unstarted command that's killed
+// once ctx is done. It gets a process group of its own, so Kill can get rid of
+// anything it starts too, like the pager git runs.
+func CommandWithContext(ctx context.Context, cmd *exec.Cmd) *exec.Cmd {
+	ctxCmd := exec.CommandContext(ctx, cmd.Path)
+	ctxCmd.Args = cmd.Args
+	ctxCmd.Env = cmd.Env
+	ctxCmd.Dir = cmd.Dir
+	ctxCmd.Stdin = cmd.Stdin
+	if cmd.SysProcAttr != nil {
+		sysProcAttr := *cmd.SysProcAttr
+		ctxCmd.SysProcAttr = &sysProcAttr
+	}
+	setProcessGroup(ctxCmd)
+	return ctxCmd
 }
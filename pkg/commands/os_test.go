@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -239,6 +241,117 @@ func TestOSCommandEditFile(t *testing.T) {
 	}
 }
 
+// TestOSCommandEditFileAtLine is a function.
+func TestOSCommandEditFileAtLine(t *testing.T) {
+	type scenario struct {
+		editor       string
+		expectedArgs []string
+	}
+
+	scenarios := []scenario{
+		{"vim", []string{"+12", "test"}},
+		{"/usr/local/bin/nvim", []string{"+12", "test"}},
+		{"subl", []string{"test"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.editor, func(t *testing.T) {
+			OSCmd := NewDummyOSCommand()
+			OSCmd.command = func(name string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, s.editor, name)
+				assert.EqualValues(t, s.expectedArgs, args)
+				return nil
+			}
+			OSCmd.getGlobalGitConfig = func(string) (string, error) { return s.editor, nil }
+
+			_, err := OSCmd.EditFileAtLine("test", 12)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestOSCommandRunCommandWithOutputContext is a function.
+func TestOSCommandRunCommandWithOutputContext(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		// the sleep keeps the output pipe open, so this only returns early if
+		// the sleep is killed along with the shell
+		_, err := osCommand.RunCommandWithOutputContext(ctx, `sh -c "sleep 10 | cat"`)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Error("command is still running")
+	}
+
+	output, err := osCommand.RunCommandWithOutputContext(context.Background(), "echo hello")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "hello\n", output)
+}
+
+// TestOSCommandCancelCommands is a function.
+func TestOSCommandCancelCommands(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.CancelCommands()
+
+	assert.Error(t, osCommand.RunCommand("echo hello"))
+}
+
+// TestCommandWithContext is a function.
+func TestCommandWithContext(t *testing.T) {
+	type scenario struct {
+		testName string
+		cmd      *exec.Cmd
+		test     func(cmd *exec.Cmd, cancel context.CancelFunc)
+	}
+
+	scenarios := []scenario{
+		{
+			"copies the command",
+			&exec.Cmd{Path: "/bin/sh", Args: []string{"sh", "-c", "echo $FOO; pwd"}, Env: []string{"FOO=bar"}, Dir: "/"},
+			func(cmd *exec.Cmd, cancel context.CancelFunc) {
+				output, err := cmd.Output()
+				assert.NoError(t, err)
+				assert.EqualValues(t, "bar\n/\n", string(output))
+			},
+		},
+		{
+			"is killed once the context is cancelled",
+			exec.Command("sleep", "10"),
+			func(cmd *exec.Cmd, cancel context.CancelFunc) {
+				assert.NoError(t, cmd.Start())
+				cancel()
+				done := make(chan error)
+				go func() {
+					done <- cmd.Wait()
+				}()
+				select {
+				case err := <-done:
+					assert.Error(t, err)
+				case <-time.After(5 * time.Second):
+					t.Error("command is still running")
+				}
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			s.test(CommandWithContext(ctx, s.cmd), cancel)
+		})
+	}
+}
+
 // TestOSCommandQuote is a function.
 func TestOSCommandQuote(t *testing.T) {
 	osCommand := NewDummyOSCommand()
@@ -375,3 +488,22 @@ func TestOSCommandCreateTempFile(t *testing.T) {
 		})
 	}
 }
+
+// TestOSCommandCreateTutorialRepo is a function.
+func TestOSCommandCreateTutorialRepo(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+
+	repo, err := osCommand.CreateTutorialRepo()
+	assert.NoError(t, err)
+	defer os.RemoveAll(repo.Path)
+
+	branch, err := osCommand.RunCommandWithOutput("git -C %s rev-parse --abbrev-ref HEAD", osCommand.Quote(repo.Path))
+	assert.NoError(t, err)
+	assert.Equal(t, "main\n", branch)
+
+	status, err := osCommand.RunCommandWithOutput("git -C %s status --porcelain", osCommand.Quote(repo.Path))
+	assert.NoError(t, err)
+	assert.Equal(t, " M README.md\n?? todo.txt\n", status)
+
+	assert.Len(t, repo.FeatureSha, 40)
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"strings"
+)
+
+// When pairing or mobbing, whoever is driving i.e. at the keyboard gets
+// credited as a co-author of each commit with a Co-authored-by trailer, which
+// GitHub and GitLab both understand.
+
+// GetPairs returns the identities in git.commit.pairs, as 'Name <email>'
+func (c *GitCommand) GetPairs() []string {
+	pairs := []string{}
+	for _, pair := range c.Config.GetUserConfig().GetStringSlice("git.commit.pairs") {
+		if pair = strings.TrimSpace(pair); pair != "" {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
+// identityEmail returns the email of an identity like 'Name <email>', or the
+// whole thing if it doesn't have one
+func identityEmail(identity string) string {
+	start := strings.Index(identity, "<")
+	end := strings.LastIndex(identity, ">")
+	if start == -1 || end < start {
+		return strings.TrimSpace(identity)
+	}
+	return identity[start+1 : end]
+}
+
+// IdentityName returns the name of an identity like 'Name <email>'
+func IdentityName(identity string) string {
+	if start := strings.Index(identity, "<"); start > 0 {
+		return strings.TrimSpace(identity[:start])
+	}
+	return identity
+}
+
+// AddCoAuthor credits the identity as a co-author at the end of the message,
+// unless it already is or it's who the commit will be authored by anyway
+func (c *GitCommand) AddCoAuthor(message string, identity string) string {
+	if author, err := c.GetAuthorIdentity(); err == nil && strings.EqualFold(identityEmail(author), identityEmail(identity)) {
+		return message
+	}
+
+	trailer := Trailer{Key: "Co-authored-by", Value: identity}
+	if HasTrailer(message, trailer) {
+		return message
+	}
+	return AddTrailer(message, trailer)
+}
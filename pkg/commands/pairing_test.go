@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetPairs is a function.
+func TestGitCommandGetPairs(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config.GetUserConfig().Set("git.commit.pairs", []string{"Jane Doe <jane@example.com>", " ", "John Smith <john@example.com> "})
+
+	assert.EqualValues(t, []string{"Jane Doe <jane@example.com>", "John Smith <john@example.com>"}, gitCmd.GetPairs())
+}
+
+// TestIdentityName is a function.
+func TestIdentityName(t *testing.T) {
+	assert.EqualValues(t, "Jane Doe", IdentityName("Jane Doe <jane@example.com>"))
+	assert.EqualValues(t, "jane", IdentityName("jane"))
+}
+
+// TestGitCommandAddCoAuthor is a function.
+func TestGitCommandAddCoAuthor(t *testing.T) {
+	type scenario struct {
+		testName string
+		message  string
+		identity string
+		command  func(string, ...string) *exec.Cmd
+		expected string
+	}
+
+	author := &test.CommandSwapper{
+		Expect:  "git var GIT_AUTHOR_IDENT",
+		Replace: "echo Jesse Duffield <jesse@example.com> 1600000000 +1000",
+	}
+
+	scenarios := []scenario{
+		{
+			"someone else driving",
+			"Fix typo",
+			"Jane Doe <jane@example.com>",
+			test.CreateMockCommand(t, []*test.CommandSwapper{author}),
+			"Fix typo\n\nCo-authored-by: Jane Doe <jane@example.com>",
+		},
+		{
+			"already credited",
+			"Fix typo\n\nCo-authored-by: Jane Doe <jane@example.com>",
+			"Jane Doe <jane@example.com>",
+			test.CreateMockCommand(t, []*test.CommandSwapper{author}),
+			"Fix typo\n\nCo-authored-by: Jane Doe <jane@example.com>",
+		},
+		{
+			"the author driving",
+			"Fix typo",
+			"Jesse <JESSE@example.com>",
+			test.CreateMockCommand(t, []*test.CommandSwapper{author}),
+			"Fix typo",
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			assert.EqualValues(t, s.expected, gitCmd.AddCoAuthor(s.message, s.identity))
+		})
+	}
+}
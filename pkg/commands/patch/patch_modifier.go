@@ -1,4 +1,4 @@
-package commands
+package patch
 
 import (
 	"fmt"
@@ -145,6 +145,28 @@ func (hunk *PatchHunk) updatedHeader(newBodyLines []string, startOffset int, rev
 	return newStartOffset, formattedHeader, true
 }
 
+// NewLineRange returns the first and last lines of the new version of the file
+// that the hunk covers. A hunk that only removes lines covers none of them, so
+// both are the line the removal comes after.
+func (hunk *PatchHunk) NewLineRange() (int, int) {
+	match := hunkHeaderRegexp.FindStringSubmatch(hunk.header)
+	if match == nil {
+		return 0, 0
+	}
+	start := mustConvertToInt(match[2])
+
+	length := 0
+	for _, line := range hunk.bodyLines {
+		if strings.HasPrefix(line, "+") || strings.HasPrefix(line, " ") {
+			length++
+		}
+	}
+	if length == 0 {
+		return start, start
+	}
+	return start, start + length - 1
+}
+
 func mustConvertToInt(s string) int {
 	i, err := strconv.Atoi(s)
 	if err != nil {
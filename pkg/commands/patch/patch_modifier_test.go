@@ -1,4 +1,4 @@
-package commands
+package patch
 
 import (
 	"fmt"
@@ -509,3 +509,99 @@ func TestModifyPatchForRange(t *testing.T) {
 		})
 	}
 }
+
+// TestPatchHunkNewLineRange is a function.
+func TestPatchHunkNewLineRange(t *testing.T) {
+	type scenario struct {
+		testName      string
+		diffText      string
+		expectedStart int
+		expectedEnd   int
+	}
+
+	scenarios := []scenario{
+		{"lines changed", simpleDiff, 1, 5},
+		{"lines added to an empty file", addNewlineToPreviouslyEmptyFile, 1, 1},
+		{"no newline markers ignored", addNewlineToEndOfFile, 60, 63},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			hunks := GetHunksFromDiff(s.diffText)
+			start, end := hunks[0].NewLineRange()
+			assert.EqualValues(t, s.expectedStart, start)
+			assert.EqualValues(t, s.expectedEnd, end)
+		})
+	}
+}
+
+// TestModifiedPatchForLines is a function.
+func TestModifiedPatchForLines(t *testing.T) {
+	type scenario struct {
+		testName    string
+		lineIndices []int
+		reverse     bool
+		expected    string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:    "no lines selected",
+			lineIndices: []int{},
+			expected:    "",
+		},
+		{
+			testName:    "lines from either hunk",
+			lineIndices: []int{6, 16},
+			expected: `--- a/filename
++++ b/filename
+@@ -1,5 +1,4 @@
+ apple
+-grape
+ ...
+ ...
+ ...
+@@ -8,6 +7,7 @@ grape
+ ...
+ ...
+ ...
++lemon
+ ...
+ ...
+ ...
+`,
+		},
+		{
+			testName:    "lines from either hunk, reversed",
+			lineIndices: []int{7, 15},
+			reverse:     true,
+			expected: `--- a/filename
++++ b/filename
+@@ -1,5 +1,4 @@
+ apple
+-orange
+ ...
+ ...
+ ...
+@@ -8,8 +7,7 @@ grape
+ ...
+ ...
+ ...
+-pear
+ lemon
+ ...
+ ...
+ ...
+`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			result := NewPatchModifier(nil, "filename", twoHunks).ModifiedPatchForLines(s.lineIndices, s.reverse, false)
+			if !assert.Equal(t, s.expected, result) {
+				fmt.Println(result)
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package commands
+package patch
 
 import (
 	"regexp"
@@ -96,7 +96,7 @@ func (l *PatchLine) render(selected bool, included bool) string {
 	if l.Kind == HUNK_HEADER {
 		re := regexp.MustCompile("(@@.*?@@)(.*)")
 		match := re.FindStringSubmatch(content)
-		return coloredString(color.FgCyan, match[1], selected, included) + coloredString(theme.DefaultTextColor, match[2], selected, false)
+		return coloredString(theme.CurrentPalette.Info, match[1], selected, included) + coloredString(theme.DefaultTextColor, match[2], selected, false)
 	}
 
 	var colorAttr color.Attribute
@@ -104,11 +104,11 @@ func (l *PatchLine) render(selected bool, included bool) string {
 	case PATCH_HEADER:
 		colorAttr = color.Bold
 	case ADDITION:
-		colorAttr = color.FgGreen
+		colorAttr = theme.CurrentPalette.Added
 	case DELETION:
-		colorAttr = color.FgRed
+		colorAttr = theme.CurrentPalette.Removed
 	case COMMIT_SHA:
-		colorAttr = color.FgYellow
+		colorAttr = theme.CurrentPalette.Warning
 	default:
 		colorAttr = theme.DefaultTextColor
 	}
@@ -125,7 +125,7 @@ func coloredString(colorAttr color.Attribute, str string, selected bool, include
 	cl = color.New(attributes...)
 	var clIncluded *color.Color
 	if included {
-		clIncluded = color.New(append(attributes, color.BgGreen)...)
+		clIncluded = color.New(append(attributes, theme.CurrentPalette.IncludedLineBg)...)
 	} else {
 		clIncluded = color.New(attributes...)
 	}
@@ -188,8 +188,17 @@ func parsePatch(patch string) ([]int, []int, []*PatchLine, error) {
 
 // Render returns the coloured string of the diff with any selected lines highlighted
 func (p *PatchParser) Render(firstLineIndex int, lastLineIndex int, incLineIndices []int) string {
-	renderedLines := make([]string, len(p.PatchLines))
-	for index, patchLine := range p.PatchLines {
+	return p.RenderUpTo(len(p.PatchLines), firstLineIndex, lastLineIndex, incLineIndices)
+}
+
+// RenderUpTo is like Render but only renders the diff's first lineCount lines,
+// so that showing the top of a huge diff doesn't mean rendering all of it
+func (p *PatchParser) RenderUpTo(lineCount int, firstLineIndex int, lastLineIndex int, incLineIndices []int) string {
+	if lineCount > len(p.PatchLines) {
+		lineCount = len(p.PatchLines)
+	}
+	renderedLines := make([]string, lineCount)
+	for index, patchLine := range p.PatchLines[:lineCount] {
 		selected := index >= firstLineIndex && index <= lastLineIndex
 		included := utils.IncludesInt(incLineIndices, index)
 		renderedLines[index] = patchLine.render(selected, included)
@@ -0,0 +1,54 @@
+package patch
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDummyLog() *logrus.Entry {
+	log := logrus.New()
+	log.Out = ioutil.Discard
+	return log.WithField("test", "test")
+}
+
+// TestPatchParserRenderUpTo is a function.
+func TestPatchParserRenderUpTo(t *testing.T) {
+	patch := "diff --git a/a b/a\n--- a/a\n+++ b/a\n@@ -1,2 +1,2 @@\n-one\n+two\n three"
+	patchParser, err := NewPatchParser(newDummyLog(), patch)
+	assert.NoError(t, err)
+
+	assert.EqualValues(t, "diff --git a/a b/a\n--- a/a\n+++ b/a\n@@ -1,2 +1,2 @@\n-one", utils.Decolorise(patchParser.RenderUpTo(5, -1, -1, nil)))
+	assert.EqualValues(t, utils.Decolorise(patchParser.Render(-1, -1, nil)), utils.Decolorise(patchParser.RenderUpTo(100, -1, -1, nil)))
+}
+
+// TestPatchParserStagingHunk is a function.
+func TestPatchParserStagingHunk(t *testing.T) {
+	patchParser, err := NewPatchParser(newDummyLog(), twoHunks)
+	assert.NoError(t, err)
+
+	// the first line of the second hunk that can be staged
+	lineIdx := patchParser.GetNextStageableLineIndex(8)
+	assert.EqualValues(t, 15, lineIdx)
+
+	hunk := patchParser.GetHunkContainingLine(lineIdx, 0)
+	assert.EqualValues(t, 11, hunk.FirstLineIdx)
+	assert.EqualValues(t, hunk, patchParser.GetHunkContainingLine(lineIdx-10, 1))
+
+	expected := `--- a/filename
++++ b/filename
+@@ -8,6 +8,8 @@ grape
+ ...
+ ...
+ ...
++pear
++lemon
+ ...
+ ...
+ ...
+`
+	assert.EqualValues(t, expected, ModifiedPatchForRange(nil, "filename", twoHunks, hunk.FirstLineIdx, hunk.LastLineIdx, false, false))
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"sort"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/sirupsen/logrus"
 )
@@ -101,7 +102,7 @@ func (p *PatchManager) RenderPlainPatchForFile(filename string, reverse bool, ke
 		return info.diff
 	case PART:
 		// generate a new diff with just the selected lines
-		m := NewPatchModifier(p.Log, filename, info.diff)
+		m := patch.NewPatchModifier(p.Log, filename, info.diff)
 		return m.ModifiedPatchForLines(info.includedLineIndices, reverse, keepOriginalHeader)
 	default:
 		return ""
@@ -109,11 +110,11 @@ func (p *PatchManager) RenderPlainPatchForFile(filename string, reverse bool, ke
 }
 
 func (p *PatchManager) RenderPatchForFile(filename string, plain bool, reverse bool, keepOriginalHeader bool) string {
-	patch := p.RenderPlainPatchForFile(filename, reverse, keepOriginalHeader)
+	plainPatch := p.RenderPlainPatchForFile(filename, reverse, keepOriginalHeader)
 	if plain {
-		return patch
+		return plainPatch
 	}
-	parser, err := NewPatchParser(p.Log, patch)
+	parser, err := patch.NewPatchParser(p.Log, plainPatch)
 	if err != nil {
 		// swallowing for now
 		return ""
@@ -0,0 +1,29 @@
+// +build !windows
+
+package commands
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup puts the command in a process group of its own, so that
+// killing the group kills anything it started too
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	// a process starting its own session already leads its own process group,
+	// and it can't be moved to another one
+	if !cmd.SysProcAttr.Setsid {
+		cmd.SysProcAttr.Setpgid = true
+	}
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	attr := cmd.SysProcAttr
+	if attr == nil || !(attr.Setpgid || attr.Setsid) {
+		return cmd.Process.Kill()
+	}
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
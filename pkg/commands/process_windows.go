@@ -0,0 +1,11 @@
+package commands
+
+import "os/exec"
+
+// Windows has no process groups to kill, so only the command itself is killed
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ProjectCommand is a command from the user's config for working on the
+// project itself, like running its tests or building it:
+//
+//   projectCommands:
+//     - name: test
+//       command: 'go test ./...'
+//
+// It's run in the background so that the user can keep going while it runs.
+type ProjectCommand struct {
+	Name    string `yaml:"name"`
+	Command string `yaml:"command"`
+}
+
+// GetProjectCommands returns the project commands from the user's config,
+// leaving out any without a command
+func (c *OSCommand) GetProjectCommands() []ProjectCommand {
+	rawCommands := c.Config.GetUserConfig().Get("projectCommands")
+	if rawCommands == nil {
+		return nil
+	}
+	commandsYaml, err := yaml.Marshal(rawCommands)
+	if err != nil {
+		return nil
+	}
+	projectCommands := []ProjectCommand{}
+	if err := yaml.Unmarshal(commandsYaml, &projectCommands); err != nil {
+		c.Log.Error(err)
+		return nil
+	}
+
+	result := []ProjectCommand{}
+	for _, projectCommand := range projectCommands {
+		if projectCommand.Command == "" {
+			continue
+		}
+		if projectCommand.Name == "" {
+			projectCommand.Name = projectCommand.Command
+		}
+		result = append(result, projectCommand)
+	}
+	return result
+}
@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandGetProjectCommands is a function.
+func TestOSCommandGetProjectCommands(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	assert.Len(t, osCommand.GetProjectCommands(), 0)
+
+	osCommand.Config.GetUserConfig().Set("projectCommands", []interface{}{
+		map[string]interface{}{"name": "test", "command": "go test ./..."},
+		map[string]interface{}{"command": "make"},
+		map[string]interface{}{"name": "nothing"},
+	})
+	assert.EqualValues(t, []ProjectCommand{
+		{Name: "test", Command: "go test ./..."},
+		{Name: "make", Command: "make"},
+	}, osCommand.GetProjectCommands())
+}
@@ -12,6 +12,8 @@ import (
 type Service struct {
 	Name           string
 	PullRequestURL string
+	CommitURL      string
+	IssueURL       string
 }
 
 // PullRequest opens a link in browser to create new pull request
@@ -36,16 +38,22 @@ func NewService(typeName string, repositoryDomain string, siteDomain string) *Se
 		service = &Service{
 			Name:           repositoryDomain,
 			PullRequestURL: fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/compare/%s?expand=1"),
+			CommitURL:      fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/commit/%s"),
+			IssueURL:       fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/issues/%s"),
 		}
 	case "bitbucket":
 		service = &Service{
 			Name:           repositoryDomain,
 			PullRequestURL: fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/pull-requests/new?source=%s&t=1"),
+			CommitURL:      fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/commits/%s"),
+			IssueURL:       fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/issues/%s"),
 		}
 	case "gitlab":
 		service = &Service{
 			Name:           repositoryDomain,
 			PullRequestURL: fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/merge_requests/new?merge_request[source_branch]=%s"),
+			CommitURL:      fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/-/commit/%s"),
+			IssueURL:       fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/-/issues/%s"),
 		}
 	}
 
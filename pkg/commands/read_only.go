@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/mgutz/str"
+)
+
+// ReadOnlyError is what we return instead of doing something that could
+// change the repo or the working tree while we're in read-only mode
+type ReadOnlyError struct {
+	// Action is what we didn't do e.g. "run 'git checkout master'"
+	Action string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return "read-only mode: not going to " + e.Action
+}
+
+// NewReadOnlyCommandError returns the error for not running a command
+func NewReadOnlyCommandError(command string) *ReadOnlyError {
+	return &ReadOnlyError{Action: fmt.Sprintf("run '%s'", command)}
+}
+
+// gitReaders are the git subcommands that we run to load things, each with a
+// function telling whether the given args keep it from changing anything.
+// Anything not in here, and anything other than git, is assumed to write.
+var gitReaders = map[string]func(args []string) bool{
+	"annotate":      always,
+	"blame":         always,
+	"cat-file":      always,
+	"check-attr":    always,
+	"check-ignore":  always,
+	"cherry":        always,
+	"count-objects": always,
+	"describe":      always,
+	"diff":          withoutOutputFile,
+	"diff-files":    withoutOutputFile,
+	"diff-index":    withoutOutputFile,
+	"diff-tree":     withoutOutputFile,
+	"for-each-ref":  always,
+	"grep":          always,
+	"log":           withoutOutputFile,
+	"ls-files":      always,
+	"ls-remote":     always,
+	"ls-tree":       always,
+	"merge-base":    always,
+	"name-rev":      always,
+	"range-diff":    withoutOutputFile,
+	"rev-list":      always,
+	"rev-parse":     always,
+	"shortlog":      always,
+	"show":          withoutOutputFile,
+	"show-ref":      always,
+	"status":        always,
+	"var":           always,
+	"version":       always,
+	"branch":        isBranchListing,
+	"config":        isConfigRead,
+	"fsck": func(args []string) bool {
+		return !includesAny(args, "--lost-found")
+	},
+	"interpret-trailers": func(args []string) bool {
+		return !includesAny(args, "--in-place", "-i")
+	},
+	"reflog": func(args []string) bool {
+		// `git reflog` with just options is `git reflog show`
+		return len(args) == 0 || strings.HasPrefix(args[0], "-") || args[0] == "show" || args[0] == "exists"
+	},
+	"remote":          firstArgIn("", "-v", "--verbose", "show", "get-url"),
+	"sparse-checkout": firstArgIn("list"),
+	"stash":           firstArgIn("list", "show"),
+	"submodule":       firstArgIn("", "status", "summary"),
+	"symbolic-ref": func(args []string) bool {
+		return len(positionalArgs(args)) <= 1 && !includesAny(args, "-d", "--delete")
+	},
+	"tag": func(args []string) bool {
+		return len(positionalArgs(args)) == 0 || includesAny(args, "-l", "--list", "-v", "--verify", "--contains", "--points-at")
+	},
+	"worktree": firstArgIn("list"),
+}
+
+var shells = []string{"sh", "bash", "zsh", "cmd", "cmd.exe"}
+
+func always(args []string) bool {
+	return true
+}
+
+// withoutOutputFile is for the diff and log family, which can be told to
+// write their output to a file instead of stdout
+func withoutOutputFile(args []string) bool {
+	return !includesAny(args, "--output", "-o")
+}
+
+func includesAny(args []string, flags ...string) bool {
+	for _, arg := range args {
+		for _, flag := range flags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func positionalArgs(args []string) []string {
+	positional := []string{}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+		}
+	}
+	return positional
+}
+
+// firstArgIn is for subcommands that have subcommands of their own, like
+// `git stash list`. An empty string allows running it without any args.
+func firstArgIn(allowed ...string) func(args []string) bool {
+	return func(args []string) bool {
+		first := ""
+		if len(args) > 0 {
+			first = args[0]
+		}
+		for _, a := range allowed {
+			if first == a {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isBranchListing(args []string) bool {
+	if includesAny(args, "-d", "-D", "--delete", "-m", "-M", "--move", "-c", "-C", "--copy", "-f", "--force", "-u", "--set-upstream-to", "--unset-upstream", "--edit-description", "-t", "--track") {
+		return false
+	}
+	return len(positionalArgs(args)) == 0 || includesAny(args, "-l", "--list", "--contains", "--no-contains", "--merged", "--no-merged", "--points-at")
+}
+
+func isConfigRead(args []string) bool {
+	if includesAny(args, "--get", "--get-all", "--get-regexp", "--get-urlmatch", "-l", "--list") {
+		return true
+	}
+	if includesAny(args, "--unset", "--unset-all", "--add", "--replace-all", "--rename-section", "--remove-section", "-e", "--edit") {
+		return false
+	}
+	// `git config key` reads the key whereas `git config key value` sets it
+	return len(positionalArgs(args)) == 1
+}
+
+// splitShellCommands splits a command line at each pipe and command separator
+// that isn't in quotes, so that we can check every command in it. It returns
+// false if the command line redirects to or from a file or substitutes the
+// output of another command, because then we can't tell what it does.
+func splitShellCommands(command string) ([]string, bool) {
+	commands := []string{}
+	current := ""
+	var quote rune
+	var prev rune
+	for _, char := range command {
+		switch {
+		case quote == '\'':
+			if char == quote {
+				quote = 0
+			}
+		case char == '`' || (prev == '$' && char == '('):
+			// substitutions are expanded even in double quotes
+			return nil, false
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '\'' || char == '"':
+			quote = char
+		case char == '>' || char == '<':
+			return nil, false
+		case char == '|' || char == ';' || char == '&' || char == '\n':
+			if strings.TrimSpace(current) != "" {
+				commands = append(commands, current)
+			}
+			current = ""
+			prev = char
+			continue
+		}
+		current += string(char)
+		prev = char
+	}
+	if strings.TrimSpace(current) != "" {
+		commands = append(commands, current)
+	}
+	return commands, true
+}
+
+// gitSubcommand returns the subcommand and its args of a git command line,
+// skipping git's own options, or false if it's not a git command
+func gitSubcommand(argv []string) (string, []string, bool) {
+	if len(argv) == 0 || argv[0] != "git" {
+		return "", nil, false
+	}
+	for i := 1; i < len(argv); i++ {
+		switch {
+		case argv[i] == "-c" || argv[i] == "-C":
+			i++
+		case strings.HasPrefix(argv[i], "-"):
+		default:
+			return argv[i], argv[i+1:], true
+		}
+	}
+	return "", nil, false
+}
+
+// IsReadOnlyCommand tells us whether a command line only reads from the repo,
+// which is the case if every command in it is a git command that we know
+// doesn't write anything with the args it's been given
+func IsReadOnlyCommand(command string) bool {
+	commands, ok := splitShellCommands(command)
+	if !ok || len(commands) == 0 {
+		return false
+	}
+
+	for _, command := range commands {
+		if !IsReadOnlyArgs(str.ToArgv(strings.TrimSpace(command))) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsReadOnlyArgs is IsReadOnlyCommand for a single command that's already
+// been split into its args, as in exec.Cmd.Args
+func IsReadOnlyArgs(argv []string) bool {
+	// custom commands are run through the shell
+	if len(argv) == 3 && utils.IncludesString(shells, filepath.Base(argv[0])) && (argv[1] == "-c" || argv[1] == "/c") {
+		return IsReadOnlyCommand(argv[2])
+	}
+
+	subcommand, args, ok := gitSubcommand(argv)
+	if !ok {
+		return false
+	}
+	isReader, ok := gitReaders[subcommand]
+	return ok && isReader(args)
+}
+
+// ReadOnly tells us whether we're in read-only mode, where we don't change
+// anything, for browsing a checkout without any risk of breaking it
+func (c *OSCommand) ReadOnly() bool {
+	return c.Config.GetReadOnly()
+}
+
+// CheckReadOnly returns a ReadOnlyError if we're in read-only mode and the
+// given command line could write something
+func (c *OSCommand) CheckReadOnly(command string) error {
+	if !c.ReadOnly() || IsReadOnlyCommand(command) {
+		return nil
+	}
+	c.Log.WithField("command", command).Warn("not running command in read-only mode")
+	return NewReadOnlyCommandError(command)
+}
+
+// checkReadOnlyCmd is CheckReadOnly for a command we've already prepared
+func (c *OSCommand) checkReadOnlyCmd(cmd *exec.Cmd) error {
+	if !c.ReadOnly() || IsReadOnlyArgs(cmd.Args) {
+		return nil
+	}
+	command := strings.Join(cmd.Args, " ")
+	c.Log.WithField("command", command).Warn("not running command in read-only mode")
+	return NewReadOnlyCommandError(command)
+}
+
+// CheckWritable returns a ReadOnlyError if we're in read-only mode, for when
+// we're about to write to a file ourselves
+func (c *OSCommand) CheckWritable(fileName string) error {
+	if !c.ReadOnly() {
+		return nil
+	}
+	return &ReadOnlyError{Action: "write " + fileName}
+}
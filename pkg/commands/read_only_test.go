@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsReadOnlyCommand is a function.
+func TestIsReadOnlyCommand(t *testing.T) {
+	type scenario struct {
+		command  string
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{"git status --untracked-files=all --porcelain", true},
+		{"git -c color.ui=false log --oneline", true},
+		{`git reflog --date=relative --pretty='%gd|%gs' --grep-reflog='checkout  moving' HEAD`, true},
+		{"git reflog --abbrev=20 --date=unix", true},
+		{"git reflog expire --all", false},
+		{"git config --get remote.origin.url", true},
+		{"git config --local gitflow.branch.master", true},
+		{"git config --local core.untrackedCache true", false},
+		{"git config --local --unset core.untrackedCache", false},
+		{"git branch -r", true},
+		{"git branch --contains abc", true},
+		{"git branch -D feature", false},
+		{"git branch feature", false},
+		{"git stash list", true},
+		{"git stash", false},
+		{"git stash drop stash@{1}", false},
+		{"git tag --list", true},
+		{"git tag v1.0", false},
+		{"git symbolic-ref --short HEAD", true},
+		{"git symbolic-ref HEAD refs/heads/master", false},
+		{"git checkout master", false},
+		{"git fetch", false},
+		{"git update-index -q --really-refresh", false},
+		{"git fsck --no-reflogs --no-progress", true},
+		{"git fsck --lost-found", false},
+		{"git log -1 --format=%B | git interpret-trailers --trailer 'a: b' | git commit --amend -F -", false},
+		{"git diff | git apply --cached", false},
+		{"rm -rf .", false},
+		{"git log > file", false},
+		{"git log >> file", false},
+		{"git status < file", false},
+		{"git status 2>&1", false},
+		{"git status $(rm -rf x)", false},
+		{`git status "$(rm -rf x)"`, false},
+		{"git status `rm -rf x`", false},
+		{`git log --grep='$(not run) > here'`, true},
+		{"git log --oneline\nrm -rf .", false},
+		{"git diff --output=patch.diff", false},
+		{"git diff --output patch.diff", false},
+		{"git log -p -o patch.diff", false},
+		{"git show --output=patch.diff HEAD", false},
+		{"git ls-files -o", true},
+		{"", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.command, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, IsReadOnlyCommand(s.command))
+		})
+	}
+}
+
+// TestIsReadOnlyArgs is a function.
+func TestIsReadOnlyArgs(t *testing.T) {
+	type scenario struct {
+		testName string
+		args     []string
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{"git reader", []string{"git", "log", "--pretty=%gd|%gs"}, true},
+		{"git writer", []string{"git", "commit"}, false},
+		{"editor", []string{"vim", "file.txt"}, false},
+		{"reading custom command", []string{"bash", "-c", "git log --oneline"}, true},
+		{"writing custom command", []string{"bash", "-c", "git log && git push"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, IsReadOnlyArgs(s.args))
+		})
+	}
+}
+
+// TestOSCommandCheckReadOnly is a function.
+func TestOSCommandCheckReadOnly(t *testing.T) {
+	type scenario struct {
+		testName string
+		readOnly bool
+		command  string
+		test     func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"not in read-only mode",
+			false,
+			"git checkout master",
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"reading in read-only mode",
+			true,
+			"git log --oneline",
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"writing in read-only mode",
+			true,
+			"git checkout master",
+			func(err error) {
+				assert.IsType(t, &ReadOnlyError{}, err)
+				assert.EqualError(t, err, "read-only mode: not going to run 'git checkout master'")
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			osCommand := NewDummyOSCommand()
+			osCommand.Config.GetUserConfig().Set("readOnly", s.readOnly)
+			s.test(osCommand.CheckReadOnly(s.command))
+		})
+	}
+}
+
+// TestOSCommandRunCommandInReadOnlyMode is a function.
+func TestOSCommandRunCommandInReadOnlyMode(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("readOnly", true)
+	// nothing is expected to run
+	osCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{})
+
+	assert.IsType(t, &ReadOnlyError{}, osCommand.RunCommand("git checkout %s", "master"))
+	assert.IsType(t, &ReadOnlyError{}, osCommand.RunExecutable(exec.Command("git", "push")))
+	assert.IsType(t, &ReadOnlyError{}, osCommand.CreateFileWithContent("file.txt", "content"))
+}
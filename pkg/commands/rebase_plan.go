@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+)
+
+// RebasePlanStep is a commit in an interactive rebase we're planning, along
+// with what's to be done with it
+type RebasePlanStep struct {
+	Commit *Commit
+	Action string // one of "pick", "squash", "fixup", "edit", "drop" or "reword"
+	// Message is the commit's new message when it's being reworded
+	Message string
+}
+
+// RebasePlan is an interactive rebase that's yet to be started. Its steps are
+// newest first, as in the commits panel, and are rebased onto BaseSha
+type RebasePlan struct {
+	Steps   []*RebasePlanStep
+	BaseSha string
+}
+
+// NewRebasePlan plans to pick each commit from HEAD down to the one at the
+// given index, rebasing them onto that commit's parent
+func (c *GitCommand) NewRebasePlan(commits []*Commit, index int) (*RebasePlan, error) {
+	if len(commits) <= index+1 {
+		return nil, errors.New(c.Tr.SLocalize("CannotRebaseOntoFirstCommit"))
+	}
+
+	steps := make([]*RebasePlanStep, index+1)
+	for i, commit := range commits[0 : index+1] {
+		steps[i] = &RebasePlanStep{Commit: commit, Action: "pick"}
+	}
+	return &RebasePlan{Steps: steps, BaseSha: commits[index+1].Sha}, nil
+}
+
+// MoveStep swaps the step at the given index with its neighbour in the given
+// direction, returning false if there's no neighbour there
+func (p *RebasePlan) MoveStep(index int, change int) bool {
+	other := index + change
+	if index < 0 || other < 0 || index >= len(p.Steps) || other >= len(p.Steps) {
+		return false
+	}
+	p.Steps[index], p.Steps[other] = p.Steps[other], p.Steps[index]
+	return true
+}
+
+// ValidateRebasePlan makes sure every squash and fixup has an earlier commit
+// to go into, which git would otherwise only tell us once the rebase had begun
+func (c *GitCommand) ValidateRebasePlan(plan *RebasePlan) error {
+	squashable := false
+	for i := len(plan.Steps) - 1; i >= 0; i-- {
+		step := plan.Steps[i]
+		switch step.Action {
+		case "squash", "fixup":
+			if !squashable {
+				return errors.New(c.Tr.TemplateLocalize("CannotSquashWithoutEarlierStep", i18n.Teml{"sha": step.Commit.ShortSha()}))
+			}
+		case "drop":
+		default:
+			squashable = true
+		}
+	}
+	return nil
+}
+
+// RebasePlanTodo returns the git-rebase-todo for the plan, oldest first.
+// Git would open an editor for a reword, so instead we pick the commit and then
+// amend it with the new message, which we've written to a temp file
+func (c *GitCommand) RebasePlanTodo(plan *RebasePlan) (string, error) {
+	if err := c.ValidateRebasePlan(plan); err != nil {
+		return "", err
+	}
+
+	todo := ""
+	for i := len(plan.Steps) - 1; i >= 0; i-- {
+		step := plan.Steps[i]
+		commit := step.Commit
+		if step.Action != "reword" {
+			todo += step.Action + " " + commit.Sha + " " + commit.Name + "\n"
+			continue
+		}
+
+		todo += "pick " + commit.Sha + " " + commit.Name + "\n"
+		path, err := c.OSCommand.CreateTempFile("lazygit-reword-"+commit.Sha, step.Message)
+		if err != nil {
+			return "", err
+		}
+		quotedPath := c.OSCommand.Quote(path)
+		todo += fmt.Sprintf("exec git commit --allow-empty --amend --no-verify -F %s && rm %s\n", quotedPath, quotedPath)
+	}
+	return todo, nil
+}
+
+// RunRebasePlan starts the planned interactive rebase, with lazygit standing in
+// as git's sequence editor to hand it the todo
+func (c *GitCommand) RunRebasePlan(plan *RebasePlan) error {
+	if c.usingGpg() {
+		return errors.New(c.Tr.SLocalize("DisabledForGPG"))
+	}
+
+	todo, err := c.RebasePlanTodo(plan)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := c.PrepareInteractiveRebaseCommand(plan.BaseSha, todo, true)
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
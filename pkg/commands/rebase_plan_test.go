@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rebasePlanCommits() []*Commit {
+	return []*Commit{
+		{Sha: "ddd", Name: "fourth"},
+		{Sha: "ccc", Name: "third"},
+		{Sha: "bbb", Name: "second"},
+		{Sha: "aaa", Name: "first"},
+	}
+}
+
+// TestGitCommandNewRebasePlan is a function.
+func TestGitCommandNewRebasePlan(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	commits := rebasePlanCommits()
+
+	plan, err := gitCmd.NewRebasePlan(commits, 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "aaa", plan.BaseSha)
+	assert.Len(t, plan.Steps, 3)
+	for i, step := range plan.Steps {
+		assert.EqualValues(t, commits[i], step.Commit)
+		assert.EqualValues(t, "pick", step.Action)
+	}
+
+	// there's nothing to rebase the first commit onto
+	_, err = gitCmd.NewRebasePlan(commits, 3)
+	assert.Error(t, err)
+}
+
+// TestRebasePlanMoveStep is a function.
+func TestRebasePlanMoveStep(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	plan, err := gitCmd.NewRebasePlan(rebasePlanCommits(), 2)
+	assert.NoError(t, err)
+
+	assert.True(t, plan.MoveStep(0, 1))
+	assert.EqualValues(t, "ccc", plan.Steps[0].Commit.Sha)
+	assert.EqualValues(t, "ddd", plan.Steps[1].Commit.Sha)
+
+	assert.False(t, plan.MoveStep(0, -1))
+	assert.False(t, plan.MoveStep(2, 1))
+}
+
+// TestGitCommandRebasePlanTodo is a function.
+func TestGitCommandRebasePlanTodo(t *testing.T) {
+	type scenario struct {
+		testName string
+		actions  []string
+		test     func(string, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"dropping and fixing up",
+			[]string{"drop", "fixup", "pick"},
+			func(todo string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "pick bbb second\nfixup ccc third\ndrop ddd fourth\n", todo)
+			},
+		},
+		{
+			"editing",
+			[]string{"edit", "pick"},
+			func(todo string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "pick ccc third\nedit ddd fourth\n", todo)
+			},
+		},
+		{
+			"squashing into the oldest step",
+			[]string{"pick", "pick", "squash"},
+			func(todo string, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"squashing into a dropped step",
+			[]string{"pick", "fixup", "drop"},
+			func(todo string, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			plan, err := gitCmd.NewRebasePlan(rebasePlanCommits(), len(s.actions)-1)
+			assert.NoError(t, err)
+			for i, action := range s.actions {
+				plan.Steps[i].Action = action
+			}
+			s.test(gitCmd.RebasePlanTodo(plan))
+		})
+	}
+}
+
+// TestGitCommandRunRebasePlan is a function.
+func TestGitCommandRunRebasePlan(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	var cmd *exec.Cmd
+	gitCmd.OSCommand.command = func(name string, args ...string) *exec.Cmd {
+		if name == "git" && len(args) > 0 && args[0] == "config" {
+			// we aren't signing commits
+			return exec.Command("false")
+		}
+		assert.EqualValues(t, []string{"rebase", "--interactive", "--autostash", "--keep-empty", "--rebase-merges", "bbb"}, args)
+		cmd = exec.Command("echo")
+		return cmd
+	}
+
+	plan, err := gitCmd.NewRebasePlan(rebasePlanCommits(), 1)
+	assert.NoError(t, err)
+	plan.MoveStep(0, 1)
+	plan.Steps[0].Action = "reword"
+	plan.Steps[0].Message = "Third\n\nWith a body"
+	assert.NoError(t, gitCmd.RunRebasePlan(plan))
+
+	todo := ""
+	for _, env := range cmd.Env {
+		if strings.HasPrefix(env, "LAZYGIT_REBASE_TODO=") {
+			todo = strings.TrimPrefix(env, "LAZYGIT_REBASE_TODO=")
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(todo), "\n")
+	assert.Len(t, lines, 3)
+	assert.EqualValues(t, "pick ddd fourth", lines[0])
+	assert.EqualValues(t, "pick ccc third", lines[1])
+
+	match := regexp.MustCompile(`^exec git commit --allow-empty --amend --no-verify -F '(.*)' && rm '.*'$`).FindStringSubmatch(lines[2])
+	if assert.NotNil(t, match, lines[2]) {
+		content, err := ioutil.ReadFile(match[1])
+		assert.NoError(t, err)
+		assert.EqualValues(t, "Third\n\nWith a body", string(content))
+		_ = os.Remove(match[1])
+	}
+}
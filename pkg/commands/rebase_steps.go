@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// Besides picking commits, a rebase todo can have 'exec' lines, which run a
+// shell command and stop the rebase if it fails, 'break' lines, which stop
+// the rebase until it's continued, and 'update-ref' lines, which move a branch
+// to wherever the rebase has got to. None of them has a commit, so they show
+// up in the commits panel with no sha.
+
+// IsRebaseStep tells us whether this is an exec, break or update-ref line in a
+// rebase todo rather than a commit
+func (c *Commit) IsRebaseStep() bool {
+	return c.Action == "exec" || c.Action == "break" || c.Action == "update-ref"
+}
+
+// parseRebaseTodoLine turns a line from git-rebase-todo into a commit,
+// expanding the short forms git allows for exec and break
+func parseRebaseTodoLine(line string) *Commit {
+	splitLine := strings.Split(line, " ")
+	switch splitLine[0] {
+	case "exec", "x":
+		return &Commit{Name: strings.Join(splitLine[1:], " "), Status: "rebasing", Action: "exec"}
+	case "break", "b":
+		return &Commit{Status: "rebasing", Action: "break"}
+	case "update-ref", "u":
+		return &Commit{Name: strings.Join(splitLine[1:], " "), Status: "rebasing", Action: "update-ref"}
+	}
+
+	commit := &Commit{Status: "rebasing", Action: splitLine[0]}
+	if len(splitLine) > 1 {
+		commit.Sha = splitLine[1]
+		commit.Name = strings.Join(splitLine[2:], " ")
+	}
+	return commit
+}
+
+func (c *GitCommand) rebaseTodoPath() string {
+	return fmt.Sprintf("%s/rebase-merge/git-rebase-todo", c.DotGitDir)
+}
+
+// updateRebaseTodo hands the lines of the todo file to f along with where in
+// the file the todo item at the given index in the commits panel is, and then
+// writes back whatever f returns
+func (c *GitCommand) updateRebaseTodo(index int, f func(content []string, contentIndex int) []string) error {
+	fileName := c.rebaseTodoPath()
+	if err := c.OSCommand.CheckWritable(fileName); err != nil {
+		return err
+	}
+	bytes, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	content := strings.Split(string(bytes), "\n")
+	// we have the most recent commit at the top whereas the todo file has it at
+	// the bottom
+	contentIndex := c.getTodoCommitCount(content) - 1 - index
+	if contentIndex < 0 || contentIndex >= len(content) {
+		return nil
+	}
+
+	result := strings.Join(f(content, contentIndex), "\n")
+	return WrapError(ioutil.WriteFile(fileName, []byte(result), 0644))
+}
+
+// InsertRebaseTodoAfter adds a line to the todo file so that it's run right
+// after the todo item at the given index in the commits panel
+func (c *GitCommand) InsertRebaseTodoAfter(index int, line string) error {
+	return c.updateRebaseTodo(index, func(content []string, contentIndex int) []string {
+		result := append([]string{}, content[:contentIndex+1]...)
+		result = append(result, line)
+		return append(result, content[contentIndex+1:]...)
+	})
+}
+
+// RemoveRebaseTodo takes the todo item at the given index in the commits panel
+// out of the todo file. Unlike dropping a commit, this is how we get rid of
+// exec and break lines.
+func (c *GitCommand) RemoveRebaseTodo(index int) error {
+	return c.updateRebaseTodo(index, func(content []string, contentIndex int) []string {
+		return append(content[:contentIndex], content[contentIndex+1:]...)
+	})
+}
+
+// RebaseWithExec begins an interactive rebase from the commit at the given
+// index which runs command after that commit, or after each of the commits
+// from there up to HEAD if afterEach is true. The rebase stops wherever the
+// command fails.
+func (c *GitCommand) RebaseWithExec(commits []*Commit, index int, command string, afterEach bool) error {
+	if len(commits) <= index+1 {
+		return errors.New(c.Tr.SLocalize("CannotRebaseOntoFirstCommit"))
+	}
+
+	todo := ""
+	for i, commit := range commits[0 : index+1] {
+		lines := "pick " + commit.Sha + " " + commit.Name + "\n"
+		if afterEach || i == index {
+			lines += "exec " + command + "\n"
+		}
+		todo = lines + todo
+	}
+
+	cmd, err := c.PrepareInteractiveRebaseCommand(commits[index+1].Sha, todo, true)
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
+
+// RebaseStoppedAtExec returns the command of the exec line that the rebase
+// stopped at, if it stopped at one. Git only stops after an exec when the
+// command fails or leaves changes behind.
+func (c *GitCommand) RebaseStoppedAtExec() (string, bool) {
+	bytes, err := ioutil.ReadFile(fmt.Sprintf("%s/rebase-merge/done", c.DotGitDir))
+	if err != nil {
+		return "", false
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(bytes)), "\n")
+	lastLine := lines[len(lines)-1]
+	commit := parseRebaseTodoLine(lastLine)
+	if commit.Action != "exec" {
+		return "", false
+	}
+	return commit.Name, true
+}
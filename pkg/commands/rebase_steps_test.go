@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseRebaseTodoLine is a function.
+func TestParseRebaseTodoLine(t *testing.T) {
+	type scenario struct {
+		line     string
+		expected *Commit
+	}
+
+	scenarios := []scenario{
+		{
+			"pick afb893148791a2fbd8091aeb81deba4930c73031 fourth commit",
+			&Commit{Sha: "afb893148791a2fbd8091aeb81deba4930c73031", Name: "fourth commit", Status: "rebasing", Action: "pick"},
+		},
+		{
+			"exec go test ./...",
+			&Commit{Name: "go test ./...", Status: "rebasing", Action: "exec"},
+		},
+		{
+			"x make",
+			&Commit{Name: "make", Status: "rebasing", Action: "exec"},
+		},
+		{
+			"b",
+			&Commit{Status: "rebasing", Action: "break"},
+		},
+		{
+			"update-ref refs/heads/feature",
+			&Commit{Name: "refs/heads/feature", Status: "rebasing", Action: "update-ref"},
+		},
+		{
+			"label onto",
+			&Commit{Sha: "onto", Status: "rebasing", Action: "label"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.line, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, parseRebaseTodoLine(s.line))
+		})
+	}
+}
+
+const rebaseStepsTodo = `pick aaa first
+pick bbb second
+pick ccc third
+
+# Rebase instructions
+`
+
+// TestGitCommandEditRebaseSteps is a function.
+func TestGitCommandEditRebaseSteps(t *testing.T) {
+	type scenario struct {
+		testName string
+		run      func(*GitCommand) error
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			// index 1 in the commits panel is 'second', given that it's newest first
+			"insert an exec after a commit",
+			func(gitCmd *GitCommand) error { return gitCmd.InsertRebaseTodoAfter(1, "exec make test") },
+			"pick aaa first\npick bbb second\nexec make test\npick ccc third\n\n# Rebase instructions\n",
+		},
+		{
+			"insert a break after the last commit",
+			func(gitCmd *GitCommand) error { return gitCmd.InsertRebaseTodoAfter(0, "break") },
+			"pick aaa first\npick bbb second\npick ccc third\nbreak\n\n# Rebase instructions\n",
+		},
+		{
+			"remove a todo item",
+			func(gitCmd *GitCommand) error { return gitCmd.RemoveRebaseTodo(2) },
+			"pick bbb second\npick ccc third\n\n# Rebase instructions\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "lazygit-rebase-steps")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			todoPath := filepath.Join(dir, "rebase-merge", "git-rebase-todo")
+			assert.NoError(t, os.MkdirAll(filepath.Dir(todoPath), 0755))
+			assert.NoError(t, ioutil.WriteFile(todoPath, []byte(rebaseStepsTodo), 0644))
+
+			gitCmd := NewDummyGitCommand()
+			gitCmd.DotGitDir = dir
+			assert.NoError(t, s.run(gitCmd))
+
+			content, err := ioutil.ReadFile(todoPath)
+			assert.NoError(t, err)
+			assert.Equal(t, s.expected, string(content))
+		})
+	}
+}
+
+// TestGitCommandRebaseStoppedAtExec is a function.
+func TestGitCommandRebaseStoppedAtExec(t *testing.T) {
+	type scenario struct {
+		testName        string
+		done            string
+		expectedCommand string
+		expectedOk      bool
+	}
+
+	scenarios := []scenario{
+		{
+			"stopped at a failed exec",
+			"pick aaa first\nexec make test\n",
+			"make test",
+			true,
+		},
+		{
+			"stopped at a commit",
+			"pick aaa first\nedit bbb second\n",
+			"",
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "lazygit-rebase-steps")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			assert.NoError(t, os.MkdirAll(filepath.Join(dir, "rebase-merge"), 0755))
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "rebase-merge", "done"), []byte(s.done), 0644))
+
+			gitCmd := NewDummyGitCommand()
+			gitCmd.DotGitDir = dir
+			command, ok := gitCmd.RebaseStoppedAtExec()
+			assert.Equal(t, s.expectedCommand, command)
+			assert.Equal(t, s.expectedOk, ok)
+		})
+	}
+}
+
+// TestGitCommandRebaseWithExec is a function.
+func TestGitCommandRebaseWithExec(t *testing.T) {
+	commits := []*Commit{
+		{Sha: "ccc", Name: "third"},
+		{Sha: "bbb", Name: "second"},
+		{Sha: "aaa", Name: "first"},
+	}
+
+	type scenario struct {
+		testName     string
+		afterEach    bool
+		expectedTodo string
+	}
+
+	scenarios := []scenario{
+		{
+			"after the selected commit",
+			false,
+			"pick bbb second\nexec make test\npick ccc third\n",
+		},
+		{
+			"after each commit",
+			true,
+			"pick bbb second\nexec make test\npick ccc third\nexec make test\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			var cmd *exec.Cmd
+			gitCmd.OSCommand.command = func(name string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, []string{"rebase", "--interactive", "--autostash", "--keep-empty", "--rebase-merges", "aaa"}, args)
+				cmd = exec.Command("echo")
+				return cmd
+			}
+
+			assert.NoError(t, gitCmd.RebaseWithExec(commits, 1, "make test", s.afterEach))
+
+			todo := ""
+			for _, env := range cmd.Env {
+				if strings.HasPrefix(env, "LAZYGIT_REBASE_TODO=") {
+					todo = strings.TrimPrefix(env, "LAZYGIT_REBASE_TODO=")
+				}
+			}
+			assert.Equal(t, s.expectedTodo, todo)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// reflogActions are the kinds of reflog entry that a reflog filter can ask
+// for, with 'is:', e.g. 'is:checkout'
+var reflogActions = []string{"checkout", "reset", "rebase", "commit", "merge", "pull", "cherry-pick"}
+
+// ReflogAction returns what kind of reflog entry a reflog commit is, taken
+// from the first word of its message e.g. 'rebase' for
+// 'rebase -i (pick): some commit'
+func ReflogAction(commit *Commit) string {
+	fields := strings.Fields(strings.SplitN(commit.Name, ":", 2)[0])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// ReflogFilter narrows down the reflog. It's made up of actions like
+// 'is:checkout' and dates like 'since:2020-03-01' or 'until:2w', separated by
+// spaces. Dates can also be a number of hours, days or weeks ago e.g. '3d'. An
+// entry needs to match one of the actions, if there are any, and fall between
+// the dates.
+type ReflogFilter struct {
+	Actions []string
+	Since   time.Time
+	Until   time.Time
+}
+
+// NewReflogFilter parses a filter as typed in by the user, with relative dates
+// counting back from now
+func NewReflogFilter(filter string, now time.Time) (*ReflogFilter, error) {
+	reflogFilter := &ReflogFilter{Actions: []string{}}
+	for _, term := range strings.Fields(filter) {
+		var err error
+		switch {
+		case strings.HasPrefix(term, "is:"):
+			action := strings.TrimPrefix(term, "is:")
+			if !utils.IncludesString(reflogActions, action) {
+				return nil, errors.New("unknown reflog filter: " + term)
+			}
+			reflogFilter.Actions = append(reflogFilter.Actions, action)
+		case strings.HasPrefix(term, "since:"):
+			reflogFilter.Since, err = parseReflogFilterDate(strings.TrimPrefix(term, "since:"), now)
+		case strings.HasPrefix(term, "until:"):
+			reflogFilter.Until, err = parseReflogFilterDate(strings.TrimPrefix(term, "until:"), now)
+		default:
+			return nil, errors.New("unknown reflog filter: " + term)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reflogFilter, nil
+}
+
+var relativeDateRegexp = regexp.MustCompile(`^(\d+)([hdw])$`)
+
+func parseReflogFilterDate(date string, now time.Time) (time.Time, error) {
+	if match := relativeDateRegexp.FindStringSubmatch(date); match != nil {
+		count, _ := strconv.Atoi(match[1])
+		unit := map[string]time.Duration{"h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}[match[2]]
+		return now.Add(-time.Duration(count) * unit), nil
+	}
+
+	parsed, err := time.ParseInLocation("2006-01-02", date, now.Location())
+	if err != nil {
+		return time.Time{}, errors.New("invalid date in reflog filter: " + date)
+	}
+	return parsed, nil
+}
+
+// IsEmpty tells us whether the filter lets every entry through
+func (f *ReflogFilter) IsEmpty() bool {
+	return f == nil || (len(f.Actions) == 0 && f.Since.IsZero() && f.Until.IsZero())
+}
+
+// Matches tells us whether a reflog entry gets through the filter
+func (f *ReflogFilter) Matches(commit *Commit) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	if len(f.Actions) > 0 && !utils.IncludesString(f.Actions, ReflogAction(commit)) {
+		return false
+	}
+
+	commitTime := time.Unix(commit.UnixTimestamp, 0)
+	if !f.Since.IsZero() && commitTime.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && commitTime.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Filter returns the reflog entries that get through the filter
+func (f *ReflogFilter) Filter(commits []*Commit) []*Commit {
+	if f.IsEmpty() {
+		return commits
+	}
+
+	result := make([]*Commit, 0, len(commits))
+	for _, commit := range commits {
+		if f.Matches(commit) {
+			result = append(result, commit)
+		}
+	}
+	return result
+}
+
+func isRebaseStep(commit *Commit) bool {
+	return ReflogAction(commit) == "rebase" || strings.HasPrefix(commit.Name, "pull --rebase")
+}
+
+// GroupReflogCommits collapses each run of rebase steps in the reflog into a
+// single entry, unless the run's newest entry is in expanded (keyed by sha).
+// The reflog is newest first, so a run ends with the rebase's '(start)' entry.
+// A collapsed run is shown as a copy of its newest entry with its StepCount
+// set.
+func GroupReflogCommits(commits []*Commit, expanded map[string]bool) []*Commit {
+	result := make([]*Commit, 0, len(commits))
+	for i := 0; i < len(commits); i++ {
+		commit := commits[i]
+		if !isRebaseStep(commit) {
+			result = append(result, commit)
+			continue
+		}
+
+		end := i
+		for end+1 < len(commits) && isRebaseStep(commits[end+1]) && !strings.Contains(commits[end].Name, "(start)") {
+			end++
+		}
+		if end == i || expanded[commit.Sha] {
+			result = append(result, commits[i:end+1]...)
+		} else {
+			group := *commit
+			group.StepCount = end - i + 1
+			result = append(result, &group)
+		}
+		i = end
+	}
+	return result
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewReflogFilter is a function.
+func TestNewReflogFilter(t *testing.T) {
+	now := time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	type scenario struct {
+		testName string
+		filter   string
+		test     func(*ReflogFilter, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"actions",
+			"is:checkout is:reset",
+			func(reflogFilter *ReflogFilter, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []string{"checkout", "reset"}, reflogFilter.Actions)
+				assert.True(t, reflogFilter.Since.IsZero())
+			},
+		},
+		{
+			"absolute and relative dates",
+			"since:2020-03-01 until:2d",
+			func(reflogFilter *ReflogFilter, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), reflogFilter.Since)
+				assert.Equal(t, time.Date(2020, 3, 8, 12, 0, 0, 0, time.UTC), reflogFilter.Until)
+			},
+		},
+		{
+			"unknown action",
+			"is:bogus",
+			func(reflogFilter *ReflogFilter, err error) {
+				assert.EqualError(t, err, "unknown reflog filter: is:bogus")
+			},
+		},
+		{
+			"invalid date",
+			"since:yesterday",
+			func(reflogFilter *ReflogFilter, err error) {
+				assert.EqualError(t, err, "invalid date in reflog filter: yesterday")
+			},
+		},
+		{
+			"empty",
+			"  ",
+			func(reflogFilter *ReflogFilter, err error) {
+				assert.NoError(t, err)
+				assert.True(t, reflogFilter.IsEmpty())
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			s.test(NewReflogFilter(s.filter, now))
+		})
+	}
+}
+
+// TestReflogFilterMatches is a function.
+func TestReflogFilterMatches(t *testing.T) {
+	march := time.Date(2020, 3, 5, 0, 0, 0, 0, time.UTC).Unix()
+
+	type scenario struct {
+		testName string
+		filter   *ReflogFilter
+		commit   *Commit
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{
+			"nil filter",
+			nil,
+			&Commit{Name: "commit: a"},
+			true,
+		},
+		{
+			"matching action",
+			&ReflogFilter{Actions: []string{"rebase"}},
+			&Commit{Name: "rebase -i (pick): a"},
+			true,
+		},
+		{
+			"other action",
+			&ReflogFilter{Actions: []string{"checkout"}},
+			&Commit{Name: "commit (amend): a"},
+			false,
+		},
+		{
+			"within dates",
+			&ReflogFilter{Since: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), Until: time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC)},
+			&Commit{Name: "commit: a", UnixTimestamp: march},
+			true,
+		},
+		{
+			"before since",
+			&ReflogFilter{Since: time.Date(2020, 3, 6, 0, 0, 0, 0, time.UTC)},
+			&Commit{Name: "commit: a", UnixTimestamp: march},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.Equal(t, s.expected, s.filter.Matches(s.commit))
+		})
+	}
+}
+
+// TestGroupReflogCommits is a function.
+func TestGroupReflogCommits(t *testing.T) {
+	commits := []*Commit{
+		{Sha: "f", Name: "rebase -i (finish): returning to refs/heads/feature"},
+		{Sha: "e", Name: "rebase -i (pick): b"},
+		{Sha: "d", Name: "rebase -i (start): checkout master"},
+		{Sha: "c", Name: "rebase (finish): returning to refs/heads/other"},
+		{Sha: "b", Name: "rebase (start): checkout master"},
+		{Sha: "a", Name: "checkout: moving from master to feature"},
+	}
+
+	type scenario struct {
+		testName      string
+		expanded      map[string]bool
+		expectedShas  []string
+		expectedSteps []int
+	}
+
+	scenarios := []scenario{
+		{
+			"every rebase collapsed",
+			map[string]bool{},
+			[]string{"f", "c", "a"},
+			[]int{3, 2, 0},
+		},
+		{
+			"one rebase expanded",
+			map[string]bool{"c": true},
+			[]string{"f", "c", "b", "a"},
+			[]int{3, 0, 0, 0},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			result := GroupReflogCommits(commits, s.expanded)
+			shas := []string{}
+			steps := []int{}
+			for _, commit := range result {
+				shas = append(shas, commit.Sha)
+				steps = append(steps, commit.StepCount)
+			}
+			assert.EqualValues(t, s.expectedShas, shas)
+			assert.EqualValues(t, s.expectedSteps, steps)
+			// the original entries are left alone
+			assert.Equal(t, 0, commits[0].StepCount)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// RemoteSettings are the settings from the user's config for talking to some
+// remotes, so that e.g. a work GitLab can use a different ssh key to GitHub:
+//
+//   git:
+//     remotes:
+//       - url: 'gitlab\.example\.com'
+//         sshCommand: 'ssh -i ~/.ssh/work_key'
+//       - name: upstream
+//         credentialHelper: 'store --file ~/.upstream-credentials'
+//         env:
+//           GIT_TERMINAL_PROMPT: '0'
+//
+// They apply to the environment of anything we run that fetches from, pulls
+// from or pushes to a matching remote.
+type RemoteSettings struct {
+	// Name is the name of the remote to match, and URL is a regexp to match
+	// against its url. If both are given then both have to match.
+	Name             string            `yaml:"name"`
+	URL              string            `yaml:"url"`
+	SSHCommand       string            `yaml:"sshCommand"`
+	CredentialHelper string            `yaml:"credentialHelper"`
+	Env              map[string]string `yaml:"env"`
+}
+
+func (s RemoteSettings) matches(remoteName string, remoteURL string) bool {
+	if s.Name == "" && s.URL == "" {
+		return false
+	}
+	if s.Name != "" && s.Name != remoteName {
+		return false
+	}
+	if s.URL != "" {
+		urlRegexp, err := regexp.Compile(s.URL)
+		if err != nil || !urlRegexp.MatchString(remoteURL) {
+			return false
+		}
+	}
+	return true
+}
+
+// env returns the environment variables that apply these settings
+func (s RemoteSettings) env() []string {
+	env := []string{}
+	if s.SSHCommand != "" {
+		env = append(env, "GIT_SSH_COMMAND="+s.SSHCommand)
+	}
+	if s.CredentialHelper != "" {
+		// an empty helper clears the ones from the user's git config so that
+		// only this one gets asked
+		env = append(env,
+			"GIT_CONFIG_COUNT=2",
+			"GIT_CONFIG_KEY_0=credential.helper",
+			"GIT_CONFIG_VALUE_0=",
+			"GIT_CONFIG_KEY_1=credential.helper",
+			"GIT_CONFIG_VALUE_1="+s.CredentialHelper,
+		)
+	}
+	keys := make([]string, 0, len(s.Env))
+	for key := range s.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env = append(env, key+"="+s.Env[key])
+	}
+	return env
+}
+
+func (c *GitCommand) getRemoteSettings() []RemoteSettings {
+	rawSettings := c.Config.GetUserConfig().Get("git.remotes")
+	if rawSettings == nil {
+		return nil
+	}
+	settingsYaml, err := yaml.Marshal(rawSettings)
+	if err != nil {
+		return nil
+	}
+	settings := []RemoteSettings{}
+	if err := yaml.Unmarshal(settingsYaml, &settings); err != nil {
+		c.Log.Error(err)
+		return nil
+	}
+	return settings
+}
+
+// remoteEnv returns the environment for a command that talks to the remote,
+// from the first of the user's remote settings that matches it. If the
+// remote isn't known we use the remote of the checked out branch.
+func (c *GitCommand) remoteEnv(remoteName string) []string {
+	settings := c.getRemoteSettings()
+	if len(settings) == 0 {
+		return nil
+	}
+
+	if remoteName == "" {
+		remoteName = c.getBranchRemote("")
+	}
+	remoteURL, _ := c.getLocalGitConfig("remote." + remoteName + ".url")
+	remoteURL = strings.TrimSpace(remoteURL)
+
+	for _, s := range settings {
+		if s.matches(remoteName, remoteURL) {
+			return s.env()
+		}
+	}
+	return nil
+}
+
+// getBranchRemote returns the remote the branch (or the checked out branch
+// if branchName is empty) pulls from, falling back to origin
+func (c *GitCommand) getBranchRemote(branchName string) string {
+	if branchName == "" {
+		branchName, _ = c.CurrentBranchName()
+	}
+	if branchName != "" {
+		if remoteName, _ := c.getLocalGitConfig("branch." + branchName + ".remote"); strings.TrimSpace(remoteName) != "" {
+			return strings.TrimSpace(remoteName)
+		}
+	}
+	return "origin"
+}
+
+// remoteFromArgs returns the remote named in the args of a pull or push, i.e.
+// the first argument that isn't a flag, if there is one
+func remoteFromArgs(args string) string {
+	for _, arg := range strings.Fields(args) {
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+	return ""
+}
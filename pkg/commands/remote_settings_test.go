@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandRemoteEnv is a function.
+func TestGitCommandRemoteEnv(t *testing.T) {
+	type scenario struct {
+		testName   string
+		remoteName string
+		settings   []interface{}
+		expected   []string
+	}
+
+	gitConfig := map[string]string{
+		"remote.origin.url":    "git@github.com:jesseduffield/lazygit.git",
+		"remote.work.url":      "https://gitlab.example.com/team/lazygit.git",
+		"branch.master.remote": "work",
+	}
+
+	scenarios := []scenario{
+		{
+			"no settings",
+			"origin",
+			nil,
+			nil,
+		},
+		{
+			"match by url",
+			"work",
+			[]interface{}{
+				map[string]interface{}{"url": `github\.com`, "sshCommand": "ssh -i ~/.ssh/personal"},
+				map[string]interface{}{"url": `gitlab\.example\.com`, "sshCommand": "ssh -i ~/.ssh/work"},
+			},
+			[]string{"GIT_SSH_COMMAND=ssh -i ~/.ssh/work"},
+		},
+		{
+			"match by name",
+			"origin",
+			[]interface{}{
+				map[string]interface{}{
+					"name":             "origin",
+					"credentialHelper": "store",
+					"env":              map[string]interface{}{"GIT_TRACE": "1", "GIT_TERMINAL_PROMPT": "0"},
+				},
+			},
+			[]string{
+				"GIT_CONFIG_COUNT=2",
+				"GIT_CONFIG_KEY_0=credential.helper",
+				"GIT_CONFIG_VALUE_0=",
+				"GIT_CONFIG_KEY_1=credential.helper",
+				"GIT_CONFIG_VALUE_1=store",
+				"GIT_TERMINAL_PROMPT=0",
+				"GIT_TRACE=1",
+			},
+		},
+		{
+			"name and url both have to match",
+			"origin",
+			[]interface{}{
+				map[string]interface{}{"name": "origin", "url": "gitlab", "sshCommand": "ssh -i ~/.ssh/work"},
+			},
+			nil,
+		},
+		{
+			"remote of the checked out branch",
+			"",
+			[]interface{}{
+				map[string]interface{}{"name": "work", "sshCommand": "ssh -i ~/.ssh/work"},
+			},
+			[]string{"GIT_SSH_COMMAND=ssh -i ~/.ssh/work"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git symbolic-ref --short HEAD",
+					Replace: "echo master",
+				},
+			})
+			gitCmd.getLocalGitConfig = func(key string) (string, error) {
+				return gitConfig[key], nil
+			}
+			if s.settings != nil {
+				gitCmd.Config.GetUserConfig().Set("git.remotes", s.settings)
+			}
+			assert.EqualValues(t, s.expected, gitCmd.remoteEnv(s.remoteName))
+		})
+	}
+}
+
+// TestRemoteFromArgs is a function.
+func TestRemoteFromArgs(t *testing.T) {
+	assert.EqualValues(t, "", remoteFromArgs(""))
+	assert.EqualValues(t, "", remoteFromArgs("--rebase"))
+	assert.EqualValues(t, "upstream", remoteFromArgs("--force upstream master"))
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+)
+
+// fingerprintedRefPrefixes are the refs we act on by what we've read of them.
+// Remote branches are left out because our own background fetches move them,
+// and autosaves because we make those ourselves on a timer.
+var fingerprintedRefPrefixes = []string{"HEAD", "refs/heads/", "refs/tags/", "refs/stash"}
+
+func fingerprint(str string) string {
+	sum := sha1.Sum([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetRefsFingerprint returns a hash of where HEAD, the local branches, the
+// tags and the stash point, so that we can tell whether something other than
+// us has moved them since we last looked. It returns an empty string if we
+// can't tell, e.g. in a repo without any commits yet.
+func (c *GitCommand) GetRefsFingerprint() string {
+	output, err := c.OSCommand.RunCommandWithOutput("git show-ref --head")
+	if err != nil {
+		return ""
+	}
+
+	refs := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		for _, prefix := range fingerprintedRefPrefixes {
+			if strings.HasPrefix(fields[1], prefix) {
+				refs = append(refs, line)
+				break
+			}
+		}
+	}
+
+	return fingerprint(strings.Join(refs, "\n"))
+}
+
+// GetIndexFingerprint returns a hash of the mode, object and stage of every
+// entry in the index. Unlike the index file's modification time this doesn't
+// change when git status merely refreshes the stat info cached in there.
+func (c *GitCommand) GetIndexFingerprint() string {
+	output, err := c.OSCommand.RunCommandWithOutput("git ls-files --stage")
+	if err != nil {
+		return ""
+	}
+	return fingerprint(output)
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetRefsFingerprint is a function.
+func TestGitCommandGetRefsFingerprint(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		expected string
+	}
+
+	local := "echo 'a1 HEAD\na1 refs/heads/master\nb2 refs/tags/v1\nc3 refs/stash'"
+	localFingerprint := fingerprint("a1 HEAD\na1 refs/heads/master\nb2 refs/tags/v1\nc3 refs/stash")
+
+	scenarios := []scenario{
+		{
+			"local refs",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git show-ref --head", Replace: local},
+			}),
+			localFingerprint,
+		},
+		{
+			"remote branches and autosaves are left out",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git show-ref --head",
+					Replace: "echo 'a1 HEAD\na1 refs/heads/master\nd4 refs/remotes/origin/master\nb2 refs/tags/v1\ne5 refs/lazygit/autosaves/1\nc3 refs/stash'",
+				},
+			}),
+			localFingerprint,
+		},
+		{
+			"a moved branch changes the fingerprint",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git show-ref --head", Replace: "echo 'a1 HEAD\nf6 refs/heads/master\nb2 refs/tags/v1\nc3 refs/stash'"},
+			}),
+			fingerprint("a1 HEAD\nf6 refs/heads/master\nb2 refs/tags/v1\nc3 refs/stash"),
+		},
+		{
+			"no refs yet",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git show-ref --head", Replace: "test"},
+			}),
+			"",
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			assert.EqualValues(t, s.expected, gitCmd.GetRefsFingerprint())
+		})
+	}
+}
+
+// TestGitCommandGetIndexFingerprint is a function.
+func TestGitCommandGetIndexFingerprint(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"index entries",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git ls-files --stage", Replace: "echo 100644 a1 0 file.txt"},
+			}),
+			fingerprint("100644 a1 0 file.txt\n"),
+		},
+		{
+			"not a repo",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git ls-files --stage", Replace: "test"},
+			}),
+			"",
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			assert.EqualValues(t, s.expected, gitCmd.GetIndexFingerprint())
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// fileExistsAtCommit tells us whether the file is in the given commit's tree,
+// which it won't be if the commit deleted it
+func (c *GitCommand) fileExistsAtCommit(commitSha string, fileName string) bool {
+	return c.OSCommand.RunCommand("git cat-file -e %s", c.OSCommand.Quote(commitSha+":"+fileName)) == nil
+}
+
+// RestoreFileToCommit makes the file in the working tree and the index what
+// it was at the given commit, so that restoring it is ready to be committed.
+// If the commit deleted the file, we delete it too.
+func (c *GitCommand) RestoreFileToCommit(commitSha string, fileName string) error {
+	quotedFileName := c.OSCommand.Quote(fileName)
+	if !c.fileExistsAtCommit(commitSha, fileName) {
+		return c.OSCommand.RunCommand("git rm -f -q --ignore-unmatch -- %s", quotedFileName)
+	}
+	return c.OSCommand.RunCommand("git checkout %s -- %s", commitSha, quotedFileName)
+}
+
+// CheckoutFileIntoWorktree writes the file as it was at the given commit into
+// the working tree, leaving the index alone so that what's changed shows up
+// as unstaged. If the commit deleted the file, we delete it too.
+func (c *GitCommand) CheckoutFileIntoWorktree(commitSha string, fileName string) error {
+	if err := c.OSCommand.CheckWritable(fileName); err != nil {
+		return err
+	}
+	if !c.fileExistsAtCommit(commitSha, fileName) {
+		if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+			return WrapError(err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return WrapError(err)
+	}
+	// opening the existing file rather than recreating it keeps its mode
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return WrapError(err)
+	}
+	defer file.Close()
+
+	// --filters converts line endings etc the way checking it out would
+	cmd := c.OSCommand.ExecutableFromString("git cat-file --filters " + c.OSCommand.Quote(commitSha+":"+fileName))
+	cmd.Stdout = file
+	if err := cmd.Run(); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
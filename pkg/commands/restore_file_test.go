@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandRestoreFileToCommit is a function.
+func TestGitCommandRestoreFileToCommit(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+	}
+
+	scenarios := []scenario{
+		{
+			"file at the commit",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: `git cat-file -e "abc:test.txt"`, Replace: "echo"},
+				{Expect: `git checkout abc -- "test.txt"`, Replace: "echo"},
+			}),
+		},
+		{
+			"file deleted by the commit",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: `git cat-file -e "abc:test.txt"`, Replace: "false"},
+				{Expect: `git rm -f -q --ignore-unmatch -- "test.txt"`, Replace: "echo"},
+			}),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			assert.NoError(t, gitCmd.RestoreFileToCommit("abc", "test.txt"))
+		})
+	}
+}
+
+// TestGitCommandCheckoutFileIntoWorktree is a function.
+func TestGitCommandCheckoutFileIntoWorktree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "sub", "test.txt")
+
+	t.Run("file at the commit", func(t *testing.T) {
+		gitCmd := NewDummyGitCommand()
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+			{Expect: `git cat-file -e "abc:` + fileName + `"`, Replace: "echo"},
+			{Expect: `git cat-file --filters "abc:` + fileName + `"`, Replace: "echo old version"},
+		})
+		assert.NoError(t, gitCmd.CheckoutFileIntoWorktree("abc", fileName))
+
+		content, err := ioutil.ReadFile(fileName)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "old version\n", string(content))
+	})
+
+	t.Run("file deleted by the commit", func(t *testing.T) {
+		gitCmd := NewDummyGitCommand()
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+			{Expect: `git cat-file -e "abc:` + fileName + `"`, Replace: "false"},
+		})
+		assert.NoError(t, gitCmd.CheckoutFileIntoWorktree("abc", fileName))
+
+		_, err := os.Stat(fileName)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RevertCommits reverts the given commits in a single sequencer session, so
+// that after resolving a conflict `git revert --continue` goes on with the
+// rest. The commits are expected newest first, as they are in the commits
+// panel, which is the order they need reverting in. With noCommit the reverts
+// only go into the index and working tree, for committing them all as one.
+func (c *GitCommand) RevertCommits(commits []*Commit, noCommit bool) error {
+	shas := make([]string, len(commits))
+	for i, commit := range commits {
+		shas[i] = commit.Sha
+	}
+
+	flag := "--no-edit"
+	if noCommit {
+		flag = "--no-commit"
+	}
+	return c.OSCommand.RunCommand("git revert %s %s", flag, strings.Join(shas, " "))
+}
+
+// RevertCommitsMessage is the message for a single commit reverting all of the
+// given commits, with a 'This reverts commit' line for each like git writes
+// when reverting one
+func RevertCommitsMessage(commits []*Commit) string {
+	subjects := []string{}
+	reverts := []string{}
+	for _, commit := range commits {
+		subjects = append(subjects, fmt.Sprintf("Revert \"%s\"", commit.Name))
+		reverts = append(reverts, fmt.Sprintf("This reverts commit %s.", commit.Sha))
+	}
+	return fmt.Sprintf("Revert %d commits\n\n%s\n\n%s", len(commits), strings.Join(subjects, "\n"), strings.Join(reverts, "\n"))
+}
+
+// IsReverting tells us whether a revert has stopped, which is the case when
+// it's hit a conflict, and after reverting without committing
+func (c *GitCommand) IsReverting() bool {
+	_, err := c.readGitDirFile("REVERT_HEAD")
+	return err == nil
+}
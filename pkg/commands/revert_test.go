@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandRevertCommits is a function.
+func TestGitCommandRevertCommits(t *testing.T) {
+	type scenario struct {
+		testName string
+		noCommit bool
+		command  func(string, ...string) *exec.Cmd
+	}
+
+	scenarios := []scenario{
+		{
+			"one commit per revert",
+			false,
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git revert --no-edit ccc aaa", Replace: "echo"},
+			}),
+		},
+		{
+			"all reverts in one commit",
+			true,
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git revert --no-commit ccc aaa", Replace: "echo"},
+			}),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			commits := []*Commit{{Sha: "ccc"}, {Sha: "aaa"}}
+			assert.NoError(t, gitCmd.RevertCommits(commits, s.noCommit))
+		})
+	}
+}
+
+// TestRevertCommitsMessage is a function.
+func TestRevertCommitsMessage(t *testing.T) {
+	commits := []*Commit{{Sha: "ccc", Name: "Add login"}, {Sha: "aaa", Name: "Fix typo"}}
+	expected := "Revert 2 commits\n\nRevert \"Add login\"\nRevert \"Fix typo\"\n\nThis reverts commit ccc.\nThis reverts commit aaa."
+	assert.EqualValues(t, expected, RevertCommitsMessage(commits))
+}
+
+// TestGitCommandIsReverting is a function.
+func TestGitCommandIsReverting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-revert")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dir
+	assert.False(t, gitCmd.IsReverting())
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "REVERT_HEAD"), []byte("aaa\n"), 0644))
+	assert.True(t, gitCmd.IsReverting())
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+)
+
+// ReviewWorktree is a throwaway worktree with a branch checked out in it, so
+// that the user can look the branch over without touching their own working
+// tree
+type ReviewWorktree struct {
+	Path string
+	Ref  string
+}
+
+// CreateReviewWorktree adds a worktree in a temp directory with the ref
+// checked out. The HEAD is detached so that it works for branches that are
+// checked out elsewhere, and so that any commits made while reviewing don't
+// move the branch.
+func (c *GitCommand) CreateReviewWorktree(ref string) (*ReviewWorktree, error) {
+	dir, err := ioutil.TempDir("", "lazygit-review")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.OSCommand.RunCommand("git worktree add --detach %s %s", c.OSCommand.Quote(dir), c.OSCommand.Quote(ref)); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return &ReviewWorktree{Path: dir, Ref: ref}, nil
+}
+
+// RemoveReviewWorktree deletes the worktree, along with anything the user
+// changed in it, and tells git it's gone. This has to be run from the repo
+// the worktree was made from.
+func (c *GitCommand) RemoveReviewWorktree(worktree *ReviewWorktree) error {
+	if err := c.OSCommand.RunCommand("git worktree remove --force %s", c.OSCommand.Quote(worktree.Path)); err != nil {
+		// if git's lost track of it we can still clean up after ourselves
+		if err := os.RemoveAll(worktree.Path); err != nil {
+			return err
+		}
+		return c.OSCommand.RunCommand("git worktree prune")
+	}
+	return nil
+}
+
+// RunInWorktree prepares a shell command to run in the worktree, e.g. to run
+// the tests against the commit checked out there
+func (c *GitCommand) RunInWorktree(worktree *ReviewWorktree, command string) *exec.Cmd {
+	cmd := c.OSCommand.RunCustomCommand(command)
+	cmd.Dir = worktree.Path
+	return cmd
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandCreateReviewWorktree is a function.
+func TestGitCommandCreateReviewWorktree(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	var dir string
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"worktree", "add", "--detach"}, args[:3])
+		assert.Regexp(t, regexp.MustCompile("lazygit-review"), args[3])
+		assert.EqualValues(t, "feature/review-me", args[4])
+		dir = args[3]
+		return exec.Command("echo")
+	}
+
+	worktree, err := gitCmd.CreateReviewWorktree("feature/review-me")
+	assert.NoError(t, err)
+	defer os.RemoveAll(worktree.Path)
+	assert.EqualValues(t, &ReviewWorktree{Path: dir, Ref: "feature/review-me"}, worktree)
+}
+
+// TestGitCommandRemoveReviewWorktree is a function.
+func TestGitCommandRemoveReviewWorktree(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git worktree remove --force /tmp/lazygit-review123",
+			Replace: "false",
+		},
+		{
+			Expect:  "git worktree prune",
+			Replace: "echo",
+		},
+	})
+
+	assert.NoError(t, gitCmd.RemoveReviewWorktree(&ReviewWorktree{Path: "/tmp/lazygit-review123"}))
+}
+
+// TestGitCommandRunInWorktree is a function.
+func TestGitCommandRunInWorktree(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "bash", cmd)
+		assert.EqualValues(t, []string{"-c", "make test"}, args)
+		return exec.Command("echo")
+	}
+
+	cmd := gitCmd.RunInWorktree(&ReviewWorktree{Path: "/tmp/lazygit-review123"}, "make test")
+	assert.EqualValues(t, "/tmp/lazygit-review123", cmd.Dir)
+}
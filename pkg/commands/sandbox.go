@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+)
+
+// TutorialRepo is a throwaway repo for the user to practice on
+type TutorialRepo struct {
+	Path string
+	// FeatureSha is the tip of the branch we ask the user to rebase onto
+	FeatureSha string
+}
+
+// CreateTutorialRepo creates a small repo in a temp directory with a couple of
+// branches and some uncommitted changes, so that the tutorial has something to
+// stage, commit and rebase without going anywhere near the user's own repos
+func (c *OSCommand) CreateTutorialRepo() (*TutorialRepo, error) {
+	dir, err := ioutil.TempDir("", "lazygit-tutorial")
+	if err != nil {
+		return nil, err
+	}
+
+	git := func(args string) error {
+		return c.RunCommand("git -C %s %s", c.Quote(dir), args)
+	}
+	writeFile := func(name string, content string) error {
+		return ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	}
+
+	steps := []func() error{
+		func() error { return git("init") },
+		func() error { return git("symbolic-ref HEAD refs/heads/main") },
+		// so that committing works even if the user hasn't set up their identity
+		func() error { return git("config user.name " + c.Quote("Lazygit Tutorial")) },
+		func() error { return git("config user.email tutorial@lazygit.invalid") },
+		func() error {
+			return writeFile("README.md", "# Tutorial\n\nThis repo was made by lazygit's tutorial.\n")
+		},
+		func() error { return git("add README.md") },
+		func() error { return git("commit -m " + c.Quote("Initial commit")) },
+		func() error { return git("branch feature") },
+		func() error { return writeFile("notes.txt", "some notes\n") },
+		func() error { return git("add notes.txt") },
+		func() error { return git("commit -m " + c.Quote("Add notes")) },
+		func() error { return git("checkout feature") },
+		func() error { return writeFile("feature.txt", "a shiny new feature\n") },
+		func() error { return git("add feature.txt") },
+		func() error { return git("commit -m " + c.Quote("Add feature")) },
+		func() error { return git("checkout main") },
+		func() error {
+			return writeFile("README.md", "# Tutorial\n\nThis repo was made by lazygit's tutorial.\n\nHere is a new line.\n")
+		},
+		func() error { return writeFile("todo.txt", "learn lazygit\n") },
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+
+	featureSha, err := c.RunCommandWithOutput("git -C %s rev-parse feature", c.Quote(dir))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return &TutorialRepo{Path: dir, FeatureSha: strings.TrimSpace(featureSha)}, nil
+}
+
+// Sandbox is a copy of the repo for the user to try out something risky in
+type Sandbox struct {
+	Path string
+	// BranchName is the branch that was checked out when we made the sandbox,
+	// which is the one we'll update if the user wants to keep what they did
+	BranchName string
+	// OriginalSha is where the branch was when we made the sandbox
+	OriginalSha string
+}
+
+// CreateSandbox copies the repo's refs into a new repo in a temp directory and
+// checks out the current branch there, so that the user can try out something
+// risky without consequences. The sandbox has no remotes, so nothing done in it
+// can escape.
+func (c *GitCommand) CreateSandbox() (*Sandbox, error) {
+	branchName, err := c.OSCommand.RunCommandWithOutput("git symbolic-ref --short HEAD")
+	if err != nil {
+		return nil, errors.New(c.Tr.SLocalize("SandboxNeedsBranch"))
+	}
+	branchName = strings.TrimSpace(branchName)
+
+	originalSha, err := c.OSCommand.RunCommandWithOutput("git rev-parse HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "lazygit-sandbox")
+	if err != nil {
+		return nil, err
+	}
+
+	commands := []string{
+		fmt.Sprintf("git -C %s init", c.OSCommand.Quote(dir)),
+		// this brings over remote branches and tags too, so that the sandbox looks
+		// just like the real thing
+		fmt.Sprintf("git -C %s fetch --update-head-ok --no-tags %s %s", c.OSCommand.Quote(dir), c.OSCommand.Quote(repoPath), c.OSCommand.Quote("+refs/*:refs/*")),
+		fmt.Sprintf("git -C %s checkout --force %s", c.OSCommand.Quote(dir), c.OSCommand.Quote(branchName)),
+	}
+
+	for _, command := range commands {
+		if err := c.OSCommand.RunCommand(command); err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+
+	return &Sandbox{
+		Path:        dir,
+		BranchName:  branchName,
+		OriginalSha: strings.TrimSpace(originalSha),
+	}, nil
+}
+
+// ApplySandbox points our branch at wherever the same branch ended up in the
+// sandbox, which has the same effect as repeating whatever the user did there.
+// We bail if the branch has moved since the sandbox was created.
+func (c *GitCommand) ApplySandbox(sandbox *Sandbox) error {
+	ref := "refs/heads/" + sandbox.BranchName
+
+	branchName, err := c.OSCommand.RunCommandWithOutput("git symbolic-ref --short HEAD")
+	if err != nil {
+		return err
+	}
+	currentSha, err := c.OSCommand.RunCommandWithOutput("git rev-parse HEAD")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(branchName) != sandbox.BranchName || strings.TrimSpace(currentSha) != sandbox.OriginalSha {
+		return errors.New(c.Tr.TemplateLocalize("BranchMovedSinceSandbox", i18n.Teml{"branchName": sandbox.BranchName}))
+	}
+
+	if err := c.OSCommand.RunCommand("git fetch --no-tags %s %s", c.OSCommand.Quote(sandbox.Path), c.OSCommand.Quote(ref)); err != nil {
+		return err
+	}
+
+	// --keep refuses to clobber any local changes
+	return c.OSCommand.RunCommand("git reset --keep FETCH_HEAD")
+}
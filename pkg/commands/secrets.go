@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SecretRule is a pattern that something that shouldn't be committed, like an
+// API key, tends to match
+type SecretRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// these are deliberately specific so that a warning is worth reading
+var secretRules = []SecretRule{
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_\-]{20}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9\-]{10,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{"Stripe key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{20,}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY-----`)},
+	{"password or secret", regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|api_?key|access_?token|auth_?token)\b["']?\s*[:=]\s*["'][^"'\s]{8,}["']`)},
+}
+
+// SecretMatch is an added line that looks like it has a secret in it
+type SecretMatch struct {
+	FileName   string
+	LineNumber int
+	Line       string
+	Rule       string
+	// Match is the part of the line that matched the rule
+	Match string
+}
+
+// ScanStagedChanges returns the staged lines that match one of the built-in
+// secret rules
+func (c *GitCommand) ScanStagedChanges() ([]*SecretMatch, error) {
+	diff, err := c.OSCommand.RunCommandWithOutput("git diff --cached --no-color --no-ext-diff --no-renames -U0")
+	if err != nil {
+		return nil, err
+	}
+	return findSecrets(diff), nil
+}
+
+func findSecrets(diff string) []*SecretMatch {
+	matches := []*SecretMatch{}
+	forEachAddedLine(diff, func(fileName string, lineNumber int, line string) {
+		for _, rule := range secretRules {
+			if match := rule.Pattern.FindString(line); match != "" {
+				matches = append(matches, &SecretMatch{
+					FileName:   fileName,
+					LineNumber: lineNumber,
+					Line:       strings.TrimSpace(line),
+					Rule:       rule.Name,
+					Match:      match,
+				})
+				// one warning per line is plenty
+				break
+			}
+		}
+	})
+	return matches
+}
+
+// RunSecretScanner runs the user's own secret scanner (e.g. gitleaks) on the
+// staged changes. Scanners exit with a non-zero code when they find
+// something, in which case we return what they said.
+func (c *GitCommand) RunSecretScanner(command string) (string, bool) {
+	output, err := c.OSCommand.RunCommandWithOutput(command)
+	if err != nil {
+		return strings.TrimSpace(output), true
+	}
+	return "", false
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// SigningStatus tells us whether commits get signed, with what, and whether
+// the agent that holds the key can be reached
+type SigningStatus struct {
+	Enabled bool
+	// Format is one of 'openpgp', 'x509' or 'ssh', as set by gpg.format
+	Format string
+	// Key is the user.signingkey, which is empty if git picks the key itself
+	Key string
+	// AgentReachable tells us whether gpg-agent (or ssh-agent when signing
+	// with ssh) responded
+	AgentReachable bool
+}
+
+// GetSigningStatus returns the user's signing setup. Checking the agent means
+// running a command, so don't call this on every refresh.
+func (c *GitCommand) GetSigningStatus() SigningStatus {
+	status := SigningStatus{
+		Enabled: c.usingGpg(),
+		Format:  c.getGitConfigValue("gpg.format"),
+		Key:     c.getGitConfigValue("user.signingkey"),
+	}
+	if status.Format == "" {
+		status.Format = "openpgp"
+	}
+	if !status.Enabled {
+		return status
+	}
+
+	switch status.Format {
+	case "ssh":
+		// ssh-add fails when the agent is up but has no keys, which also means
+		// it can't sign anything for us
+		status.AgentReachable = os.Getenv("SSH_AUTH_SOCK") != "" && c.OSCommand.RunCommand("ssh-add -l") == nil
+	default:
+		// gpgsm signs x509 commits using the same agent as gpg
+		status.AgentReachable = c.OSCommand.RunCommand("gpg-connect-agent /bye") == nil
+	}
+	return status
+}
+
+// TestSigning signs a throwaway commit the same way git would sign a real one,
+// using the empty tree so that it works in a repo without commits. Signing
+// may need to prompt for a passphrase, so it happens in a subprocess.
+func (c *GitCommand) TestSigning() *exec.Cmd {
+	command := fmt.Sprintf("git commit-tree -S %s -m %s", emptyTreeSha, c.OSCommand.Quote("lazygit signing test"))
+	return c.OSCommand.PrepareSubProcess(c.OSCommand.Platform.shell, c.OSCommand.Platform.shellArg, command)
+}
+
+// Description returns a short summary of the status for the status panel
+func (s SigningStatus) Description() string {
+	if !s.Enabled {
+		return ""
+	}
+	format := strings.Replace(s.Format, "openpgp", "gpg", 1)
+	if s.Key != "" && s.Format != "ssh" {
+		// ssh keys are paths or whole public keys, which are too long to show
+		format += " " + s.Key
+	}
+	return format
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetSigningStatus is a function.
+func TestGitCommandGetSigningStatus(t *testing.T) {
+	type scenario struct {
+		testName string
+		config   map[string]string
+		command  func(string, ...string) *exec.Cmd
+		expected SigningStatus
+	}
+
+	scenarios := []scenario{
+		{
+			"signing disabled",
+			map[string]string{},
+			test.CreateMockCommand(t, []*test.CommandSwapper{}),
+			SigningStatus{Format: "openpgp"},
+		},
+		{
+			"gpg agent reachable",
+			map[string]string{"commit.gpgsign": "true", "user.signingkey": "ABCD1234"},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "gpg-connect-agent /bye",
+					Replace: "echo",
+				},
+			}),
+			SigningStatus{Enabled: true, Format: "openpgp", Key: "ABCD1234", AgentReachable: true},
+		},
+		{
+			"gpg agent unreachable",
+			map[string]string{"commit.gpgsign": "true", "gpg.format": "x509"},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "gpg-connect-agent /bye",
+					Replace: "false",
+				},
+			}),
+			SigningStatus{Enabled: true, Format: "x509"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			gitCmd.getLocalGitConfig = func(key string) (string, error) {
+				return s.config[key], nil
+			}
+			assert.EqualValues(t, s.expected, gitCmd.GetSigningStatus())
+		})
+	}
+}
+
+// TestSigningStatusDescription is a function.
+func TestSigningStatusDescription(t *testing.T) {
+	assert.EqualValues(t, "", SigningStatus{Format: "openpgp"}.Description())
+	assert.EqualValues(t, "gpg ABCD1234", SigningStatus{Enabled: true, Format: "openpgp", Key: "ABCD1234"}.Description())
+	assert.EqualValues(t, "ssh", SigningStatus{Enabled: true, Format: "ssh", Key: "~/.ssh/id_ed25519.pub"}.Description())
+}
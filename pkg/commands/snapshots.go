@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// A snapshot is a local backup of where the user was before doing something
+// risky. We keep the tip of the checked out branch under
+// refs/lazygit/snapshots/<name>/head and, if there were uncommitted changes, a
+// stash of them (made with `git stash create` so that the stash list is left
+// alone) under refs/lazygit/snapshots/<name>/worktree. Being refs, they're
+// safe from gc and don't show up amongst the branches.
+
+const snapshotsRefPrefix = "refs/lazygit/snapshots/"
+
+// Snapshot is a saved branch tip and, optionally, the uncommitted changes that
+// went with it
+type Snapshot struct {
+	Name    string
+	HeadSha string
+	// WorktreeSha is the stash commit of the uncommitted changes, if there
+	// were any. Untracked files aren't included.
+	WorktreeSha string
+}
+
+// Ref returns the ref with the snapshot's content i.e. the stash if there is
+// one, otherwise the branch tip
+func (s *Snapshot) Ref() string {
+	if s.WorktreeSha != "" {
+		return snapshotsRefPrefix + s.Name + "/worktree"
+	}
+	return snapshotsRefPrefix + s.Name + "/head"
+}
+
+// GetSnapshots returns the snapshots ordered by name
+func (c *GitCommand) GetSnapshots() ([]*Snapshot, error) {
+	output, err := c.OSCommand.RunCommandWithOutput(`git for-each-ref --format="%%(refname)|%%(objectname)" %s`, snapshotsRefPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotsByName := map[string]*Snapshot{}
+	for _, line := range utils.SplitLines(output) {
+		split := strings.SplitN(line, "|", 2)
+		if len(split) != 2 {
+			continue
+		}
+		refName := strings.TrimPrefix(split[0], snapshotsRefPrefix)
+		slashIndex := strings.LastIndex(refName, "/")
+		if slashIndex == -1 {
+			continue
+		}
+		name := refName[:slashIndex]
+		snapshot, ok := snapshotsByName[name]
+		if !ok {
+			snapshot = &Snapshot{Name: name}
+			snapshotsByName[name] = snapshot
+		}
+		switch refName[slashIndex+1:] {
+		case "head":
+			snapshot.HeadSha = split[1]
+		case "worktree":
+			snapshot.WorktreeSha = split[1]
+		}
+	}
+
+	snapshots := []*Snapshot{}
+	for _, snapshot := range snapshotsByName {
+		if snapshot.HeadSha != "" {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+	return snapshots, nil
+}
+
+// CreateSnapshot records the current branch tip and uncommitted changes under
+// the given name, leaving the working tree as it is
+func (c *GitCommand) CreateSnapshot(name string) error {
+	snapshots, err := c.GetSnapshots()
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Name == name {
+			return errors.New(c.Tr.TemplateLocalize("SnapshotAlreadyExists", i18n.Teml{"name": name}))
+		}
+	}
+
+	headSha, err := c.OSCommand.RunCommandWithOutput("git rev-parse HEAD")
+	if err != nil {
+		return err
+	}
+	// this gives us nothing if there's nothing to stash
+	worktreeSha, err := c.OSCommand.RunCommandWithOutput("git stash create %s", c.OSCommand.Quote("lazygit snapshot "+name))
+	if err != nil {
+		return err
+	}
+
+	if err := c.OSCommand.RunCommand("git update-ref %s %s", c.OSCommand.Quote(snapshotsRefPrefix+name+"/head"), strings.TrimSpace(headSha)); err != nil {
+		return err
+	}
+	if worktreeSha = strings.TrimSpace(worktreeSha); worktreeSha != "" {
+		return c.OSCommand.RunCommand("git update-ref %s %s", c.OSCommand.Quote(snapshotsRefPrefix+name+"/worktree"), worktreeSha)
+	}
+	return nil
+}
+
+// RestoreSnapshot hard resets the checked out branch to the snapshot's tip and
+// then reapplies its uncommitted changes
+func (c *GitCommand) RestoreSnapshot(snapshot *Snapshot) error {
+	if err := c.OSCommand.RunCommand("git reset --hard %s", snapshot.HeadSha); err != nil {
+		return err
+	}
+	if snapshot.WorktreeSha == "" {
+		return nil
+	}
+	return c.OSCommand.RunCommand("git stash apply %s", snapshot.WorktreeSha)
+}
+
+// DeleteSnapshot deletes the snapshot's refs, after which its commits can be
+// garbage collected
+func (c *GitCommand) DeleteSnapshot(snapshot *Snapshot) error {
+	if err := c.OSCommand.RunCommand("git update-ref -d %s", c.OSCommand.Quote(snapshotsRefPrefix+snapshot.Name+"/head")); err != nil {
+		return err
+	}
+	if snapshot.WorktreeSha == "" {
+		return nil
+	}
+	return c.OSCommand.RunCommand("git update-ref -d %s", c.OSCommand.Quote(snapshotsRefPrefix+snapshot.Name+"/worktree"))
+}
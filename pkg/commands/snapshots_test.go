@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetSnapshots is a function.
+func TestGitCommandGetSnapshots(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  `git for-each-ref --format="%(refname)|%(objectname)" refs/lazygit/snapshots/`,
+			Replace: "echo 'refs/lazygit/snapshots/b/head|aaa\nrefs/lazygit/snapshots/a/b/head|bbb\nrefs/lazygit/snapshots/a/b/worktree|ccc\nrefs/lazygit/snapshots/orphan/worktree|ddd'",
+		},
+	})
+
+	snapshots, err := gitCmd.GetSnapshots()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*Snapshot{
+		{Name: "a/b", HeadSha: "bbb", WorktreeSha: "ccc"},
+		{Name: "b", HeadSha: "aaa"},
+	}, snapshots)
+	assert.EqualValues(t, "refs/lazygit/snapshots/a/b/worktree", snapshots[0].Ref())
+	assert.EqualValues(t, "refs/lazygit/snapshots/b/head", snapshots[1].Ref())
+}
+
+// TestGitCommandCreateSnapshot is a function.
+func TestGitCommandCreateSnapshot(t *testing.T) {
+	type scenario struct {
+		testName   string
+		name       string
+		stashSha   string
+		extraSteps []*test.CommandSwapper
+		test       func(error)
+	}
+
+	listStep := &test.CommandSwapper{
+		Expect:  `git for-each-ref --format="%(refname)|%(objectname)" refs/lazygit/snapshots/`,
+		Replace: `echo "refs/lazygit/snapshots/existing/head|aaa"`,
+	}
+
+	scenarios := []scenario{
+		{
+			"clean working tree",
+			"before-rebase",
+			"",
+			nil,
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"uncommitted changes",
+			"before-rebase",
+			"def456",
+			[]*test.CommandSwapper{
+				{
+					Expect:  "git update-ref refs/lazygit/snapshots/before-rebase/worktree def456",
+					Replace: "echo",
+				},
+			},
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = test.CreateMockCommand(t, append([]*test.CommandSwapper{
+				listStep,
+				{
+					Expect:  "git rev-parse HEAD",
+					Replace: "echo abc123",
+				},
+				{
+					Expect:  "git stash create 'lazygit snapshot before-rebase'",
+					Replace: "echo " + s.stashSha,
+				},
+				{
+					Expect:  "git update-ref refs/lazygit/snapshots/before-rebase/head abc123",
+					Replace: "echo",
+				},
+			}, s.extraSteps...))
+			s.test(gitCmd.CreateSnapshot(s.name))
+		})
+	}
+
+	t.Run("name taken", func(t *testing.T) {
+		gitCmd := NewDummyGitCommand()
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{listStep})
+		assert.Error(t, gitCmd.CreateSnapshot("existing"))
+	})
+}
+
+// TestGitCommandRestoreSnapshot is a function.
+func TestGitCommandRestoreSnapshot(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git reset --hard abc123",
+			Replace: "echo",
+		},
+		{
+			Expect:  "git stash apply def456",
+			Replace: "echo",
+		},
+	})
+	assert.NoError(t, gitCmd.RestoreSnapshot(&Snapshot{Name: "x", HeadSha: "abc123", WorktreeSha: "def456"}))
+}
+
+// TestGitCommandDeleteSnapshot is a function.
+func TestGitCommandDeleteSnapshot(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git update-ref -d refs/lazygit/snapshots/x/head",
+			Replace: "echo",
+		},
+	})
+	assert.NoError(t, gitCmd.DeleteSnapshot(&Snapshot{Name: "x", HeadSha: "abc123"}))
+}
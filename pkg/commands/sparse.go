@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"github.com/go-errors/errors"
+)
+
+// SparseCheckoutStatus is how sparse checkout is set up in a repo. With a
+// sparse index in a cone-mode sparse checkout, git leaves directories outside
+// the cone collapsed in the index, so status and staging don't have to wade
+// through the whole of a large monorepo.
+type SparseCheckoutStatus struct {
+	Enabled     bool
+	Cone        bool
+	SparseIndex bool
+}
+
+// GetSparseCheckoutStatus reads the repo's sparse checkout config
+func (c *GitCommand) GetSparseCheckoutStatus() SparseCheckoutStatus {
+	isSet := func(key string) bool {
+		value, _ := c.getLocalGitConfig(key)
+		return isTruthy(value)
+	}
+
+	return SparseCheckoutStatus{
+		Enabled:     isSet("core.sparseCheckout"),
+		Cone:        isSet("core.sparseCheckoutCone"),
+		SparseIndex: isSet("index.sparse"),
+	}
+}
+
+// SetSparseIndex turns the sparse index on or off. git only supports it in
+// cone mode.
+func (c *GitCommand) SetSparseIndex(enabled bool) error {
+	status := c.GetSparseCheckoutStatus()
+	if !status.Enabled || !status.Cone {
+		return errors.New(c.Tr.SLocalize("SparseIndexNeedsCone"))
+	}
+
+	flag := "--no-sparse-index"
+	if enabled {
+		flag = "--sparse-index"
+	}
+	return c.OSCommand.RunCommand("git sparse-checkout init --cone %s", flag)
+}
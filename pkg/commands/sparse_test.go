@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandSetSparseIndex is a function.
+func TestGitCommandSetSparseIndex(t *testing.T) {
+	type scenario struct {
+		testName          string
+		getLocalGitConfig func(string) (string, error)
+		enabled           bool
+		command           func(string, ...string) *exec.Cmd
+		test              func(error)
+	}
+
+	coneConfig := func(key string) (string, error) {
+		switch key {
+		case "core.sparseCheckout", "core.sparseCheckoutCone":
+			return "true", nil
+		}
+		return "", nil
+	}
+
+	scenarios := []scenario{
+		{
+			"enable in a cone-mode sparse checkout",
+			coneConfig,
+			true,
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git sparse-checkout init --cone --sparse-index", Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"disable",
+			coneConfig,
+			false,
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git sparse-checkout init --cone --no-sparse-index", Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"not a sparse checkout",
+			func(string) (string, error) {
+				return "", nil
+			},
+			true,
+			test.CreateMockCommand(t, []*test.CommandSwapper{}),
+			func(err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"sparse checkout without cone mode",
+			func(key string) (string, error) {
+				if key == "core.sparseCheckout" {
+					return "true", nil
+				}
+				return "", nil
+			},
+			true,
+			test.CreateMockCommand(t, []*test.CommandSwapper{}),
+			func(err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getLocalGitConfig = s.getLocalGitConfig
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.SetSparseIndex(s.enabled))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetBranchSquashMessage drafts the message for squashing the checked out
+// branch's commits since it diverged from base into one: the subject of the
+// oldest commit followed by a list of the rest. It also returns how many
+// commits there are to squash.
+func (c *GitCommand) GetBranchSquashMessage(base string) (string, int, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git log --reverse --format=%%s %s..HEAD", c.OSCommand.Quote(base))
+	if err != nil {
+		return "", 0, err
+	}
+
+	subjects := utils.SplitLines(output)
+	if len(subjects) == 0 {
+		return "", 0, nil
+	}
+
+	message := subjects[0]
+	if len(subjects) > 1 {
+		message += "\n\n* " + strings.Join(subjects[1:], "\n* ")
+	}
+	return message, len(subjects), nil
+}
+
+// SquashBranch replaces the checked out branch's commits since it diverged
+// from base with a single commit with the given message. The commit's hooks
+// have already run on the commits being squashed so we skip them, like a
+// squash in an interactive rebase would. If committing fails the old tip is
+// still in ORIG_HEAD.
+func (c *GitCommand) SquashBranch(base string, message string) (*exec.Cmd, error) {
+	mergeBase, err := c.GetMergeBase(base)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.OSCommand.RunCommand("git reset --soft %s", mergeBase); err != nil {
+		return nil, err
+	}
+	return c.Commit(message, "--no-verify")
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetBranchSquashMessage is a function.
+func TestGitCommandGetBranchSquashMessage(t *testing.T) {
+	type scenario struct {
+		testName        string
+		command         func(string, ...string) *exec.Cmd
+		expectedMessage string
+		expectedCount   int
+	}
+
+	scenarios := []scenario{
+		{
+			"several commits",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: `git log --reverse --format=%s "master"..HEAD`, Replace: "echo 'Add login\nFix typo\nAdd tests'"},
+			}),
+			"Add login\n\n* Fix typo\n* Add tests",
+			3,
+		},
+		{
+			"one commit",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: `git log --reverse --format=%s "master"..HEAD`, Replace: "echo 'Add login'"},
+			}),
+			"Add login",
+			1,
+		},
+		{
+			"nothing to squash",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: `git log --reverse --format=%s "master"..HEAD`, Replace: "echo"},
+			}),
+			"",
+			0,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			message, count, err := gitCmd.GetBranchSquashMessage("master")
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedMessage, message)
+			assert.EqualValues(t, s.expectedCount, count)
+		})
+	}
+}
+
+// TestGitCommandSquashBranch is a function.
+func TestGitCommandSquashBranch(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.getLocalGitConfig = func(string) (string, error) { return "", nil }
+	gitCmd.getGlobalGitConfig = func(string) (string, error) { return "", nil }
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git merge-base "master" HEAD`, Replace: "echo abc123"},
+		{Expect: "git reset --soft abc123", Replace: "echo"},
+		{Expect: `git commit --no-verify -m "Add login"`, Replace: "echo"},
+	})
+
+	cmd, err := gitCmd.SquashBranch("master", "Add login")
+	assert.NoError(t, err)
+	assert.Nil(t, cmd)
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A stack is a chain of branches each built on top of the one before, e.g. for
+// a feature being reviewed in several small pull requests. We keep track of
+// each branch's parent in the repo's git config, along with the commit of the
+// parent that the branch was last built on, so that after the parent is
+// amended or rebased we know which commits belong to the child and can move
+// just those onto the parent's new tip.
+
+const (
+	stackParentKey = "lazygitstackparent"
+	stackBaseKey   = "lazygitstackbase"
+)
+
+// GetStackParents returns the parent of every branch that has one
+func (c *GitCommand) GetStackParents() map[string]string {
+	parents := map[string]string{}
+
+	output, err := c.OSCommand.RunCommandWithOutput("git config --local --get-regexp %s", c.OSCommand.Quote(`^branch\..*\.`+stackParentKey+`$`))
+	if err != nil {
+		// no branches are stacked
+		return parents
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		split := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if len(split) < 2 {
+			continue
+		}
+		branchName := strings.TrimSuffix(strings.TrimPrefix(split[0], "branch."), "."+stackParentKey)
+		parents[branchName] = split[1]
+	}
+
+	return parents
+}
+
+// SetStackParent stacks a branch on top of another, treating everything on
+// the branch that isn't on the parent as belonging to the branch
+func (c *GitCommand) SetStackParent(branchName string, parentName string) error {
+	base, err := c.OSCommand.RunCommandWithOutput("git merge-base %s %s", parentName, branchName)
+	if err != nil {
+		return err
+	}
+
+	if err := c.setBranchConfig(branchName, stackParentKey, parentName); err != nil {
+		return err
+	}
+	return c.setBranchConfig(branchName, stackBaseKey, strings.TrimSpace(base))
+}
+
+// UnsetStackParent takes a branch out of its stack
+func (c *GitCommand) UnsetStackParent(branchName string) error {
+	for _, key := range []string{stackParentKey, stackBaseKey} {
+		// exit code 5 just means the key wasn't set
+		_ = c.OSCommand.RunCommand("git config --local --unset %s", c.OSCommand.Quote(fmt.Sprintf("branch.%s.%s", branchName, key)))
+	}
+	return nil
+}
+
+func (c *GitCommand) setBranchConfig(branchName string, key string, value string) error {
+	return c.OSCommand.RunCommand("git config --local %s %s", c.OSCommand.Quote(fmt.Sprintf("branch.%s.%s", branchName, key)), c.OSCommand.Quote(value))
+}
+
+// GetStack returns the stack that the given branch is in, from the bottom up.
+// The bottom of the stack is the branch everything is built on (e.g. master),
+// and the rest are in an order where every branch comes after its parent.
+func GetStack(branches []*Branch, branchName string) []*Branch {
+	byName := map[string]*Branch{}
+	for _, branch := range branches {
+		byName[branch.Name] = branch
+	}
+
+	bottom, ok := byName[branchName]
+	if !ok {
+		return nil
+	}
+	// walk down to the bottom, watching out for cycles
+	seen := map[string]bool{}
+	for bottom.StackParent != "" && !seen[bottom.Name] {
+		seen[bottom.Name] = true
+		parent, ok := byName[bottom.StackParent]
+		if !ok {
+			break
+		}
+		bottom = parent
+	}
+
+	stack := []*Branch{bottom}
+	inStack := map[string]bool{bottom.Name: true}
+	for i := 0; i < len(stack); i++ {
+		for _, branch := range branches {
+			if branch.StackParent == stack[i].Name && !inStack[branch.Name] {
+				stack = append(stack, branch)
+				inStack[branch.Name] = true
+			}
+		}
+	}
+
+	return stack
+}
+
+// RestackBranch rebases a branch onto its parent's current tip, taking along
+// only the commits that were made on top of the parent. If the branch already
+// contains the parent's tip there's nothing to do.
+func (c *GitCommand) RestackBranch(branch *Branch) error {
+	parentSha, err := c.OSCommand.RunCommandWithOutput("git rev-parse %s", branch.StackParent)
+	if err != nil {
+		return err
+	}
+	parentSha = strings.TrimSpace(parentSha)
+
+	if err := c.OSCommand.RunCommand("git merge-base --is-ancestor %s %s", parentSha, branch.Name); err != nil {
+		base, err := c.OSCommand.RunCommandWithOutput("git config --local %s", c.OSCommand.Quote(fmt.Sprintf("branch.%s.%s", branch.Name, stackBaseKey)))
+		if err != nil {
+			return err
+		}
+
+		if err := c.OSCommand.RunCommand("git rebase --onto %s %s %s", parentSha, strings.TrimSpace(base), branch.Name); err != nil {
+			return err
+		}
+	}
+
+	return c.setBranchConfig(branch.Name, stackBaseKey, parentSha)
+}
+
+// Restack rebases every branch in the stack onto its parent in turn, then goes
+// back to whichever branch was checked out. If a rebase stops because of
+// conflicts we stop too, and once they're resolved restacking again picks up
+// where we left off.
+func (c *GitCommand) Restack(stack []*Branch, checkedOutBranch string) error {
+	for _, branch := range stack {
+		if branch.StackParent == "" {
+			continue
+		}
+		if err := c.RestackBranch(branch); err != nil {
+			return err
+		}
+	}
+
+	return c.Checkout(checkedOutBranch, false)
+}
@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetStack is a function.
+func TestGetStack(t *testing.T) {
+	branches := []*Branch{
+		{Name: "feature/c", StackParent: "feature/b"},
+		{Name: "master"},
+		{Name: "feature/b", StackParent: "feature/a"},
+		{Name: "feature/a", StackParent: "master"},
+		{Name: "feature/d", StackParent: "feature/a"},
+		{Name: "unrelated"},
+		{Name: "orphan", StackParent: "deleted"},
+		{Name: "loop1", StackParent: "loop2"},
+		{Name: "loop2", StackParent: "loop1"},
+	}
+
+	type scenario struct {
+		testName   string
+		branchName string
+		expected   []string
+	}
+
+	scenarios := []scenario{
+		{
+			"from the top of the stack",
+			"feature/c",
+			[]string{"master", "feature/a", "feature/b", "feature/d", "feature/c"},
+		},
+		{
+			"from the bottom of the stack",
+			"master",
+			[]string{"master", "feature/a", "feature/b", "feature/d", "feature/c"},
+		},
+		{
+			"unstacked branch",
+			"unrelated",
+			[]string{"unrelated"},
+		},
+		{
+			"parent no longer exists",
+			"orphan",
+			[]string{"orphan"},
+		},
+		{
+			"cycle",
+			"loop1",
+			[]string{"loop1", "loop2"},
+		},
+		{
+			"unknown branch",
+			"nope",
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			names := []string{}
+			for _, branch := range GetStack(branches, s.branchName) {
+				names = append(names, branch.Name)
+			}
+			assert.EqualValues(t, s.expected, names)
+		})
+	}
+}
+
+// TestGitCommandRestackBranch is a function.
+func TestGitCommandRestackBranch(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"parent has moved on",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse feature/a", Replace: "echo newsha"},
+				{Expect: "git merge-base --is-ancestor newsha feature/b", Replace: "test"},
+				{Expect: "git config --local branch.feature/b.lazygitstackbase", Replace: "echo oldsha"},
+				{Expect: "git rebase --onto newsha oldsha feature/b", Replace: "echo"},
+				{Expect: "git config --local branch.feature/b.lazygitstackbase newsha", Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"already on top of the parent",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse feature/a", Replace: "echo newsha"},
+				{Expect: "git merge-base --is-ancestor newsha feature/b", Replace: "echo"},
+				{Expect: "git config --local branch.feature/b.lazygitstackbase newsha", Replace: "echo"},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"rebase conflicts",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse feature/a", Replace: "echo newsha"},
+				{Expect: "git merge-base --is-ancestor newsha feature/b", Replace: "test"},
+				{Expect: "git config --local branch.feature/b.lazygitstackbase", Replace: "echo oldsha"},
+				{Expect: "git rebase --onto newsha oldsha feature/b", Replace: "test"},
+			}),
+			func(err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.RestackBranch(&Branch{Name: "feature/b", StackParent: "feature/a"}))
+		})
+	}
+}
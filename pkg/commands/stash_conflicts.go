@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// When applying or popping a stash hits conflicts, git leaves the stash where
+// it is and the working tree half applied, with no state of its own like a
+// rebase or merge has. These functions let us finish the job once the
+// conflicts are resolved, or put things back how they were.
+
+// GetStashSha returns the commit of a stash entry, which unlike its index
+// won't change as other entries come and go
+func (c *GitCommand) GetStashSha(index int) (string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git rev-parse %s", stashRef(index))
+	return strings.TrimSpace(output), err
+}
+
+// getStashIndex finds where a stash entry is in the stash, returning -1 if
+// it's no longer there
+func (c *GitCommand) getStashIndex(stashSha string) int {
+	output, err := c.OSCommand.RunCommandWithOutput("git rev-list --walk-reflogs refs/stash")
+	if err != nil {
+		return -1
+	}
+	for i, sha := range utils.SplitLines(output) {
+		if strings.TrimSpace(sha) == stashSha {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitByPresenceInHead splits the names of the stash's files into those that
+// exist at HEAD and those that the stash added
+func (c *GitCommand) splitByPresenceInHead(stashSha string) ([]string, []string, error) {
+	stashFiles, err := c.getStashFiles(stashSha)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(stashFiles) == 0 {
+		return nil, nil, nil
+	}
+
+	fileNames := make([]string, len(stashFiles))
+	for i, stashFile := range stashFiles {
+		fileNames[i] = stashFile.Name
+	}
+	output, err := c.OSCommand.RunCommandWithOutput("git ls-tree --name-only HEAD -- %s", c.quoteFileNames(fileNames))
+	if err != nil {
+		return nil, nil, err
+	}
+	inHead := map[string]bool{}
+	for _, name := range utils.SplitLines(output) {
+		inHead[name] = true
+	}
+
+	existing, added := []string{}, []string{}
+	for _, fileName := range fileNames {
+		if inHead[fileName] {
+			existing = append(existing, fileName)
+		} else {
+			added = append(added, fileName)
+		}
+	}
+	return existing, added, nil
+}
+
+func (c *GitCommand) quoteFileNames(fileNames []string) string {
+	quotedFileNames := make([]string, len(fileNames))
+	for i, fileName := range fileNames {
+		quotedFileNames[i] = c.OSCommand.Quote(fileName)
+	}
+	return strings.Join(quotedFileNames, " ")
+}
+
+// FinishStashApply is for once the conflicts from applying a stash have been
+// resolved. Like a stash applied without conflicts, the changes to existing
+// files end up unstaged. If the stash was being popped we drop it too.
+func (c *GitCommand) FinishStashApply(stashSha string, drop bool) error {
+	existing, _, err := c.splitByPresenceInHead(stashSha)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		if err := c.OSCommand.RunCommand("git reset -q -- %s", c.quoteFileNames(existing)); err != nil {
+			return err
+		}
+	}
+
+	if !drop {
+		return nil
+	}
+	index := c.getStashIndex(stashSha)
+	if index == -1 {
+		// it's already gone
+		return nil
+	}
+	return c.StashDo(index, "drop")
+}
+
+// AbortStashApply puts the files touched by a conflicted stash apply back how
+// they were. Git refuses to apply a stash over local changes to the same
+// files, so that means how they are at HEAD, and files the stash added are
+// removed. The stash itself is left alone.
+func (c *GitCommand) AbortStashApply(stashSha string) error {
+	existing, added, err := c.splitByPresenceInHead(stashSha)
+	if err != nil {
+		return err
+	}
+
+	if len(existing) > 0 {
+		if err := c.OSCommand.RunCommand("git checkout HEAD -- %s", c.quoteFileNames(existing)); err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		// exit code 1 just means none of them were in the index
+		_ = c.OSCommand.RunCommand("git rm --cached -q --ignore-unmatch -- %s", c.quoteFileNames(added))
+		for _, fileName := range added {
+			if err := c.removeFile(fileName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandFinishStashApply is a function.
+func TestGitCommandFinishStashApply(t *testing.T) {
+	type scenario struct {
+		testName string
+		drop     bool
+		command  func(string, ...string) *exec.Cmd
+	}
+
+	stashFilesCommands := []*test.CommandSwapper{
+		{Expect: "git diff --name-only abc^1 abc", Replace: "echo 'a.txt\nnew.txt'"},
+		{Expect: "git rev-parse --verify --quiet abc^3", Replace: "test"},
+		{Expect: `git ls-tree --name-only HEAD -- "a.txt" "new.txt"`, Replace: "echo a.txt"},
+		{Expect: `git reset -q -- "a.txt"`, Replace: "echo"},
+	}
+
+	scenarios := []scenario{
+		{
+			"apply",
+			false,
+			test.CreateMockCommand(t, stashFilesCommands),
+		},
+		{
+			"pop",
+			true,
+			test.CreateMockCommand(t, append(stashFilesCommands,
+				&test.CommandSwapper{Expect: "git rev-list --walk-reflogs refs/stash", Replace: "echo 'def\nabc'"},
+				&test.CommandSwapper{Expect: "git stash drop stash@{1}", Replace: "echo"},
+			)),
+		},
+		{
+			"pop with the stash already gone",
+			true,
+			test.CreateMockCommand(t, append(stashFilesCommands,
+				&test.CommandSwapper{Expect: "git rev-list --walk-reflogs refs/stash", Replace: "echo def"},
+			)),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			assert.NoError(t, gitCmd.FinishStashApply("abc", s.drop))
+		})
+	}
+}
+
+// TestGitCommandAbortStashApply is a function.
+func TestGitCommandAbortStashApply(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: "git diff --name-only abc^1 abc", Replace: "echo 'a.txt\nnew.txt'"},
+		{Expect: "git rev-parse --verify --quiet abc^3", Replace: "echo"},
+		{Expect: "git ls-tree -r --name-only abc^3", Replace: "echo untracked.txt"},
+		{Expect: `git ls-tree --name-only HEAD -- "a.txt" "new.txt" "untracked.txt"`, Replace: "echo a.txt"},
+		{Expect: `git checkout HEAD -- "a.txt"`, Replace: "echo"},
+		{Expect: `git rm --cached -q --ignore-unmatch -- "new.txt" "untracked.txt"`, Replace: "echo"},
+	})
+	removed := []string{}
+	gitCmd.removeFile = func(path string) error {
+		removed = append(removed, path)
+		return nil
+	}
+
+	assert.NoError(t, gitCmd.AbortStashApply("abc"))
+	assert.EqualValues(t, []string{"new.txt", "untracked.txt"}, removed)
+}
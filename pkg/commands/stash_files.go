@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// A stash entry is a commit whose first parent is the commit the stash was
+// made on, whose second parent holds what was staged, and whose optional third
+// parent holds the untracked files. The functions here let us deal with the
+// files in a stash one at a time rather than applying the whole thing.
+
+// emptyTreeSha is the id of git's empty tree, which we diff the stash's
+// untracked files against given that their commit has no parent
+const emptyTreeSha = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// StashFile is a file in a stash entry
+type StashFile struct {
+	Name string
+	// Untracked files come from the stash's untracked files commit
+	Untracked bool
+}
+
+func stashRef(index int) string {
+	return fmt.Sprintf("stash@{%d}", index)
+}
+
+// StashHasUntrackedFiles tells us whether the stash was made with
+// --include-untracked and had untracked files to include
+func (c *GitCommand) StashHasUntrackedFiles(index int) bool {
+	return c.stashHasUntrackedFiles(stashRef(index))
+}
+
+func (c *GitCommand) stashHasUntrackedFiles(ref string) bool {
+	return c.OSCommand.RunCommand("git rev-parse --verify --quiet %s^3", ref) == nil
+}
+
+// GetStashFiles returns the files changed in a stash entry, followed by its
+// untracked files
+func (c *GitCommand) GetStashFiles(index int) ([]*StashFile, error) {
+	return c.getStashFiles(stashRef(index))
+}
+
+func (c *GitCommand) getStashFiles(ref string) ([]*StashFile, error) {
+	stashFiles := []*StashFile{}
+
+	output, err := c.OSCommand.RunCommandWithOutput("git diff --name-only %s^1 %s", ref, ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range utils.SplitLines(output) {
+		stashFiles = append(stashFiles, &StashFile{Name: name})
+	}
+
+	if c.stashHasUntrackedFiles(ref) {
+		output, err := c.OSCommand.RunCommandWithOutput("git ls-tree -r --name-only %s^3", ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range utils.SplitLines(output) {
+			stashFiles = append(stashFiles, &StashFile{Name: name, Untracked: true})
+		}
+	}
+
+	return stashFiles, nil
+}
+
+// ShowStashUntrackedFilesCmdStr shows the untracked files in a stash entry,
+// which `git stash show` leaves out
+func (c *GitCommand) ShowStashUntrackedFilesCmdStr(index int) string {
+	return fmt.Sprintf("git diff --color=%s %s %s^3", c.colorArg(PagingContextStash), emptyTreeSha, stashRef(index))
+}
+
+// GetStashFilePatch returns the changes the stash entry makes to a file
+func (c *GitCommand) GetStashFilePatch(index int, stashFile *StashFile) (string, error) {
+	ref := stashRef(index)
+	quotedFileName := c.OSCommand.Quote(stashFile.Name)
+	if stashFile.Untracked {
+		return c.OSCommand.RunCommandWithOutput("git diff --color=never --binary %s %s^3 -- %s", emptyTreeSha, ref, quotedFileName)
+	}
+	return c.OSCommand.RunCommandWithOutput("git diff --color=never --binary %s^1 %s -- %s", ref, ref, quotedFileName)
+}
+
+// ApplyStashFile applies the stash entry's changes to a single file to the
+// working tree, leaving the rest of the stash be. Untracked files are restored
+// as untracked files.
+func (c *GitCommand) ApplyStashFile(index int, stashFile *StashFile) error {
+	ref := stashRef(index)
+	quotedFileName := c.OSCommand.Quote(stashFile.Name)
+
+	if stashFile.Untracked {
+		if err := c.OSCommand.RunCommand("git checkout %s^3 -- %s", ref, quotedFileName); err != nil {
+			return err
+		}
+		return c.OSCommand.RunCommand("git reset -q -- %s", quotedFileName)
+	}
+
+	patch, err := c.GetStashFilePatch(index, stashFile)
+	if err != nil {
+		return err
+	}
+	return c.ApplyPatch(patch)
+}
+
+// PopStashFile applies the stash entry's changes to a single file and then
+// takes the file out of the stash entry
+func (c *GitCommand) PopStashFile(index int, stashFile *StashFile) error {
+	if err := c.ApplyStashFile(index, stashFile); err != nil {
+		return err
+	}
+	return c.DropStashFile(index, stashFile)
+}
+
+// DropStashFile takes a file out of a stash entry. Stash entries can't be
+// edited, so we build a new stash commit without the file and swap it in for
+// the old one, which moves the entry to the top of the stash. If there's
+// nothing left in the stash entry we just drop it.
+func (c *GitCommand) DropStashFile(index int, stashFile *StashFile) error {
+	ref := stashRef(index)
+
+	revParse := func(rev string) (string, error) {
+		output, err := c.OSCommand.RunCommandWithOutput("git rev-parse %s", rev)
+		return strings.TrimSpace(output), err
+	}
+
+	baseSha, err := revParse(ref + "^1")
+	if err != nil {
+		return err
+	}
+	baseTree, err := revParse(baseSha + "^{tree}")
+	if err != nil {
+		return err
+	}
+
+	indexFileDir, err := ioutil.TempDir("", "lazygit-stash")
+	if err != nil {
+		return WrapError(err)
+	}
+	defer os.RemoveAll(indexFileDir)
+	indexFile := filepath.Join(indexFileDir, "index")
+
+	quotedFileName := c.OSCommand.Quote(stashFile.Name)
+	// treeWithoutFile gives us the tree of a commit with the file removed, or
+	// put back to how it was in the base commit for tracked files
+	treeWithoutFile := func(commit string) (string, error) {
+		cmdStrs := []string{fmt.Sprintf("git read-tree %s", commit)}
+		if stashFile.Untracked {
+			cmdStrs = append(cmdStrs, fmt.Sprintf("git update-index --force-remove -- %s", quotedFileName))
+		} else {
+			cmdStrs = append(cmdStrs, fmt.Sprintf("git reset -q %s -- %s", baseSha, quotedFileName))
+		}
+		cmdStrs = append(cmdStrs, "git write-tree")
+
+		output := ""
+		for _, cmdStr := range cmdStrs {
+			cmd := c.OSCommand.ExecutableFromString(cmdStr)
+			cmd.Env = append(cmd.Env, "GIT_INDEX_FILE="+indexFile)
+			var err error
+			if output, err = c.OSCommand.RunExecutableWithOutput(cmd); err != nil {
+				return "", err
+			}
+		}
+		return strings.TrimSpace(output), nil
+	}
+
+	workingTree, err := revParse(ref + "^{tree}")
+	if err != nil {
+		return err
+	}
+	indexTree, err := revParse(ref + "^2^{tree}")
+	if err != nil {
+		return err
+	}
+	untrackedTree := ""
+	if c.StashHasUntrackedFiles(index) {
+		if untrackedTree, err = revParse(ref + "^3^{tree}"); err != nil {
+			return err
+		}
+	}
+
+	if stashFile.Untracked {
+		if untrackedTree, err = treeWithoutFile(ref + "^3"); err != nil {
+			return err
+		}
+		if untrackedTree == emptyTreeSha {
+			untrackedTree = ""
+		}
+	} else {
+		if workingTree, err = treeWithoutFile(ref); err != nil {
+			return err
+		}
+		if indexTree, err = treeWithoutFile(ref + "^2"); err != nil {
+			return err
+		}
+	}
+
+	if workingTree == baseTree && indexTree == baseTree && untrackedTree == "" {
+		return c.StashDo(index, "drop")
+	}
+
+	commitTree := func(tree string, message string, parents ...string) (string, error) {
+		parentArgs := ""
+		for _, parent := range parents {
+			parentArgs += " -p " + parent
+		}
+		output, err := c.OSCommand.RunCommandWithOutput("git commit-tree %s%s -m %s", tree, parentArgs, c.OSCommand.Quote(message))
+		return strings.TrimSpace(output), err
+	}
+	subject := func(commit string) (string, error) {
+		output, err := c.OSCommand.RunCommandWithOutput("git log -1 --format=%%s %s", commit)
+		return strings.TrimSpace(output), err
+	}
+
+	message, err := subject(ref)
+	if err != nil {
+		return err
+	}
+	indexMessage, err := subject(ref + "^2")
+	if err != nil {
+		return err
+	}
+
+	indexSha, err := commitTree(indexTree, indexMessage, baseSha)
+	if err != nil {
+		return err
+	}
+	parents := []string{baseSha, indexSha}
+	if untrackedTree != "" {
+		untrackedMessage, err := subject(ref + "^3")
+		if err != nil {
+			return err
+		}
+		untrackedSha, err := commitTree(untrackedTree, untrackedMessage)
+		if err != nil {
+			return err
+		}
+		parents = append(parents, untrackedSha)
+	}
+	stashSha, err := commitTree(workingTree, message, parents...)
+	if err != nil {
+		return err
+	}
+
+	if err := c.StashDo(index, "drop"); err != nil {
+		return err
+	}
+	return c.OSCommand.RunCommand("git stash store -m %s %s", c.OSCommand.Quote(message), stashSha)
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetStashFiles is a function.
+func TestGitCommandGetStashFiles(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func([]*StashFile, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"tracked and untracked files",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git diff --name-only stash@{1}^1 stash@{1}", Replace: "echo 'a.txt\nb.txt'"},
+				{Expect: "git rev-parse --verify --quiet stash@{1}^3", Replace: "echo"},
+				{Expect: "git ls-tree -r --name-only stash@{1}^3", Replace: "echo new.txt"},
+			}),
+			func(stashFiles []*StashFile, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*StashFile{
+					{Name: "a.txt"},
+					{Name: "b.txt"},
+					{Name: "new.txt", Untracked: true},
+				}, stashFiles)
+			},
+		},
+		{
+			"no untracked files",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git diff --name-only stash@{1}^1 stash@{1}", Replace: "echo a.txt"},
+				{Expect: "git rev-parse --verify --quiet stash@{1}^3", Replace: "test"},
+			}),
+			func(stashFiles []*StashFile, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*StashFile{{Name: "a.txt"}}, stashFiles)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.GetStashFiles(1))
+		})
+	}
+}
+
+// TestGitCommandApplyStashFile is a function.
+func TestGitCommandApplyStashFile(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git checkout stash@{0}^3 -- "new.txt"`, Replace: "echo"},
+		{Expect: `git reset -q -- "new.txt"`, Replace: "echo"},
+	})
+
+	assert.NoError(t, gitCmd.ApplyStashFile(0, &StashFile{Name: "new.txt", Untracked: true}))
+}
+
+// TestGitCommandDropStashFile is a function.
+func TestGitCommandDropStashFile(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+	}
+
+	scenarios := []scenario{
+		{
+			"last file in the stash",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse stash@{0}^1", Replace: "echo base"},
+				{Expect: "git rev-parse base^{tree}", Replace: "echo baseTree"},
+				{Expect: "git rev-parse stash@{0}^{tree}", Replace: "echo workingTree"},
+				{Expect: "git rev-parse stash@{0}^2^{tree}", Replace: "echo baseTree"},
+				{Expect: "git rev-parse --verify --quiet stash@{0}^3", Replace: "test"},
+				{Expect: "git read-tree stash@{0}", Replace: "echo"},
+				{Expect: `git reset -q base -- "a.txt"`, Replace: "echo"},
+				{Expect: "git write-tree", Replace: "echo baseTree"},
+				{Expect: "git read-tree stash@{0}^2", Replace: "echo"},
+				{Expect: `git reset -q base -- "a.txt"`, Replace: "echo"},
+				{Expect: "git write-tree", Replace: "echo baseTree"},
+				{Expect: "git stash drop stash@{0}", Replace: "echo"},
+			}),
+		},
+		{
+			"other files left in the stash",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse stash@{0}^1", Replace: "echo base"},
+				{Expect: "git rev-parse base^{tree}", Replace: "echo baseTree"},
+				{Expect: "git rev-parse stash@{0}^{tree}", Replace: "echo workingTree"},
+				{Expect: "git rev-parse stash@{0}^2^{tree}", Replace: "echo baseTree"},
+				{Expect: "git rev-parse --verify --quiet stash@{0}^3", Replace: "test"},
+				{Expect: "git read-tree stash@{0}", Replace: "echo"},
+				{Expect: `git reset -q base -- "a.txt"`, Replace: "echo"},
+				{Expect: "git write-tree", Replace: "echo newWorkingTree"},
+				{Expect: "git read-tree stash@{0}^2", Replace: "echo"},
+				{Expect: `git reset -q base -- "a.txt"`, Replace: "echo"},
+				{Expect: "git write-tree", Replace: "echo baseTree"},
+				{Expect: "git log -1 --format=%s stash@{0}", Replace: "echo On master: wip"},
+				{Expect: "git log -1 --format=%s stash@{0}^2", Replace: "echo index on master: 123 msg"},
+				{Expect: `git commit-tree baseTree -p base -m "index on master: 123 msg"`, Replace: "echo newIndex"},
+				{Expect: `git commit-tree newWorkingTree -p base -p newIndex -m "On master: wip"`, Replace: "echo newStash"},
+				{Expect: "git stash drop stash@{0}", Replace: "echo"},
+				{Expect: `git stash store -m "On master: wip" newStash`, Replace: "echo"},
+			}),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			assert.NoError(t, gitCmd.DropStashFile(0, &StashFile{Name: "a.txt"}))
+		})
+	}
+}
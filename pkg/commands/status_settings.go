@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"strings"
+)
+
+// UntrackedFilesModes are the values of status.showUntrackedFiles. 'all' lists
+// every untracked file, 'normal' collapses untracked directories, and 'no'
+// skips looking for untracked files altogether, which is by far the fastest in
+// a big repo.
+var UntrackedFilesModes = []string{"all", "normal", "no"}
+
+// StatusSettings are the git settings that most affect how long `git status`
+// takes in a big repo
+type StatusSettings struct {
+	UntrackedCache     bool
+	UntrackedFilesMode string
+	ManyFiles          bool
+	// RecurseSubmodules lists the files of the repo's submodules alongside
+	// its own. This one's ours rather than git's
+	RecurseSubmodules bool
+}
+
+func isTruthy(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return value == "true" || value == "1" || value == "yes" || value == "on"
+}
+
+// GetStatusSettings returns the repo's status settings. We default to showing
+// all untracked files because the files panel lists files rather than
+// directories.
+func (c *GitCommand) GetStatusSettings() StatusSettings {
+	untrackedCache, _ := c.getLocalGitConfig("core.untrackedCache")
+	manyFiles, _ := c.getLocalGitConfig("feature.manyFiles")
+
+	return StatusSettings{
+		UntrackedCache:     isTruthy(untrackedCache),
+		UntrackedFilesMode: c.untrackedFilesMode(),
+		ManyFiles:          isTruthy(manyFiles),
+		RecurseSubmodules:  c.RecursesSubmodules(),
+	}
+}
+
+func (c *GitCommand) untrackedFilesMode() string {
+	mode, _ := c.getLocalGitConfig("status.showUntrackedFiles")
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	for _, known := range UntrackedFilesModes {
+		if mode == known {
+			return mode
+		}
+	}
+	return "all"
+}
+
+// SetLocalConfigValue sets a config value for this repo only
+func (c *GitCommand) SetLocalConfigValue(key string, value string) error {
+	return c.OSCommand.RunCommand("git config --local %s %s", key, c.OSCommand.Quote(value))
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetStatusSettings is a function.
+func TestGitCommandGetStatusSettings(t *testing.T) {
+	type scenario struct {
+		testName          string
+		getLocalGitConfig func(string) (string, error)
+		expected          StatusSettings
+	}
+
+	scenarios := []scenario{
+		{
+			"nothing set",
+			func(string) (string, error) {
+				return "", nil
+			},
+			StatusSettings{UntrackedFilesMode: "all"},
+		},
+		{
+			"everything set",
+			func(key string) (string, error) {
+				switch key {
+				case "core.untrackedCache":
+					return "true", nil
+				case "status.showUntrackedFiles":
+					return "no", nil
+				case "feature.manyFiles":
+					return "yes", nil
+				case "lazygit.status.recurseSubmodules":
+					return "on", nil
+				}
+				return "", nil
+			},
+			StatusSettings{UntrackedCache: true, UntrackedFilesMode: "no", ManyFiles: true, RecurseSubmodules: true},
+		},
+		{
+			"unknown untracked files mode",
+			func(key string) (string, error) {
+				if key == "status.showUntrackedFiles" {
+					return "some", nil
+				}
+				return "", nil
+			},
+			StatusSettings{UntrackedFilesMode: "all"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getLocalGitConfig = s.getLocalGitConfig
+			assert.EqualValues(t, s.expected, gitCmd.GetStatusSettings())
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// With lazygit.status.recurseSubmodules set, the files panel lists the dirty
+// files of every submodule alongside the superproject's own, with the
+// submodule's path in front of their names, for projects that treat their
+// submodules as one tree. Git won't touch a path inside a submodule from the
+// superproject, so commands on those files are run in their submodule, with
+// the name the submodule knows them by.
+
+const recurseSubmodulesConfigKey = "lazygit.status.recurseSubmodules"
+
+// RecursesSubmodules tells us whether the files panel lists the files of the
+// repo's submodules too
+func (c *GitCommand) RecursesSubmodules() bool {
+	value, _ := c.getLocalGitConfig(recurseSubmodulesConfigKey)
+	return isTruthy(value)
+}
+
+// getSubmoduleStatusFiles returns the dirty files of the submodules of the
+// repo at dir, and of their submodules in turn
+func (c *GitCommand) getSubmoduleStatusFiles(dir string) []*File {
+	paths, err := c.submodulePathsIn(dir)
+	if err != nil {
+		c.Log.Error(err)
+		return nil
+	}
+
+	files := []*File{}
+	for _, path := range paths {
+		// git would look for a repo further up if the submodule hasn't been
+		// checked out, and give us the superproject's status
+		if checkedOut, _ := c.OSCommand.FileExists(filepath.Join(path, ".git")); !checkedOut {
+			continue
+		}
+		output, err := c.OSCommand.RunCommandWithOutput("git -C %s status --untracked-files=%s --porcelain", c.OSCommand.Quote(path), c.untrackedFilesMode())
+		if err != nil {
+			c.Log.Error(err)
+			continue
+		}
+		files = append(files, c.parseStatusFiles(output, path)...)
+		files = append(files, c.getSubmoduleStatusFiles(path)...)
+	}
+	return files
+}
+
+// inSubmodule puts the submodule's path in front of a file name git gave us
+// from within the submodule, or both names if it's a rename
+func inSubmodule(submodule string, fileName string) string {
+	names := strings.Split(fileName, " -> ")
+	for i, name := range names {
+		names[i] = submodule + "/" + name
+	}
+	return strings.Join(names, " -> ")
+}
+
+// NameInRepo returns the file's name as the repo it's in knows it, without
+// its submodule's path in front
+func (f *File) NameInRepo() string {
+	if f.Submodule == "" {
+		return f.Name
+	}
+	names := strings.Split(f.Name, " -> ")
+	for i, name := range names {
+		names[i] = strings.TrimPrefix(name, f.Submodule+"/")
+	}
+	return strings.Join(names, " -> ")
+}
+
+// repoArg is what runs a git command in the submodule the file's in, if it's
+// in one
+func (c *GitCommand) repoArg(file *File) string {
+	if file.Submodule == "" {
+		return ""
+	}
+	return fmt.Sprintf("-C %s ", c.OSCommand.Quote(file.Submodule))
+}
+
+// StageSubmoduleFiles stages files in the given submodule, by the names the
+// submodule knows them by
+func (c *GitCommand) StageSubmoduleFiles(submodule string, fileNames []string) error {
+	quotedFileNames := make([]string, len(fileNames))
+	for i, fileName := range fileNames {
+		quotedFileNames[i] = c.OSCommand.Quote(fileName)
+	}
+	return c.OSCommand.RunCommand("git -C %s add -- %s", c.OSCommand.Quote(submodule), strings.Join(quotedFileNames, " "))
+}
+
+// UnStageSubmoduleFile unstages a file in its submodule
+func (c *GitCommand) UnStageSubmoduleFile(file *File) error {
+	command := "git %srm --cached -- %s"
+	if file.Tracked {
+		command = "git %sreset HEAD -- %s"
+	}
+
+	// renamed files look like "file1 -> file2"
+	for _, name := range strings.Split(file.NameInRepo(), " -> ") {
+		if err := c.OSCommand.RunCommand(command, c.repoArg(file), c.OSCommand.Quote(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StageAllInSubmodule stages everything in the given submodule
+func (c *GitCommand) StageAllInSubmodule(submodule string) error {
+	return c.OSCommand.RunCommand("git -C %s add -A", c.OSCommand.Quote(submodule))
+}
+
+// UnstageAllInSubmodule unstages everything in the given submodule
+func (c *GitCommand) UnstageAllInSubmodule(submodule string) error {
+	return c.OSCommand.RunCommand("git -C %s reset", c.OSCommand.Quote(submodule))
+}
+
+// CommitInSubmodule commits what's staged in the given submodule
+func (c *GitCommand) CommitInSubmodule(submodule string, message string, flags string) error {
+	return c.OSCommand.RunCommand("git -C %s commit %s -m %s", c.OSCommand.Quote(submodule), flags, c.OSCommand.Quote(message))
+}
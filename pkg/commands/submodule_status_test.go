@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetStatusFilesRecursingSubmodules is a function.
+func TestGitCommandGetStatusFilesRecursingSubmodules(t *testing.T) {
+	inRepoWithSubmodules(t, func() {
+		// the submodule has been checked out
+		assert.NoError(t, os.MkdirAll(filepath.Join("vendor", "lib"), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join("vendor", "lib", ".git"), []byte("gitdir: ../../.git/modules/lib\n"), 0644))
+
+		gitCmd := NewDummyGitCommand()
+		gitCmd.getLocalGitConfig = func(key string) (string, error) {
+			if key == "lazygit.status.recurseSubmodules" {
+				return "true", nil
+			}
+			return "", nil
+		}
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+			{Expect: "git status --untracked-files=all --porcelain", Replace: "bash -c 'echo \" M vendor/lib\"; echo \"M  README.md\"'"},
+			{Expect: `git config --file .gitmodules --get-regexp ^submodule\..*\.path$`, Replace: "echo submodule.lib.path vendor/lib"},
+			{Expect: "git -C vendor/lib status --untracked-files=all --porcelain", Replace: "bash -c 'echo \"MM src/main.go\"; echo \"R  old.go -> new.go\"'"},
+		})
+
+		files := gitCmd.GetStatusFiles()
+		assert.Len(t, files, 4)
+		assert.EqualValues(t, "README.md", files[1].Name)
+		assert.EqualValues(t, "", files[1].Submodule)
+
+		assert.EqualValues(t, "vendor/lib/src/main.go", files[2].Name)
+		assert.EqualValues(t, "MM vendor/lib/src/main.go", files[2].DisplayString)
+		assert.EqualValues(t, "vendor/lib", files[2].Submodule)
+		assert.EqualValues(t, "src/main.go", files[2].NameInRepo())
+		assert.True(t, files[2].HasStagedChanges)
+		assert.True(t, files[2].HasUnstagedChanges)
+
+		assert.EqualValues(t, "vendor/lib/old.go -> vendor/lib/new.go", files[3].Name)
+		assert.EqualValues(t, "old.go -> new.go", files[3].NameInRepo())
+	})
+}
+
+// TestGitCommandGetStatusFilesSkipsSubmodulesNotCheckedOut is a function.
+func TestGitCommandGetStatusFilesSkipsSubmodulesNotCheckedOut(t *testing.T) {
+	inRepoWithSubmodules(t, func() {
+		gitCmd := NewDummyGitCommand()
+		gitCmd.getLocalGitConfig = func(key string) (string, error) {
+			return "true", nil
+		}
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+			{Expect: "git status --untracked-files=all --porcelain", Replace: "echo 'M  README.md'"},
+			{Expect: `git config --file .gitmodules --get-regexp ^submodule\..*\.path$`, Replace: "echo submodule.lib.path vendor/lib"},
+		})
+
+		files := gitCmd.GetStatusFiles()
+		assert.Len(t, files, 1)
+		assert.EqualValues(t, "README.md", files[0].Name)
+	})
+}
+
+// TestGitCommandSubmoduleFileCommands is a function.
+func TestGitCommandSubmoduleFileCommands(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config.GetUserConfig().Set("git.paging.colorArg", "always")
+	file := &File{Name: "vendor/lib/src/main.go", Submodule: "vendor/lib", Tracked: true, HasStagedChanges: true}
+
+	assert.EqualValues(t, "git -C 'vendor/lib' diff --color=always --cached -- 'src/main.go'", gitCmd.DiffCmdStr(file, false, true))
+
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: "git -C vendor/lib add -- src/main.go src/util.go", Replace: "echo"},
+		{Expect: "git -C vendor/lib reset HEAD -- src/main.go", Replace: "echo"},
+		{Expect: "git -C vendor/lib reset -- src/main.go", Replace: "echo"},
+		{Expect: "git -C vendor/lib checkout -- src/main.go", Replace: "echo"},
+		{Expect: "git -C vendor/lib add -A", Replace: "echo"},
+		{Expect: "git -C vendor/lib reset", Replace: "echo"},
+		{Expect: "git -C vendor/lib commit --no-verify -m Bump", Replace: "echo"},
+	})
+
+	assert.NoError(t, gitCmd.StageSubmoduleFiles("vendor/lib", []string{"src/main.go", "src/util.go"}))
+	assert.NoError(t, gitCmd.UnStageSubmoduleFile(file))
+	assert.NoError(t, gitCmd.DiscardAllFileChanges(file))
+	assert.NoError(t, gitCmd.StageAllInSubmodule("vendor/lib"))
+	assert.NoError(t, gitCmd.UnstageAllInSubmodule("vendor/lib"))
+	assert.NoError(t, gitCmd.CommitInSubmodule("vendor/lib", "Bump", "--no-verify"))
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// A submodule's pin is the commit of it that the superproject records. When
+// a diff moves one git only shows the old and new shas, unless it's asked for
+// --submodule=log, which lists the submodule's commits in between.
+
+// submoduleDiffArg asks a diff for the submodule commits between each moved
+// pin, if the repo has any submodules to move
+func (c *GitCommand) submoduleDiffArg() string {
+	if hasSubmodules, _ := c.OSCommand.FileExists(".gitmodules"); hasSubmodules {
+		return " --submodule=log"
+	}
+	return ""
+}
+
+// GetSubmodulePaths returns where the repo's submodules are, going by
+// .gitmodules
+func (c *GitCommand) GetSubmodulePaths() ([]string, error) {
+	return c.submodulePathsIn("")
+}
+
+// submodulePathsIn returns where the submodules of the repo at dir are, with
+// dir in front of them, where the superproject's dir is ""
+func (c *GitCommand) submodulePathsIn(dir string) ([]string, error) {
+	if hasSubmodules, _ := c.OSCommand.FileExists(filepath.Join(dir, ".gitmodules")); !hasSubmodules {
+		return nil, nil
+	}
+
+	dirArg := ""
+	if dir != "" {
+		dirArg = "-C " + c.OSCommand.Quote(dir) + " "
+	}
+	output, err := c.OSCommand.RunCommandWithOutput(`git %sconfig --file .gitmodules --get-regexp "^submodule\..*\.path$"`, dirArg)
+	if err != nil {
+		// git exits with status 1 when nothing matches
+		if output == "" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, line := range utils.SplitLines(output) {
+		// e.g. 'submodule.vendor/lib.path vendor/lib'
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) == 2 {
+			paths = append(paths, path.Join(dir, fields[1]))
+		}
+	}
+	return paths, nil
+}
+
+// IsSubmodule tells us whether there's a submodule at the given path
+func (c *GitCommand) IsSubmodule(path string) bool {
+	paths, err := c.GetSubmodulePaths()
+	if err != nil {
+		c.Log.Error(err)
+		return false
+	}
+	path = strings.TrimSuffix(path, "/")
+	for _, submodulePath := range paths {
+		if submodulePath == path {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSubmodulePin returns the commit of the submodule at path that ref pins
+// it to, or with no ref, the commit checked out in it
+func (c *GitCommand) GetSubmodulePin(ref string, path string) (string, error) {
+	cmdStr := fmt.Sprintf("git rev-parse %s", c.OSCommand.Quote(ref+":"+path))
+	if ref == "" {
+		cmdStr = fmt.Sprintf("git -C %s rev-parse HEAD", c.OSCommand.Quote(path))
+	}
+	output, err := c.OSCommand.RunCommandWithOutput(cmdStr)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// CheckoutSubmodulePin checks out the given commit in the submodule at path,
+// leaving it on a detached HEAD like `git submodule update` does
+func (c *GitCommand) CheckoutSubmodulePin(path string, sha string) error {
+	return c.OSCommand.RunCommand("git -C %s checkout --detach %s", c.OSCommand.Quote(path), sha)
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// inRepoWithSubmodules runs f in a directory with a .gitmodules file in it
+func inRepoWithSubmodules(t *testing.T, f func()) {
+	actual, err := os.Getwd()
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.Chdir(actual))
+	}()
+
+	repoDir, err := ioutil.TempDir("", "lazygit-submodules")
+	assert.NoError(t, err)
+	defer os.RemoveAll(repoDir)
+
+	assert.NoError(t, os.Chdir(repoDir))
+	assert.NoError(t, ioutil.WriteFile(".gitmodules", []byte("[submodule \"lib\"]\n\tpath = vendor/lib\n"), 0644))
+	f()
+}
+
+// TestGitCommandGetSubmodulePaths is a function.
+func TestGitCommandGetSubmodulePaths(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{})
+
+	// without a .gitmodules there's no need to ask git
+	paths, err := gitCmd.GetSubmodulePaths()
+	assert.NoError(t, err)
+	assert.Len(t, paths, 0)
+	assert.False(t, gitCmd.IsSubmodule("vendor/lib"))
+
+	inRepoWithSubmodules(t, func() {
+		expect := `git config --file .gitmodules --get-regexp ^submodule\..*\.path$`
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+			{Expect: expect, Replace: "bash -c 'echo submodule.lib.path vendor/lib; echo submodule.docs.path docs/site'"},
+		})
+		paths, err := gitCmd.GetSubmodulePaths()
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{"vendor/lib", "docs/site"}, paths)
+
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+			{Expect: expect, Replace: "echo submodule.lib.path vendor/lib"},
+			{Expect: expect, Replace: "echo submodule.lib.path vendor/lib"},
+		})
+		assert.True(t, gitCmd.IsSubmodule("vendor/lib/"))
+		assert.False(t, gitCmd.IsSubmodule("vendor"))
+
+		gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+			{Expect: expect, Replace: "false"},
+		})
+		paths, err = gitCmd.GetSubmodulePaths()
+		assert.NoError(t, err)
+		assert.Len(t, paths, 0)
+	})
+}
+
+// TestGitCommandSubmoduleDiffs is a function.
+func TestGitCommandSubmoduleDiffs(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config.GetUserConfig().Set("git.paging.colorArg", "always")
+	file := &File{Name: "vendor/lib", Tracked: true, HasUnstagedChanges: true}
+
+	assert.EqualValues(t, "git diff --color=always  -- 'vendor/lib'", gitCmd.DiffCmdStr(file, false, false))
+
+	inRepoWithSubmodules(t, func() {
+		assert.EqualValues(t, "git diff --submodule=log --color=always  -- 'vendor/lib'", gitCmd.DiffCmdStr(file, false, false))
+		// a plain diff is for patches, which want the shas
+		assert.EqualValues(t, "git diff --color=never  -- 'vendor/lib'", gitCmd.DiffCmdStr(file, true, false))
+		assert.EqualValues(t, "git show --submodule=log --color=always --no-renames --stat -p 1234567890", gitCmd.ShowCmdStr("1234567890", PagingContextCommits, 0))
+		assert.EqualValues(t, "git show --submodule=log --no-renames --color=always 1234567890 -- vendor/lib", gitCmd.ShowCommitFileCmdStr("1234567890", "vendor/lib", false))
+		assert.EqualValues(t, "git show --no-renames --color=never 1234567890 -- vendor/lib", gitCmd.ShowCommitFileCmdStr("1234567890", "vendor/lib", true))
+	})
+}
+
+// TestGitCommandGetSubmodulePin is a function.
+func TestGitCommandGetSubmodulePin(t *testing.T) {
+	type scenario struct {
+		testName string
+		ref      string
+		command  func(string, ...string) *exec.Cmd
+		test     func(string, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"pinned by a commit",
+			"abc123^",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse abc123^:vendor/lib", Replace: "echo def456"},
+			}),
+			func(sha string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "def456", sha)
+			},
+		},
+		{
+			"checked out",
+			"",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git -C vendor/lib rev-parse HEAD", Replace: "echo 789abc"},
+			}),
+			func(sha string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "789abc", sha)
+			},
+		},
+		{
+			"not there at the commit",
+			"abc123^",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-parse abc123^:vendor/lib", Replace: "bash -c 'echo fatal: path vendor/lib does not exist in abc123^; exit 128'"},
+			}),
+			func(sha string, err error) {
+				assert.Error(t, err)
+				assert.EqualValues(t, "", sha)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.GetSubmodulePin(s.ref, "vendor/lib"))
+		})
+	}
+}
+
+// TestGitCommandCheckoutSubmodulePin is a function.
+func TestGitCommandCheckoutSubmodulePin(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: "git -C vendor/lib checkout --detach def456", Replace: "echo"},
+	})
+
+	assert.NoError(t, gitCmd.CheckoutSubmodulePin("vendor/lib", "def456"))
+}
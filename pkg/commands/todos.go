@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Todo is a TODO or FIXME comment on a line that's been added
+type Todo struct {
+	FileName   string
+	LineNumber int
+	Text       string
+}
+
+var todoRegexp = regexp.MustCompile(`\b(TODO|FIXME)\b.*`)
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)[^\+]+\+(\d+)[^@]+@@`)
+
+// GetIntroducedTodos returns the TODOs and FIXMEs on lines added since HEAD
+// diverged from the base branch, including ones that haven't been committed
+// yet, so that the line numbers match the files in the working tree
+func (c *GitCommand) GetIntroducedTodos(baseBranch string) ([]*Todo, error) {
+	mergeBase, err := c.GetMergeBase(baseBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := c.OSCommand.RunCommandWithOutput("git diff --no-color --no-ext-diff --no-renames -U0 %s", mergeBase)
+	if err != nil {
+		return nil, err
+	}
+	return parseTodos(diff), nil
+}
+
+// parseTodos finds the TODOs and FIXMEs on the added lines of a diff with no
+// context lines
+func parseTodos(diff string) []*Todo {
+	todos := []*Todo{}
+	forEachAddedLine(diff, func(fileName string, lineNumber int, line string) {
+		if match := todoRegexp.FindString(line); match != "" {
+			todos = append(todos, &Todo{FileName: fileName, LineNumber: lineNumber, Text: strings.TrimSpace(match)})
+		}
+	})
+	return todos
+}
+
+// forEachAddedLine calls f with each line added by a diff with no context
+// lines, along with the file it's in and its line number there
+func forEachAddedLine(diff string, f func(fileName string, lineNumber int, line string)) {
+	fileName := ""
+	lineNumber := 0
+	for _, line := range utils.SplitLines(diff) {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			// this is /dev/null for deleted files, which have no added lines
+			fileName = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+		case strings.HasPrefix(line, "@@ "):
+			if match := hunkHeaderRegexp.FindStringSubmatch(line); match != nil {
+				lineNumber, _ = strconv.Atoi(match[2])
+			}
+		case strings.HasPrefix(line, "+"):
+			f(fileName, lineNumber, line[1:])
+			lineNumber++
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTodosDiff = `diff --git a/pkg/app.go b/pkg/app.go
+index 1111111..2222222 100644
+--- a/pkg/app.go
++++ b/pkg/app.go
+@@ -3,0 +4,2 @@ import (
++	// TODO: tidy this up
++	"fmt"
+@@ -20 +22 @@ func main() {
+-	// FIXME: old one that's been removed
++	run() // FIXME handle the error
+diff --git a/README.md b/README.md
+new file mode 100644
+--- /dev/null
++++ b/README.md
+@@ -0,0 +1,3 @@
++# Readme
++
++TODOS aren't todos but a TODO is
+`
+
+// TestParseTodos is a function.
+func TestParseTodos(t *testing.T) {
+	assert.EqualValues(t, []*Todo{
+		{FileName: "pkg/app.go", LineNumber: 4, Text: "TODO: tidy this up"},
+		{FileName: "pkg/app.go", LineNumber: 22, Text: "FIXME handle the error"},
+		{FileName: "README.md", LineNumber: 3, Text: "TODO is"},
+	}, parseTodos(testTodosDiff))
+}
+
+// TestGitCommandGetIntroducedTodos is a function.
+func TestGitCommandGetIntroducedTodos(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git merge-base master HEAD",
+			Replace: "echo abc123",
+		},
+		{
+			Expect:  "git diff --no-color --no-ext-diff --no-renames -U0 abc123",
+			Replace: "echo '+++ b/main.go\n@@ -1 +1 @@\n+// TODO: write main'",
+		},
+	})
+
+	todos, err := gitCmd.GetIntroducedTodos("master")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*Todo{{FileName: "main.go", LineNumber: 1, Text: "TODO: write main"}}, todos)
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Trailers are the 'Key: value' lines at the end of a commit message, like
+// 'Signed-off-by: Jesse Duffield <jesse@example.com>'. They go in a paragraph
+// of their own after the rest of the message.
+
+var trailerLineRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+: `)
+
+// Trailer is a trailer in a commit message. An empty Value stands for any
+// trailer with the key, or one the user still has to fill in.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// ParseTrailer parses a trailer as written in a commit message or the user's
+// config e.g. 'Reviewed-by: ' or 'Fixes: #123'
+func ParseTrailer(str string) Trailer {
+	split := strings.SplitN(str, ":", 2)
+	trailer := Trailer{Key: strings.TrimSpace(split[0])}
+	if len(split) == 2 {
+		trailer.Value = strings.TrimSpace(split[1])
+	}
+	return trailer
+}
+
+func (t Trailer) String() string {
+	return t.Key + ": " + t.Value
+}
+
+func (t Trailer) matches(line string) bool {
+	if !trailerLineRegexp.MatchString(line) {
+		return false
+	}
+	other := ParseTrailer(line)
+	return strings.EqualFold(other.Key, t.Key) && (t.Value == "" || other.Value == t.Value)
+}
+
+// trailerBlockStart returns the index of the first line of the paragraph of
+// trailers at the end of the message, or len(lines) if there isn't one
+func trailerBlockStart(lines []string) int {
+	start := len(lines)
+	for i := len(lines) - 1; i > 0; i-- {
+		if !trailerLineRegexp.MatchString(lines[i]) {
+			break
+		}
+		start = i
+	}
+	// the subject line is never a trailer, and neither is a paragraph that
+	// runs straight on from it
+	if start < len(lines) && strings.TrimSpace(lines[start-1]) != "" {
+		return len(lines)
+	}
+	return start
+}
+
+func splitMessage(message string) []string {
+	return strings.Split(strings.TrimRight(message, "\n "), "\n")
+}
+
+// HasTrailer tells us whether the message ends with the trailer
+func HasTrailer(message string, trailer Trailer) bool {
+	lines := splitMessage(message)
+	for _, line := range lines[trailerBlockStart(lines):] {
+		if trailer.matches(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTrailer adds the trailer to the end of the message, starting a paragraph
+// of trailers if there isn't one already
+func AddTrailer(message string, trailer Trailer) string {
+	lines := splitMessage(message)
+	if trailerBlockStart(lines) == len(lines) {
+		lines = append(lines, "")
+	}
+	return strings.Join(append(lines, trailer.String()), "\n")
+}
+
+// RemoveTrailer removes the trailer from the end of the message, along with
+// the paragraph of trailers if that leaves it empty
+func RemoveTrailer(message string, trailer Trailer) string {
+	lines := splitMessage(message)
+	start := trailerBlockStart(lines)
+	result := lines[:start]
+	for _, line := range lines[start:] {
+		if !trailer.matches(line) {
+			result = append(result, line)
+		}
+	}
+	return strings.TrimRight(strings.Join(result, "\n"), "\n ")
+}
+
+// GetSignOffTrailer returns the 'Signed-off-by' trailer that 'git commit -s'
+// would add
+func (c *GitCommand) GetSignOffTrailer() (Trailer, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git var GIT_COMMITTER_IDENT")
+	if err != nil {
+		return Trailer{}, err
+	}
+	// the ident ends with a timestamp and timezone, which we don't want
+	fields := strings.Fields(output)
+	if len(fields) > 2 {
+		fields = fields[:len(fields)-2]
+	}
+	return Trailer{Key: "Signed-off-by", Value: strings.Join(fields, " ")}, nil
+}
+
+// AddTrailerToCommit adds a trailer to the message of the commit at the given
+// index, rewording it straight away if it's HEAD and rebasing otherwise
+func (c *GitCommand) AddTrailerToCommit(commits []*Commit, index int, trailer Trailer) error {
+	if index == 0 {
+		cmdStr := "git log -1 --format=%B"
+		message, err := c.OSCommand.RunCommandWithOutput(cmdStr)
+		if err != nil {
+			return err
+		}
+		if HasTrailer(message, trailer) {
+			return nil
+		}
+		return c.RenameCommit(AddTrailer(message, trailer))
+	}
+
+	command := fmt.Sprintf(
+		"git log -1 --format=%%B | git interpret-trailers --if-exists addIfDifferent --trailer %s | git commit --allow-empty --amend --no-verify -F -",
+		c.OSCommand.Quote(trailer.String()),
+	)
+	return c.RebaseWithExec(commits, index, command, false)
+}
+
+// GetConfiguredTrailers returns the trailers the user has added to the
+// trailers menu in their config
+func (c *GitCommand) GetConfiguredTrailers() []Trailer {
+	trailers := []Trailer{}
+	for _, str := range c.Config.GetUserConfig().GetStringSlice("git.commit.trailers") {
+		if trailer := ParseTrailer(str); trailer.Key != "" {
+			trailers = append(trailers, trailer)
+		}
+	}
+	return trailers
+}
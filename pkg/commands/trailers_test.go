@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseTrailer is a function.
+func TestParseTrailer(t *testing.T) {
+	assert.EqualValues(t, Trailer{Key: "Fixes", Value: "#123"}, ParseTrailer("Fixes: #123"))
+	assert.EqualValues(t, Trailer{Key: "Reviewed-by"}, ParseTrailer("Reviewed-by: "))
+	assert.EqualValues(t, Trailer{Key: "Acked-by"}, ParseTrailer("Acked-by"))
+}
+
+// TestAddTrailer is a function.
+func TestAddTrailer(t *testing.T) {
+	type scenario struct {
+		testName string
+		message  string
+		expected string
+	}
+
+	trailer := Trailer{Key: "Signed-off-by", Value: "Jesse <jesse@example.com>"}
+
+	scenarios := []scenario{
+		{
+			"subject only",
+			"Fix typo",
+			"Fix typo\n\nSigned-off-by: Jesse <jesse@example.com>",
+		},
+		{
+			"body without trailers",
+			"Fix typo\n\nIt was bugging me\n",
+			"Fix typo\n\nIt was bugging me\n\nSigned-off-by: Jesse <jesse@example.com>",
+		},
+		{
+			"existing trailers",
+			"Fix typo\n\nFixes: #123",
+			"Fix typo\n\nFixes: #123\nSigned-off-by: Jesse <jesse@example.com>",
+		},
+		{
+			"body that looks like a trailer straight after the subject",
+			"Fix typo\nNote: not a trailer",
+			"Fix typo\nNote: not a trailer\n\nSigned-off-by: Jesse <jesse@example.com>",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, AddTrailer(s.message, trailer))
+		})
+	}
+}
+
+// TestRemoveTrailer is a function.
+func TestRemoveTrailer(t *testing.T) {
+	message := "Fix typo\n\nFixes: #123\nReviewed-by: Someone <someone@example.com>"
+
+	assert.True(t, HasTrailer(message, Trailer{Key: "Reviewed-by"}))
+	assert.False(t, HasTrailer(message, Trailer{Key: "Signed-off-by"}))
+	assert.EqualValues(t, "Fix typo\n\nFixes: #123", RemoveTrailer(message, Trailer{Key: "Reviewed-by"}))
+	assert.EqualValues(t, "Fix typo", RemoveTrailer(RemoveTrailer(message, Trailer{Key: "Reviewed-by"}), Trailer{Key: "fixes", Value: "#123"}))
+	assert.EqualValues(t, message, RemoveTrailer(message, Trailer{Key: "Fixes", Value: "#456"}))
+}
+
+// TestGitCommandGetSignOffTrailer is a function.
+func TestGitCommandGetSignOffTrailer(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git var GIT_COMMITTER_IDENT",
+			Replace: "echo Jesse Duffield <jesse@example.com> 1600000000 +1000",
+		},
+	})
+
+	trailer, err := gitCmd.GetSignOffTrailer()
+	assert.NoError(t, err)
+	assert.EqualValues(t, Trailer{Key: "Signed-off-by", Value: "Jesse Duffield <jesse@example.com>"}, trailer)
+}
+
+// TestGitCommandAddTrailerToHeadCommit is a function.
+func TestGitCommandAddTrailerToHeadCommit(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git log -1 --format=%B",
+			Replace: "echo 'Fix typo\n'",
+		},
+		{
+			Expect:  "git commit --allow-empty --amend -m \"Fix typo\n\nFixes: #123\"",
+			Replace: "echo",
+		},
+	})
+
+	commits := []*Commit{{Sha: "abc123", Name: "Fix typo"}}
+	assert.NoError(t, gitCmd.AddTrailerToCommit(commits, 0, Trailer{Key: "Fixes", Value: "#123"}))
+}
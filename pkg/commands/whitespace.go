@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"strings"
+)
+
+// MarkWhitespaceOnlyFiles sets WhitespaceOnly on the files whose unstaged
+// changes only add or remove whitespace and blank lines, like the ones an
+// autoformatter leaves all over the place
+func (c *GitCommand) MarkWhitespaceOnlyFiles(files []*File) error {
+	candidates := map[string]*File{}
+	fileNames := []string{}
+	for _, file := range files {
+		file.WhitespaceOnly = false
+		if !file.Tracked || len(file.ShortStatus) < 2 || file.ShortStatus[1] != 'M' {
+			continue
+		}
+		split := strings.Split(file.Name, " -> ") // in case of a renamed file we get the new filename
+		fileName := split[len(split)-1]
+		candidates[fileName] = file
+		fileNames = append(fileNames, fileName)
+	}
+	if len(fileNames) == 0 {
+		return nil
+	}
+
+	// a file only counts as whitespace-only if git reports it as changed
+	// without -w and leaves it out with -w. Anything we can't match up stays
+	// unmarked, so that discarding whitespace-only changes never touches it
+	quotedFileNames := c.quoteFileNames(fileNames)
+	output, err := c.OSCommand.RunCommandWithOutput("git diff -z --name-only -- %s", quotedFileNames)
+	if err != nil {
+		return err
+	}
+	changed := map[string]bool{}
+	for _, name := range strings.Split(output, "\x00") {
+		if name != "" {
+			changed[name] = true
+		}
+	}
+
+	output, err = c.OSCommand.RunCommandWithOutput("git diff -w --ignore-blank-lines -z --numstat -- %s", quotedFileNames)
+	if err != nil {
+		return err
+	}
+	changedIgnoringWhitespace := parseNumstatPaths(output)
+
+	for fileName, file := range candidates {
+		file.WhitespaceOnly = changed[fileName] && !changedIgnoringWhitespace[fileName]
+	}
+	return nil
+}
+
+// parseNumstatPaths returns the paths in the output of `git diff -z --numstat`.
+// Each entry is `added\tdeleted\tpath\0`, or for a rename
+// `added\tdeleted\t\0oldpath\0newpath\0`, in which case both paths are included
+func parseNumstatPaths(output string) map[string]bool {
+	paths := map[string]bool{}
+	records := strings.Split(output, "\x00")
+	for i := 0; i < len(records); i++ {
+		fields := strings.SplitN(records[i], "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		if fields[2] != "" {
+			paths[fields[2]] = true
+			continue
+		}
+		for j := 0; j < 2 && i+1 < len(records); j++ {
+			i++
+			paths[records[i]] = true
+		}
+	}
+	return paths
+}
+
+// DiscardUnstagedFilesChanges discards the unstaged changes to several files
+// at once
+func (c *GitCommand) DiscardUnstagedFilesChanges(fileNames []string) error {
+	return c.OSCommand.RunCommand("git checkout -- %s", c.quoteFileNames(fileNames))
+}
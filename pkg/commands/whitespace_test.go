@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandMarkWhitespaceOnlyFiles is a function.
+func TestGitCommandMarkWhitespaceOnlyFiles(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git diff -z --name-only -- "formatted.go" "real.go" "café.go" "unmatched.go"`, Replace: `printf "formatted.go\\0real.go\\0café.go\\0"`},
+		{Expect: `git diff -w --ignore-blank-lines -z --numstat -- "formatted.go" "real.go" "café.go" "unmatched.go"`, Replace: `printf "1\\t1\\treal.go\\0"`},
+	})
+
+	files := []*File{
+		{Name: "formatted.go", Tracked: true, ShortStatus: " M"},
+		{Name: "real.go", Tracked: true, ShortStatus: "MM"},
+		{Name: "staged.go", Tracked: true, ShortStatus: "M ", WhitespaceOnly: true},
+		{Name: "new.go", Tracked: false, ShortStatus: "??"},
+		{Name: "café.go", Tracked: true, ShortStatus: " M"},
+		{Name: "unmatched.go", Tracked: true, ShortStatus: " M"},
+	}
+
+	assert.NoError(t, gitCmd.MarkWhitespaceOnlyFiles(files))
+	assert.True(t, files[0].WhitespaceOnly)
+	assert.False(t, files[1].WhitespaceOnly)
+	assert.False(t, files[2].WhitespaceOnly)
+	assert.False(t, files[3].WhitespaceOnly)
+	assert.True(t, files[4].WhitespaceOnly)
+	assert.False(t, files[5].WhitespaceOnly)
+}
+
+// TestParseNumstatPaths is a function.
+func TestParseNumstatPaths(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		expected map[string]bool
+	}
+
+	scenarios := []scenario{
+		{
+			"empty",
+			"",
+			map[string]bool{},
+		},
+		{
+			"plain and non-ascii paths",
+			"1\t1\ta.go\x002\t0\tcafé.txt\x00",
+			map[string]bool{"a.go": true, "café.txt": true},
+		},
+		{
+			"rename",
+			"1\t1\t\x00old.go\x00new.go\x003\t0\tb.go\x00",
+			map[string]bool{"old.go": true, "new.go": true, "b.go": true},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, parseNumstatPaths(s.output))
+		})
+	}
+}
+
+// TestGitCommandMarkWhitespaceOnlyFilesNoCandidates is a function.
+func TestGitCommandMarkWhitespaceOnlyFilesNoCandidates(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{})
+
+	assert.NoError(t, gitCmd.MarkWhitespaceOnlyFiles([]*File{{Name: "new.go", ShortStatus: "??"}}))
+}
+
+// TestGitCommandDiscardUnstagedFilesChanges is a function.
+func TestGitCommandDiscardUnstagedFilesChanges(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{Expect: `git checkout -- "a.go" "b.go"`, Replace: "echo"},
+	})
+
+	assert.NoError(t, gitCmd.DiscardUnstagedFilesChanges([]string{"a.go", "b.go"}))
+}
@@ -23,6 +23,13 @@ type AppConfig struct {
 	UserConfigDir string
 	AppState      *AppState
 	IsNewRepo     bool
+	RepoContext   RepoContext
+	// Resolve is set when we're started with --resolve, to go straight to
+	// resolving conflicts, starting with ResolveFile if there is one
+	Resolve     bool
+	ResolveFile string
+	// ReadOnly is set when we're started with --read-only
+	ReadOnly bool
 }
 
 // AppConfigurer interface allows individual app config structs to inherit Fields
@@ -36,12 +43,24 @@ type AppConfigurer interface {
 	GetBuildSource() string
 	GetUserConfig() *viper.Viper
 	GetUserConfigDir() string
+	GetUserConfigPath() string
+	LoadUserConfig() (*viper.Viper, error)
+	SetUserConfig(*viper.Viper)
+	SetRepoContext(RepoContext) error
+	GetRepoConfigPath() string
+	GetRepoConfigTrust() RepoConfigTrust
+	TrustRepoConfig() error
+	DenyRepoConfig() error
+	ForgetRepoConfig(string) error
 	GetAppState() *AppState
 	WriteToUserConfig(string, interface{}) error
 	SaveAppState() error
 	LoadAppState() error
 	SetIsNewRepo(bool)
 	GetIsNewRepo() bool
+	GetResolve() bool
+	GetResolveFile() string
+	GetReadOnly() bool
 }
 
 // NewAppConfig makes a new app config
@@ -51,6 +70,17 @@ func NewAppConfig(name, version, commit, date string, buildSource string, debugg
 		return nil, err
 	}
 
+	// we don't know the repo's remotes yet, but we can at least match on the path
+	// until SetRepoContext is called
+	repoPath, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	repoContext := RepoContext{Path: repoPath}
+	if err := applyConfigLayers(userConfig, filepath.Dir(userConfigPath), repoContext); err != nil {
+		return nil, err
+	}
+
 	if os.Getenv("DEBUG") == "TRUE" {
 		debuggingFlag = true
 	}
@@ -66,6 +96,7 @@ func NewAppConfig(name, version, commit, date string, buildSource string, debugg
 		UserConfigDir: filepath.Dir(userConfigPath),
 		AppState:      &AppState{},
 		IsNewRepo:     false,
+		RepoContext:   repoContext,
 	}
 
 	if err := appConfig.LoadAppState(); err != nil {
@@ -80,6 +111,23 @@ func (c *AppConfig) GetIsNewRepo() bool {
 	return c.IsNewRepo
 }
 
+// GetResolve returns whether we were started with --resolve
+func (c *AppConfig) GetResolve() bool {
+	return c.Resolve
+}
+
+// GetResolveFile returns the file that --resolve was given, if any
+func (c *AppConfig) GetResolveFile() string {
+	return c.ResolveFile
+}
+
+// GetReadOnly returns whether we're in read-only mode, either because we were
+// started with --read-only or because the config says so, e.g. for a
+// particular repo
+func (c *AppConfig) GetReadOnly() bool {
+	return c.ReadOnly || c.UserConfig.GetBool("readOnly")
+}
+
 // SetIsNewRepo set if the current repo is known
 func (c *AppConfig) SetIsNewRepo(toSet bool) {
 	c.IsNewRepo = toSet
@@ -130,6 +178,88 @@ func (c *AppConfig) GetUserConfigDir() string {
 	return c.UserConfigDir
 }
 
+// GetUserConfigPath returns the path of the user's config file
+func (c *AppConfig) GetUserConfigPath() string {
+	return filepath.Join(c.UserConfigDir, "config.yml")
+}
+
+// LoadUserConfig reads the user's config (along with the defaults) fresh from
+// disk, without replacing the config currently in use. This lets us validate a
+// changed config before we switch over to it
+func (c *AppConfig) LoadUserConfig() (*viper.Viper, error) {
+	userConfig, _, err := LoadConfig("config", true)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyConfigLayers(userConfig, c.UserConfigDir, c.RepoContext); err != nil {
+		return nil, err
+	}
+	if err := c.AppState.applyTrustedRepoConfig(userConfig, c.GetRepoConfigPath()); err != nil {
+		return nil, err
+	}
+	return userConfig, nil
+}
+
+// SetRepoContext tells us which repo we're in and reloads the user config so
+// that any conditional config sections matching the repo take effect
+func (c *AppConfig) SetRepoContext(repoContext RepoContext) error {
+	c.RepoContext = repoContext
+	userConfig, err := c.LoadUserConfig()
+	if err != nil {
+		return err
+	}
+	c.UserConfig = userConfig
+	return nil
+}
+
+// GetRepoConfigPath returns where the current repo's own config file would be
+func (c *AppConfig) GetRepoConfigPath() string {
+	return filepath.Join(c.RepoContext.Path, RepoConfigFileName)
+}
+
+// GetRepoConfigTrust tells us whether the user has said we can load the
+// current repo's own config file
+func (c *AppConfig) GetRepoConfigTrust() RepoConfigTrust {
+	return c.AppState.repoConfigTrust(c.GetRepoConfigPath())
+}
+
+// TrustRepoConfig remembers that the user trusts the current repo's own config
+// file as it is now. It's loaded along with the user's config until it changes
+func (c *AppConfig) TrustRepoConfig() error {
+	path := c.GetRepoConfigPath()
+	hash, err := hashRepoConfig(path)
+	if err != nil {
+		return err
+	}
+	c.AppState.forgetRepoConfig(path)
+	if c.AppState.TrustedRepoConfigHashes == nil {
+		c.AppState.TrustedRepoConfigHashes = map[string]string{}
+	}
+	c.AppState.TrustedRepoConfigHashes[path] = hash
+	return c.SaveAppState()
+}
+
+// DenyRepoConfig remembers that the user never wants the current repo's own
+// config file loaded, so that we stop asking
+func (c *AppConfig) DenyRepoConfig() error {
+	path := c.GetRepoConfigPath()
+	c.AppState.forgetRepoConfig(path)
+	c.AppState.DeniedRepoConfigs = append(c.AppState.DeniedRepoConfigs, path)
+	return c.SaveAppState()
+}
+
+// ForgetRepoConfig revokes whatever the user said about the given repo config
+// file, so that we ask again next time
+func (c *AppConfig) ForgetRepoConfig(path string) error {
+	c.AppState.forgetRepoConfig(path)
+	return c.SaveAppState()
+}
+
+// SetUserConfig replaces the user config in use
+func (c *AppConfig) SetUserConfig(userConfig *viper.Viper) {
+	c.UserConfig = userConfig
+}
+
 func newViper(filename string) (*viper.Viper, error) {
 	v := viper.New()
 	v.SetConfigType("yaml")
@@ -242,11 +372,16 @@ func GetDefaultConfig() []byte {
   ## stuff relating to the UI
   scrollHeight: 2
   scrollPastBottom: true
+  scrollOffMargin: 2
+  pageScroll: full
+  wrapListSelection: false
   mouseEvents: true
   skipUnstageLineWarning: false
+  backupDiscardedFiles: false
   sidePanelWidth: 0.3333
   theme:
     lightTheme: false
+    palette: default # one of 'default' | 'deuteranopia' | 'protanopia' | 'highContrast'
     activeBorderColor:
       - green
       - bold
@@ -258,20 +393,64 @@ func GetDefaultConfig() []byte {
       - blue
   commitLength:
     show: true
+  showCommitStats: false
+  showCommitDecorations: true
+  hugeDiffLines: 5000
+  markWhitespaceOnlyFiles: false
+  showLastRefreshed: false
+  lowBandwidthMode: false # one of true | false | 'ssh'
+  hintsBar:
+    show: false
+    density: normal # one of 'compact' | 'normal' | 'full'
 git:
   paging:
     colorArg: always
     useConfig: false
   merging:
     manualCommit: false
+  rebase:
+    updateRefs: false
   skipHookPrefix: 'WIP'
+  commit:
+    trailers: []
+    pairs: []
+    subjectFromBranch:
+      pattern: ''
+      template: ''
   autoFetch: true
+  autoFetchInterval: 60
+  remotes: []
+  formatters: []
+  env: []
+  pushAfterCommit: []
+  retry:
+    attempts: 3
+    delay: 2
+  baseBranches: ['main', 'master']
+  largeFiles:
+    threshold: 50
+    patterns: []
+  secretScanning:
+    enabled: false
+    command: ''
+  autoSave:
+    interval: 0
+    keep: 20
+  writeCommitGraph: auto # one of 'auto' | 'never'
+  detectExternalChanges: true
+  flow:
+    enabled: auto # one of 'auto' | true | false
+    trunkBased: false
 update:
   method: prompt # can be: prompt | background | never
   days: 14 # how often a update is checked for
 reporting: 'undetermined' # one of: 'on' | 'off' | 'undetermined'
 splashUpdatesIndex: 0
 confirmOnQuit: false
+readOnly: false
+customCommandOutput: terminal # one of 'terminal' | 'popup' | 'main' | 'log' | 'none' | 'ask'
+projectCommands: []
+serviceConnections: []
 keybinding:
   universal:
     quit: 'q'
@@ -283,6 +462,8 @@ keybinding:
     nextItem: '<down>'
     prevItem-alt: 'k'
     nextItem-alt: 'j'
+    prevPage: ','
+    nextPage: '.'
     prevBlock: '<left>'
     nextBlock: '<right>'
     prevBlock-alt: 'h'
@@ -290,6 +471,7 @@ keybinding:
     nextMatch: 'n'
     prevMatch: 'N'
     startSearch: '/'
+    searchMain: '<c-/>'
     optionMenu: 'x'
     optionMenu-alt1: '?'
     select: '<space>'
@@ -304,11 +486,26 @@ keybinding:
     scrollDownMain-alt1: 'J'
     scrollUpMain-alt2: '<c-u>'
     scrollDownMain-alt2: '<c-d>'
+    prevFileInMain: '{'
+    nextFileInMain: '}'
+    prevHunkInMain: '('
+    nextHunkInMain: ')'
+    jumpToFileInMain: '<c-g>'
+    toggleMarkInMain: '<c-b>'
+    jumpToMarkInMain: "'"
+    toggleFoldInMain: '='
+    foldAllInMain: '<c-o>'
+    unfoldAllInMain: '<c-e>'
+    toggleWrapInMain: '<c-y>'
+    compareRefs: '<c-t>'
     executeCustomCommand: ':'
+    viewCustomCommandLog: '<c-l>'
+    viewProjectCommands: '!'
     createRebaseOptionsMenu: 'm'
     pushFiles: 'P'
     pullFiles: 'p'
     refresh: 'R'
+    forceRefresh: '<c-r>'
     createPatchOptionsMenu: '<c-p>'
     nextTab: ']'
     prevTab: '['
@@ -317,6 +514,19 @@ keybinding:
   status:
     checkForUpdate: 'u'
     recentRepos: '<enter>'
+    showKeybindingReport: 'v'
+    toggleTutorial: 'T'
+    toggleSandbox: 's'
+    toggleSparseIndex: 'i'
+    testSigning: 'g'
+    toggleOfflineMode: 'O'
+    viewStatusSettings: 'S'
+    createSnapshot: 'n'
+    viewSnapshots: 'N'
+    viewAutoSaves: 'b'
+    switchPairingDriver: 'D'
+    viewRepoConfigTrust: 't'
+    toggleGitTrace: 'G'
   files:
     commitChanges: 'c'
     commitChangesWithoutHook: 'w'
@@ -329,6 +539,15 @@ keybinding:
     toggleStagedAll: 'a'
     viewResetOptions: 'D'
     fetch: 'f'
+    restoreDiscardedFile: 'B'
+    filterFiles: 'F'
+    stageByPattern: '*'
+    viewDirectoryOptions: 'L'
+    viewIntroducedTodos: 'T'
+    diffAgainstRef: 'W'
+    viewWhitespaceOptions: 'E'
+    formatAndStage: 'O'
+    viewSubmodulePins: 'u'
   branches:
     createPullRequest: 'o'
     checkoutBranchByName: 'c'
@@ -337,14 +556,20 @@ keybinding:
     renameBranch: 'R'
     mergeIntoCurrentBranch: 'M'
     viewGitFlowOptions: 'i'
+    viewStackOptions: 'S'
     fastForward: 'f'
     pushTag: 'P'
     setUpstream: 'u'
     fetchRemote: 'f'
+    reviewInWorktree: 'w'
+    squashBranch: 's'
+    viewCommitsAheadOfBase: 'b'
+    viewAncestryOptions: 'y'
   commits:
     squashDown: 's'
     renameCommit: 'r'
     renameCommitWithEditor: 'R'
+    rewordCommits: 'W'
     viewResetOptions: 'g'
     markCommitAsFixup: 'f'
     createFixupCommit: 'F'
@@ -361,30 +586,79 @@ keybinding:
     toggleDiffCommit: 'i'
     checkoutCommit: '<space>'
     resetCherryPick: '<c-R>'
+    openInBrowser: 'o'
+    filterCommits: '<c-f>'
+    filterByAuthor: 'a'
+    addTrailer: 'w'
+    exportPatches: 'X'
+    applyMailbox: 'M'
+    insertRebaseStep: 'b'
+    viewAuthorOptions: 'U'
+    viewAncestryOptions: 'y'
+    viewLogSettings: 'L'
+    runCommandAtCommit: 'E'
+    bisectRun: 'B'
+    planRebase: 'I'
+  reflogCommits:
+    filterReflog: 'F'
   stash:
     popStash: 'g'
+    viewLostCommits: 'L'
   commitFiles:
     checkoutCommitFile: 'c'
+    checkoutCommitFileIntoWorktree: 'C'
+    viewSubmodulePins: 'u'
+    filterByPath: '<c-f>'
+  commitMessage:
+    trailersMenu: '<c-t>'
+    commitAuthorship: '<c-o>'
+    commitAndPush: '<c-s>'
+    toggleWrap: '<c-y>'
+  customCommandOutput:
+    cancel: '<c-c>'
+  rebasePlan:
+    pick: 'p'
+    squash: 's'
+    fixup: 'f'
+    edit: 'e'
+    drop: 'd'
+    reword: 'r'
+    moveDownStep: '<c-j>'
+    moveUpStep: '<c-k>'
+    startRebase: '<enter>'
   main:
     toggleDragSelect: 'v'
     toggleDragSelect-alt: 'V'
     toggleSelectHunk: 'a'
+    editHunk: 'E'
     pickBothHunks: 'b'
     undo: 'z'
 `)
 }
 
 // AppState stores data between runs of the app like when the last update check
-// was performed and which other repos have been checked out
+// was performed, which other repos have been checked out and who's driving
 type AppState struct {
 	LastUpdateCheck int64
 	RecentRepos     []string
+	PairingDriver   string
+	// LineWrap is whether long lines are wrapped, by view
+	LineWrap map[string]bool
+	// TrustedRepoConfigHashes maps the repo config files the user trusts to a
+	// hash of what they trusted, so that we ask again when one changes
+	TrustedRepoConfigHashes map[string]string
+	// DeniedRepoConfigs are the repo config files the user never wants loaded
+	DeniedRepoConfigs []string
 }
 
 func getDefaultAppState() []byte {
 	return []byte(`
     lastUpdateCheck: 0
     recentRepos: []
+    pairingDriver: ''
+    lineWrap: {}
+    trustedRepoConfigHashes: {}
+    deniedRepoConfigs: []
   `)
 }
 
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Beyond the main config file, the user can layer in extra config in two ways:
+//
+//   includes:
+//     - work.yml # relative to the config dir
+//   conditionalConfigs:
+//     - repoPath: '~/work/**' # glob
+//       remoteUrl: 'github\.com[:/]acme/' # regex
+//       include: work.yml # and/or inline config:
+//       config:
+//         git:
+//           autoFetch: false
+//
+// Layers are merged in the order they're listed, includes first, with later
+// layers winning. A conditional section applies when all of its conditions
+// match the current repo.
+
+// RepoContext describes the repo we're in, for the sake of matching
+// conditional config sections
+type RepoContext struct {
+	Path       string
+	RemoteURLs []string
+}
+
+func applyConfigLayers(v *viper.Viper, configDir string, repoContext RepoContext) error {
+	for _, includePath := range v.GetStringSlice("includes") {
+		if err := mergeConfigFile(v, resolveConfigPath(configDir, includePath)); err != nil {
+			return err
+		}
+	}
+
+	conditionalConfigs, err := getConditionalConfigs(v)
+	if err != nil {
+		return err
+	}
+
+	for _, conditionalConfig := range conditionalConfigs {
+		matches, err := conditionalConfig.matches(repoContext)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			continue
+		}
+
+		if conditionalConfig.Include != "" {
+			if err := mergeConfigFile(v, resolveConfigPath(configDir, conditionalConfig.Include)); err != nil {
+				return err
+			}
+		}
+
+		if conditionalConfig.Config != nil {
+			// going via yaml so that we don't have to worry about the nested
+			// map types that the yaml parser gives us
+			inlineConfigYaml, err := yaml.Marshal(conditionalConfig.Config)
+			if err != nil {
+				return err
+			}
+			if err := v.MergeConfig(bytes.NewReader(inlineConfigYaml)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type conditionalConfig struct {
+	RepoPath  string      `yaml:"repoPath"`
+	RemoteURL string      `yaml:"remoteUrl"`
+	Include   string      `yaml:"include"`
+	Config    interface{} `yaml:"config"`
+}
+
+func getConditionalConfigs(v *viper.Viper) ([]conditionalConfig, error) {
+	rawConditionalConfigs := v.Get("conditionalConfigs")
+	if rawConditionalConfigs == nil {
+		return nil, nil
+	}
+
+	conditionalConfigsYaml, err := yaml.Marshal(rawConditionalConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	conditionalConfigs := []conditionalConfig{}
+	if err := yaml.Unmarshal(conditionalConfigsYaml, &conditionalConfigs); err != nil {
+		return nil, err
+	}
+	return conditionalConfigs, nil
+}
+
+func (c conditionalConfig) matches(repoContext RepoContext) (bool, error) {
+	if c.RepoPath == "" && c.RemoteURL == "" {
+		return false, errors.New("conditional config sections need a 'repoPath' or 'remoteUrl' condition")
+	}
+
+	if c.RepoPath != "" && !utils.MatchesGlob(expandHomeDir(c.RepoPath), repoContext.Path) {
+		return false, nil
+	}
+
+	if c.RemoteURL != "" {
+		remoteURLRegex, err := regexp.Compile(c.RemoteURL)
+		if err != nil {
+			return false, err
+		}
+		for _, remoteURL := range repoContext.RemoteURLs {
+			if remoteURLRegex.MatchString(remoteURL) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func mergeConfigFile(v *viper.Viper, path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := v.MergeConfig(bytes.NewReader(content)); err != nil {
+		return errors.New(path + ": " + err.Error())
+	}
+	return nil
+}
+
+func resolveConfigPath(configDir string, path string) string {
+	path = expandHomeDir(path)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(configDir, path)
+}
+
+func expandHomeDir(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return homeDir + strings.TrimPrefix(path, "~")
+}
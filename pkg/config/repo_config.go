@@ -0,0 +1,179 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// A repo can ship its own config in a .lazygit.yml at its root, so that a team
+// can share the project's commands and keybindings alongside the code:
+//
+//   projectCommands:
+//     - name: test
+//       command: 'make test'
+//   keybinding:
+//     projectCommands:
+//       test: '<c-t>'
+//
+// The project commands run whatever the repo says, so we only load the file
+// once the user has said they trust it. Like direnv, we trust the file as it
+// was when they said so, and ask again once it changes. Only projectCommands
+// and keybinding.projectCommands are read from it, so it can't rebind
+// lazygit's own keys, and its project commands are added to the user's own
+// rather than replacing them.
+
+// RepoConfigFileName is the name of a repo's own config file, at its root
+const RepoConfigFileName = ".lazygit.yml"
+
+// RepoConfigTrust is what the user has said about loading a repo config file
+type RepoConfigTrust int
+
+const (
+	// RepoConfigUnknown means we've not asked about the file yet
+	RepoConfigUnknown RepoConfigTrust = iota
+	RepoConfigTrusted
+	// RepoConfigChanged means the file was trusted but it's changed since
+	RepoConfigChanged
+	// RepoConfigDenied means the user never wants the file loaded
+	RepoConfigDenied
+)
+
+func (s *AppState) repoConfigTrust(path string) RepoConfigTrust {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		content = nil
+	}
+	return s.repoConfigContentTrust(path, content)
+}
+
+// repoConfigContentTrust is repoConfigTrust for content we've already read
+// from the file, so that what we check is what we load
+func (s *AppState) repoConfigContentTrust(path string, content []byte) RepoConfigTrust {
+	for _, deniedPath := range s.DeniedRepoConfigs {
+		if deniedPath == path {
+			return RepoConfigDenied
+		}
+	}
+
+	trustedHash, ok := s.TrustedRepoConfigHashes[path]
+	if !ok {
+		return RepoConfigUnknown
+	}
+	if content == nil || hashRepoConfigContent(content) != trustedHash {
+		return RepoConfigChanged
+	}
+	return RepoConfigTrusted
+}
+
+func (s *AppState) forgetRepoConfig(path string) {
+	delete(s.TrustedRepoConfigHashes, path)
+	deniedRepoConfigs := []string{}
+	for _, deniedPath := range s.DeniedRepoConfigs {
+		if deniedPath != path {
+			deniedRepoConfigs = append(deniedRepoConfigs, deniedPath)
+		}
+	}
+	s.DeniedRepoConfigs = deniedRepoConfigs
+}
+
+func hashRepoConfig(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return hashRepoConfigContent(content), nil
+}
+
+func hashRepoConfigContent(content []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
+type repoConfig struct {
+	ProjectCommands []interface{} `yaml:"projectCommands"`
+	Keybinding      struct {
+		ProjectCommands map[string]interface{} `yaml:"projectCommands"`
+	} `yaml:"keybinding"`
+}
+
+// applyTrustedRepoConfig loads the repo config file at path into v if the
+// user trusts it as it is. The file is only read once, so the content we
+// check against the trusted hash is the content we load.
+func (s *AppState) applyTrustedRepoConfig(v *viper.Viper, path string) error {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if s.repoConfigContentTrust(path, content) != RepoConfigTrusted {
+		return nil
+	}
+	return applyRepoConfig(v, path, content)
+}
+
+func applyRepoConfig(v *viper.Viper, path string, content []byte) error {
+	config := repoConfig{}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return errors.New(path + ": " + err.Error())
+	}
+
+	if len(config.Keybinding.ProjectCommands) > 0 {
+		keybindingYaml, err := yaml.Marshal(map[string]interface{}{
+			"keybinding": map[string]interface{}{"projectCommands": config.Keybinding.ProjectCommands},
+		})
+		if err != nil {
+			return err
+		}
+		if err := v.MergeConfig(bytes.NewReader(keybindingYaml)); err != nil {
+			return errors.New(path + ": " + err.Error())
+		}
+	}
+
+	if len(config.ProjectCommands) > 0 {
+		projectCommands, _ := v.Get("projectCommands").([]interface{})
+		v.Set("projectCommands", appendNewProjectCommands(projectCommands, config.ProjectCommands))
+	}
+
+	return nil
+}
+
+// appendNewProjectCommands adds the repo's project commands to the ones we
+// have, leaving out any with the name, or for unnamed ones the command, of
+// one we've already got
+func appendNewProjectCommands(projectCommands []interface{}, repoProjectCommands []interface{}) []interface{} {
+	seen := map[string]bool{}
+	for _, projectCommand := range projectCommands {
+		seen[projectCommandKey(projectCommand)] = true
+	}
+	for _, projectCommand := range repoProjectCommands {
+		key := projectCommandKey(projectCommand)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		projectCommands = append(projectCommands, projectCommand)
+	}
+	return projectCommands
+}
+
+func projectCommandKey(projectCommand interface{}) string {
+	var name, command interface{}
+	switch fields := projectCommand.(type) {
+	case map[interface{}]interface{}:
+		name, command = fields["name"], fields["command"]
+	case map[string]interface{}:
+		name, command = fields["name"], fields["command"]
+	}
+	if name != nil && name != "" {
+		return fmt.Sprintf("name:%v", name)
+	}
+	return fmt.Sprintf("command:%v", command)
+}
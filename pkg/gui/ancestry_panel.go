@@ -0,0 +1,90 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+func (gui *Gui) handleCommitAncestryOptions(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedCommit(g)
+	if commit == nil {
+		return nil
+	}
+	return gui.createAncestryMenu(v, commit.Sha, commit.ShortSha())
+}
+
+func (gui *Gui) handleBranchAncestryOptions(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	return gui.createAncestryMenu(v, branch.Name, branch.Name)
+}
+
+// createAncestryMenu answers questions about where ref is in history compared
+// to another ref that we ask for, e.g. whether a fix has made it into a release
+func (gui *Gui) createAncestryMenu(v *gocui.View, ref string, name string) error {
+	// the menu closes once we return, so the prompt has to wait until then
+	askForRef := func(titleId string, onPick func(string) error) func() error {
+		return func() error {
+			gui.g.Update(func(*gocui.Gui) error {
+				return gui.pickRef(v, gui.Tr.TemplateLocalize(titleId, Teml{"ref": name}), onPick)
+			})
+			return nil
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.TemplateLocalize("IsAncestorOf", Teml{"ref": name}),
+			onPress: askForRef("IsAncestorOfPrompt", func(other string) error {
+				return gui.showIsAncestor(v, ref, name, other, other)
+			}),
+		},
+		{
+			displayString: gui.Tr.TemplateLocalize("HasAncestor", Teml{"ref": name}),
+			onPress: askForRef("HasAncestorPrompt", func(other string) error {
+				return gui.showIsAncestor(v, other, other, ref, name)
+			}),
+		},
+		{
+			displayString: gui.Tr.TemplateLocalize("MergeBaseWith", Teml{"ref": name}),
+			onPress: askForRef("MergeBaseWithPrompt", func(other string) error {
+				return gui.showMergeBase(v, ref, name, other)
+			}),
+		},
+	}
+
+	return gui.createMenu(gui.Tr.TemplateLocalize("AncestryTitle", Teml{"ref": name}), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) showIsAncestor(v *gocui.View, ancestor string, ancestorName string, descendant string, descendantName string) error {
+	isAncestor, err := gui.GitCommand.IsAncestor(ancestor, descendant)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	answerId := "IsNotAncestor"
+	if isAncestor {
+		answerId = "IsAncestor"
+	}
+	answer := gui.Tr.TemplateLocalize(answerId, Teml{"ancestor": ancestorName, "descendant": descendantName})
+	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("AncestryAnswerTitle"), answer, nil, nil)
+}
+
+func (gui *Gui) showMergeBase(v *gocui.View, ref string, name string, other string) error {
+	mergeBase, err := gui.GitCommand.GetMergeBaseOf(ref, other)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	answer := gui.Tr.TemplateLocalize("NoMergeBase", Teml{"ref": name, "otherRef": other})
+	if mergeBase != nil {
+		answer = gui.Tr.TemplateLocalize("MergeBaseIs", Teml{
+			"ref":      name,
+			"otherRef": other,
+			"sha":      mergeBase.Sha,
+			"name":     mergeBase.Name,
+		})
+	}
+	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("AncestryAnswerTitle"), answer, nil, nil)
+}
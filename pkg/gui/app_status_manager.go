@@ -15,6 +15,8 @@ type appStatus struct {
 
 type statusManager struct {
 	statuses []appStatus
+	// staticLoader is set in low bandwidth mode, where we don't animate
+	staticLoader bool
 }
 
 func (m *statusManager) removeStatus(name string) {
@@ -37,12 +39,25 @@ func (m *statusManager) addWaitingStatus(name string) {
 	m.statuses = append([]appStatus{newStatus}, m.statuses...)
 }
 
+func (m *statusManager) addToastStatus(name string) {
+	m.removeStatus(name)
+	newStatus := appStatus{
+		name:       name,
+		statusType: "toast",
+		duration:   0,
+	}
+	m.statuses = append([]appStatus{newStatus}, m.statuses...)
+}
+
 func (m *statusManager) getStatusString() string {
 	if len(m.statuses) == 0 {
 		return ""
 	}
 	topStatus := m.statuses[0]
 	if topStatus.statusType == "waiting" {
+		if m.staticLoader {
+			return topStatus.name + " ..."
+		}
 		return topStatus.name + " " + utils.Loader()
 	}
 	return topStatus.name
@@ -55,20 +70,28 @@ func (gui *Gui) WithWaitingStatus(name string, f func() error) error {
 
 		defer func() {
 			gui.statusManager.removeStatus(name)
+			if gui.statusManager.staticLoader {
+				// there's no ticker to take care of this for us
+				gui.renderString(gui.g, "appStatus", gui.statusManager.getStatusString())
+			}
 		}()
 
-		go func() {
-			ticker := time.NewTicker(time.Millisecond * 50)
-			defer ticker.Stop()
-			for range ticker.C {
-				appStatus := gui.statusManager.getStatusString()
-				gui.Log.Warn(appStatus)
-				if appStatus == "" {
-					return
+		if gui.statusManager.staticLoader {
+			gui.renderString(gui.g, "appStatus", gui.statusManager.getStatusString())
+		} else {
+			go func() {
+				ticker := time.NewTicker(time.Millisecond * 50)
+				defer ticker.Stop()
+				for range ticker.C {
+					appStatus := gui.statusManager.getStatusString()
+					gui.Log.Warn(appStatus)
+					if appStatus == "" {
+						return
+					}
+					gui.renderString(gui.g, "appStatus", appStatus)
 				}
-				gui.renderString(gui.g, "appStatus", appStatus)
-			}
-		}()
+			}()
+		}
 
 		if err := f(); err != nil {
 			gui.g.Update(func(g *gocui.Gui) error {
@@ -79,3 +102,16 @@ func (gui *Gui) WithWaitingStatus(name string, f func() error) error {
 
 	return nil
 }
+
+// raiseToast shows a message in the status bar for a few seconds, for things
+// worth mentioning that don't warrant a popup
+func (gui *Gui) raiseToast(message string) {
+	gui.statusManager.addToastStatus(message)
+	gui.renderString(gui.g, "appStatus", gui.statusManager.getStatusString())
+
+	go func() {
+		time.Sleep(time.Second * 3)
+		gui.statusManager.removeStatus(message)
+		gui.renderString(gui.g, "appStatus", gui.statusManager.getStatusString())
+	}()
+}
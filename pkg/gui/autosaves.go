@@ -0,0 +1,75 @@
+package gui
+
+import (
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// startAutoSaving saves the uncommitted changes every so often if the user has
+// asked us to, so that there's something to go back to after an accidental
+// discard or reset
+func (gui *Gui) startAutoSaving() {
+	userConfig := gui.Config.GetUserConfig()
+	interval := userConfig.GetInt("git.autoSave.interval")
+	if interval <= 0 || gui.OSCommand.ReadOnly() {
+		return
+	}
+
+	gui.goEvery(time.Duration(interval)*time.Minute, gui.stopChan, func() error {
+		if _, err := gui.GitCommand.CreateAutoSave(userConfig.GetInt("git.autoSave.keep")); err != nil {
+			// we're in the background so there's nobody to tell
+			gui.Log.Error(err)
+		}
+		return nil
+	})
+}
+
+func (gui *Gui) handleViewAutoSaves(g *gocui.Gui, v *gocui.View) error {
+	autoSaves, err := gui.GitCommand.GetAutoSaves()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if len(autoSaves) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoAutoSaves"))
+	}
+
+	menuItems := make([]*menuItem, len(autoSaves))
+	for i, autoSave := range autoSaves {
+		autoSave := autoSave
+		menuItems[i] = &menuItem{
+			displayStrings: []string{autoSave.Sha[:8], autoSave.Time().Format("2006-01-02 15:04:05")},
+			onPress: func() error {
+				gui.g.Update(func(*gocui.Gui) error {
+					return gui.createAutoSaveMenu(autoSave)
+				})
+				return nil
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("AutoSaves"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) createAutoSaveMenu(autoSave *commands.AutoSave) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("RestoreAutoSave"),
+			onPress: func() error {
+				if err := gui.GitCommand.RestoreAutoSave(autoSave); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				return gui.refreshSidePanels(gui.g)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("DiffAgainstAutoSave"),
+			onPress: func() error {
+				return gui.startComparison(autoSave.Ref(), "HEAD")
+			},
+		},
+	}
+
+	return gui.createMenu(autoSave.Time().Format("2006-01-02 15:04:05"), menuItems, createMenuOptions{showCancel: true})
+}
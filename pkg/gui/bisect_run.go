@@ -0,0 +1,118 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// A bisect run looks for the commit that broke something, given a command that
+// fails on broken commits, e.g. the tests. The selected commit is taken to be
+// good and HEAD to be bad. It runs in a temporary worktree like running a
+// command at a commit does, with the output streamed into the
+// customCommandOutput popup, and once it's found the first bad commit we offer
+// to jump to it.
+
+func (gui *Gui) handleBisectRun(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedCommit(g)
+	if commit == nil {
+		return nil
+	}
+
+	sha := commit.ShortSha()
+	title := gui.Tr.TemplateLocalize("BisectRunTitle", Teml{"sha": sha})
+	promptTitle := gui.Tr.TemplateLocalize("BisectRunPrompt", Teml{"sha": sha})
+	return gui.pickCommand(v, title, promptTitle, func(command string) error {
+		return gui.bisectRun(commit, command)
+	})
+}
+
+// bisectRun starts bisecting between the good commit and HEAD in a temporary
+// worktree and streams the output of `git bisect run` into the popup
+func (gui *Gui) bisectRun(good *commands.Commit, command string) error {
+	if gui.customCommandRunning() {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CustomCommandAlreadyRunning"))
+	}
+	if err := gui.OSCommand.CheckReadOnly(command); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("CreatingWorktreeStatus"), func() error {
+		worktree, err := gui.GitCommand.CreateReviewWorktree("HEAD")
+		if err != nil {
+			return err
+		}
+		if err := gui.GitCommand.StartBisect(worktree, good.Sha); err != nil {
+			_ = gui.GitCommand.RemoveReviewWorktree(worktree)
+			return err
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			gui.State.RunAtCommitWorktrees = append(gui.State.RunAtCommitWorktrees, worktree)
+			title := gui.Tr.TemplateLocalize("BisectRunOutputTitle", Teml{"command": command, "sha": good.ShortSha()})
+			return gui.streamCommand(title, gui.GitCommand.BisectRun(worktree, command), func(runErr error) error {
+				firstBad, shaErr := gui.GitCommand.FirstBadCommit(worktree)
+				if err := gui.removeRunAtCommitWorktree(worktree); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				// if the run didn't get to the end, the output in the popup
+				// will say why
+				if runErr != nil || shaErr != nil {
+					return nil
+				}
+				return gui.showFirstBadCommit(firstBad)
+			})
+		})
+		return nil
+	})
+}
+
+// showFirstBadCommit swaps the output popup for one saying which commit the
+// bisect run found, offering to jump to it
+func (gui *Gui) showFirstBadCommit(sha string) error {
+	if v, err := gui.g.View("customCommandOutput"); err == nil {
+		if err := gui.closeCustomCommandOutput(gui.g, v); err != nil {
+			return err
+		}
+	}
+
+	commit := &commands.Commit{Sha: sha}
+	if i, ok := gui.hasCommit(gui.State.Commits, sha); ok {
+		commit = gui.State.Commits[i]
+	}
+
+	title := gui.Tr.SLocalize("BisectRunFoundTitle")
+	prompt := gui.Tr.TemplateLocalize("BisectRunFound", Teml{"sha": commit.ShortSha(), "name": commit.Name})
+	return gui.createConfirmationPanel(gui.g, gui.g.CurrentView(), true, title, prompt, func(*gocui.Gui, *gocui.View) error {
+		// the confirmation panel gives focus back once we return
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.jumpToCommit(sha)
+		})
+		return nil
+	}, nil)
+}
+
+// jumpToCommit selects the commit in the commits panel and focuses it, loading
+// the rest of the commits if it's further back than we've loaded
+func (gui *Gui) jumpToCommit(sha string) error {
+	commitsView := gui.getCommitsView()
+	if commitsView.Context != "branch-commits" {
+		if err := gui.switchCommitsPanelContext("branch-commits"); err != nil {
+			return err
+		}
+	}
+
+	i, ok := gui.hasCommit(gui.State.Commits, sha)
+	if !ok && gui.State.Panels.Commits.LimitCommits {
+		gui.State.Panels.Commits.LimitCommits = false
+		if err := gui.refreshCommitsWithLimit(); err != nil {
+			return err
+		}
+		i, ok = gui.hasCommit(gui.State.Commits, sha)
+	}
+	if !ok {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CommitNotInCommitsPanel"))
+	}
+
+	gui.State.Panels.Commits.SelectedLine = i
+	return gui.switchFocus(gui.g, gui.g.CurrentView(), commitsView)
+}
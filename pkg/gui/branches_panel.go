@@ -2,6 +2,7 @@ package gui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/jesseduffield/gocui"
@@ -40,7 +41,7 @@ func (gui *Gui) handleBranchSelect(g *gocui.Gui, v *gocui.View) error {
 		return gui.newStringTask("main", gui.Tr.SLocalize("NoBranchesThisRepo"))
 	}
 	branch := gui.getSelectedBranch()
-	v.FocusPoint(0, gui.State.Panels.Branches.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.Branches.SelectedLine)
 
 	cmd := gui.OSCommand.ExecutableFromString(
 		gui.GitCommand.GetBranchGraphCmdStr(branch.Name),
@@ -54,24 +55,48 @@ func (gui *Gui) handleBranchSelect(g *gocui.Gui, v *gocui.View) error {
 // gui.refreshStatus is called at the end of this because that's when we can
 // be sure there is a state.Branches array to pick the current branch from
 func (gui *Gui) refreshBranches(g *gocui.Gui) error {
-	if err := gui.refreshRemotes(); err != nil {
-		return err
-	}
+	gui.recordRefsFingerprint()
 
-	if err := gui.refreshTags(); err != nil {
-		return err
+	if gui.contextLoaded("remotes") {
+		if err := gui.refreshRemotes(); err != nil {
+			return err
+		}
 	}
 
-	g.Update(func(g *gocui.Gui) error {
-		builder, err := commands.NewBranchListBuilder(gui.Log, gui.GitCommand)
-		if err != nil {
+	if gui.contextLoaded("tags") {
+		if err := gui.refreshTags(); err != nil {
 			return err
 		}
-		gui.State.Branches = builder.Build()
+	}
+
+	return gui.loadLocalBranches(gui.renderLocalBranchesWithSelection)
+}
+
+// refreshBranchesInBackground updates the local branches and their
+// ahead/behind counts for a refresh the user didn't ask for, like a background
+// fetch. Unlike refreshBranches it leaves the main view alone rather than
+// starting its task over, seeing as it's showing something the user picked.
+func (gui *Gui) refreshBranchesInBackground() error {
+	gui.recordRefsFingerprint()
+	return gui.loadLocalBranches(gui.renderLocalBranches)
+}
+
+func (gui *Gui) loadLocalBranches(render func() error) error {
+	// building the branch list off the main loop so that we're not blocking
+	// the other panels from loading in the meantime
+	builder, err := commands.NewBranchListBuilder(gui.Log, gui.GitCommand)
+	if err != nil {
+		return err
+	}
+	branches := builder.Build()
+	gui.recordRefresh("branches")
+
+	gui.g.Update(func(g *gocui.Gui) error {
+		gui.State.Branches = branches
 
 		// TODO: if we're in the remotes view and we've just deleted a remote we need to refresh accordingly
 		if gui.getBranchesView().Context == "local-branches" {
-			if err := gui.renderLocalBranchesWithSelection(); err != nil {
+			if err := render(); err != nil {
 				return err
 			}
 		}
@@ -82,11 +107,10 @@ func (gui *Gui) refreshBranches(g *gocui.Gui) error {
 }
 
 func (gui *Gui) renderLocalBranchesWithSelection() error {
+	if err := gui.renderLocalBranches(); err != nil {
+		return err
+	}
 	branchesView := gui.getBranchesView()
-
-	gui.refreshSelectedLine(&gui.State.Panels.Branches.SelectedLine, len(gui.State.Branches))
-	displayStrings := presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL)
-	gui.renderDisplayStrings(branchesView, displayStrings)
 	if gui.g.CurrentView() == branchesView {
 		if err := gui.handleBranchSelect(gui.g, branchesView); err != nil {
 			return err
@@ -96,6 +120,13 @@ func (gui *Gui) renderLocalBranchesWithSelection() error {
 	return nil
 }
 
+func (gui *Gui) renderLocalBranches() error {
+	gui.refreshSelectedLine(&gui.State.Panels.Branches.SelectedLine, len(gui.State.Branches))
+	displayStrings := presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL)
+	gui.renderDisplayStrings(gui.getBranchesView(), displayStrings)
+	return nil
+}
+
 // specific functions
 
 func (gui *Gui) handleBranchPress(g *gocui.Gui, v *gocui.View) error {
@@ -121,6 +152,14 @@ func (gui *Gui) handleCreatePullRequestPress(g *gocui.Gui, v *gocui.View) error
 }
 
 func (gui *Gui) handleGitFetch(g *gocui.Gui, v *gocui.View) error {
+	if gui.queueIfOffline(gui.Tr.SLocalize("fetch"), func() {
+		_ = gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("FetchWait"))
+		unamePassOpend, err := gui.fetch(g, v, true)
+		gui.HandleCredentialsPopup(g, unamePassOpend, err)
+	}) {
+		return nil
+	}
+
 	if err := gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("FetchWait")); err != nil {
 		return err
 	}
@@ -296,18 +335,82 @@ func (gui *Gui) handleRebaseOntoBranch(selectedBranchName string) error {
 	if selectedBranchName == checkedOutBranch {
 		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantRebaseOntoSelf"))
 	}
-	prompt := gui.Tr.TemplateLocalize(
-		"ConfirmRebase",
+	title := gui.Tr.TemplateLocalize(
+		"RebaseOntoTitle",
 		Teml{
 			"checkedOutBranch": checkedOutBranch,
 			"selectedBranch":   selectedBranchName,
 		},
 	)
-	return gui.createConfirmationPanel(gui.g, gui.getBranchesView(), true, gui.Tr.SLocalize("RebasingTitle"), prompt,
-		func(g *gocui.Gui, v *gocui.View) error {
+
+	rebase := &menuItem{
+		displayString: gui.Tr.SLocalize("RebaseBranch"),
+		onPress: func() error {
 			err := gui.GitCommand.RebaseBranch(selectedBranchName)
 			return gui.handleGenericMergeCommandResult(err)
-		}, nil)
+		},
+	}
+	rebaseUpdatingRefs := &menuItem{
+		displayString: gui.Tr.SLocalize("RebaseBranchUpdatingRefs"),
+		onPress: func() error {
+			return gui.rebaseBranchUpdatingRefs(selectedBranchName)
+		},
+	}
+
+	// whichever the config prefers comes first, so that it's what enter picks
+	menuItems := []*menuItem{rebase, rebaseUpdatingRefs}
+	if gui.Config.GetUserConfig().GetBool("git.rebase.updateRefs") {
+		menuItems = []*menuItem{rebaseUpdatingRefs, rebase}
+	}
+
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+// rebaseBranchUpdatingRefs rebases with --update-refs, remembering where each
+// branch was beforehand so that once the rebase is done we can say which
+// branches it moved
+func (gui *Gui) rebaseBranchUpdatingRefs(selectedBranchName string) error {
+	heads, err := gui.GitCommand.GetBranchHeads()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	gui.State.BranchHeadsBeforeRebase = heads
+
+	err = gui.GitCommand.RebaseBranchUpdatingRefs(selectedBranchName)
+	return gui.handleGenericMergeCommandResult(err)
+}
+
+// reportUpdatedRefs tells the user which other branches were moved by a rebase
+// with --update-refs, once the rebase has finished
+func (gui *Gui) reportUpdatedRefs() error {
+	headsBefore := gui.State.BranchHeadsBeforeRebase
+	if headsBefore == nil {
+		return nil
+	}
+	if rebaseMode, err := gui.GitCommand.RebaseMode(); err != nil || rebaseMode != "" {
+		return err
+	}
+	gui.State.BranchHeadsBeforeRebase = nil
+
+	headsAfter, err := gui.GitCommand.GetBranchHeads()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	currentBranchName, _ := gui.GitCommand.CurrentBranchName()
+
+	updatedBranches := []string{}
+	for branchName, sha := range headsAfter {
+		if branchName != currentBranchName && headsBefore[branchName] != "" && headsBefore[branchName] != sha {
+			updatedBranches = append(updatedBranches, branchName)
+		}
+	}
+	if len(updatedBranches) == 0 {
+		return nil
+	}
+	sort.Strings(updatedBranches)
+
+	message := gui.Tr.TemplateLocalize("UpdatedRefs", Teml{"branches": strings.Join(updatedBranches, "\n")})
+	return gui.createConfirmationPanel(gui.g, gui.getBranchesView(), true, gui.Tr.SLocalize("UpdatedRefsTitle"), message, nil, nil)
 }
 
 func (gui *Gui) handleFastForward(g *gocui.Gui, v *gocui.View) error {
@@ -385,6 +488,10 @@ func (gui *Gui) switchBranchesPanelContext(context string) error {
 
 	branchesView.TabIndex = contextTabIndexMap[context]
 
+	if !gui.contextLoaded(context) {
+		return gui.loadContext(context)
+	}
+
 	return gui.refreshBranchesViewWithSelection()
 }
 
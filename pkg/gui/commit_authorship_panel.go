@@ -0,0 +1,210 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// commitAuthorshipState is the author and date the next commit will be made
+// with, in place of the user and the current time
+type commitAuthorshipState struct {
+	Author string
+	Date   string
+}
+
+// commitDateFormat is one of the formats git takes for --date
+const commitDateFormat = "2006-01-02 15:04:05 -0700"
+
+// handleCreateCommitAuthorshipMenu lets the user commit as someone else, or
+// at some other time
+func (gui *Gui) handleCreateCommitAuthorshipMenu(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.CommitAuthorship
+	if state == nil {
+		state = &commitAuthorshipState{}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("SetCommitAuthor"), state.Author},
+			onPress: func() error {
+				initial := state.Author
+				if initial == "" {
+					initial, _ = gui.GitCommand.GetAuthorIdentity()
+				}
+				return gui.promptForCommitAuthorship(v, gui.Tr.SLocalize("CommitAuthorPrompt"), initial, state, func(value string) {
+					state.Author = value
+				})
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("SetCommitDate"), state.Date},
+			onPress: func() error {
+				initial := state.Date
+				if initial == "" {
+					initial = time.Now().Format(commitDateFormat)
+				}
+				return gui.promptForCommitAuthorship(v, gui.Tr.SLocalize("CommitDatePrompt"), initial, state, func(value string) {
+					state.Date = value
+				})
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("UseStagedFilesModTime"),
+			onPress: func() error {
+				modTime, err := commands.GetLatestModTime(fileNames(gui.stagedFiles()))
+				if err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				if modTime.IsZero() {
+					return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoStagedFilesToTime"))
+				}
+				state.Date = modTime.Format(commitDateFormat)
+				gui.setCommitAuthorship(state)
+				return nil
+			},
+		},
+	}
+
+	if gui.State.CommitAuthorship != nil {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("ClearCommitAuthorship"),
+			onPress: func() error {
+				gui.setCommitAuthorship(nil)
+				return nil
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("CommitAuthorshipMenu"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) promptForCommitAuthorship(v *gocui.View, title string, initial string, state *commitAuthorshipState, set func(string)) error {
+	// the menu closes once we return, so the prompt has to wait until then
+	gui.g.Update(func(*gocui.Gui) error {
+		return gui.createPromptPanel(gui.g, v, title, initial, func(g *gocui.Gui, promptView *gocui.View) error {
+			set(gui.trimmedContent(promptView))
+			gui.setCommitAuthorship(state)
+			return nil
+		})
+	})
+	return nil
+}
+
+// setCommitAuthorship sets the author and date to use for the next commit,
+// showing them in the commit message panel's title so that they aren't
+// forgotten about
+func (gui *Gui) setCommitAuthorship(state *commitAuthorshipState) {
+	if state != nil && state.Author == "" && state.Date == "" {
+		state = nil
+	}
+	gui.State.CommitAuthorship = state
+
+	title := gui.Tr.SLocalize("CommitMessage")
+	if state != nil {
+		overrides := strings.TrimSpace(state.Author + " " + state.Date)
+		title = fmt.Sprintf("%s (%s)", title, gui.Tr.TemplateLocalize("CommitAuthorshipTitle", Teml{"overrides": overrides}))
+	}
+	gui.getCommitMessageView().Title = title
+}
+
+// commitAuthorshipFlags returns the flags to commit with the author and date
+// the user chose, if any
+func (gui *Gui) commitAuthorshipFlags() string {
+	state := gui.State.CommitAuthorship
+	if state == nil {
+		return ""
+	}
+	return gui.GitCommand.CommitAuthorshipFlags(state.Author, state.Date)
+}
+
+func (gui *Gui) handleCreateCommitAuthorOptionsMenu(g *gocui.Gui, v *gocui.View) error {
+	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
+		return err
+	}
+	commit := gui.getSelectedCommit(g)
+	if commit == nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoCommitsThisBranch"))
+	}
+	index := gui.selectedCommitIndex()
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("ResetCommitAuthor"),
+			onPress: func() error {
+				return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+					err := gui.GitCommand.ResetCommitAuthor(gui.State.Commits, index)
+					return gui.handleGenericMergeCommandResult(err)
+				})
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("SetAuthorOfCommitsSince"),
+			onPress: func() error {
+				indexes := []int{}
+				for i := 0; i <= index; i++ {
+					indexes = append(indexes, i)
+				}
+				return gui.promptForCommitsAuthor(v, indexes)
+			},
+		},
+	}
+
+	copiedIndexes := []int{}
+	for i, commit := range gui.State.Commits {
+		if commit.Copied {
+			copiedIndexes = append(copiedIndexes, i)
+		}
+	}
+	if len(copiedIndexes) > 0 {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("SetAuthorOfCopiedCommits"),
+			onPress: func() error {
+				return gui.promptForCommitsAuthor(v, copiedIndexes)
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.TemplateLocalize("CommitAuthorOptionsTitle", Teml{"author": commit.Author}), menuItems, createMenuOptions{showCancel: true})
+}
+
+// promptForCommitsAuthor asks who the commits should have been authored by,
+// e.g. after committing with the wrong email, and then rewrites them after
+// making sure the user knows what that means for anyone who has them already
+func (gui *Gui) promptForCommitsAuthor(v *gocui.View, indexes []int) error {
+	initial, _ := gui.GitCommand.GetAuthorIdentity()
+	gui.g.Update(func(*gocui.Gui) error {
+		return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("CommitAuthorPrompt"), initial, func(g *gocui.Gui, promptView *gocui.View) error {
+			author := gui.trimmedContent(promptView)
+			if author == "" {
+				return nil
+			}
+
+			pushed := 0
+			for _, index := range indexes {
+				if gui.State.Commits[index].Status == "pushed" {
+					pushed++
+				}
+			}
+			prompt := gui.Tr.TemplateLocalize("SetCommitsAuthorPrompt", Teml{"count": len(indexes), "author": author})
+			if pushed > 0 {
+				prompt += "\n\n" + gui.Tr.TemplateLocalize("SetPushedCommitsAuthorWarning", Teml{"count": pushed})
+			}
+
+			// the prompt only closes once we return
+			gui.g.Update(func(*gocui.Gui) error {
+				return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("SetCommitsAuthor"), prompt, func(*gocui.Gui, *gocui.View) error {
+					return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+						err := gui.GitCommand.SetCommitsAuthor(gui.State.Commits, indexes, author)
+						return gui.handleGenericMergeCommandResult(err)
+					})
+				}, nil)
+			})
+			return nil
+		})
+	})
+	return nil
+}
@@ -44,12 +44,14 @@ func (gui *Gui) handleCommitFileSelect(g *gocui.Gui, v *gocui.View) error {
 		return err
 	}
 
-	v.FocusPoint(0, gui.State.Panels.CommitFiles.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.CommitFiles.SelectedLine)
 
-	cmd := gui.OSCommand.ExecutableFromString(
-		gui.GitCommand.ShowCommitFileCmdStr(commitFile.Sha, commitFile.Name, false),
-	)
-	if err := gui.newPtyTask("main", cmd); err != nil {
+	cmdStr := gui.GitCommand.ShowCommitFileCmdStr(commitFile.Sha, commitFile.Name, false)
+	if comparison := gui.State.Comparison; comparison != nil {
+		cmdStr = gui.GitCommand.ComparisonFileCmdStr(comparison.From, comparison.To, commitFile.Name)
+	}
+	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
+	if err := gui.newPtyTask("main", cmd, commands.PagingContextCommitFiles); err != nil {
 		gui.Log.Error(err)
 	}
 
@@ -57,29 +59,60 @@ func (gui *Gui) handleCommitFileSelect(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleSwitchToCommitsPanel(g *gocui.Gui, v *gocui.View) error {
+	gui.endComparison()
 	return gui.switchFocus(g, v, gui.getCommitsView())
 }
 
+// handleCheckoutCommitFile restores the file to its version at this commit,
+// staged and ready to commit
 func (gui *Gui) handleCheckoutCommitFile(g *gocui.Gui, v *gocui.View) error {
-	file := gui.State.CommitFiles[gui.State.Panels.CommitFiles.SelectedLine]
+	return gui.restoreCommitFile(v, gui.GitCommand.RestoreFileToCommit)
+}
+
+// handleCheckoutCommitFileIntoWorktree puts the file's version at this commit
+// in the working tree without staging it, e.g. to pick bits out of it
+func (gui *Gui) handleCheckoutCommitFileIntoWorktree(g *gocui.Gui, v *gocui.View) error {
+	return gui.restoreCommitFile(v, gui.GitCommand.CheckoutFileIntoWorktree)
+}
 
-	if err := gui.GitCommand.CheckoutFile(file.Sha, file.Name); err != nil {
-		return gui.createErrorPanel(gui.g, err.Error())
+func (gui *Gui) restoreCommitFile(v *gocui.View, restore func(commitSha string, fileName string) error) error {
+	file := gui.getSelectedCommitFile(gui.g)
+	if file == nil {
+		return nil
 	}
 
-	return gui.refreshFiles()
+	restoreFile := func() error {
+		if err := restore(file.Sha, file.Name); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		return gui.refreshFiles()
+	}
+
+	// anything the user has changed in the file is about to be overwritten
+	for _, workingTreeFile := range gui.State.Files {
+		if workingTreeFile.Name == file.Name && workingTreeFile.HasUnstagedChanges {
+			return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("RestoreCommitFileTitle"), gui.Tr.TemplateLocalize("RestoreCommitFilePrompt", Teml{"file": file.Name}), func(*gocui.Gui, *gocui.View) error {
+				return restoreFile()
+			}, nil)
+		}
+	}
+
+	return restoreFile()
 }
 
 func (gui *Gui) handleDiscardOldFileChange(g *gocui.Gui, v *gocui.View) error {
 	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
 		return err
 	}
+	if gui.State.Comparison != nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NotAvailableWhileComparing"))
+	}
 
 	fileName := gui.State.CommitFiles[gui.State.Panels.CommitFiles.SelectedLine].Name
 
 	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("DiscardFileChangesTitle"), gui.Tr.SLocalize("DiscardFileChangesPrompt"), func(g *gocui.Gui, v *gocui.View) error {
 		return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
-			if err := gui.GitCommand.DiscardOldFileChanges(gui.State.Commits, gui.State.Panels.Commits.SelectedLine, fileName); err != nil {
+			if err := gui.GitCommand.DiscardOldFileChanges(gui.State.Commits, gui.selectedCommitIndex(), fileName); err != nil {
 				if err := gui.handleGenericMergeCommandResult(err); err != nil {
 					return err
 				}
@@ -99,20 +132,30 @@ func (gui *Gui) refreshCommitFilesView() error {
 		return err
 	}
 
-	commit := gui.getSelectedCommit(gui.g)
-	if commit == nil {
-		return nil
-	}
+	commitsFileView := gui.getCommitFilesView()
+	if comparison := gui.State.Comparison; comparison != nil {
+		files, err := gui.GitCommand.GetComparisonFiles(comparison.From, comparison.To)
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		gui.State.CommitFiles = files
+		commitsFileView.Title = gui.Tr.TemplateLocalize("ComparisonTitle", Teml{"from": comparison.From, "to": comparison.To})
+	} else {
+		commit := gui.getSelectedCommit(gui.g)
+		if commit == nil {
+			return nil
+		}
 
-	files, err := gui.GitCommand.GetCommitFiles(commit.Sha, gui.GitCommand.PatchManager)
-	if err != nil {
-		return gui.createErrorPanel(gui.g, err.Error())
+		files, err := gui.GitCommand.GetCommitFiles(commit.Sha, gui.GitCommand.PatchManager)
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		gui.State.CommitFiles = files
+		commitsFileView.Title = gui.Tr.SLocalize("CommitFiles")
 	}
-	gui.State.CommitFiles = files
 
 	gui.refreshSelectedLine(&gui.State.Panels.CommitFiles.SelectedLine, len(gui.State.CommitFiles))
 
-	commitsFileView := gui.getCommitFilesView()
 	displayStrings := presentation.GetCommitFileListDisplayStrings(gui.State.CommitFiles)
 	gui.renderDisplayStrings(commitsFileView, displayStrings)
 
@@ -128,6 +171,9 @@ func (gui *Gui) handleToggleFileForPatch(g *gocui.Gui, v *gocui.View) error {
 	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
 		return err
 	}
+	if gui.State.Comparison != nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NotAvailableWhileComparing"))
+	}
 
 	commitFile := gui.getSelectedCommitFile(g)
 	if commitFile == nil {
@@ -184,6 +230,9 @@ func (gui *Gui) enterCommitFile(selectedLineIdx int) error {
 	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
 		return err
 	}
+	if gui.State.Comparison != nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NotAvailableWhileComparing"))
+	}
 
 	commitFile := gui.getSelectedCommitFile(gui.g)
 	if commitFile == nil {
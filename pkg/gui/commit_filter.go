@@ -0,0 +1,173 @@
+package gui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// visibleCommits returns the branch's commits that get through the commit
+// filter, in history order
+func (gui *Gui) visibleCommits() []*commands.Commit {
+	return gui.State.CommitFilter.Filter(gui.State.Commits)
+}
+
+// getCommitsTabTitle shows the commit filter and the log settings in the
+// commits tab, so that it's obvious why commits are missing
+func (gui *Gui) getCommitsTabTitle() string {
+	title := gui.Tr.SLocalize("CommitsTabTitle")
+	if !gui.State.CommitFilter.IsEmpty() {
+		title = gui.Tr.TemplateLocalize("FilteredCommitsTitle", Teml{
+			"filter": gui.State.CommitFilterText,
+			"count":  strconv.Itoa(len(gui.visibleCommits())),
+			"total":  strconv.Itoa(len(gui.State.Commits)),
+		})
+	}
+
+	settings := gui.State.LogSettings
+	if settings.FirstParent {
+		title += " (" + gui.Tr.SLocalize("FirstParent") + ")"
+	}
+	if settings.NoMerges {
+		title += " (" + gui.Tr.SLocalize("NoMerges") + ")"
+	}
+	if settings.Order != "" {
+		title += " (" + gui.logOrderName(settings.Order) + ")"
+	}
+	return title
+}
+
+// isHistoryPartial tells us whether commits are missing from the commits
+// panel, in which case the commits next to each other in it might not be
+// next to each other in history
+func (gui *Gui) isHistoryPartial() bool {
+	return !gui.State.CommitFilter.IsEmpty() || !gui.State.LogSettings.IsEmpty()
+}
+
+func (gui *Gui) handleFilterCommits(g *gocui.Gui, v *gocui.View) error {
+	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("FilterCommitsPrompt"), gui.State.CommitFilterText, func(g *gocui.Gui, promptView *gocui.View) error {
+		text := gui.trimmedContent(promptView)
+		return gui.setCommitFilter(text)
+	})
+}
+
+// handleFilterByAuthor narrows the commits panel down to the selected
+// commit's author
+func (gui *Gui) handleFilterByAuthor(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedCommit(g)
+	if commit == nil {
+		return nil
+	}
+	return gui.addCommitFilterTerm(commands.CommitFilterTerm("author", commit.Author))
+}
+
+// handleFilterByPath goes back to the commits panel, narrowed down to the
+// commits touching the selected commit file
+func (gui *Gui) handleFilterByPath(g *gocui.Gui, v *gocui.View) error {
+	commitFile := gui.getSelectedCommitFile(g)
+	if commitFile == nil {
+		return nil
+	}
+	if err := gui.addCommitFilterTerm(commands.CommitFilterTerm("path", commitFile.Name)); err != nil {
+		return err
+	}
+	return gui.handleSwitchToCommitsPanel(g, v)
+}
+
+// addCommitFilterTerm adds a term to the commit filter, unless it's already
+// there
+func (gui *Gui) addCommitFilterTerm(term string) error {
+	text := gui.State.CommitFilterText
+	if strings.Contains(" "+text+" ", " "+term+" ") {
+		return nil
+	}
+	return gui.setCommitFilter(strings.TrimSpace(text + " " + term))
+}
+
+// loadCommitFilter works out which commits get through the parts of the
+// filter that we need git for
+func (gui *Gui) loadCommitFilter(commitFilter *commands.CommitFilter) error {
+	if err := gui.GitCommand.LoadCommitFilterPaths(commitFilter); err != nil {
+		return err
+	}
+	return gui.GitCommand.LoadCommitFilterAhead(commitFilter)
+}
+
+func (gui *Gui) setCommitFilter(text string) error {
+	commitFilter := commands.NewCommitFilter(text)
+	if err := gui.loadCommitFilter(commitFilter); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	gui.State.CommitFilter = commitFilter
+	gui.State.CommitFilterText = text
+	gui.State.Panels.Commits.SelectedLine = 0
+	return gui.renderBranchCommitsWithSelection()
+}
+
+// handleViewCommitsAheadOfBase narrows the commits panel down to the commits
+// that the checked out branch has and its base branch doesn't, i.e. the ones a
+// pull request would have
+func (gui *Gui) handleViewCommitsAheadOfBase(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	// the commits panel only ever shows the checked out branch
+	if checkedOut := gui.getCheckedOutBranch(); checkedOut == nil || branch.Name != checkedOut.Name {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("ViewCommitsAheadNotCheckedOut"))
+	}
+	baseBranch := gui.GitCommand.GetBaseBranch(branch.Name)
+	if baseBranch == "" {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoBaseBranch"))
+	}
+
+	if err := gui.setCommitFilter(commands.CommitFilterTerm("ahead", baseBranch)); err != nil {
+		return err
+	}
+	return gui.handleSwitchToCommitsPanel(g, v)
+}
+
+// scopedCommits returns the commits that the actions on several commits go
+// with when nothing's been copied: all of them when we're only showing the
+// commits ahead of the base branch, otherwise none
+func (gui *Gui) scopedCommits() []*commands.Commit {
+	if gui.State.CommitFilter == nil || gui.State.CommitFilter.AheadOf == "" {
+		return nil
+	}
+	return gui.visibleCommits()
+}
+
+// handleExportPatches writes the copied commits out as patch files, or the
+// commits ahead of the base branch when we're only showing those, or just the
+// selected commit. Copied commits can be picked out of a filtered commits
+// panel, so they needn't be next to each other in history.
+func (gui *Gui) handleExportPatches(g *gocui.Gui, v *gocui.View) error {
+	commits := []*commands.Commit{}
+	for _, commit := range gui.State.Commits {
+		if commit.Copied {
+			commits = append(commits, commit)
+		}
+	}
+	if len(commits) == 0 {
+		commits = gui.scopedCommits()
+	}
+	if len(commits) == 0 {
+		commit := gui.getSelectedCommit(g)
+		if commit == nil {
+			return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoCommitsThisBranch"))
+		}
+		commits = append(commits, commit)
+	}
+
+	title := gui.Tr.TemplateLocalize("ExportPatchesPrompt", Teml{"count": strconv.Itoa(len(commits))})
+	return gui.createPromptPanel(gui.g, v, title, "patches", func(g *gocui.Gui, promptView *gocui.View) error {
+		dir := gui.trimmedContent(promptView)
+		if err := gui.GitCommand.FormatPatches(commits, dir); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		return gui.refreshFiles()
+	})
+}
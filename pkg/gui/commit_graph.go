@@ -0,0 +1,32 @@
+package gui
+
+// commitGraphCommitThreshold is how many commits a repo needs before a
+// commit-graph is worth writing for it
+const commitGraphCommitThreshold = 10000
+
+// writeCommitGraphIfNeeded writes a commit-graph in the background for large
+// repos that don't have one. This is a cache that git itself would write
+// during gc, so we don't ask first, but the user can turn it off with
+// git.writeCommitGraph: never.
+func (gui *Gui) writeCommitGraphIfNeeded() {
+	if gui.Config.GetUserConfig().GetString("git.writeCommitGraph") != "auto" || gui.OSCommand.ReadOnly() {
+		return
+	}
+
+	if gui.GitCommand.HasCommitGraph() {
+		return
+	}
+
+	count, err := gui.GitCommand.GetCommitCount()
+	if err != nil || count < commitGraphCommitThreshold {
+		return
+	}
+
+	_ = gui.WithWaitingStatus(gui.Tr.SLocalize("WritingCommitGraphStatus"), func() error {
+		if err := gui.GitCommand.WriteCommitGraph(); err != nil {
+			// not worth bothering the user about, since it's only a cache
+			gui.Log.Error(err)
+		}
+		return nil
+	})
+}
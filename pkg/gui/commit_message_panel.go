@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // runSyncOrAsyncCommand takes the output of a command that may have returned
@@ -26,31 +28,84 @@ func (gui *Gui) runSyncOrAsyncCommand(sub *exec.Cmd, err error) (bool, error) {
 }
 
 func (gui *Gui) handleCommitConfirm(g *gocui.Gui, v *gocui.View) error {
-	message := gui.trimmedContent(v)
-	if message == "" {
+	return gui.commit(g, v, false)
+}
+
+// handleCommitAndPush commits and then pushes in the background
+func (gui *Gui) handleCommitAndPush(g *gocui.Gui, v *gocui.View) error {
+	return gui.commit(g, v, true)
+}
+
+// commit commits with the message in the commit message view, then pushes if
+// asked to or if the branch is one the user's config says to push after
+// committing on
+func (gui *Gui) commit(g *gocui.Gui, v *gocui.View, push bool) error {
+	commentChar := gui.GitCommand.GetCommentChar()
+	message := commands.CleanupCommitMessage(gui.trimmedContent(v), commentChar)
+	// like git, we won't commit a template that hasn't been filled in, and
+	// nor will we commit just the subject prefix from the branch name
+	prefill, _ := gui.commitMessagePrefill()
+	if message == "" || message == commands.CleanupCommitMessage(prefill, commentChar) {
 		return gui.createErrorPanel(g, gui.Tr.SLocalize("CommitWithoutMessageErr"))
 	}
+	if driver := gui.getPairingDriver(); driver != "" {
+		message = gui.GitCommand.AddCoAuthor(message, driver)
+	}
 	flags := ""
 	skipHookPrefix := gui.Config.GetUserConfig().GetString("git.skipHookPrefix")
 	if skipHookPrefix != "" && strings.HasPrefix(message, skipHookPrefix) {
 		flags = "--no-verify"
 	}
-	ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.Commit(message, flags))
+	flags += gui.commitAuthorshipFlags()
+	commitSuperproject, err := gui.commitInSubmodules(message, flags)
 	if err != nil {
-		return err
+		return gui.createErrorPanel(g, err.Error())
 	}
-	if !ok {
-		return nil
+	if commitSuperproject {
+		// when signing, the commit happens in a subprocess and we don't get to
+		// push afterwards
+		ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.Commit(message, flags))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
 	}
 
+	gui.setCommitAuthorship(nil)
 	v.Clear()
 	_ = v.SetCursor(0, 0)
 	_ = v.SetOrigin(0, 0)
 	_, _ = g.SetViewOnBottom("commitMessage")
 	_ = gui.switchFocus(g, v, gui.getFilesView())
+
+	branch := gui.getCheckedOutBranch()
+	if branch != nil && (push || gui.shouldPushAfterCommit(branch.Name)) {
+		if branch.Pullables == "?" {
+			// without an upstream we'd have to ask where to push to
+			if push {
+				return gui.pushFiles(g, gui.getFilesView())
+			}
+			gui.raiseToast(gui.Tr.TemplateLocalize("NoUpstreamToPushTo", Teml{"branch": branch.Name}))
+		} else if err := gui.pushInBackground(branch.Name); err != nil {
+			return err
+		}
+	}
 	return gui.refreshSidePanels(g)
 }
 
+// shouldPushAfterCommit tells us whether the branch matches one of the globs
+// in git.pushAfterCommit
+func (gui *Gui) shouldPushAfterCommit(branchName string) bool {
+	for _, pattern := range gui.Config.GetUserConfig().GetStringSlice("git.pushAfterCommit") {
+		if utils.MatchesGlob(pattern, branchName) {
+			return true
+		}
+	}
+	return false
+}
+
 func (gui *Gui) handleCommitClose(g *gocui.Gui, v *gocui.View) error {
 	_, _ = g.SetViewOnBottom("commitMessage")
 	return gui.switchFocus(g, v, gui.getFilesView())
@@ -85,37 +140,10 @@ func (gui *Gui) RenderCommitLength() {
 	v.Subtitle = gui.getBufferLength(v)
 }
 
-// we've just copy+pasted the editor from gocui to here so that we can also re-
-// render the commit message length on each keypress
+// commitMessageEditor is our default editor, plus it re-renders the commit
+// message length on each keypress
 func (gui *Gui) commitMessageEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
-	switch {
-	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
-		v.EditDelete(true)
-	case key == gocui.KeyDelete:
-		v.EditDelete(false)
-	case key == gocui.KeyArrowDown:
-		v.MoveCursor(0, 1, false)
-	case key == gocui.KeyArrowUp:
-		v.MoveCursor(0, -1, false)
-	case key == gocui.KeyArrowLeft:
-		v.MoveCursor(-1, 0, false)
-	case key == gocui.KeyArrowRight:
-		v.MoveCursor(1, 0, false)
-	case key == gocui.KeyTab:
-		v.EditNewLine()
-	case key == gocui.KeySpace:
-		v.EditWrite(' ')
-	case key == gocui.KeyInsert:
-		v.Overwrite = !v.Overwrite
-	case key == gocui.KeyCtrlU:
-		v.EditDeleteToStartOfLine()
-	case key == gocui.KeyCtrlA:
-		v.EditGotoToStartOfLine()
-	case key == gocui.KeyCtrlE:
-		v.EditGotoToEndOfLine()
-	default:
-		v.EditWrite(ch)
-	}
+	gui.defaultEditor(v, key, ch, mod)
 
 	gui.RenderCommitLength()
 }
@@ -0,0 +1,70 @@
+package gui
+
+import (
+	"sync"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// commitStatsBatchSize is how many commits we load the stats of at a time, so
+// that the ones at the top show up quickly
+const commitStatsBatchSize = 50
+
+// commitStatsCache holds the stats of every commit we've loaded them for.
+// Commits don't change, so nothing in here goes stale.
+type commitStatsCache struct {
+	stats   map[string]*commands.CommitStat
+	loading bool
+	mutex   sync.Mutex
+}
+
+// loadCommitStats sets the stats of the commits we already have them for,
+// and loads the next batch of the rest in the background, rendering the
+// commits again once they're in. That in turn loads the next batch, until
+// we've got them all.
+func (gui *Gui) loadCommitStats(commits []*commands.Commit) {
+	if gui.State.CommitStats == nil {
+		gui.State.CommitStats = &commitStatsCache{stats: map[string]*commands.CommitStat{}}
+	}
+	cache := gui.State.CommitStats
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	missing := []string{}
+	for _, commit := range commits {
+		if stat, ok := cache.stats[commit.Sha]; ok {
+			commit.Stat = stat
+		} else if len(missing) < commitStatsBatchSize {
+			missing = append(missing, commit.Sha)
+		}
+	}
+	if len(missing) == 0 || cache.loading {
+		return
+	}
+
+	cache.loading = true
+	go func() {
+		stats, err := gui.GitCommand.GetCommitStats(missing)
+		if err != nil {
+			gui.Log.Error(err)
+		}
+
+		cache.mutex.Lock()
+		cache.loading = false
+		for _, sha := range missing {
+			// if we couldn't get a commit's stats we leave them blank rather
+			// than trying again and again
+			cache.stats[sha] = stats[sha]
+		}
+		cache.mutex.Unlock()
+
+		gui.g.Update(func(*gocui.Gui) error {
+			if gui.getCommitsView().Context != "branch-commits" {
+				return nil
+			}
+			return gui.renderBranchCommits()
+		})
+	}()
+}
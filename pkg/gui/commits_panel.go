@@ -3,6 +3,7 @@ package gui
 import (
 	"strconv"
 
+	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
@@ -13,11 +14,28 @@ import (
 
 func (gui *Gui) getSelectedCommit(g *gocui.Gui) *commands.Commit {
 	selectedLine := gui.State.Panels.Commits.SelectedLine
-	if selectedLine == -1 {
+	commits := gui.visibleCommits()
+	if selectedLine == -1 || selectedLine >= len(commits) {
 		return nil
 	}
 
-	return gui.State.Commits[selectedLine]
+	return commits[selectedLine]
+}
+
+// selectedCommitIndex returns where the selected commit is in the branch's
+// history, which when the commits panel is filtered isn't where it's shown
+func (gui *Gui) selectedCommitIndex() int {
+	if gui.State.CommitFilter.IsEmpty() {
+		return gui.State.Panels.Commits.SelectedLine
+	}
+
+	selectedCommit := gui.getSelectedCommit(gui.g)
+	for index, commit := range gui.State.Commits {
+		if commit == selectedCommit {
+			return index
+		}
+	}
+	return -1
 }
 
 func (gui *Gui) handleCommitSelect(g *gocui.Gui, v *gocui.View) error {
@@ -53,17 +71,23 @@ func (gui *Gui) handleCommitSelect(g *gocui.Gui, v *gocui.View) error {
 		return gui.newStringTask("main", gui.Tr.SLocalize("NoCommitsThisBranch"))
 	}
 
-	v.FocusPoint(0, gui.State.Panels.Commits.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.Commits.SelectedLine)
+
+	// exec and break lines in a rebase have no commit to show
+	if commit.IsRebaseStep() {
+		return gui.newStringTask("main", commit.Action+" "+commit.Name)
+	}
 
 	// if specific diff mode is on, don't show diff
 	if gui.State.Panels.Commits.SpecificDiffMode {
 		return nil
 	}
 
+	width, _ := gui.getMainView().Size()
 	cmd := gui.OSCommand.ExecutableFromString(
-		gui.GitCommand.ShowCmdStr(commit.Sha),
+		gui.GitCommand.ShowCmdStr(commit.Sha, commands.PagingContextCommits, width),
 	)
-	if err := gui.newPtyTask("main", cmd); err != nil {
+	if err := gui.newPtyTask("main", cmd, commands.PagingContextCommits); err != nil {
 		gui.Log.Error(err)
 	}
 
@@ -71,6 +95,8 @@ func (gui *Gui) handleCommitSelect(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) refreshCommits(g *gocui.Gui) error {
+	gui.recordRefsFingerprint()
+
 	g.Update(func(*gocui.Gui) error {
 		// I think this is here for the sake of some kind of rebasing thing
 		_ = gui.refreshStatus(g)
@@ -78,13 +104,17 @@ func (gui *Gui) refreshCommits(g *gocui.Gui) error {
 		if err := gui.refreshCommitsWithLimit(); err != nil {
 			return err
 		}
-
-		// doing this async because it shouldn't hold anything up
-		go func() {
-			if err := gui.refreshReflogCommits(); err != nil {
-				_ = gui.createErrorPanel(gui.g, err.Error())
-			}
-		}()
+		gui.recordRefresh("commits")
+
+		// doing this async because it shouldn't hold anything up. If the reflog
+		// hasn't been viewed yet we leave it until it is.
+		if gui.contextLoaded("reflog-commits") {
+			go func() {
+				if err := gui.refreshReflogCommits(); err != nil {
+					_ = gui.createErrorPanel(gui.g, err.Error())
+				}
+			}()
+		}
 
 		if g.CurrentView() == gui.getCommitFilesView() || (g.CurrentView() == gui.getMainView() || gui.State.MainContext == "patch-building") {
 			return gui.refreshCommitFilesView()
@@ -99,6 +129,8 @@ func (gui *Gui) refreshCommitsWithLimit() error {
 	if err != nil {
 		return err
 	}
+	gui.State.LogSettings = gui.GitCommand.GetLogSettings()
+	builder.LogSettings = gui.State.LogSettings
 
 	commits, err := builder.GetCommits(gui.State.Panels.Commits.LimitCommits)
 	if err != nil {
@@ -106,6 +138,11 @@ func (gui *Gui) refreshCommitsWithLimit() error {
 	}
 	gui.State.Commits = commits
 
+	// new commits may touch the paths we're filtering by
+	if err := gui.loadCommitFilter(gui.State.CommitFilter); err != nil {
+		return err
+	}
+
 	if gui.getCommitsView().Context == "branch-commits" {
 		if err := gui.renderBranchCommitsWithSelection(); err != nil {
 			return err
@@ -130,9 +167,22 @@ func (gui *Gui) handleCommitSquashDown(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
+	// when we're only showing the commits ahead of the base branch, they're
+	// what we squash
+	if len(gui.scopedCommits()) > 0 {
+		branch := gui.getCheckedOutBranch()
+		if branch == nil {
+			return nil
+		}
+		if len(gui.stagedFiles()) > 0 {
+			return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("SquashBranchWithStagedChanges"))
+		}
+		return gui.confirmSquashBranch(v, branch.Name, gui.State.CommitFilter.AheadOf)
+	}
+
 	return gui.createConfirmationPanel(g, v, true, gui.Tr.SLocalize("Squash"), gui.Tr.SLocalize("SureSquashThisCommit"), func(g *gocui.Gui, v *gocui.View) error {
 		return gui.WithWaitingStatus(gui.Tr.SLocalize("SquashingStatus"), func() error {
-			err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLine, "squash")
+			err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.selectedCommitIndex(), "squash")
 			return gui.handleGenericMergeCommandResult(err)
 		})
 	}, nil)
@@ -153,7 +203,7 @@ func (gui *Gui) handleCommitFixup(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.createConfirmationPanel(g, v, true, gui.Tr.SLocalize("Fixup"), gui.Tr.SLocalize("SureFixupThisCommit"), func(g *gocui.Gui, v *gocui.View) error {
 		return gui.WithWaitingStatus(gui.Tr.SLocalize("FixingStatus"), func() error {
-			err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLine, "fixup")
+			err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.selectedCommitIndex(), "fixup")
 			return gui.handleGenericMergeCommandResult(err)
 		})
 	}, nil)
@@ -168,7 +218,7 @@ func (gui *Gui) handleRenameCommit(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	if gui.State.Panels.Commits.SelectedLine != 0 {
+	if gui.selectedCommitIndex() != 0 {
 		return gui.createErrorPanel(g, gui.Tr.SLocalize("OnlyRenameTopCommit"))
 	}
 	return gui.createPromptPanel(g, v, gui.Tr.SLocalize("renameCommit"), "", func(g *gocui.Gui, v *gocui.View) error {
@@ -191,7 +241,7 @@ func (gui *Gui) handleRenameCommitEditor(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	subProcess, err := gui.GitCommand.RewordCommit(gui.State.Commits, gui.State.Panels.Commits.SelectedLine)
+	subProcess, err := gui.GitCommand.RewordCommit(gui.State.Commits, gui.selectedCommitIndex())
 	if err != nil {
 		return gui.createErrorPanel(gui.g, err.Error())
 	}
@@ -203,15 +253,95 @@ func (gui *Gui) handleRenameCommitEditor(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// handleRewordCommits rewords the copied commits, or the commits ahead of the
+// base branch when we're only showing those, or just the selected commit,
+// asking for each new message in turn starting from the oldest and then
+// rewording them all in one rebase
+func (gui *Gui) handleRewordCommits(g *gocui.Gui, v *gocui.View) error {
+	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
+		return err
+	}
+
+	indexes := []int{}
+	for i := len(gui.State.Commits) - 1; i >= 0; i-- {
+		if gui.State.Commits[i].Copied {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		scoped := gui.scopedCommits()
+		for i := len(gui.State.Commits) - 1; i >= 0; i-- {
+			for _, commit := range scoped {
+				if gui.State.Commits[i] == commit {
+					indexes = append(indexes, i)
+				}
+			}
+		}
+	}
+	if len(indexes) == 0 {
+		if gui.getSelectedCommit(g) == nil {
+			return nil
+		}
+		indexes = append(indexes, gui.selectedCommitIndex())
+	}
+
+	messages := map[int]string{}
+	var promptForMessage func(position int) error
+	promptForMessage = func(position int) error {
+		if position == len(indexes) {
+			return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+				err := gui.GitCommand.RewordCommits(gui.State.Commits, messages)
+				return gui.handleGenericMergeCommandResult(err)
+			})
+		}
+
+		index := indexes[position]
+		commit := gui.State.Commits[index]
+		message, err := gui.GitCommand.GetCommitMessage(commit.Sha)
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		title := gui.Tr.TemplateLocalize("RewordCommitsPrompt", Teml{
+			"number": strconv.Itoa(position + 1),
+			"total":  strconv.Itoa(len(indexes)),
+			"sha":    commit.ShortSha(),
+		})
+		return gui.createPromptPanel(gui.g, v, title, message, func(g *gocui.Gui, promptView *gocui.View) error {
+			// an empty message leaves the commit as it was
+			if newMessage := gui.trimmedContent(promptView); newMessage != "" && newMessage != message {
+				messages[index] = newMessage
+			}
+			// the prompt closes once we return, so the next one has to wait
+			gui.g.Update(func(*gocui.Gui) error {
+				return promptForMessage(position + 1)
+			})
+			return nil
+		})
+	}
+
+	return promptForMessage(0)
+}
+
 // handleMidRebaseCommand sees if the selected commit is in fact a rebasing
 // commit meaning you are trying to edit the todo file rather than actually
 // begin a rebase. It then updates the todo file with that action
 func (gui *Gui) handleMidRebaseCommand(action string) (bool, error) {
-	selectedCommit := gui.State.Commits[gui.State.Panels.Commits.SelectedLine]
+	selectedCommit := gui.State.Commits[gui.selectedCommitIndex()]
 	if selectedCommit.Status != "rebasing" {
 		return false, nil
 	}
 
+	// exec and break lines can only be removed
+	if selectedCommit.IsRebaseStep() {
+		if action != "drop" {
+			return true, gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantChangeRebaseStep"))
+		}
+		if err := gui.GitCommand.RemoveRebaseTodo(gui.selectedCommitIndex()); err != nil {
+			return false, gui.createErrorPanel(gui.g, err.Error())
+		}
+		return true, gui.refreshCommits(gui.g)
+	}
+
 	// for now we do not support setting 'reword' because it requires an editor
 	// and that means we either unconditionally wait around for the subprocess to ask for
 	// our input or we set a lazygit client as the EDITOR env variable and have it
@@ -220,7 +350,7 @@ func (gui *Gui) handleMidRebaseCommand(action string) (bool, error) {
 		return true, gui.createErrorPanel(gui.g, gui.Tr.SLocalize("rewordNotSupported"))
 	}
 
-	if err := gui.GitCommand.EditRebaseTodo(gui.State.Panels.Commits.SelectedLine, action); err != nil {
+	if err := gui.GitCommand.EditRebaseTodo(gui.selectedCommitIndex(), action); err != nil {
 		return false, gui.createErrorPanel(gui.g, err.Error())
 	}
 	return true, gui.refreshCommits(gui.g)
@@ -237,13 +367,17 @@ func (gui *Gui) handleCommitDelete(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("DeleteCommitTitle"), gui.Tr.SLocalize("DeleteCommitPrompt"), func(*gocui.Gui, *gocui.View) error {
 		return gui.WithWaitingStatus(gui.Tr.SLocalize("DeletingStatus"), func() error {
-			err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLine, "drop")
+			err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.selectedCommitIndex(), "drop")
 			return gui.handleGenericMergeCommandResult(err)
 		})
 	}, nil)
 }
 
 func (gui *Gui) handleCommitMoveDown(g *gocui.Gui, v *gocui.View) error {
+	// with commits hidden, moving past a neighbour would be a guess
+	if gui.isHistoryPartial() {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantMoveCommitsWhileFiltered"))
+	}
 	index := gui.State.Panels.Commits.SelectedLine
 	selectedCommit := gui.State.Commits[index]
 	if selectedCommit.Status == "rebasing" {
@@ -267,6 +401,9 @@ func (gui *Gui) handleCommitMoveDown(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleCommitMoveUp(g *gocui.Gui, v *gocui.View) error {
+	if gui.isHistoryPartial() {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantMoveCommitsWhileFiltered"))
+	}
 	index := gui.State.Panels.Commits.SelectedLine
 	if index == 0 {
 		return nil
@@ -299,7 +436,7 @@ func (gui *Gui) handleCommitEdit(g *gocui.Gui, v *gocui.View) error {
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
-		err = gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLine, "edit")
+		err = gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.selectedCommitIndex(), "edit")
 		return gui.handleGenericMergeCommandResult(err)
 	})
 }
@@ -307,7 +444,7 @@ func (gui *Gui) handleCommitEdit(g *gocui.Gui, v *gocui.View) error {
 func (gui *Gui) handleCommitAmendTo(g *gocui.Gui, v *gocui.View) error {
 	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("AmendCommitTitle"), gui.Tr.SLocalize("AmendCommitPrompt"), func(*gocui.Gui, *gocui.View) error {
 		return gui.WithWaitingStatus(gui.Tr.SLocalize("AmendingStatus"), func() error {
-			err := gui.GitCommand.AmendTo(gui.State.Commits[gui.State.Panels.Commits.SelectedLine].Sha)
+			err := gui.GitCommand.AmendTo(gui.State.Commits[gui.selectedCommitIndex()].Sha)
 			return gui.handleGenericMergeCommandResult(err)
 		})
 	}, nil)
@@ -327,17 +464,68 @@ func (gui *Gui) handleCommitPick(g *gocui.Gui, v *gocui.View) error {
 	return gui.handlePullFiles(g, v)
 }
 
+// handleCommitRevert reverts the copied commits, or just the selected commit if
+// nothing's been copied. With several commits we ask whether to give each
+// revert its own commit or to revert them all in one.
 func (gui *Gui) handleCommitRevert(g *gocui.Gui, v *gocui.View) error {
-	if err := gui.GitCommand.Revert(gui.State.Commits[gui.State.Panels.Commits.SelectedLine].Sha); err != nil {
+	commits := []*commands.Commit{}
+	for _, commit := range gui.State.Commits {
+		if commit.Copied {
+			commits = append(commits, commit)
+		}
+	}
+	if len(commits) > 1 {
+		return gui.handleCreateRevertCommitsMenu(commits)
+	}
+
+	if err := gui.GitCommand.Revert(gui.State.Commits[gui.selectedCommitIndex()].Sha); err != nil {
 		return gui.createErrorPanel(gui.g, err.Error())
 	}
 	gui.State.Panels.Commits.SelectedLine++
 	return gui.refreshCommits(gui.g)
 }
 
+func (gui *Gui) handleCreateRevertCommitsMenu(commits []*commands.Commit) error {
+	count := strconv.Itoa(len(commits))
+	revert := func(squash bool) error {
+		return gui.WithWaitingStatus(gui.Tr.SLocalize("RevertingStatus"), func() error {
+			if err := gui.GitCommand.RevertCommits(commits, squash); err != nil {
+				return gui.handleGenericMergeCommandResult(err)
+			}
+
+			added := len(commits)
+			if squash {
+				added = 1
+				if ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.Commit(commands.RevertCommitsMessage(commits), "")); !ok {
+					return err
+				}
+			}
+			gui.State.Panels.Commits.SelectedLine += added
+			return gui.refreshSidePanels(gui.g)
+		})
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.TemplateLocalize("RevertCommitsSeparately", Teml{"count": count}),
+			onPress: func() error {
+				return revert(false)
+			},
+		},
+		{
+			displayString: gui.Tr.TemplateLocalize("RevertCommitsAsOne", Teml{"count": count}),
+			onPress: func() error {
+				return revert(true)
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.TemplateLocalize("RevertCommitsTitle", Teml{"count": count}), menuItems, createMenuOptions{showCancel: true})
+}
+
 func (gui *Gui) handleCopyCommit(g *gocui.Gui, v *gocui.View) error {
 	// get currently selected commit, add the sha to state.
-	commit := gui.State.Commits[gui.State.Panels.Commits.SelectedLine]
+	commit := gui.State.Commits[gui.selectedCommitIndex()]
 
 	// we will un-copy it if it's already copied
 	for index, cherryPickedCommit := range gui.State.CherryPickedCommits {
@@ -347,14 +535,14 @@ func (gui *Gui) handleCopyCommit(g *gocui.Gui, v *gocui.View) error {
 		}
 	}
 
-	gui.addCommitToCherryPickedCommits(gui.State.Panels.Commits.SelectedLine)
+	gui.addCommitToCherryPickedCommits(commit)
 	return gui.refreshCommits(gui.g)
 }
 
-func (gui *Gui) addCommitToCherryPickedCommits(index int) {
+func (gui *Gui) addCommitToCherryPickedCommits(commit *commands.Commit) {
 	// not super happy with modifying the state of the Commits array here
 	// but the alternative would be very tricky
-	gui.State.Commits[index].Copied = true
+	commit.Copied = true
 
 	newCommits := []*commands.Commit{}
 	for _, commit := range gui.State.Commits {
@@ -371,9 +559,12 @@ func (gui *Gui) handleCopyCommitRange(g *gocui.Gui, v *gocui.View) error {
 	// whenever I add a commit, I need to make sure I retain its order
 
 	// find the last commit that is copied that's above our position
-	// if there are none, startIndex = 0
+	// if there are none, startIndex = 0. When the commits panel is filtered
+	// only the commits we're showing make it into the range, and they're
+	// copied in the order they come in history regardless
+	commits := gui.visibleCommits()
 	startIndex := 0
-	for index, commit := range gui.State.Commits[0:gui.State.Panels.Commits.SelectedLine] {
+	for index, commit := range commits[0:gui.State.Panels.Commits.SelectedLine] {
 		if commit.Copied {
 			startIndex = index
 		}
@@ -382,23 +573,72 @@ func (gui *Gui) handleCopyCommitRange(g *gocui.Gui, v *gocui.View) error {
 	gui.Log.Info("commit copy start index: " + strconv.Itoa(startIndex))
 
 	for index := startIndex; index <= gui.State.Panels.Commits.SelectedLine; index++ {
-		gui.addCommitToCherryPickedCommits(index)
+		gui.addCommitToCherryPickedCommits(commits[index])
 	}
 
 	return gui.refreshCommits(gui.g)
 }
 
-// HandlePasteCommits begins a cherry-pick rebase with the commits the user has copied
+// HandlePasteCommits shows the commits the user has copied in the order
+// they'll be picked, oldest first, so that they can drop any of them before
+// we cherry-pick the rest onto the checked out branch. The cherry-pick is a
+// single rebase so that any conflicts come up one commit at a time.
 func (gui *Gui) HandlePasteCommits(g *gocui.Gui, v *gocui.View) error {
-	return gui.createConfirmationPanel(g, v, true, gui.Tr.SLocalize("CherryPick"), gui.Tr.SLocalize("SureCherryPick"), func(g *gocui.Gui, v *gocui.View) error {
-		return gui.WithWaitingStatus(gui.Tr.SLocalize("CherryPickingStatus"), func() error {
-			err := gui.GitCommand.CherryPickCommits(gui.State.CherryPickedCommits)
-			return gui.handleGenericMergeCommandResult(err)
+	commits := gui.State.CherryPickedCommits
+	if len(commits) == 0 {
+		return nil
+	}
+
+	branchName := ""
+	if branch := gui.getCheckedOutBranch(); branch != nil {
+		branchName = branch.Name
+	}
+	title := gui.Tr.TemplateLocalize("CherryPickPreviewTitle", Teml{
+		"count":  strconv.Itoa(len(commits)),
+		"branch": branchName,
+	})
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("CherryPickConfirm"), ""},
+			onPress: func() error {
+				return gui.WithWaitingStatus(gui.Tr.SLocalize("CherryPickingStatus"), func() error {
+					err := gui.GitCommand.CherryPickCommits(gui.State.CherryPickedCommits)
+					return gui.handleGenericMergeCommandResult(err)
+				})
+			},
+		},
+	}
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{utils.ColoredString(commit.ShortSha(), color.FgYellow), commit.Name},
+			onPress: func() error {
+				// pressing a commit drops it, after which we show what's left
+				gui.removeFromCherryPickedCommits(commit.Sha)
+				gui.g.Update(func(*gocui.Gui) error {
+					return gui.HandlePasteCommits(g, v)
+				})
+				return gui.refreshCommits(gui.g)
+			},
 		})
-	}, nil)
+	}
+
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) removeFromCherryPickedCommits(sha string) {
+	commits := []*commands.Commit{}
+	for _, commit := range gui.State.CherryPickedCommits {
+		if commit.Sha != sha {
+			commits = append(commits, commit)
+		}
+	}
+	gui.State.CherryPickedCommits = commits
 }
 
 func (gui *Gui) handleSwitchToCommitFilesPanel(g *gocui.Gui, v *gocui.View) error {
+	gui.endComparison()
 	if err := gui.refreshCommitFilesView(); err != nil {
 		return err
 	}
@@ -545,11 +785,11 @@ func (gui *Gui) handleCheckoutCommit(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) renderBranchCommitsWithSelection() error {
-	commitsView := gui.getCommitsView()
+	if err := gui.renderBranchCommits(); err != nil {
+		return err
+	}
 
-	gui.refreshSelectedLine(&gui.State.Panels.Commits.SelectedLine, len(gui.State.Commits))
-	displayStrings := presentation.GetCommitListDisplayStrings(gui.State.Commits, gui.State.ScreenMode != SCREEN_NORMAL)
-	gui.renderDisplayStrings(commitsView, displayStrings)
+	commitsView := gui.getCommitsView()
 	if gui.g.CurrentView() == commitsView && commitsView.Context == "branch-commits" {
 		if err := gui.handleCommitSelect(gui.g, commitsView); err != nil {
 			return err
@@ -559,6 +799,22 @@ func (gui *Gui) renderBranchCommitsWithSelection() error {
 	return nil
 }
 
+func (gui *Gui) renderBranchCommits() error {
+	commitsView := gui.getCommitsView()
+
+	commits := gui.visibleCommits()
+	gui.refreshSelectedLine(&gui.State.Panels.Commits.SelectedLine, len(commits))
+	commitsView.Tabs[0] = gui.getCommitsTabTitle()
+	showStats := gui.Config.GetUserConfig().GetBool("gui.showCommitStats")
+	if showStats {
+		gui.loadCommitStats(commits)
+	}
+	showDecorations := gui.Config.GetUserConfig().GetBool("gui.showCommitDecorations")
+	displayStrings := presentation.GetCommitListDisplayStrings(commits, gui.State.ScreenMode != SCREEN_NORMAL, showStats, showDecorations)
+	gui.renderDisplayStrings(commitsView, displayStrings)
+	return nil
+}
+
 func (gui *Gui) onCommitsTabClick(tabIndex int) error {
 	contexts := []string{"branch-commits", "reflog-commits"}
 	commitsView := gui.getCommitsView()
@@ -581,6 +837,10 @@ func (gui *Gui) switchCommitsPanelContext(context string) error {
 
 	commitsView.TabIndex = contextTabIndexMap[context]
 
+	if !gui.contextLoaded(context) {
+		return gui.loadContext(context)
+	}
+
 	return gui.refreshCommitsViewWithSelection()
 }
 
@@ -0,0 +1,86 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// comparisonState is the two refs being compared in the commit files panel
+type comparisonState struct {
+	From string
+	To   string
+}
+
+// handleCreateComparison asks for two refs and then lists the files that
+// differ between them, whatever happens to be selected
+func (gui *Gui) handleCreateComparison(g *gocui.Gui, v *gocui.View) error {
+	return gui.pickRef(v, gui.Tr.SLocalize("CompareFromPrompt"), func(from string) error {
+		title := gui.Tr.TemplateLocalize("CompareToPrompt", Teml{"from": from})
+		return gui.pickRef(v, title, func(to string) error {
+			return gui.startComparison(from, to)
+		})
+	})
+}
+
+// pickRef prompts for a ref. Anything git can resolve to a commit is taken as
+// is, otherwise we look for branches and tags with the characters typed in
+// them, in order, and let the user choose if there's more than one.
+func (gui *Gui) pickRef(v *gocui.View, title string, onPick func(string) error) error {
+	return gui.createPromptPanel(gui.g, v, title, "", func(g *gocui.Gui, promptView *gocui.View) error {
+		query := gui.trimmedContent(promptView)
+		// the prompt closes once we return, so whatever comes next has to wait
+		// until then
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.resolveRef(query, onPick)
+		})
+		return nil
+	})
+}
+
+func (gui *Gui) resolveRef(query string, onPick func(string) error) error {
+	if query != "" && gui.GitCommand.IsCommitRef(query) {
+		return onPick(query)
+	}
+
+	refNames, err := gui.GitCommand.GetRefNames()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	matches := utils.FuzzyFilter(query, refNames)
+	switch len(matches) {
+	case 0:
+		return gui.createErrorPanel(gui.g, gui.Tr.TemplateLocalize("NoRefsMatch", Teml{"query": query}))
+	case 1:
+		return onPick(matches[0])
+	}
+
+	menuItems := make([]*menuItem, len(matches))
+	for i, ref := range matches {
+		ref := ref
+		menuItems[i] = &menuItem{
+			displayString: ref,
+			onPress: func() error {
+				gui.g.Update(func(*gocui.Gui) error {
+					return onPick(ref)
+				})
+				return nil
+			},
+		}
+	}
+	return gui.createMenu(gui.Tr.TemplateLocalize("PickRefTitle", Teml{"query": query}), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) startComparison(from string, to string) error {
+	gui.State.Comparison = &comparisonState{From: from, To: to}
+	gui.State.Panels.CommitFiles.SelectedLine = 0
+	if err := gui.refreshCommitFilesView(); err != nil {
+		return err
+	}
+	return gui.switchFocus(gui.g, nil, gui.getCommitFilesView())
+}
+
+// endComparison goes back to showing the files of the selected commit in the
+// commit files panel
+func (gui *Gui) endComparison() {
+	gui.State.Comparison = nil
+}
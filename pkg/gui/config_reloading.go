@@ -0,0 +1,124 @@
+package gui
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/gocui"
+	"github.com/spf13/viper"
+)
+
+// watchConfigFile polls the user's config file and reloads it when it changes,
+// so that tweaking keybindings or the theme doesn't require a restart. We poll
+// rather than using fsnotify because the file watcher has been disabled for
+// crashing on some platforms.
+func (gui *Gui) watchConfigFile() {
+	configPath := gui.Config.GetUserConfigPath()
+	lastModTime := getModTime(configPath)
+
+	gui.goEvery(gui.getPollInterval(time.Second), gui.stopChan, func() error {
+		modTime := getModTime(configPath)
+		if modTime.Equal(lastModTime) {
+			return nil
+		}
+
+		// popups set up their own keybindings which we'd clobber by reloading, so
+		// we'll wait until the user is done with the popup
+		if gui.popupPanelFocused() {
+			return nil
+		}
+
+		lastModTime = modTime
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.reloadUserConfig()
+		})
+		return nil
+	})
+}
+
+func getModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// reloadUserConfig reads the config file again and applies it. If the new
+// config is invalid we keep the old one and tell the user what's wrong.
+func (gui *Gui) reloadUserConfig() error {
+	userConfig, err := gui.Config.LoadUserConfig()
+	if err == nil {
+		err = validateUserConfig(userConfig)
+	}
+	if err != nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.TemplateLocalize(
+			"ConfigReloadFailed",
+			Teml{
+				"error": err.Error(),
+			},
+		))
+	}
+
+	gui.Config.SetUserConfig(userConfig)
+	gui.statusManager.staticLoader = gui.lowBandwidthMode()
+
+	if err := gui.setColorScheme(); err != nil {
+		return err
+	}
+
+	if err := gui.resetKeybindings(); err != nil {
+		return err
+	}
+
+	if err := gui.renderPanelOptions(); err != nil {
+		return err
+	}
+
+	gui.raiseToast(gui.Tr.SLocalize("ConfigReloaded"))
+
+	return nil
+}
+
+// validateUserConfig checks the parts of the config that would otherwise cause
+// us to bail at runtime
+func validateUserConfig(userConfig *viper.Viper) error {
+	errorMessages := []string{}
+	for _, key := range userConfig.AllKeys() {
+		if !strings.HasPrefix(key, "keybinding.") {
+			continue
+		}
+		if _, err := getKeyFromConfig(userConfig, strings.TrimPrefix(key, "keybinding.")); err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+// resetKeybindings swaps out our keybindings for ones built from the current
+// config
+func (gui *Gui) resetKeybindings() error {
+	bindings := gui.GetInitialKeybindings()
+
+	deletedViewNames := map[string]bool{}
+	for _, binding := range bindings {
+		if deletedViewNames[binding.ViewName] {
+			continue
+		}
+		gui.g.DeleteKeybindings(binding.ViewName)
+		deletedViewNames[binding.ViewName] = true
+	}
+
+	gui.g.SearchEscapeKey = gui.getKey("universal.return")
+	gui.g.NextSearchMatchKey = gui.getKey("universal.nextMatch")
+	gui.g.PrevSearchMatchKey = gui.getKey("universal.prevMatch")
+
+	return gui.setKeybindings(gui.g, bindings)
+}
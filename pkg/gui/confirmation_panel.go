@@ -20,7 +20,7 @@ func (gui *Gui) wrappedConfirmationFunction(function func(*gocui.Gui, *gocui.Vie
 
 		if function != nil {
 			if err := function(g, v); err != nil {
-				return err
+				return gui.refuseSubProcessIfReadOnly(err)
 			}
 		}
 
@@ -75,12 +75,13 @@ func (gui *Gui) prepareConfirmationPanel(currentView *gocui.View, title, prompt
 			return nil, err
 		}
 		confirmationView.HasLoader = hasLoader
-		if hasLoader {
+		if hasLoader && !gui.lowBandwidthMode() {
 			gui.g.StartTicking()
 		}
 		confirmationView.Title = title
 		confirmationView.Wrap = true
 		confirmationView.FgColor = theme.GocuiDefaultTextColor
+		confirmationView.Editor = gocui.EditorFunc(gui.defaultEditor)
 	}
 	gui.g.Update(func(g *gocui.Gui) error {
 		return gui.switchFocus(gui.g, currentView, confirmationView)
@@ -123,7 +124,7 @@ func (gui *Gui) createPopupPanel(g *gocui.Gui, currentView *gocui.View, title, p
 		}
 
 		gui.renderString(g, "confirmation", prompt)
-		return gui.setKeyBindings(g, handleConfirm, handleClose, returnFocusOnClose)
+		return gui.setKeyBindings(g, handleConfirm, handleClose, returnFocusOnClose, editable)
 	})
 	return nil
 }
@@ -141,7 +142,7 @@ func (gui *Gui) createPromptPanel(g *gocui.Gui, currentView *gocui.View, title s
 	return gui.createPopupPanel(gui.g, currentView, title, initialContent, false, true, true, handleConfirm, nil)
 }
 
-func (gui *Gui) setKeyBindings(g *gocui.Gui, handleConfirm, handleClose func(*gocui.Gui, *gocui.View) error, returnFocusOnClose bool) error {
+func (gui *Gui) setKeyBindings(g *gocui.Gui, handleConfirm, handleClose func(*gocui.Gui, *gocui.View) error, returnFocusOnClose bool, editable bool) error {
 	actions := gui.Tr.TemplateLocalize(
 		"CloseConfirm",
 		Teml{
@@ -150,10 +151,18 @@ func (gui *Gui) setKeyBindings(g *gocui.Gui, handleConfirm, handleClose func(*go
 		},
 	)
 	gui.renderString(g, "options", actions)
-	if err := g.SetKeybinding("confirmation", nil, gocui.KeyEnter, gocui.ModNone, gui.wrappedConfirmationFunction(handleConfirm, returnFocusOnClose)); err != nil {
+	onConfirm := gui.wrappedConfirmationFunction(handleConfirm, returnFocusOnClose)
+	onClose := gui.wrappedConfirmationFunction(handleClose, returnFocusOnClose)
+	if editable {
+		// prompts are a single line, so there's nowhere for a pasted newline to go
+		onConfirm = gui.wrappedEditorConfirm(onConfirm, false)
+		onClose = gui.wrappedEditorClose(onClose)
+	}
+
+	if err := g.SetKeybinding("confirmation", nil, gocui.KeyEnter, gocui.ModNone, onConfirm); err != nil {
 		return err
 	}
-	return g.SetKeybinding("confirmation", nil, gocui.KeyEsc, gocui.ModNone, gui.wrappedConfirmationFunction(handleClose, returnFocusOnClose))
+	return g.SetKeybinding("confirmation", nil, gocui.KeyEsc, gocui.ModNone, onClose)
 }
 
 // createSpecificErrorPanel allows you to create an error popup, specifying the
@@ -172,7 +181,7 @@ func (gui *Gui) createSpecificErrorPanel(message string, nextView *gocui.View, w
 		}()
 	}
 
-	colorFunction := color.New(color.FgRed).SprintFunc()
+	colorFunction := color.New(theme.CurrentPalette.Conflict).SprintFunc()
 	coloredMessage := colorFunction(strings.TrimSpace(message))
 	return gui.createConfirmationPanel(gui.g, nextView, true, gui.Tr.SLocalize("Error"), coloredMessage, nil, nil)
 }
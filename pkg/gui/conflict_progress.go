@@ -0,0 +1,160 @@
+package gui
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// trackConflictedFiles remembers which files have had conflicts since the
+// merge, rebase or unstash began. Once a conflict is resolved git no longer
+// tells us the file was ever conflicted, so without this we couldn't say how
+// far along the user is.
+func (gui *Gui) trackConflictedFiles() {
+	if gui.State.WorkingTreeState == "normal" {
+		gui.State.ConflictedFileNames = nil
+		return
+	}
+
+	for _, file := range gui.State.Files {
+		if !file.HasMergeConflicts {
+			continue
+		}
+		if gui.State.ConflictedFileNames == nil {
+			gui.State.ConflictedFileNames = map[string]bool{}
+		}
+		gui.State.ConflictedFileNames[file.Name] = true
+	}
+}
+
+// getConflictProgress describes how far through resolving conflicts we are,
+// along with which commit is being applied if we're part way through a
+// rebase, cherry-pick or revert. It's empty if there's nothing to resolve.
+func (gui *Gui) getConflictProgress() string {
+	total := len(gui.State.ConflictedFileNames)
+	if total == 0 {
+		return ""
+	}
+
+	remaining := 0
+	for _, file := range gui.State.Files {
+		if file.HasMergeConflicts {
+			remaining++
+		}
+	}
+	progress := gui.Tr.TemplateLocalize("ConflictsResolvedProgress", Teml{
+		"resolved": strconv.Itoa(total - remaining),
+		"total":    strconv.Itoa(total),
+	})
+
+	sequenceProgress := gui.GitCommand.GetSequenceProgress()
+	if sequenceProgress == nil {
+		return progress
+	}
+
+	commit := (&commands.Commit{Sha: sequenceProgress.Sha}).ShortSha()
+	if sequenceProgress.Name != "" {
+		commit += " " + sequenceProgress.Name
+	}
+	switch {
+	case sequenceProgress.Total > 0:
+		progress += ", " + gui.Tr.TemplateLocalize("ApplyingCommitProgress", Teml{
+			"action":  sequenceProgress.Action,
+			"commit":  commit,
+			"current": strconv.Itoa(sequenceProgress.Current),
+			"total":   strconv.Itoa(sequenceProgress.Total),
+		})
+	case sequenceProgress.Queued > 0:
+		progress += ", " + gui.Tr.TemplateLocalize("ApplyingCommitQueued", Teml{
+			"action": sequenceProgress.Action,
+			"commit": commit,
+			"queued": strconv.Itoa(sequenceProgress.Queued),
+		})
+	default:
+		progress += ", " + gui.Tr.TemplateLocalize("ApplyingCommit", Teml{
+			"action": sequenceProgress.Action,
+			"commit": commit,
+		})
+	}
+	return progress
+}
+
+// getConflictResolutionSummary describes how each file that had conflicts was
+// resolved, compared to both sides, flagging any file that ended up the same
+// as one side given that's how you notice you took the wrong side
+func (gui *Gui) getConflictResolutionSummary() (string, error) {
+	fileNames := make([]string, 0, len(gui.State.ConflictedFileNames))
+	for fileName := range gui.State.ConflictedFileNames {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	resolutions, err := gui.GitCommand.GetConflictResolutions(fileNames)
+	if err != nil || len(resolutions) == 0 {
+		return "", err
+	}
+
+	lines := make([]string, len(resolutions))
+	for i, resolution := range resolutions {
+		line := gui.Tr.TemplateLocalize("ConflictResolutionLine", Teml{
+			"file":          resolution.Name,
+			"oursAdded":     strconv.Itoa(resolution.OursAdded),
+			"oursDeleted":   strconv.Itoa(resolution.OursDeleted),
+			"theirsAdded":   strconv.Itoa(resolution.TheirsAdded),
+			"theirsDeleted": strconv.Itoa(resolution.TheirsDeleted),
+		})
+		switch {
+		case resolution.MatchesOurs():
+			line += " " + utils.ColoredString(gui.Tr.SLocalize("ResolvedAsOurs"), color.FgYellow)
+		case resolution.MatchesTheirs():
+			line += " " + utils.ColoredString(gui.Tr.SLocalize("ResolvedAsTheirs"), color.FgYellow)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// confirmContinue asks before continuing a merge or rebase, showing how the
+// conflicts were resolved if there were any
+func (gui *Gui) confirmContinue(prompt string) error {
+	summary, err := gui.getConflictResolutionSummary()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if summary != "" {
+		prompt = summary + "\n\n" + prompt
+	}
+
+	return gui.createConfirmationPanel(gui.g, gui.getFilesView(), true, "continue", prompt, func(g *gocui.Gui, v *gocui.View) error {
+		return gui.genericMergeCommand("continue")
+	}, nil)
+}
+
+// startResolvingConflicts is for when we're started with --resolve, e.g. by
+// 'git mergetool'. It opens the given file in the merge panel, or the first
+// file with conflicts if no file is given.
+func (gui *Gui) startResolvingConflicts(fileName string) error {
+	if gui.State.WorkingTreeState == "normal" {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NotMergingOrRebasing"))
+	}
+
+	fileName = filepath.ToSlash(filepath.Clean(fileName))
+	for i, file := range gui.visibleFiles() {
+		if !file.HasInlineMergeConflicts || (fileName != "." && file.Name != fileName) {
+			continue
+		}
+		gui.State.Panels.Files.SelectedLine = i
+		if _, err := gui.g.SetCurrentView(gui.getFilesView().Name()); err != nil {
+			return err
+		}
+		return gui.handleSwitchToMerge(gui.g, gui.getFilesView())
+	}
+
+	return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoConflictsToResolve"))
+}
@@ -15,5 +15,9 @@ func (gui *Gui) changeMainViewsContext(context string) {
 		gui.getSecondaryView().Context = context
 	}
 
+	if context == "normal" {
+		gui.applyMainViewLineWrap()
+	}
+
 	gui.State.MainContext = context
 }
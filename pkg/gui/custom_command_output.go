@@ -0,0 +1,271 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+)
+
+// Where a custom command's output goes is up to customCommandOutput:
+//   - terminal: lazygit steps aside and the command runs in the terminal
+//   - popup: the output is streamed into a popup as the command runs, where
+//     it can be cancelled
+//   - main: the output is streamed into the main panel
+//   - log: the command runs in the background and its output is kept in the
+//     custom command log
+//   - none: the command runs in the background and its output is discarded,
+//     unless it fails
+//   - ask: we ask which of those each time
+
+// customCommandLogEntry is a custom command that was run with its output going
+// to the log
+type customCommandLogEntry struct {
+	command  string
+	output   string
+	failed   bool
+	finished time.Time
+}
+
+func (gui *Gui) handleCustomCommand(g *gocui.Gui, v *gocui.View) error {
+	return gui.createPromptPanel(g, v, gui.Tr.SLocalize("CustomCommand"), "", func(g *gocui.Gui, v *gocui.View) error {
+		command := gui.resolveCustomCommand(gui.trimmedContent(v))
+		output := gui.Config.GetUserConfig().GetString("customCommandOutput")
+		if output != "ask" {
+			return gui.runCustomCommand(command, output)
+		}
+
+		// the prompt closes once we return, so the menu has to wait until then
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.createCustomCommandOutputMenu(command)
+		})
+		return nil
+	})
+}
+
+func (gui *Gui) createCustomCommandOutputMenu(command string) error {
+	outputs := []string{"terminal", "popup", "main", "log", "none"}
+	menuItems := make([]*menuItem, len(outputs))
+	for i, output := range outputs {
+		output := output
+		menuItems[i] = &menuItem{
+			displayStrings: []string{output, gui.Tr.SLocalize("CustomCommandOutput-" + output)},
+			onPress: func() error {
+				return gui.runCustomCommand(command, output)
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("CustomCommandOutputTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) runCustomCommand(command string, output string) error {
+	if err := gui.OSCommand.CheckReadOnly(command); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	switch output {
+	case "popup":
+		return gui.streamCustomCommand(command)
+	case "main":
+		gui.getMainView().Title = gui.Tr.TemplateLocalize("CustomCommandOutputMainTitle", Teml{"command": command})
+		gui.State.SplitMainPanel = false
+		return gui.newCmdTask("main", gui.OSCommand.RunCustomCommand(command))
+	case "log", "none":
+		go func() {
+			result, err := gui.OSCommand.RunExecutableWithOutput(gui.OSCommand.RunCustomCommand(command))
+			gui.g.Update(func(*gocui.Gui) error {
+				if output == "log" {
+					gui.State.CustomCommandLog = append(gui.State.CustomCommandLog, &customCommandLogEntry{
+						command:  command,
+						output:   result,
+						failed:   err != nil,
+						finished: time.Now(),
+					})
+				}
+				if err != nil {
+					if output == "log" {
+						gui.raiseToast(gui.Tr.TemplateLocalize("CustomCommandFailedToast", Teml{"command": command}))
+						return nil
+					}
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				gui.raiseToast(gui.Tr.TemplateLocalize("CustomCommandFinishedToast", Teml{"command": command}))
+				return gui.refreshSidePanels(gui.g)
+			})
+		}()
+		return nil
+	default:
+		gui.SubProcess = gui.OSCommand.RunCustomCommand(command)
+		return gui.Errors.ErrSubProcess
+	}
+}
+
+// handleViewCustomCommandLog shows the output of the custom commands that were
+// run in the background, most recent first, after the git trace if we've been
+// tracing
+func (gui *Gui) handleViewCustomCommandLog(g *gocui.Gui, v *gocui.View) error {
+	entries := gui.State.CustomCommandLog
+	gitTrace := gui.gitTrace()
+	if len(entries) == 0 && gitTrace == "" {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoCustomCommandLog"))
+	}
+
+	var builder strings.Builder
+	if gitTrace != "" {
+		fmt.Fprintf(&builder, "%s\n%s\n\n", gui.Tr.SLocalize("GitTraceHeading"), gitTrace)
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		status := gui.Tr.SLocalize("CustomCommandSucceeded")
+		if entry.failed {
+			status = gui.Tr.SLocalize("CustomCommandFailed")
+		}
+		fmt.Fprintf(&builder, "$ %s  (%s, %s)\n%s\n\n", entry.command, status, entry.finished.Format("15:04:05"), strings.TrimRight(entry.output, "\n"))
+	}
+
+	gui.getMainView().Title = gui.Tr.SLocalize("CustomCommandLogTitle")
+	gui.State.SplitMainPanel = false
+	return gui.newStringTask("main", builder.String())
+}
+
+// customCommandRun is a custom command whose output is being streamed into the
+// customCommandOutput popup
+type customCommandRun struct {
+	running   bool
+	cancelled bool
+}
+
+func (gui *Gui) getCustomCommandOutputDimensions(g *gocui.Gui) (int, int, int, int) {
+	width, height := g.Size()
+	return width / 8, height / 8, width - width/8, height - height/8
+}
+
+// streamCustomCommand runs a custom command with its output streamed into a
+// popup, so that the user can watch it and cancel it if it's taking too long
+func (gui *Gui) streamCustomCommand(command string) error {
+	if gui.customCommandRunning() {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CustomCommandAlreadyRunning"))
+	}
+
+	title := gui.Tr.TemplateLocalize("CustomCommandOutputMainTitle", Teml{"command": command})
+	return gui.streamCommand(title, gui.OSCommand.RunCustomCommand(command), nil)
+}
+
+func (gui *Gui) customCommandRunning() bool {
+	run := gui.State.CustomCommandRun
+	return run != nil && run.running
+}
+
+// streamCommand runs a command with its output streamed into the
+// customCommandOutput popup. onDone is called on the UI thread once the command
+// has finished, whether or not the popup is still open
+func (gui *Gui) streamCommand(title string, cmd *exec.Cmd, onDone func(error) error) error {
+	x0, y0, x1, y1 := gui.getCustomCommandOutputDimensions(gui.g)
+	v, err := gui.g.SetView("customCommandOutput", x0, y0, x1, y1, 0)
+	if err != nil && err.Error() != "unknown view" {
+		return err
+	}
+	v.Title = title
+	v.Wrap = true
+	v.Autoscroll = true
+	v.FgColor = theme.GocuiDefaultTextColor
+	if err := gui.switchFocus(gui.g, gui.g.CurrentView(), v); err != nil {
+		return err
+	}
+
+	start := func(ctx context.Context) (*exec.Cmd, io.ReadCloser, error) {
+		ctxCmd := commands.CommandWithContext(ctx, cmd)
+		r, err := ctxCmd.StdoutPipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		ctxCmd.Stderr = ctxCmd.Stdout
+		if err := ctxCmd.Start(); err != nil {
+			return nil, nil, err
+		}
+		return ctxCmd, r, nil
+	}
+
+	run := &customCommandRun{running: true}
+	gui.State.CustomCommandRun = run
+	manager := gui.getManager(v)
+	return manager.NewTask(manager.NewStreamingCmdTask(start, func(err error) {
+		gui.g.Update(func(g *gocui.Gui) error {
+			run.running = false
+			if onDone != nil {
+				if err := onDone(err); err != nil {
+					return err
+				}
+			}
+			v, viewErr := g.View("customCommandOutput")
+			if viewErr != nil || gui.State.CustomCommandRun != run {
+				return nil
+			}
+			status := gui.Tr.SLocalize("CustomCommandSucceeded")
+			if run.cancelled {
+				status = gui.Tr.SLocalize("CustomCommandCancelled")
+			} else if err != nil {
+				status = err.Error()
+			}
+			v.Title = fmt.Sprintf("%s (%s)", v.Title, status)
+			return gui.refreshSidePanels(gui.g)
+		})
+	}))
+}
+
+// handleCancelCustomCommand kills the custom command being streamed into the
+// popup, leaving what it output so far to be read
+func (gui *Gui) handleCancelCustomCommand(g *gocui.Gui, v *gocui.View) error {
+	run := gui.State.CustomCommandRun
+	if run == nil || !run.running {
+		return nil
+	}
+	run.cancelled = true
+	if manager, ok := gui.viewBufferManagerMap["customCommandOutput"]; ok {
+		// this waits for the command to die, so we don't want to hold up the UI
+		go manager.Close()
+	}
+	return nil
+}
+
+// handleCloseCustomCommandOutput closes the popup, cancelling the command if
+// it's still running
+func (gui *Gui) handleCloseCustomCommandOutput(g *gocui.Gui, v *gocui.View) error {
+	if err := gui.handleCancelCustomCommand(g, v); err != nil {
+		return err
+	}
+	return gui.closeCustomCommandOutput(g, v)
+}
+
+func (gui *Gui) closeCustomCommandOutput(g *gocui.Gui, v *gocui.View) error {
+	delete(gui.viewBufferManagerMap, "customCommandOutput")
+	if err := gui.returnFocus(g, v); err != nil {
+		return err
+	}
+	return g.DeleteView("customCommandOutput")
+}
+
+func (gui *Gui) handleScrollUpCustomCommandOutput(g *gocui.Gui, v *gocui.View) error {
+	// we'd be dragged back down to the bottom as more output comes in
+	v.Autoscroll = false
+	return gui.scrollUpView(v.Name())
+}
+
+func (gui *Gui) handleScrollDownCustomCommandOutput(g *gocui.Gui, v *gocui.View) error {
+	if err := gui.scrollDownView(v.Name()); err != nil {
+		return err
+	}
+	_, height := v.Size()
+	_, oy := v.Origin()
+	if oy+height >= v.ViewLinesHeight() {
+		v.Autoscroll = true
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+var placeholderRegexp = regexp.MustCompile(`{{(\w+)}}`)
+
+// resolveCustomCommand fills in the placeholders in a custom command. The
+// user's own variables from customCommandVariables go in first, so that they
+// can be made out of the others. Names and paths are quoted for the shell.
+func (gui *Gui) resolveCustomCommand(command string) string {
+	// our config library lowercases keys, so the user's variables can't be
+	// told apart by case
+	variables := gui.Config.GetUserConfig().GetStringMapString("customCommandVariables")
+	command = placeholderRegexp.ReplaceAllStringFunc(command, func(placeholder string) string {
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+		if value, ok := variables[strings.ToLower(name)]; ok {
+			return value
+		}
+		return placeholder
+	})
+	return utils.ResolvePlaceholderString(command, gui.customCommandPlaceholders())
+}
+
+// customCommandPlaceholders returns what's selected in each panel, along with
+// anything else a custom command might want to know about. Placeholders for
+// things that aren't there (e.g. no hunk when we're not staging lines) are
+// left out, leaving them in the command as typed.
+func (gui *Gui) customCommandPlaceholders() map[string]string {
+	quote := gui.OSCommand.Quote
+	placeholders := map[string]string{}
+
+	if repoRoot, err := os.Getwd(); err == nil {
+		placeholders["repoRoot"] = quote(repoRoot)
+	}
+	if branch := gui.getCheckedOutBranch(); branch != nil {
+		placeholders["checkedOutBranch"] = quote(branch.Name)
+	}
+	if file, err := gui.getSelectedFile(gui.g); err == nil {
+		placeholders["selectedFile"] = quote(file.Name)
+	}
+	if selectedLine := gui.State.Panels.Branches.SelectedLine; selectedLine >= 0 && selectedLine < len(gui.State.Branches) {
+		placeholders["selectedBranch"] = quote(gui.State.Branches[selectedLine].Name)
+	}
+	if commit := gui.getSelectedCommit(gui.g); commit != nil {
+		placeholders["selectedCommit"] = commit.Sha
+	}
+	if remote := gui.getSelectedRemote(); remote != nil {
+		placeholders["selectedRemote"] = quote(remote.Name)
+	}
+	if selectedLine := gui.State.Panels.Stash.SelectedLine; selectedLine >= 0 && selectedLine < len(gui.State.StashEntries) {
+		placeholders["selectedStashIndex"] = fmt.Sprintf("%d", gui.State.StashEntries[selectedLine].Index)
+	}
+
+	// the hunk being staged, or added to a patch, in the main view
+	if state := gui.State.Panels.LineByLine; state != nil && state.PatchParser != nil {
+		if hunk := state.PatchParser.GetHunkContainingLine(state.SelectedLineIdx, 0); hunk != nil {
+			start, end := hunk.NewLineRange()
+			placeholders["selectedHunkStart"] = fmt.Sprintf("%d", start)
+			placeholders["selectedHunkEnd"] = fmt.Sprintf("%d", end)
+			if gui.State.MainContext == "patch-building" {
+				placeholders["selectedHunkFile"] = quote(gui.getSelectedCommitFileName())
+			} else if file, err := gui.getSelectedFile(gui.g); err == nil {
+				placeholders["selectedHunkFile"] = quote(file.Name)
+			}
+		}
+	}
+
+	// what the diff being shown is against, when it isn't against the index
+	if comparison := gui.State.Comparison; comparison != nil {
+		placeholders["diffBase"] = quote(comparison.From)
+	} else if fileRefDiff := gui.State.FileRefDiff; fileRefDiff != nil {
+		placeholders["diffBase"] = quote(fileRefDiff.Ref)
+	}
+
+	return placeholders
+}
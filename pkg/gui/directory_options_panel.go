@@ -0,0 +1,76 @@
+package gui
+
+import (
+	"path"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// fileDirectories returns the directories a file is in, innermost first. A
+// file at the top of the repo is just in '.'.
+func fileDirectories(fileName string) []string {
+	// in case of a renamed file we get the new filename
+	split := strings.Split(fileName, " -> ")
+	dirs := []string{}
+	for dir := path.Dir(split[len(split)-1]); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		dirs = append(dirs, ".")
+	}
+	return dirs
+}
+
+// handleCreateDirectoryOptionsMenu offers to show the log of, or diff, each of
+// the directories the selected file is in
+func (gui *Gui) handleCreateDirectoryOptionsMenu(g *gocui.Gui, v *gocui.View) error {
+	file, err := gui.getSelectedFile(g)
+	if err != nil {
+		if err != gui.Errors.ErrNoFiles {
+			return err
+		}
+		return nil
+	}
+
+	menuItems := []*menuItem{}
+	for _, dir := range fileDirectories(file.Name) {
+		dir := dir
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.TemplateLocalize("ShowDirectoryLog", Teml{"dir": dir}),
+			onPress: func() error {
+				return gui.showDirectoryLog(dir)
+			},
+		}, &menuItem{
+			displayString: gui.Tr.TemplateLocalize("DiffDirectoryAgainstRef", Teml{"dir": dir}),
+			onPress: func() error {
+				// the menu closes once we return, so the prompt has to wait until then
+				gui.g.Update(func(*gocui.Gui) error {
+					return gui.handleDiffDirectoryAgainstRef(v, dir)
+				})
+				return nil
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("DirectoryOptionsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// showDirectoryLog filters the commits panel down to the commits touching a
+// directory
+func (gui *Gui) showDirectoryLog(dir string) error {
+	if err := gui.setCommitFilter("path:" + dir); err != nil {
+		return err
+	}
+	return gui.switchFocus(gui.g, nil, gui.getCommitsView())
+}
+
+func (gui *Gui) handleDiffDirectoryAgainstRef(v *gocui.View, dir string) error {
+	title := gui.Tr.TemplateLocalize("DiffDirectoryAgainstRefPrompt", Teml{"dir": dir})
+	return gui.pickRef(v, title, func(ref string) error {
+		gui.getMainView().Title = gui.Tr.TemplateLocalize("DirectoryDiffTitle", Teml{"dir": dir, "ref": ref})
+		cmd := gui.OSCommand.ExecutableFromString(gui.GitCommand.PathDiffCmdStr(ref, dir))
+		return gui.newPtyTask("main", cmd, commands.PagingContextFiles)
+	})
+}
@@ -1,7 +1,10 @@
 package gui
 
 import (
+	"strings"
+
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 )
 
 func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
@@ -17,6 +20,9 @@ func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
 		{
 			displayString: gui.Tr.SLocalize("discardAllChanges"),
 			onPress: func() error {
+				if err := gui.backupFile(file); err != nil {
+					return err
+				}
 				if err := gui.GitCommand.DiscardAllFileChanges(file); err != nil {
 					return err
 				}
@@ -29,6 +35,9 @@ func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
 		menuItems = append(menuItems, &menuItem{
 			displayString: gui.Tr.SLocalize("discardUnstagedChanges"),
 			onPress: func() error {
+				if err := gui.backupFile(file); err != nil {
+					return err
+				}
 				if err := gui.GitCommand.DiscardUnstagedFileChanges(file); err != nil {
 					return err
 				}
@@ -40,3 +49,40 @@ func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.createMenu(file.Name, menuItems, createMenuOptions{showCancel: true})
 }
+
+// backupFile copies a file out of the way before we discard its changes, if
+// the user has asked us to, so that it can be restored later
+func (gui *Gui) backupFile(file *commands.File) error {
+	if !gui.Config.GetUserConfig().GetBool("gui.backupDiscardedFiles") {
+		return nil
+	}
+
+	split := strings.Split(file.Name, " -> ") // in case of a renamed file we want the new filename
+	return gui.GitCommand.BackupFile(split[len(split)-1])
+}
+
+func (gui *Gui) handleCreateRestoreBackupMenu(g *gocui.Gui, v *gocui.View) error {
+	backups, err := gui.GitCommand.GetBackups()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if len(backups) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoBackups"))
+	}
+
+	menuItems := make([]*menuItem, len(backups))
+	for i, backup := range backups {
+		backup := backup
+		menuItems[i] = &menuItem{
+			displayStrings: []string{backup.Time.Format("2006-01-02 15:04:05"), backup.FileName},
+			onPress: func() error {
+				if err := gui.GitCommand.RestoreBackup(backup); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				return gui.refreshFiles()
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("RestoreBackupTitle"), menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,335 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// termbox doesn't know about bracketed paste, or about the escape sequences
+// terminals send for alt+b, ctrl+left and friends. Any escape sequence it
+// doesn't recognise reaches us as an escape keypress followed by the rest of
+// the sequence as plain characters, all in quick succession. So when escape is
+// pressed in an editable view we hold off on closing the view until we know
+// whether it's the start of a sequence we care about.
+
+const (
+	bracketedPasteStart = "[200~"
+	bracketedPasteEnd   = "[201~"
+
+	enableBracketedPaste  = "\x1b[?2004h"
+	disableBracketedPaste = "\x1b[?2004l"
+
+	// the rest of an escape sequence arrives in the same read as the escape
+	// itself, so if nothing has turned up by now the user really did press
+	// escape
+	escapeSequenceTimeout = 50 * time.Millisecond
+)
+
+type editorAction func(v *gocui.View)
+
+var editorEscapeSequences = map[string]editorAction{
+	"b":     moveCursorByWord(false), // alt+b
+	"f":     moveCursorByWord(true),  // alt+f
+	"d":     deleteWord(true),        // alt+d
+	"[1;5D": moveCursorByWord(false), // ctrl+left
+	"[1;5C": moveCursorByWord(true),  // ctrl+right
+	"[1;3D": moveCursorByWord(false), // alt+left
+	"[1;3C": moveCursorByWord(true),  // alt+right
+	"[1~":   (*gocui.View).EditGotoToStartOfLine,
+	"[4~":   (*gocui.View).EditGotoToEndOfLine,
+	"[H":    (*gocui.View).EditGotoToStartOfLine,
+	"[F":    (*gocui.View).EditGotoToEndOfLine,
+	"[3;5~": deleteWord(true), // ctrl+delete
+	"[3;3~": deleteWord(true), // alt+delete
+	"[1;5H": (*gocui.View).EditGotoToStartOfLine,
+	"[1;5F": (*gocui.View).EditGotoToEndOfLine,
+}
+
+type editorState struct {
+	// Pasting is true between the start and end of a bracketed paste
+	Pasting bool
+	// BracketedPaste is whether we've asked the terminal for bracketed paste
+	BracketedPaste bool
+	// EscapeSequence is the escape sequence we're in the middle of receiving,
+	// if any
+	EscapeSequence *escapeSequence
+}
+
+type escapeSequence struct {
+	chars string
+	// onTimeout is what the escape key would have done had it been pressed on
+	// its own
+	onTimeout func() error
+}
+
+// updateBracketedPaste asks the terminal to mark pastes while an editable view
+// is focused. We leave it off otherwise, because in the other views a paste
+// is just a lot of keypresses and the markers would only add to them.
+func (gui *Gui) updateBracketedPaste() {
+	v := gui.g.CurrentView()
+	gui.setBracketedPaste(v != nil && v.Editable)
+}
+
+func (gui *Gui) setBracketedPaste(enabled bool) {
+	// termbox talks to the windows console directly rather than via escape
+	// sequences, so there's nothing we can do there
+	if runtime.GOOS == "windows" || gui.editorState.BracketedPaste == enabled {
+		return
+	}
+	gui.editorState.BracketedPaste = enabled
+
+	if enabled {
+		fmt.Fprint(os.Stdout, enableBracketedPaste)
+	} else {
+		fmt.Fprint(os.Stdout, disableBracketedPaste)
+		gui.editorState.Pasting = false
+	}
+}
+
+// wrappedEditorConfirm wraps the handler for enter in an editable view so that
+// the newlines in a paste don't submit it. multiline views get the newline,
+// anything else drops it.
+func (gui *Gui) wrappedEditorConfirm(handler func(*gocui.Gui, *gocui.View) error, multiline bool) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		gui.editorState.EscapeSequence = nil
+		if gui.editorState.Pasting {
+			if multiline {
+				v.EditNewLine()
+			}
+			return nil
+		}
+		return handler(g, v)
+	}
+}
+
+// wrappedEditorClose wraps the handler for escape in an editable view so that
+// the view is only closed if the escape isn't the start of an escape sequence
+func (gui *Gui) wrappedEditorClose(handler func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		sequence := &escapeSequence{
+			onTimeout: func() error { return handler(g, v) },
+		}
+		gui.editorState.EscapeSequence = sequence
+
+		time.AfterFunc(escapeSequenceTimeout, func() {
+			gui.g.Update(func(*gocui.Gui) error {
+				if gui.editorState.EscapeSequence != sequence {
+					return nil
+				}
+				gui.editorState.EscapeSequence = nil
+				if gui.editorState.Pasting {
+					// an escape in the middle of a paste isn't the user trying to leave
+					return nil
+				}
+				return sequence.onTimeout()
+			})
+		})
+
+		return nil
+	}
+}
+
+// handleEscapeSequence feeds a keypress into the escape sequence we're in the
+// middle of receiving, returning true if the keypress was part of it
+func (gui *Gui) handleEscapeSequence(v *gocui.View, key gocui.Key, ch rune) bool {
+	sequence := gui.editorState.EscapeSequence
+	if sequence == nil {
+		return false
+	}
+
+	if ch == 0 {
+		gui.editorState.EscapeSequence = nil
+		if sequence.chars == "" && (key == gocui.KeyBackspace || key == gocui.KeyBackspace2) {
+			// alt+backspace
+			deleteWord(false)(v)
+			return true
+		}
+		return false
+	}
+
+	sequence.chars += string(ch)
+
+	switch sequence.chars {
+	case bracketedPasteStart:
+		gui.editorState.EscapeSequence = nil
+		gui.editorState.Pasting = true
+		return true
+	case bracketedPasteEnd:
+		gui.editorState.EscapeSequence = nil
+		gui.editorState.Pasting = false
+		return true
+	}
+
+	if action, ok := editorEscapeSequences[sequence.chars]; ok {
+		gui.editorState.EscapeSequence = nil
+		action(v)
+		return true
+	}
+
+	known := []string{bracketedPasteStart, bracketedPasteEnd}
+	for chars := range editorEscapeSequences {
+		known = append(known, chars)
+	}
+	for _, chars := range known {
+		if strings.HasPrefix(chars, sequence.chars) {
+			// wait for the rest of it
+			return true
+		}
+	}
+
+	// a sequence we don't know, which we swallow rather than dump into the
+	// user's text
+	gui.editorState.EscapeSequence = nil
+	return true
+}
+
+// defaultEditor is the editor for our editable views. On top of gocui's default
+// editor it handles escape sequences, home/end, and some readline-style
+// shortcuts for moving and deleting by word or to the end of the line.
+func (gui *Gui) defaultEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	if gui.handleEscapeSequence(v, key, ch) {
+		return
+	}
+
+	switch {
+	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+		v.EditDelete(true)
+	case key == gocui.KeyDelete:
+		v.EditDelete(false)
+	case key == gocui.KeyArrowDown:
+		v.MoveCursor(0, 1, false)
+	case key == gocui.KeyArrowUp:
+		v.MoveCursor(0, -1, false)
+	case key == gocui.KeyArrowLeft:
+		v.MoveCursor(-1, 0, false)
+	case key == gocui.KeyArrowRight:
+		v.MoveCursor(1, 0, false)
+	case key == gocui.KeyTab:
+		if gui.editorState.Pasting {
+			v.EditWrite(' ')
+		} else {
+			v.EditNewLine()
+		}
+	case key == gocui.KeySpace:
+		v.EditWrite(' ')
+	case key == gocui.KeyInsert:
+		v.Overwrite = !v.Overwrite
+	case key == gocui.KeyCtrlU:
+		v.EditDeleteToStartOfLine()
+	case key == gocui.KeyCtrlK:
+		deleteToEndOfLine(v)
+	case key == gocui.KeyCtrlW:
+		deleteWord(false)(v)
+	case key == gocui.KeyCtrlA || key == gocui.KeyHome:
+		v.EditGotoToStartOfLine()
+	case key == gocui.KeyCtrlE || key == gocui.KeyEnd:
+		v.EditGotoToEndOfLine()
+	case ch != 0:
+		v.EditWrite(ch)
+	}
+}
+
+func isWordRune(ch rune) bool {
+	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_'
+}
+
+// wordDistance returns how many characters lie between the cursor and the
+// start of the previous word, or the end of the next one, counting line breaks
+// as a character
+func wordDistance(v *gocui.View, forward bool) int {
+	cx, cy := v.Cursor()
+	ox, oy := v.Origin()
+	x, y := ox+cx, oy+cy
+
+	lines := v.ViewBufferLines()
+	if y >= len(lines) {
+		return 0
+	}
+
+	// the characters from the cursor to the start or end of the buffer, in the
+	// order we'd come across them
+	chars := []rune{}
+	if forward {
+		for i := y; i < len(lines); i++ {
+			line := []rune(lines[i])
+			if i == y {
+				line = line[min(x, len(line)):]
+			} else {
+				chars = append(chars, '\n')
+			}
+			chars = append(chars, line...)
+		}
+	} else {
+		for i := y; i >= 0; i-- {
+			line := []rune(lines[i])
+			if i == y {
+				line = line[:min(x, len(line))]
+			} else {
+				chars = append(chars, '\n')
+			}
+			for j := len(line) - 1; j >= 0; j-- {
+				chars = append(chars, line[j])
+			}
+		}
+	}
+
+	// skip the gap before the word, then the word itself
+	distance := 0
+	for _, inWord := range []bool{false, true} {
+		for distance < len(chars) && isWordRune(chars[distance]) == inWord {
+			distance++
+		}
+	}
+	return distance
+}
+
+// moveCursorByWord returns an action that moves the cursor to the start of the
+// previous word, or the end of the next one
+func moveCursorByWord(forward bool) editorAction {
+	dx := -1
+	if forward {
+		dx = 1
+	}
+	return func(v *gocui.View) {
+		for i := wordDistance(v, forward); i > 0; i-- {
+			v.MoveCursor(dx, 0, false)
+		}
+	}
+}
+
+// deleteWord returns an action that deletes up to the start of the previous
+// word, or the end of the next one
+func deleteWord(forward bool) editorAction {
+	return func(v *gocui.View) {
+		for i := wordDistance(v, forward); i > 0; i-- {
+			v.EditDelete(!forward)
+		}
+	}
+}
+
+// deleteToEndOfLine is the equivalent of ctrl+k in a terminal. If we're already
+// at the end of the line it deletes the line break instead.
+func deleteToEndOfLine(v *gocui.View) {
+	cx, cy := v.Cursor()
+	ox, oy := v.Origin()
+	x, y := ox+cx, oy+cy
+
+	lines := v.ViewBufferLines()
+	if y >= len(lines) {
+		return
+	}
+
+	count := len([]rune(lines[y])) - x
+	if count <= 0 {
+		count = 1
+	}
+	for ; count > 0; count-- {
+		v.EditDelete(false)
+	}
+}
@@ -0,0 +1,87 @@
+package gui
+
+import (
+	"sync"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// repoFingerprints are the fingerprints of the refs and the index as they
+// were when we last loaded them, so that we can tell whether some other
+// process has changed them before we act on what we're showing
+type repoFingerprints struct {
+	mutex sync.Mutex
+	refs  string
+	index string
+}
+
+func (gui *Gui) detectExternalChanges() bool {
+	return gui.Config.GetUserConfig().GetBool("git.detectExternalChanges")
+}
+
+// recordRefsFingerprint is called before we load anything from the refs, so
+// that a change made while we're loading is caught the next time we check
+func (gui *Gui) recordRefsFingerprint() {
+	if !gui.detectExternalChanges() {
+		return
+	}
+
+	refs := gui.GitCommand.GetRefsFingerprint()
+
+	fingerprints := gui.repoFingerprints
+	fingerprints.mutex.Lock()
+	fingerprints.refs = refs
+	fingerprints.mutex.Unlock()
+}
+
+// recordIndexFingerprint is like recordRefsFingerprint but for the index
+func (gui *Gui) recordIndexFingerprint() {
+	if !gui.detectExternalChanges() {
+		return
+	}
+
+	index := gui.GitCommand.GetIndexFingerprint()
+
+	fingerprints := gui.repoFingerprints
+	fingerprints.mutex.Lock()
+	fingerprints.index = index
+	fingerprints.mutex.Unlock()
+}
+
+// changedExternally tells us whether the refs or the index differ from when
+// we last loaded them. If we couldn't fingerprint something either time we
+// give it the benefit of the doubt.
+func (gui *Gui) changedExternally() bool {
+	if !gui.detectExternalChanges() {
+		return false
+	}
+
+	refs := gui.GitCommand.GetRefsFingerprint()
+	index := gui.GitCommand.GetIndexFingerprint()
+
+	fingerprints := gui.repoFingerprints
+	fingerprints.mutex.Lock()
+	defer fingerprints.mutex.Unlock()
+
+	changed := func(expected string, actual string) bool {
+		return expected != "" && actual != "" && expected != actual
+	}
+	return changed(fingerprints.refs, refs) || changed(fingerprints.index, index)
+}
+
+// abortIfChangedExternally wraps a handler that acts on what we've loaded
+// about the repo, e.g. on the position of the selected commit, so that if
+// something else has changed the repo since, we refresh instead of applying
+// the operation to stale state
+func (gui *Gui) abortIfChangedExternally(handler func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if !gui.changedExternally() {
+			return handler(g, v)
+		}
+
+		if err := gui.refreshSidePanels(g); err != nil {
+			return err
+		}
+		return gui.createErrorPanel(g, gui.Tr.SLocalize("RepoChangedExternally"))
+	}
+}
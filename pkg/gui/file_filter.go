@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// getFilesTitle shows how many files get through the filter, if there is one
+func (gui *Gui) getFilesTitle() string {
+	title := gui.Tr.SLocalize("FilesTitle")
+	if !gui.State.FileFilter.IsEmpty() {
+		title = gui.Tr.TemplateLocalize("FilteredFilesTitle", Teml{
+			"filter": gui.State.FileFilterText,
+			"count":  strconv.Itoa(len(gui.visibleFiles())),
+			"total":  strconv.Itoa(len(gui.State.Files)),
+		})
+	}
+
+	// keep the stash we're applying in view until its conflicts are dealt with
+	if gui.State.StashConflict != nil {
+		title += " " + gui.Tr.TemplateLocalize("UnstashingFilesTitle", Teml{"stashName": gui.State.StashConflict.Name})
+	}
+	if progress := gui.getConflictProgress(); progress != "" {
+		title += " - " + progress
+	}
+	return title
+}
+
+func (gui *Gui) handleFilterFiles(g *gocui.Gui, v *gocui.View) error {
+	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("FilterFilesPrompt"), gui.State.FileFilterText, func(g *gocui.Gui, promptView *gocui.View) error {
+		text := gui.trimmedContent(promptView)
+		fileFilter, err := commands.NewFileFilter(text)
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+
+		gui.State.FileFilter = fileFilter
+		gui.State.FileFilterText = text
+		gui.State.Panels.Files.SelectedLine = 0
+		return gui.refreshFiles()
+	})
+}
+
+// maxStageByPatternPreview is how many of the matching files we list before
+// staging them, so that the confirmation still fits on the screen after a
+// codemod has touched hundreds of files
+const maxStageByPatternPreview = 20
+
+// handleStageByPattern stages every unstaged file matching a glob or regex in
+// one go, showing which files match before staging anything
+func (gui *Gui) handleStageByPattern(g *gocui.Gui, v *gocui.View) error {
+	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("StageByPatternPrompt"), "", func(g *gocui.Gui, promptView *gocui.View) error {
+		unstagedFiles := []*commands.File{}
+		for _, file := range gui.State.Files {
+			if file.HasUnstagedChanges && !file.HasMergeConflicts {
+				unstagedFiles = append(unstagedFiles, file)
+			}
+		}
+
+		matches, err := commands.FilesMatchingPattern(unstagedFiles, gui.trimmedContent(promptView))
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		if len(matches) == 0 {
+			return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoFilesMatchPattern"))
+		}
+
+		fileNames := make([]string, len(matches))
+		for i, file := range matches {
+			split := strings.Split(file.Name, " -> ") // in case of a renamed file we want the new filename
+			fileNames[i] = split[len(split)-1]
+		}
+
+		preview := fileNames
+		if len(preview) > maxStageByPatternPreview {
+			preview = append(preview[:maxStageByPatternPreview:maxStageByPatternPreview], gui.Tr.TemplateLocalize("AndMoreFiles", Teml{
+				"count": strconv.Itoa(len(fileNames) - maxStageByPatternPreview),
+			}))
+		}
+		prompt := gui.Tr.TemplateLocalize("StageByPatternConfirmation", Teml{"count": strconv.Itoa(len(fileNames))}) + "\n\n" + strings.Join(preview, "\n")
+
+		return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("StageByPattern"), prompt, func(g *gocui.Gui, _ *gocui.View) error {
+			if err := gui.stageFiles(matches); err != nil {
+				return gui.createErrorPanel(gui.g, err.Error())
+			}
+			return gui.refreshFiles()
+		}, nil)
+	})
+}
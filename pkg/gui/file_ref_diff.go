@@ -0,0 +1,55 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// fileRefDiffState is a file in the working tree that the main view is
+// showing the diff of against some ref, rather than against the index
+type fileRefDiffState struct {
+	FileName string
+	Ref      string
+}
+
+// handleDiffFileAgainstRef asks for a ref to diff the selected file against,
+// or if we're already doing that, goes back to the usual diff
+func (gui *Gui) handleDiffFileAgainstRef(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.FileRefDiff != nil {
+		gui.State.FileRefDiff = nil
+		return gui.selectFile(false)
+	}
+
+	file, err := gui.getSelectedFile(g)
+	if err != nil {
+		if err != gui.Errors.ErrNoFiles {
+			return err
+		}
+		return nil
+	}
+
+	title := gui.Tr.TemplateLocalize("DiffFileAgainstRefPrompt", Teml{"file": file.Name})
+	return gui.pickRef(v, title, func(ref string) error {
+		gui.State.FileRefDiff = &fileRefDiffState{FileName: file.Name, Ref: ref}
+		return gui.selectFile(false)
+	})
+}
+
+// renderFileRefDiff shows the file's diff against the ref the user picked, if
+// they've picked one for it. Selecting any other file goes back to the usual
+// diff.
+func (gui *Gui) renderFileRefDiff(file *commands.File) (bool, error) {
+	fileRefDiff := gui.State.FileRefDiff
+	if fileRefDiff == nil {
+		return false, nil
+	}
+	if fileRefDiff.FileName != file.Name {
+		gui.State.FileRefDiff = nil
+		return false, nil
+	}
+
+	gui.State.SplitMainPanel = false
+	gui.getMainView().Title = gui.Tr.TemplateLocalize("FileRefDiffTitle", Teml{"ref": fileRefDiff.Ref})
+	cmd := gui.OSCommand.ExecutableFromString(gui.GitCommand.PathDiffCmdStr(fileRefDiff.Ref, file.Name))
+	return true, gui.newPtyTask("main", cmd, commands.PagingContextFiles)
+}
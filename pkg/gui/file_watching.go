@@ -15,6 +15,9 @@ import (
 // file watching is only really an added bonus for faster refreshing.
 const MAX_WATCHED_FILES = 50
 
+// TODO: get this going again, and ensure we don't see any crashes from it
+const FILE_WATCHING_ENABLED = false
+
 type fileWatcher struct {
 	Watcher          *fsnotify.Watcher
 	WatchedFilenames []string
@@ -23,9 +26,10 @@ type fileWatcher struct {
 }
 
 func NewFileWatcher(log *logrus.Entry) *fileWatcher {
-	// TODO: get this going again, and ensure we don't see any crashes from it
-	return &fileWatcher{
-		Disabled: true,
+	if !FILE_WATCHING_ENABLED {
+		return &fileWatcher{
+			Disabled: true,
+		}
 	}
 
 	watcher, err := fsnotify.NewWatcher()
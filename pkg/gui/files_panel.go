@@ -1,14 +1,12 @@
 package gui
 
 import (
-
+	"fmt"
 	// "io"
 	// "io/ioutil"
-
 	// "strings"
-
-	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands"
@@ -23,7 +21,13 @@ func (gui *Gui) getSelectedFile(g *gocui.Gui) (*commands.File, error) {
 		return &commands.File{}, gui.Errors.ErrNoFiles
 	}
 
-	return gui.State.Files[selectedLine], nil
+	return gui.visibleFiles()[selectedLine], nil
+}
+
+// visibleFiles returns the files that get through the user's filter, which is
+// all of them unless they've set one
+func (gui *Gui) visibleFiles() []*commands.File {
+	return gui.State.FileFilter.Filter(gui.State.Files)
 }
 
 func (gui *Gui) selectFile(alreadySelected bool) error {
@@ -37,7 +41,7 @@ func (gui *Gui) selectFile(alreadySelected bool) error {
 		return gui.newStringTask("main", gui.Tr.SLocalize("NoChangedFiles"))
 	}
 
-	gui.getFilesView().FocusPoint(0, gui.State.Panels.Files.SelectedLine)
+	gui.focusPoint(gui.getFilesView(), gui.State.Panels.Files.SelectedLine)
 
 	if file.HasInlineMergeConflicts {
 		gui.getMainView().Title = gui.Tr.SLocalize("MergeConflictsTitle")
@@ -54,13 +58,17 @@ func (gui *Gui) selectFile(alreadySelected bool) error {
 		}
 	}
 
+	if rendered, err := gui.renderFileRefDiff(file); rendered {
+		return err
+	}
+
 	if file.HasStagedChanges && file.HasUnstagedChanges {
 		gui.State.SplitMainPanel = true
 		gui.getMainView().Title = gui.Tr.SLocalize("UnstagedChanges")
 		gui.getSecondaryView().Title = gui.Tr.SLocalize("StagedChanges")
 		cmdStr := gui.GitCommand.DiffCmdStr(file, false, true)
 		cmd := gui.OSCommand.ExecutableFromString(cmdStr)
-		if err := gui.newPtyTask("secondary", cmd); err != nil {
+		if err := gui.newPtyTask("secondary", cmd, commands.PagingContextFiles); err != nil {
 			return err
 		}
 	} else {
@@ -74,7 +82,7 @@ func (gui *Gui) selectFile(alreadySelected bool) error {
 
 	cmdStr := gui.GitCommand.DiffCmdStr(file, false, !file.HasUnstagedChanges && file.HasStagedChanges)
 	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
-	if err := gui.newPtyTask("main", cmd); err != nil {
+	if err := gui.newPtyTask("main", cmd, commands.PagingContextFiles); err != nil {
 		return err
 	}
 
@@ -91,8 +99,7 @@ func (gui *Gui) refreshFiles() error {
 
 	selectedFile, _ := gui.getSelectedFile(gui.g)
 
-	filesView := gui.getFilesView()
-	if filesView == nil {
+	if gui.getFilesView() == nil {
 		// if the filesView hasn't been instantiated yet we just return
 		return nil
 	}
@@ -101,17 +108,46 @@ func (gui *Gui) refreshFiles() error {
 	}
 
 	gui.g.Update(func(g *gocui.Gui) error {
-		displayStrings := presentation.GetFileListDisplayStrings(gui.State.Files)
-		gui.renderDisplayStrings(filesView, displayStrings)
+		return gui.renderFilesAfterRefresh(selectedFile)
+	})
 
-		if g.CurrentView() == filesView || (g.CurrentView() == gui.getMainView() && g.CurrentView().Context == "merging") {
-			newSelectedFile, _ := gui.getSelectedFile(gui.g)
-			alreadySelected := newSelectedFile.Name == selectedFile.Name
-			return gui.selectFile(alreadySelected)
+	return nil
+}
+
+// refreshFilesInUpdate is refreshFiles for when the status has already been
+// loaded off the main loop, as when refreshing the side panels concurrently.
+// Everything that touches the gui state happens in the gocui update.
+func (gui *Gui) refreshFilesInUpdate(files []*commands.File, statusDuration time.Duration) {
+	gui.g.Update(func(g *gocui.Gui) error {
+		gui.State.RefreshingFilesMutex.Lock()
+		gui.State.IsRefreshingFiles = true
+		defer func() {
+			gui.State.IsRefreshingFiles = false
+			gui.State.RefreshingFilesMutex.Unlock()
+		}()
+
+		if gui.getFilesView() == nil {
+			return nil
 		}
-		return nil
+		selectedFile, _ := gui.getSelectedFile(gui.g)
+		if err := gui.applyStatusFiles(files, statusDuration); err != nil {
+			return err
+		}
+		return gui.renderFilesAfterRefresh(selectedFile)
 	})
+}
+
+func (gui *Gui) renderFilesAfterRefresh(selectedFile *commands.File) error {
+	filesView := gui.getFilesView()
+	filesView.Title = gui.getFilesTitle()
+	displayStrings := presentation.GetFileListDisplayStrings(gui.visibleFiles(), gui.Tr.SLocalize("WhitespaceOnly"))
+	gui.renderDisplayStrings(filesView, displayStrings)
 
+	if gui.g.CurrentView() == filesView || (gui.g.CurrentView() == gui.getMainView() && gui.g.CurrentView().Context == "merging") {
+		newSelectedFile, _ := gui.getSelectedFile(gui.g)
+		alreadySelected := newSelectedFile.Name == selectedFile.Name
+		return gui.selectFile(alreadySelected)
+	}
 	return nil
 }
 
@@ -144,11 +180,24 @@ func (gui *Gui) stageSelectedFile(g *gocui.Gui) error {
 	if err != nil {
 		return err
 	}
-	return gui.GitCommand.StageFile(file.Name)
+	return gui.stageFile(file)
 }
 
 func (gui *Gui) handleEnterFile(g *gocui.Gui, v *gocui.View) error {
-	return gui.enterFile(false, -1)
+	file, err := gui.getSelectedFile(gui.g)
+	if err != nil {
+		if err != gui.Errors.ErrNoFiles {
+			return err
+		}
+		return nil
+	}
+	if file.HasInlineMergeConflicts || file.HasMergeConflicts || gui.State.FileRefDiff != nil {
+		return gui.enterFile(false, -1)
+	}
+
+	return gui.confirmHugeDiff(file, func() error {
+		return gui.enterFile(false, -1)
+	})
 }
 
 func (gui *Gui) enterFile(forceSecondaryFocused bool, selectedLineIdx int) error {
@@ -165,6 +214,25 @@ func (gui *Gui) enterFile(forceSecondaryFocused bool, selectedLineIdx int) error
 	if file.HasMergeConflicts {
 		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("FileStagingRequirements"))
 	}
+	if gui.State.FileRefDiff != nil {
+		// the lines we'd be staging aren't the ones being shown
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoStagingWhileDiffingAgainstRef"))
+	}
+	if file.Submodule != "" {
+		// our patches get applied to the superproject's index
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoLineStagingInSubmodule"))
+	}
+	if !file.Tracked && !file.HasStagedChanges && !strings.HasSuffix(file.Name, "/") {
+		// git can only apply our patches to the index if the file is already in
+		// it, so we add it without any content until some lines are staged
+		if err := gui.GitCommand.AddIntentToAdd(file.Name); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		gui.State.IntentToAddFile = file.Name
+		if err := gui.refreshFiles(); err != nil {
+			return err
+		}
+	}
 	gui.changeMainViewsContext("staging")
 	if err := gui.switchFocus(gui.g, gui.getFilesView(), gui.getMainView()); err != nil {
 		return err
@@ -186,10 +254,14 @@ func (gui *Gui) handleFilePress(g *gocui.Gui, v *gocui.View) error {
 	}
 
 	if file.HasUnstagedChanges {
-		err = gui.GitCommand.StageFile(file.Name)
-	} else {
-		err = gui.GitCommand.UnStageFile(file.Name, file.Tracked)
+		return gui.confirmLargeFiles([]string{file.Name}, func() error {
+			return gui.refreshAfterStaging(gui.stageFile(file))
+		})
 	}
+	return gui.refreshAfterStaging(gui.unstageFile(file))
+}
+
+func (gui *Gui) refreshAfterStaging(err error) error {
 	if err != nil {
 		return gui.createErrorPanel(gui.g, err.Error())
 	}
@@ -219,14 +291,24 @@ func (gui *Gui) focusAndSelectFile(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleStageAll(g *gocui.Gui, v *gocui.View) error {
-	var err error
 	if gui.allFilesStaged() {
-		err = gui.GitCommand.UnstageAll()
-	} else {
-		err = gui.GitCommand.StageAll()
+		return gui.refreshAfterStagingAll(gui.unstageAll())
+	}
+
+	unstagedFileNames := []string{}
+	for _, file := range gui.State.Files {
+		if file.HasUnstagedChanges {
+			unstagedFileNames = append(unstagedFileNames, file.Name)
+		}
 	}
+	return gui.confirmLargeFiles(unstagedFileNames, func() error {
+		return gui.refreshAfterStagingAll(gui.stageAll())
+	})
+}
+
+func (gui *Gui) refreshAfterStagingAll(err error) error {
 	if err != nil {
-		_ = gui.createErrorPanel(g, err.Error())
+		_ = gui.createErrorPanel(gui.g, err.Error())
 	}
 
 	if err := gui.refreshFiles(); err != nil {
@@ -281,8 +363,41 @@ func (gui *Gui) handleCommitPress(g *gocui.Gui, filesView *gocui.View) error {
 	if len(gui.stagedFiles()) == 0 && gui.State.WorkingTreeState == "normal" {
 		return gui.createErrorPanel(g, gui.Tr.SLocalize("NoStagedFilesToCommit"))
 	}
+	return gui.runPreCommitChecks(func() error {
+		return gui.openCommitMessagePanel(filesView)
+	})
+}
+
+// commitMessagePrefill returns what a new commit message starts out as: the
+// subject prefix taken from the branch name, followed by the commit template
+func (gui *Gui) commitMessagePrefill() (string, error) {
+	template, err := gui.GitCommand.GetCommitTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	prefix := ""
+	if branch := gui.getCheckedOutBranch(); branch != nil {
+		prefix, err = gui.GitCommand.GetBranchSubjectPrefix(branch.Name)
+		if err != nil {
+			return "", err
+		}
+	}
+	return prefix + template, nil
+}
+
+func (gui *Gui) openCommitMessagePanel(filesView *gocui.View) error {
 	commitMessageView := gui.getCommitMessageView()
-	g.Update(func(g *gocui.Gui) error {
+	if gui.trimmedContent(commitMessageView) == "" {
+		prefill, err := gui.commitMessagePrefill()
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		if prefill != "" {
+			gui.setCommitMessage(commitMessageView, prefill)
+		}
+	}
+	gui.g.Update(func(g *gocui.Gui) error {
 		if _, err := g.SetViewOnTop("commitMessage"); err != nil {
 			return err
 		}
@@ -308,17 +423,19 @@ func (gui *Gui) handleAmendCommitPress(g *gocui.Gui, filesView *gocui.View) erro
 	title := strings.Title(gui.Tr.SLocalize("AmendLastCommit"))
 	question := gui.Tr.SLocalize("SureToAmend")
 
-	return gui.createConfirmationPanel(g, filesView, true, title, question, func(g *gocui.Gui, v *gocui.View) error {
-		ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.AmendHead())
-		if err != nil {
-			return err
-		}
-		if !ok {
-			return nil
-		}
+	return gui.runPreCommitChecks(func() error {
+		return gui.createConfirmationPanel(g, filesView, true, title, question, func(g *gocui.Gui, v *gocui.View) error {
+			ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.AmendHead())
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
 
-		return gui.refreshSidePanels(g)
-	}, nil)
+			return gui.refreshSidePanels(g)
+		}, nil)
+	})
 }
 
 // handleCommitEditorPress - handle when the user wants to commit changes via
@@ -327,8 +444,10 @@ func (gui *Gui) handleCommitEditorPress(g *gocui.Gui, filesView *gocui.View) err
 	if len(gui.stagedFiles()) == 0 && gui.State.WorkingTreeState == "normal" {
 		return gui.createErrorPanel(g, gui.Tr.SLocalize("NoStagedFilesToCommit"))
 	}
-	gui.PrepareSubProcess(g, "git", "commit")
-	return nil
+	return gui.runPreCommitChecks(func() error {
+		gui.PrepareSubProcess(g, "git", "commit")
+		return nil
+	})
 }
 
 // PrepareSubProcess - prepare a subprocess for execution and tell the gui to switch to it
@@ -344,6 +463,11 @@ func (gui *Gui) editFile(filename string) error {
 	return err
 }
 
+func (gui *Gui) editFileAtLine(filename string, lineNumber int) error {
+	_, err := gui.runSyncOrAsyncCommand(gui.OSCommand.EditFileAtLine(filename, lineNumber))
+	return err
+}
+
 func (gui *Gui) handleFileEdit(g *gocui.Gui, v *gocui.View) error {
 	file, err := gui.getSelectedFile(g)
 	if err != nil {
@@ -366,16 +490,36 @@ func (gui *Gui) handleRefreshFiles(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) refreshStateFiles() error {
-	// get files to stage
+	return gui.applyStatusFiles(gui.loadStatusFiles())
+}
+
+// loadStatusFiles gets the files to stage without touching the gui state, so
+// that it can run off the main loop
+func (gui *Gui) loadStatusFiles() ([]*commands.File, time.Duration) {
+	gui.recordIndexFingerprint()
+
+	start := time.Now()
 	files := gui.GitCommand.GetStatusFiles()
-	gui.State.Files = gui.GitCommand.MergeStatusFiles(gui.State.Files, files)
+	duration := time.Since(start)
+	gui.recordRefresh("files")
+	return files, duration
+}
+
+func (gui *Gui) applyStatusFiles(files []*commands.File, statusDuration time.Duration) error {
+	gui.State.LastStatusDuration = statusDuration
+	gui.State.Files = commands.ConflictsFirst(gui.GitCommand.MergeStatusFiles(gui.State.Files, files))
+	gui.markWhitespaceOnlyFiles()
 
 	if err := gui.fileWatcher.addFilesToFileWatcher(files); err != nil {
 		return err
 	}
 
-	gui.refreshSelectedLine(&gui.State.Panels.Files.SelectedLine, len(gui.State.Files))
-	return gui.updateWorkTreeState()
+	gui.refreshSelectedLine(&gui.State.Panels.Files.SelectedLine, len(gui.visibleFiles()))
+	if err := gui.updateWorkTreeState(); err != nil {
+		return err
+	}
+	gui.trackConflictedFiles()
+	return nil
 }
 
 func (gui *Gui) catSelectedFile(g *gocui.Gui) (string, error) {
@@ -435,9 +579,11 @@ func (gui *Gui) pullFiles(v *gocui.View, args string) error {
 
 	go func() {
 		unamePassOpend := false
-		err := gui.GitCommand.Pull(args, func(passOrUname string) string {
-			unamePassOpend = true
-			return gui.waitForPassUname(gui.g, v, passOrUname)
+		err := gui.withNetworkRetries(gui.Tr.SLocalize("pull"), func() error {
+			return gui.GitCommand.Pull(args, func(passOrUname string) string {
+				unamePassOpend = true
+				return gui.waitForPassUname(gui.g, v, passOrUname)
+			})
 		})
 		gui.HandleCredentialsPopup(gui.g, unamePassOpend, err)
 	}()
@@ -446,21 +592,62 @@ func (gui *Gui) pullFiles(v *gocui.View, args string) error {
 }
 
 func (gui *Gui) pushWithForceFlag(g *gocui.Gui, v *gocui.View, force bool, upstream string, args string) error {
-	if err := gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("PushWait")); err != nil {
-		return err
-	}
-	go func() {
+	branchName := gui.getCheckedOutBranch().Name
+	description := gui.Tr.TemplateLocalize("PushOperation", Teml{"branch": branchName})
+	push := func(v *gocui.View) {
 		unamePassOpend := false
-		branchName := gui.getCheckedOutBranch().Name
-		err := gui.GitCommand.Push(branchName, force, upstream, args, func(passOrUname string) string {
-			unamePassOpend = true
-			return gui.waitForPassUname(g, v, passOrUname)
+		err := gui.withNetworkRetries(description, func() error {
+			return gui.GitCommand.Push(branchName, force, upstream, args, func(passOrUname string) string {
+				unamePassOpend = true
+				return gui.waitForPassUname(g, v, passOrUname)
+			})
 		})
 		gui.HandleCredentialsPopup(g, unamePassOpend, err)
-	}()
+	}
+
+	if gui.queueIfOffline(description, func() {
+		// the view we were pushing from may be a popup that's long gone
+		filesView := gui.getFilesView()
+		_ = gui.createLoaderPanel(gui.g, filesView, gui.Tr.SLocalize("PushWait"))
+		push(filesView)
+	}) {
+		return nil
+	}
+
+	if err := gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("PushWait")); err != nil {
+		return err
+	}
+	go push(v)
 	return nil
 }
 
+// pushInBackground pushes the branch to its upstream without a popup, showing
+// how it's going in the status bar so that the user can get on with things.
+// There's no one to type in credentials, so it relies on a credential helper
+// or ssh agent.
+func (gui *Gui) pushInBackground(branchName string) error {
+	description := gui.Tr.TemplateLocalize("PushOperation", Teml{"branch": branchName})
+	push := func() error {
+		if err := gui.withNetworkRetries(description, func() error {
+			return gui.GitCommand.Push(branchName, false, "", "", func(string) string { return "\n" })
+		}); err != nil {
+			return err
+		}
+		gui.raiseToast(gui.Tr.TemplateLocalize("PushedInBackground", Teml{"branch": branchName}))
+		return gui.refreshSidePanels(gui.g)
+	}
+
+	if gui.queueIfOffline(description, func() {
+		if err := push(); err != nil {
+			_ = gui.createErrorPanel(gui.g, err.Error())
+		}
+	}) {
+		return nil
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("PushWait"), push)
+}
+
 func (gui *Gui) pushFiles(g *gocui.Gui, v *gocui.View) error {
 	// if we have pullables we'll ask if the user wants to force push
 	currentBranch := gui.currentBranch()
@@ -522,14 +709,6 @@ func (gui *Gui) anyFilesWithMergeConflicts() bool {
 	return false
 }
 
-func (gui *Gui) handleCustomCommand(g *gocui.Gui, v *gocui.View) error {
-	return gui.createPromptPanel(g, v, gui.Tr.SLocalize("CustomCommand"), "", func(g *gocui.Gui, v *gocui.View) error {
-		command := gui.trimmedContent(v)
-		gui.SubProcess = gui.OSCommand.RunCustomCommand(command)
-		return gui.Errors.ErrSubProcess
-	})
-}
-
 func (gui *Gui) handleCreateStashMenu(g *gocui.Gui, v *gocui.View) error {
 	menuItems := []*menuItem{
 		{
@@ -540,9 +719,7 @@ func (gui *Gui) handleCreateStashMenu(g *gocui.Gui, v *gocui.View) error {
 		},
 		{
 			displayString: gui.Tr.SLocalize("stashStagedChanges"),
-			onPress: func() error {
-				return gui.handleStashSave(gui.GitCommand.StashSaveStagedChanges)
-			},
+			onPress:       gui.handleStashStagedChanges,
 		},
 	}
 
@@ -553,6 +730,10 @@ func (gui *Gui) handleStashChanges(g *gocui.Gui, v *gocui.View) error {
 	return gui.handleStashSave(gui.GitCommand.StashSave)
 }
 
+func (gui *Gui) handleStashStagedChanges() error {
+	return gui.handleStashSave(gui.GitCommand.StashSaveStagedChanges)
+}
+
 func (gui *Gui) handleCreateResetToUpstreamMenu(g *gocui.Gui, v *gocui.View) error {
 	return gui.createResetMenu("@{upstream}")
 }
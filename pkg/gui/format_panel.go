@@ -0,0 +1,44 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// handleFormatAndStage runs the formatters from git.formatters on the
+// selected file and stages the result, so that the usual format, stage,
+// commit loop is one keypress shorter
+func (gui *Gui) handleFormatAndStage(g *gocui.Gui, v *gocui.View) error {
+	file, err := gui.getSelectedFile(g)
+	if err != nil {
+		if err != gui.Errors.ErrNoFiles {
+			return err
+		}
+		return nil
+	}
+	if file.HasMergeConflicts {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantFormatConflictedFile"))
+	}
+	if file.Deleted {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantFormatDeletedFile"))
+	}
+
+	split := strings.Split(file.Name, " -> ") // in case of a renamed file we want the new filename
+	fileName := split[len(split)-1]
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("FormattingStatus"), func() error {
+		formatted, err := gui.GitCommand.FormatFiles([]string{fileName})
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		if len(formatted) == 0 {
+			return gui.createErrorPanel(gui.g, gui.Tr.TemplateLocalize("NoFormatterForFile", Teml{"file": fileName}))
+		}
+
+		return gui.confirmLargeFiles(formatted, func() error {
+			return gui.refreshAfterStaging(gui.stageFiles([]*commands.File{file}))
+		})
+	})
+}
@@ -2,38 +2,33 @@ package gui
 
 import (
 	"fmt"
-	"regexp"
-	"strings"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 )
 
-func (gui *Gui) gitFlowFinishBranch(gitFlowConfig string, branchName string) error {
-	// need to find out what kind of branch this is
-	prefix := strings.SplitAfterN(branchName, "/", 2)[0]
-	suffix := strings.Replace(branchName, prefix, "", 1)
-
-	branchType := ""
-	for _, line := range strings.Split(strings.TrimSpace(gitFlowConfig), "\n") {
-		if strings.HasPrefix(line, "gitflow.prefix.") && strings.HasSuffix(line, prefix) {
-			// now I just need to how do you say
-			regex := regexp.MustCompile("gitflow.prefix.([^ ]*) .*")
-			matches := regex.FindAllStringSubmatch(line, 1)
-
-			if len(matches) > 0 && len(matches[0]) > 1 {
-				branchType = matches[0][1]
-				break
-			}
-		}
+// getGitFlowConfig returns the git-flow config for the repo, or nil if the user
+// hasn't asked for git-flow in this repo. By default that means git-flow needs
+// to have been initialised with `git flow init`.
+func (gui *Gui) getGitFlowConfig() *commands.GitFlowConfig {
+	userConfig := gui.Config.GetUserConfig()
+	enabled := userConfig.GetString("git.flow.enabled")
+	if enabled == "false" {
+		return nil
 	}
 
-	if branchType == "" {
-		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NotAGitFlowBranch"))
+	gitFlowConfig, initialised := gui.GitCommand.GetGitFlowConfig(userConfig.GetBool("git.flow.trunkBased"))
+	if !initialised && enabled != "true" {
+		return nil
 	}
+	return gitFlowConfig
+}
 
-	subProcess := gui.OSCommand.PrepareSubProcess("git", "flow", branchType, "finish", suffix)
-	gui.SubProcess = subProcess
-	return gui.Errors.ErrSubProcess
+func (gui *Gui) gitFlowFinishBranch(gitFlowConfig *commands.GitFlowConfig, branchName string) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("GitFlowFinishingStatus"), func() error {
+		err := gui.GitCommand.GitFlowFinish(gitFlowConfig, branchName)
+		return gui.handleGenericMergeCommandResult(err)
+	})
 }
 
 func (gui *Gui) handleCreateGitFlowMenu(g *gocui.Gui, v *gocui.View) error {
@@ -42,10 +37,9 @@ func (gui *Gui) handleCreateGitFlowMenu(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	// get config
-	gitFlowConfig, err := gui.OSCommand.RunCommandWithOutput("git config --local --get-regexp gitflow")
-	if err != nil {
-		return gui.createErrorPanel(gui.g, "You need to install git-flow and enable it in this repo to use git-flow features")
+	gitFlowConfig := gui.getGitFlowConfig()
+	if gitFlowConfig == nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("GitFlowNotEnabled"))
 	}
 
 	startHandler := func(branchType string) func() error {
@@ -53,34 +47,33 @@ func (gui *Gui) handleCreateGitFlowMenu(g *gocui.Gui, v *gocui.View) error {
 			title := gui.Tr.TemplateLocalize("NewBranchNamePrompt", map[string]interface{}{"branchType": branchType})
 			return gui.createPromptPanel(gui.g, gui.getMenuView(), title, "", func(g *gocui.Gui, v *gocui.View) error {
 				name := gui.trimmedContent(v)
-				subProcess := gui.OSCommand.PrepareSubProcess("git", "flow", branchType, "start", name)
-				gui.SubProcess = subProcess
-				return gui.Errors.ErrSubProcess
+				if err := gui.GitCommand.GitFlowStart(gitFlowConfig, branchType, name); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				gui.State.Panels.Branches.SelectedLine = 0
+				return gui.refreshSidePanels(gui.g)
 			})
 		}
 	}
 
-	menuItems := []*menuItem{
-		{
+	menuItems := []*menuItem{}
+
+	if _, _, ok := gitFlowConfig.BranchType(branch.Name); ok {
+		menuItems = append(menuItems, &menuItem{
 			// not localising here because it's one to one with the actual git flow commands
 			displayString: fmt.Sprintf("finish branch '%s'", branch.Name),
 			onPress: func() error {
 				return gui.gitFlowFinishBranch(gitFlowConfig, branch.Name)
 			},
-		},
-		{
-			displayString: "start feature",
-			onPress:       startHandler("feature"),
-		},
-		{
-			displayString: "start hotfix",
-			onPress:       startHandler("hotfix"),
-		},
-		{
-			displayString: "start release",
-			onPress:       startHandler("release"),
-		},
+		})
+	}
+
+	for _, branchType := range commands.GitFlowBranchTypes {
+		menuItems = append(menuItems, &menuItem{
+			displayString: "start " + branchType,
+			onPress:       startHandler(branchType),
+		})
 	}
 
-	return gui.createMenu("git flow", menuItems, createMenuOptions{})
+	return gui.createMenu("git flow", menuItems, createMenuOptions{showCancel: true})
 }
@@ -0,0 +1,70 @@
+package gui
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// Git tracing sets GIT_TRACE on the git commands we run, with the trace going
+// to a temp file whose tail shows up in the command log. It's for working out
+// what git is up to when something's slow or failing, so it only lasts for the
+// session.
+
+// gitTraceLines is how much of the trace we show, which can run to a lot of
+// lines after a while
+const gitTraceLines = 500
+
+func (gui *Gui) handleToggleGitTrace(g *gocui.Gui, v *gocui.View) error {
+	if gui.OSCommand.GetGitTraceFile() != "" {
+		gui.OSCommand.SetGitTraceFile("")
+		gui.raiseToast(gui.Tr.SLocalize("GitTraceOff"))
+		return nil
+	}
+
+	if err := gui.cleanUpGitTrace(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	traceFile, err := ioutil.TempFile("", "lazygit-trace")
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if err := traceFile.Close(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	gui.State.GitTraceFile = traceFile.Name()
+	gui.OSCommand.SetGitTraceFile(traceFile.Name())
+	gui.raiseToast(gui.Tr.TemplateLocalize("GitTraceOn", Teml{"logKey": gui.getKeyDisplay("universal.viewCustomCommandLog")}))
+	return nil
+}
+
+// gitTrace returns the end of the latest trace, if we've traced this session
+func (gui *Gui) gitTrace() string {
+	if gui.State.GitTraceFile == "" {
+		return ""
+	}
+	content, err := ioutil.ReadFile(gui.State.GitTraceFile)
+	if err != nil {
+		gui.Log.Error(err)
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) > gitTraceLines {
+		lines = lines[len(lines)-gitTraceLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cleanUpGitTrace stops tracing and deletes the trace file
+func (gui *Gui) cleanUpGitTrace() error {
+	if gui.State.GitTraceFile == "" {
+		return nil
+	}
+	gui.OSCommand.SetGitTraceFile("")
+	traceFile := gui.State.GitTraceFile
+	gui.State.GitTraceFile = ""
+	return os.Remove(traceFile)
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -23,6 +24,7 @@ import (
 	"github.com/golang-collections/collections/stack"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
 	"github.com/jesseduffield/lazygit/pkg/tasks"
@@ -80,6 +82,7 @@ type Gui struct {
 	GitCommand           *commands.GitCommand
 	OSCommand            *commands.OSCommand
 	SubProcess           *exec.Cmd
+	OnSubProcessDone     func() error // run once the subprocess has exited
 	State                *guiState
 	Config               config.AppConfigurer
 	Tr                   *i18n.Localizer
@@ -87,10 +90,18 @@ type Gui struct {
 	Updater              *updates.Updater
 	statusManager        *statusManager
 	credentials          credentials
+	editorState          editorState
 	waitForIntro         sync.WaitGroup
 	fileWatcher          *fileWatcher
 	viewBufferManagerMap map[string]*tasks.ViewBufferManager
-	stopChan             chan struct{}
+	// pagerOutputs holds what we know about the output of the current task in
+	// each view, by view name
+	pagerOutputs map[string]*pagerOutput
+	refreshTimes *refreshTimes
+	// repoFingerprints are how the refs and index were when we last loaded
+	// them, for telling whether something else has changed them since
+	repoFingerprints *repoFingerprints
+	stopChan         chan struct{}
 }
 
 // for now the staging panel state, unlike the other panel states, is going to be
@@ -101,9 +112,11 @@ type lineByLinePanelState struct {
 	FirstLineIdx     int
 	LastLineIdx      int
 	Diff             string
-	PatchParser      *commands.PatchParser
+	PatchParser      *patch.PatchParser
 	SelectMode       int  // one of LINE, HUNK, or RANGE
 	SecondaryFocused bool // this is for if we show the left or right panel
+	// RenderedLineCount is how many lines of a huge diff we've rendered so far
+	RenderedLineCount int
 }
 
 type mergingPanelState struct {
@@ -146,6 +159,9 @@ type reflogCommitPanelState struct {
 
 type stashPanelState struct {
 	SelectedLine int
+	// ShowUntrackedFor is the index of the stash entry whose untracked files
+	// we're showing in the main view instead of its diff, or -1
+	ShowUntrackedFor int
 }
 
 type menuPanelState struct {
@@ -157,6 +173,10 @@ type commitFilesPanelState struct {
 	SelectedLine int
 }
 
+type rebasePlanPanelState struct {
+	SelectedLine int
+}
+
 type statusPanelState struct {
 	pushables string
 	pullables string
@@ -176,6 +196,7 @@ type panelStates struct {
 	Merging        *mergingPanelState
 	CommitFiles    *commitFilesPanelState
 	Status         *statusPanelState
+	RebasePlan     *rebasePlanPanelState
 }
 
 type searchingState struct {
@@ -200,20 +221,96 @@ type guiState struct {
 	Platform             commands.Platform
 	Updating             bool
 	Panels               *panelStates
-	WorkingTreeState     string // one of "merging", "rebasing", "normal"
+	WorkingTreeState     string // one of "merging", "rebasing", "reverting", "unstashing", "applying", "normal"
 	MainContext          string // used to keep the main and secondary views' contexts in sync
 	CherryPickedCommits  []*commands.Commit
 	SplitMainPanel       bool
 	RetainOriginalDir    bool
 	IsRefreshingFiles    bool
+	LastStatusDuration   time.Duration // how long git status took last time
 	RefreshingFilesMutex sync.Mutex
 	Searching            searchingState
+	MainSearch           *mainSearchState
+	MainTask             *mainTask
+	MainFolds            *mainFolds
+	MainMarks            *mainMarks
 	ScreenMode           int
 	SideView             *gocui.View
 	Ptmx                 *os.File
 	PrevMainWidth        int
 	PrevMainHeight       int
 	OldInformation       string
+	HintsContext         string // what the hints bar was last rendered for
+	Tutorial             *tutorialState
+	Sandbox              *sandboxState
+	ReviewWorktree       *reviewWorktreeState
+	// RunAtCommitWorktrees are the temporary worktrees of commands being run
+	// at a commit
+	RunAtCommitWorktrees []*commands.ReviewWorktree
+	TutorialOffered      bool
+	// RepoConfigsAskedAbout are the repo config files we've asked the user
+	// whether they trust this session
+	RepoConfigsAskedAbout map[string]bool
+	// CustomCommandLog holds the output of the custom commands run with their
+	// output going to the log
+	CustomCommandLog []*customCommandLogEntry
+	CustomCommandRun *customCommandRun
+	// RebasePlan is the interactive rebase being planned in the rebasePlan
+	// popup, if it's open
+	RebasePlan *commands.RebasePlan
+	// GitTraceFile is where the latest git trace went, if we've traced this
+	// session
+	GitTraceFile string
+	// ProjectCommandRuns are the latest runs of the project commands, by name
+	ProjectCommandRuns map[string]*projectCommandRun
+	// IntentToAddFile is an untracked file we've added with `git add -N` so
+	// that it can be staged line by line
+	IntentToAddFile string
+	// FileFilter narrows down which files are shown in the files panel
+	FileFilter     *commands.FileFilter
+	FileFilterText string
+	// StashConflict is set while we're 'unstashing' i.e. applying a stash
+	// entry has left conflicts to be resolved
+	StashConflict *stashConflictState
+	// Comparison is set while the commit files panel is showing the files
+	// that differ between two refs, rather than those of the selected commit
+	Comparison *comparisonState
+	// ConflictedFileNames holds every file that has had conflicts since we left
+	// the 'normal' working tree state, so that we can tell how many of them
+	// have been resolved
+	ConflictedFileNames map[string]bool
+	// ConfirmedLargeFiles holds the large files the user has said they want to
+	// stage or commit anyway, so that we don't ask about them again
+	ConfirmedLargeFiles map[string]bool
+	CommitStats         *commitStatsCache
+	// FileRefDiff is set while the main view shows the selected file's diff
+	// against a ref instead of its staged or unstaged changes
+	FileRefDiff *fileRefDiffState
+	// CommitAuthorship is the author and date to use for the next commit
+	// instead of the user's own and the current time
+	CommitAuthorship *commitAuthorshipState
+	// ReflogFilter narrows down the reflog, and ExpandedReflogGroups holds the
+	// runs of rebase steps in the reflog that the user has expanded, by sha
+	ReflogFilter         *commands.ReflogFilter
+	ReflogFilterText     string
+	ExpandedReflogGroups map[string]bool
+	// CommitFilter narrows down the commits panel by author, path or message
+	CommitFilter     *commands.CommitFilter
+	CommitFilterText string
+	// LogSettings are how much of the history the commits panel loads
+	LogSettings commands.LogSettings
+	// BranchHeadsBeforeRebase is where each branch was before a rebase with
+	// --update-refs, so that we can report which ones it moved
+	BranchHeadsBeforeRebase map[string]string
+	// Offline is set while the user has told us they're offline
+	Offline *offlineState
+	// SigningStatus is nil until we've checked on the user's signing agent
+	SigningStatus *commands.SigningStatus
+
+	// some contexts (e.g. tags and the reflog) aren't loaded until they're first
+	// focused, so that we don't pay for them at startup
+	LoadedContexts      map[string]bool
+	LoadedContextsMutex sync.Mutex
 }
 
 // for now the split view will always be on
@@ -238,7 +335,7 @@ func NewGui(log *logrus.Entry, gitCommand *commands.GitCommand, oSCommand *comma
 			Commits:        &commitPanelState{SelectedLine: -1, LimitCommits: true},
 			ReflogCommits:  &reflogCommitPanelState{SelectedLine: 0}, // TODO: might need to make -1
 			CommitFiles:    &commitFilesPanelState{SelectedLine: -1},
-			Stash:          &stashPanelState{SelectedLine: -1},
+			Stash:          &stashPanelState{SelectedLine: -1, ShowUntrackedFor: -1},
 			Menu:           &menuPanelState{SelectedLine: 0},
 			Merging: &mergingPanelState{
 				ConflictIndex: 0,
@@ -246,11 +343,13 @@ func NewGui(log *logrus.Entry, gitCommand *commands.GitCommand, oSCommand *comma
 				Conflicts:     []commands.Conflict{},
 				EditHistory:   stack.New(),
 			},
-			Status: &statusPanelState{},
+			Status:     &statusPanelState{},
+			RebasePlan: &rebasePlanPanelState{SelectedLine: 0},
 		},
-		ScreenMode: SCREEN_NORMAL,
-		SideView:   nil,
-		Ptmx:       nil,
+		ScreenMode:     SCREEN_NORMAL,
+		SideView:       nil,
+		Ptmx:           nil,
+		LoadedContexts: map[string]bool{},
 	}
 
 	gui := &Gui{
@@ -263,6 +362,9 @@ func NewGui(log *logrus.Entry, gitCommand *commands.GitCommand, oSCommand *comma
 		Updater:              updater,
 		statusManager:        &statusManager{},
 		viewBufferManagerMap: map[string]*tasks.ViewBufferManager{},
+		pagerOutputs:         map[string]*pagerOutput{},
+		refreshTimes:         newRefreshTimes(),
+		repoFingerprints:     &repoFingerprints{},
 	}
 
 	gui.watchFilesForChanges()
@@ -322,7 +424,11 @@ func (gui *Gui) scrollDownView(viewName string) error {
 		}
 	}
 	if manager, ok := gui.viewBufferManagerMap[viewName]; ok {
-		manager.ReadLines(scrollHeight)
+		// read enough to fill the view where it's scrolled to, plus a scroll's worth
+		// so the next one doesn't have to wait
+		_, height := mainView.Size()
+		_, newOy := mainView.Origin()
+		manager.RequestLinesUpTo(newOy + height + scrollHeight)
 	}
 	return nil
 }
@@ -397,6 +503,9 @@ func (gui *Gui) onFocusLost(v *gocui.View, newView *gocui.View) error {
 	case "main":
 		// if we have lost focus to a first-class panel, we need to do some cleanup
 		gui.changeMainViewsContext("normal")
+		if gui.State.MainSearch != nil && !gui.isPopupPanel(newView.Name()) {
+			gui.endMainSearch()
+		}
 	case "commitFiles":
 		if gui.State.MainContext != "patch-building" {
 			if _, err := gui.g.SetViewOnBottom(v.Name()); err != nil {
@@ -498,6 +607,9 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 	if len(gui.State.CherryPickedCommits) > 0 {
 		information = utils.ColoredString(fmt.Sprintf("%d commits copied", len(gui.State.CherryPickedCommits)), color.FgCyan)
 	}
+	if projectCommands := gui.projectCommandsInformation(); projectCommands != "" {
+		information = projectCommands + " " + information
+	}
 
 	minimumHeight := 9
 	minimumWidth := 10
@@ -585,10 +697,10 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		heightDiff := mainPanelBottom - prevMainHeight - 1
 		if heightDiff > 0 {
 			if manager, ok := gui.viewBufferManagerMap["main"]; ok {
-				manager.ReadLines(heightDiff)
+				manager.RequestMoreLines(heightDiff)
 			}
 			if manager, ok := gui.viewBufferManagerMap["secondary"]; ok {
-				manager.ReadLines(heightDiff)
+				manager.RequestMoreLines(heightDiff)
 			}
 		}
 	}
@@ -599,7 +711,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 			return err
 		}
 		v.Title = gui.Tr.SLocalize("DiffTitle")
-		v.Wrap = true
+		v.Wrap = gui.isLineWrapped("main")
 		v.FgColor = textColor
 		v.IgnoreCarriageReturns = true
 	}
@@ -668,7 +780,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 			return err
 		}
 		commitsView.Title = gui.Tr.SLocalize("CommitsTitle")
-		commitsView.Tabs = []string{"Commits", "Reflog"}
+		commitsView.Tabs = []string{gui.Tr.SLocalize("CommitsTabTitle"), gui.Tr.SLocalize("ReflogTitle")}
 		commitsView.FgColor = textColor
 		commitsView.SetOnSelectItem(gui.onSelectItemWrapper(gui.onCommitsPanelSearchSelect))
 		commitsView.ContainsList = true
@@ -693,6 +805,11 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		v.FgColor = theme.OptionsColor
 	}
 
+	tutorialTop := max(mainPanelBottom-8, 1)
+	if err := gui.layoutTutorial(mainPanelLeft+2, tutorialTop, mainPanelRight-2, mainPanelBottom-1); err != nil {
+		return err
+	}
+
 	if gui.getCommitMessageView() == nil {
 		// doesn't matter where this view starts because it will be hidden
 		if commitMessageView, err := g.SetView("commitMessage", hiddenViewOffset, hiddenViewOffset, hiddenViewOffset+10, hiddenViewOffset+10, 0); err != nil {
@@ -704,6 +821,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 			commitMessageView.FgColor = textColor
 			commitMessageView.Editable = true
 			commitMessageView.Editor = gocui.EditorFunc(gui.commitMessageEditor)
+			commitMessageView.Wrap = gui.isLineWrapped("commitMessage")
 		}
 	}
 
@@ -720,6 +838,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 			credentialsView.Title = gui.Tr.SLocalize("CredentialsUsername")
 			credentialsView.FgColor = textColor
 			credentialsView.Editable = true
+			credentialsView.Editor = gocui.EditorFunc(gui.defaultEditor)
 		}
 	}
 
@@ -784,6 +903,17 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		gui.State.OldInformation = information
 	}
 
+	// the hints bar depends on the selected item, so unlike the regular options
+	// it needs re-rendering whenever the selection changes. Popups render their
+	// own options so we leave those alone.
+	if gui.hintsBarEnabled() && gui.g.CurrentView() != nil && !gui.popupPanelFocused() && gui.getHintsContext() != gui.State.HintsContext {
+		if err := gui.renderPanelOptions(); err != nil {
+			return err
+		}
+	}
+
+	gui.updateBracketedPaste()
+
 	if gui.g.CurrentView() == nil {
 		if _, err := gui.g.SetCurrentView(gui.getFilesView().Name()); err != nil {
 			return err
@@ -802,12 +932,12 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 	}
 
 	listViews := []listViewState{
-		{view: filesView, context: "", selectedLine: gui.State.Panels.Files.SelectedLine, lineCount: len(gui.State.Files)},
+		{view: filesView, context: "", selectedLine: gui.State.Panels.Files.SelectedLine, lineCount: len(gui.visibleFiles())},
 		{view: branchesView, context: "local-branches", selectedLine: gui.State.Panels.Branches.SelectedLine, lineCount: len(gui.State.Branches)},
 		{view: branchesView, context: "remotes", selectedLine: gui.State.Panels.Remotes.SelectedLine, lineCount: len(gui.State.Remotes)},
 		{view: branchesView, context: "remote-branches", selectedLine: gui.State.Panels.RemoteBranches.SelectedLine, lineCount: len(gui.State.Remotes)},
-		{view: commitsView, context: "branch-commits", selectedLine: gui.State.Panels.Commits.SelectedLine, lineCount: len(gui.State.Commits)},
-		{view: commitsView, context: "reflog-commits", selectedLine: gui.State.Panels.ReflogCommits.SelectedLine, lineCount: len(gui.State.ReflogCommits)},
+		{view: commitsView, context: "branch-commits", selectedLine: gui.State.Panels.Commits.SelectedLine, lineCount: len(gui.visibleCommits())},
+		{view: commitsView, context: "reflog-commits", selectedLine: gui.State.Panels.ReflogCommits.SelectedLine, lineCount: len(gui.visibleReflogCommits())},
 		{view: stashView, context: "", selectedLine: gui.State.Panels.Stash.SelectedLine, lineCount: len(gui.State.StashEntries)},
 	}
 
@@ -821,9 +951,11 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 			continue
 		}
 		// check if the selected line is now out of view and if so refocus it
-		listView.view.FocusPoint(0, listView.selectedLine)
+		gui.focusPoint(listView.view, listView.selectedLine)
 	}
 
+	gui.renderLastRefreshed()
+
 	mainViewWidth, mainViewHeight := gui.getMainView().Size()
 	if mainViewWidth != gui.State.PrevMainWidth || mainViewHeight != gui.State.PrevMainHeight {
 		gui.State.PrevMainWidth = mainViewWidth
@@ -860,6 +992,10 @@ func (gui *Gui) loadNewRepo() error {
 		return err
 	}
 
+	if gui.Config.GetResolve() {
+		return gui.startResolvingConflicts(gui.Config.GetResolveFile())
+	}
+
 	return nil
 }
 
@@ -901,14 +1037,24 @@ func (gui *Gui) promptAnonymousReporting(done chan struct{}) error {
 }
 
 func (gui *Gui) fetch(g *gocui.Gui, v *gocui.View, canAskForCredentials bool) (unamePassOpend bool, err error) {
+	return gui.fetchWithContext(gui.OSCommand.Context(), g, v, canAskForCredentials)
+}
+
+// fetchWithContext is fetch for a fetch that's given up on, killing git, once
+// ctx is done
+func (gui *Gui) fetchWithContext(ctx context.Context, g *gocui.Gui, v *gocui.View, canAskForCredentials bool) (unamePassOpend bool, err error) {
 	unamePassOpend = false
-	err = gui.GitCommand.Fetch(func(passOrUname string) string {
-		unamePassOpend = true
-		return gui.waitForPassUname(gui.g, v, passOrUname)
-	}, canAskForCredentials)
+	gui.setFetching(true)
+	defer gui.setFetching(false)
+	err = gui.withNetworkRetries(gui.Tr.SLocalize("fetch"), func() error {
+		return gui.GitCommand.Fetch(ctx, func(passOrUname string) string {
+			unamePassOpend = true
+			return gui.waitForPassUname(gui.g, v, passOrUname)
+		}, canAskForCredentials)
+	})
 
 	if canAskForCredentials && err != nil && strings.Contains(err.Error(), "exit status 128") {
-		colorFunction := color.New(color.FgRed).SprintFunc()
+		colorFunction := color.New(theme.CurrentPalette.Conflict).SprintFunc()
 		coloredMessage := colorFunction(strings.TrimSpace(gui.Tr.SLocalize("PassUnameWrong")))
 		close := func(g *gocui.Gui, v *gocui.View) error {
 			return nil
@@ -946,21 +1092,52 @@ func (gui *Gui) goEvery(interval time.Duration, stop chan struct{}, function fun
 	}()
 }
 
+// backgroundFetchTimeout is how long a background fetch gets before we kill it
+const backgroundFetchTimeout = 5 * time.Minute
+
 func (gui *Gui) startBackgroundFetch() {
 	gui.waitForIntro.Wait()
 	isNew := gui.Config.GetIsNewRepo()
 	if !isNew {
 		time.After(60 * time.Second)
 	}
-	_, err := gui.fetch(gui.g, gui.g.CurrentView(), false)
+	err := gui.backgroundFetch()
 	if err != nil && strings.Contains(err.Error(), "exit status 128") && isNew {
 		_ = gui.createConfirmationPanel(gui.g, gui.g.CurrentView(), true, gui.Tr.SLocalize("NoAutomaticGitFetchTitle"), gui.Tr.SLocalize("NoAutomaticGitFetchBody"), nil, nil)
-	} else {
-		gui.goEvery(time.Second*60, gui.stopChan, func() error {
-			_, err := gui.fetch(gui.g, gui.g.CurrentView(), false)
-			return err
-		})
+		return
+	}
+	gui.onBackgroundFetched(err)
+
+	interval := gui.getPollInterval(gui.GitCommand.GetAutoFetchInterval())
+	commands.NewAutoFetcher(interval, gui.backgroundFetch).Run(gui.stopChan, gui.onBackgroundFetched)
+}
+
+// backgroundFetch fetches without asking for credentials and then updates the
+// ahead/behind counts, leaving whatever the user's looking at in the main view
+// be
+func (gui *Gui) backgroundFetch() error {
+	if gui.isOffline() {
+		return nil
+	}
+	// nobody's waiting on a background fetch, so we don't let one that's hung
+	// hold up the ones after it forever
+	ctx, cancel := context.WithTimeout(gui.OSCommand.Context(), backgroundFetchTimeout)
+	defer cancel()
+	if _, err := gui.fetchWithContext(ctx, gui.g, gui.g.CurrentView(), false); err != nil {
+		return err
 	}
+	return gui.refreshBranchesInBackground()
+}
+
+// onBackgroundFetched mentions a failed background fetch without getting in
+// the way, seeing as the user didn't ask for it
+func (gui *Gui) onBackgroundFetched(err error) {
+	if err == nil {
+		return
+	}
+	gui.Log.Error(err)
+	message := strings.SplitN(strings.TrimSpace(err.Error()), "\n", 2)[0]
+	gui.raiseToast(gui.Tr.TemplateLocalize("AutoFetchFailed", Teml{"error": message}))
 }
 
 // Run setup the gui with keybindings and start the mainloop
@@ -970,6 +1147,9 @@ func (gui *Gui) Run() error {
 		return err
 	}
 	defer g.Close()
+	// we need to leave the terminal how we found it, including for any
+	// subprocess we're about to hand over to
+	defer gui.setBracketedPaste(false)
 
 	g.OnSearchEscape = gui.onSearchEscape
 	g.SearchEscapeKey = gui.getKey("universal.return")
@@ -999,14 +1179,33 @@ func (gui *Gui) Run() error {
 	if configPopupVersion != -1 && configPopupVersion < StartupPopupVersion {
 		popupTasks = append(popupTasks, gui.showShamelessSelfPromotionMessage)
 	}
+	// a popup version of zero means the user has never seen the intro popup, so
+	// this is their first time using lazygit
+	if configPopupVersion == 0 && !gui.State.TutorialOffered && gui.State.Tutorial == nil {
+		popupTasks = append(popupTasks, gui.offerTutorial)
+	}
+	if gui.shouldPromptRepoConfigTrust() {
+		popupTasks = append(popupTasks, gui.promptRepoConfigTrust)
+	}
+	if len(gui.getKeybindingProblems()) > 0 {
+		popupTasks = append(popupTasks, gui.showKeybindingProblems)
+	}
 	gui.showInitialPopups(popupTasks)
 
 	gui.waitForIntro.Add(1)
-	if gui.Config.GetUserConfig().GetBool("git.autoFetch") {
+	if gui.Config.GetUserConfig().GetBool("git.autoFetch") && !gui.OSCommand.ReadOnly() {
 		go gui.startBackgroundFetch()
 	}
+	go gui.writeCommitGraphIfNeeded()
+	go gui.refreshSigningStatus()
+	gui.goEvery(connectivityCheckInterval, gui.stopChan, gui.runQueuedNetworkOperationsIfOnline)
+	gui.startAutoSaving()
 
-	gui.goEvery(time.Second*10, gui.stopChan, gui.refreshFiles)
+	gui.statusManager.staticLoader = gui.lowBandwidthMode()
+
+	gui.goEvery(gui.getPollInterval(time.Second*10), gui.stopChan, gui.refreshFiles)
+	gui.startRefreshTicker()
+	gui.watchConfigFile()
 
 	g.SetManager(gocui.ManagerFunc(gui.layout), gocui.ManagerFunc(gui.getFocusLayout()))
 
@@ -1038,12 +1237,31 @@ func (gui *Gui) RunWithSubprocesses() error {
 			close(gui.stopChan)
 
 			if err == gocui.ErrQuit {
+				if err := gui.cleanUpTutorial(); err != nil {
+					return err
+				}
+				if err := gui.cleanUpSandbox(); err != nil {
+					return err
+				}
+				if err := gui.cleanUpReviewWorktree(); err != nil {
+					return err
+				}
+				if err := gui.cleanUpRunAtCommitWorktrees(); err != nil {
+					return err
+				}
+				if err := gui.cleanUpGitTrace(); err != nil {
+					return err
+				}
+
 				if !gui.State.RetainOriginalDir {
 					if err := gui.recordCurrentDirectory(); err != nil {
 						return err
 					}
 				}
 
+				// commands like background fetches are in process groups of
+				// their own, so they won't go away with us unless we kill them
+				gui.OSCommand.CancelCommands()
 				break
 			} else if err == gui.Errors.ErrSwitchRepo {
 				continue
@@ -1072,6 +1290,14 @@ func (gui *Gui) runCommand() error {
 		gui.Log.Error(err)
 	}
 
+	if gui.OnSubProcessDone != nil {
+		if err := gui.OnSubProcessDone(); err != nil {
+			gui.Log.Error(err)
+			fmt.Fprintf(os.Stdout, "\n%s\n", utils.ColoredString(err.Error(), color.FgRed))
+		}
+		gui.OnSubProcessDone = nil
+	}
+
 	gui.SubProcess.Stdout = ioutil.Discard
 	gui.SubProcess.Stderr = ioutil.Discard
 	gui.SubProcess.Stdin = nil
@@ -1118,7 +1344,15 @@ func (gui *Gui) handleMouseDownMain(g *gocui.Gui, v *gocui.View) error {
 		return gui.enterCommitFile(v.SelectedLineIdx())
 	}
 
-	return nil
+	cx, cy := v.Cursor()
+	if link, ok := gui.getLinkInOutput(v, cx, cy); ok {
+		return gui.OSCommand.OpenLink(link)
+	}
+	if _, oy := v.Origin(); gui.jumpToStatFileInMain(cy + oy) {
+		return nil
+	}
+
+	return gui.openLinkUnderCursor(v)
 }
 
 func (gui *Gui) handleMouseDownSecondary(g *gocui.Gui, v *gocui.View) error {
@@ -0,0 +1,152 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// The hints bar replaces the generic options at the bottom of the screen with
+// the keybindings most likely to be useful for whatever is selected. e.g. with
+// a conflicted file selected we'd rather tell the user how to resolve it than
+// how to commit it.
+
+// hintKeybindingNames maps a hints context to the keybindings worth showing
+// for it, most relevant first
+var hintKeybindingNames = map[string][]string{
+	"files:conflicted":         {"universal.goInto", "universal.createRebaseOptionsMenu", "universal.remove", "files.viewResetOptions", "universal.edit"},
+	"files:staged":             {"files.commitChanges", "universal.select", "files.amendLastCommit", "files.commitChangesWithEditor", "files.stashAllChanges", "universal.goInto"},
+	"files:unstaged":           {"universal.select", "files.toggleStagedAll", "universal.goInto", "universal.remove", "universal.edit", "files.stashAllChanges"},
+	"files:untracked":          {"universal.select", "universal.remove", "files.ignoreFile", "universal.edit", "universal.openFile"},
+	"files":                    {"universal.pullFiles", "universal.pushFiles", "files.fetch", "universal.executeCustomCommand"},
+	"branches:local-branches":  {"universal.select", "universal.new", "branches.mergeIntoCurrentBranch", "branches.rebaseBranch", "universal.remove", "branches.renameBranch", "branches.createPullRequest"},
+	"branches:remotes":         {"universal.goInto", "universal.new", "branches.fetchRemote", "universal.remove", "universal.edit"},
+	"branches:remote-branches": {"universal.select", "branches.mergeIntoCurrentBranch", "branches.rebaseBranch", "branches.setUpstream", "universal.remove"},
+	"branches:tags":            {"universal.select", "universal.new", "branches.pushTag", "universal.remove", "commits.viewResetOptions"},
+	"commits:branch-commits":   {"universal.goInto", "commits.squashDown", "commits.renameCommit", "commits.markCommitAsFixup", "universal.edit", "universal.remove", "commits.viewResetOptions"},
+	"commits:rebasing":         {"commits.pickCommit", "universal.edit", "commits.squashDown", "commits.markCommitAsFixup", "universal.remove", "commits.moveDownCommit", "commits.moveUpCommit"},
+	"commits:reflog-commits":   {"universal.select", "commits.viewResetOptions"},
+	"stash":                    {"universal.select", "stash.popStash", "universal.remove", "universal.goInto"},
+	"commitFiles":              {"commitFiles.checkoutCommitFile", "universal.select", "universal.goInto", "universal.remove", "universal.openFile"},
+	"status":                   {"status.recentRepos", "universal.edit", "status.checkForUpdate", "status.showKeybindingReport", "status.toggleSandbox", "status.toggleTutorial"},
+	"main:staging":             {"universal.select", "main.toggleSelectHunk", "main.toggleDragSelect", "universal.remove", "universal.togglePanel", "universal.edit"},
+	"main:patch-building":      {"universal.select", "main.toggleSelectHunk", "main.toggleDragSelect", "universal.return"},
+}
+
+// hintsBarDensities gives the number of hints we show for each density.
+// A density of 'full' shows everything we have.
+var hintsBarDensities = map[string]int{
+	"compact": 3,
+	"normal":  5,
+}
+
+func (gui *Gui) hintsBarEnabled() bool {
+	return gui.Config.GetUserConfig().GetBool("gui.hintsBar.show")
+}
+
+// getHintsContext describes the focused panel along with the type of the
+// selected item, so that we know when the hints need to change
+func (gui *Gui) getHintsContext() string {
+	v := gui.g.CurrentView()
+	if v == nil {
+		return ""
+	}
+
+	switch v.Name() {
+	case "files":
+		file, err := gui.getSelectedFile(gui.g)
+		if err != nil {
+			return "files"
+		}
+		switch {
+		case file.HasMergeConflicts:
+			return "files:conflicted"
+		case !file.Tracked:
+			return "files:untracked"
+		case file.HasStagedChanges && !file.HasUnstagedChanges:
+			return "files:staged"
+		default:
+			return "files:unstaged"
+		}
+	case "branches":
+		return "branches:" + v.Context
+	case "commits":
+		if v.Context == "branch-commits" {
+			commit := gui.getSelectedCommit(gui.g)
+			if commit != nil && commit.Status == "rebasing" {
+				return "commits:rebasing"
+			}
+		}
+		return "commits:" + v.Context
+	case "main":
+		return "main:" + gui.State.MainContext
+	}
+
+	return v.Name()
+}
+
+// getHints returns the key and description of each hint for the given context
+func (gui *Gui) getHints(hintsContext string) [][]string {
+	names, ok := hintKeybindingNames[hintsContext]
+	if !ok {
+		return nil
+	}
+
+	viewName := hintsContext
+	context := ""
+	if index := strings.Index(hintsContext, ":"); index != -1 {
+		viewName = hintsContext[:index]
+		context = hintsContext[index+1:]
+		if context == "rebasing" {
+			context = "branch-commits"
+		}
+	}
+
+	limit, ok := hintsBarDensities[gui.Config.GetUserConfig().GetString("gui.hintsBar.density")]
+	if !ok {
+		limit = len(names)
+	}
+
+	bindings := gui.GetInitialKeybindings()
+	hints := [][]string{}
+	for _, name := range names {
+		if len(hints) >= limit {
+			break
+		}
+		key := gui.getKey(name)
+		for _, binding := range bindings {
+			if binding.ViewName != viewName || binding.Key != key || binding.Description == "" {
+				continue
+			}
+			if len(binding.Contexts) > 0 && !utils.IncludesString(binding.Contexts, context) {
+				continue
+			}
+			hints = append(hints, []string{GetKeyDisplay(key), binding.Description})
+			break
+		}
+	}
+
+	return hints
+}
+
+// renderHintsBar renders hints for the current context into the options view,
+// falling back to the global options if we have nothing specific to say
+func (gui *Gui) renderHintsBar() error {
+	hintsContext := gui.getHintsContext()
+	gui.State.HintsContext = hintsContext
+
+	hints := gui.getHints(hintsContext)
+	if len(hints) == 0 {
+		return gui.renderGlobalOptions()
+	}
+
+	hints = append(hints, []string{gui.getKeyDisplay("universal.optionMenu"), gui.Tr.SLocalize("menu")})
+
+	hintStrings := make([]string, len(hints))
+	for i, hint := range hints {
+		hintStrings[i] = hint[0] + ": " + hint[1]
+	}
+
+	gui.renderString(gui.g, "options", strings.Join(hintStrings, ", "))
+	return nil
+}
@@ -0,0 +1,94 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+)
+
+// Some diffs, like those of generated lockfiles, are far too big to render in
+// the staging panel every time the selection moves. Diffs longer than
+// gui.hugeDiffLines are rendered that many lines at a time, more being loaded
+// as the selection gets near the bottom, and before opening one we offer to
+// just stage the whole file instead.
+
+func (gui *Gui) hugeDiffLines() int {
+	return gui.Config.GetUserConfig().GetInt("gui.hugeDiffLines")
+}
+
+func (gui *Gui) isHugeDiff(diff string) bool {
+	limit := gui.hugeDiffLines()
+	return limit > 0 && strings.Count(diff, "\n") > limit
+}
+
+// confirmHugeDiff asks what to do with a file whose diff is too big to render
+// comfortably, calling onOpen if the user wants it in the staging panel anyway
+func (gui *Gui) confirmHugeDiff(file *commands.File, onOpen func() error) error {
+	diff := gui.GitCommand.Diff(file, true, !file.HasUnstagedChanges)
+	if !gui.isHugeDiff(diff) {
+		return onOpen()
+	}
+
+	menuItems := []*menuItem{}
+	if file.HasUnstagedChanges {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("StageWholeFileWithoutRendering"),
+			onPress: func() error {
+				return gui.confirmLargeFiles([]string{file.Name}, func() error {
+					return gui.refreshAfterStaging(gui.stageFile(file))
+				})
+			},
+		})
+	} else {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("UnstageWholeFileWithoutRendering"),
+			onPress: func() error {
+				return gui.refreshAfterStaging(gui.unstageFile(file))
+			},
+		})
+	}
+	menuItems = append(menuItems, &menuItem{
+		displayString: gui.Tr.SLocalize("OpenHugeDiffAnyway"),
+		onPress:       onOpen,
+	})
+
+	title := gui.Tr.TemplateLocalize("HugeDiffTitle", Teml{"lines": fmt.Sprintf("%d", strings.Count(diff, "\n"))})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+// linesToRender returns how many of the staging panel's diff lines to render,
+// loading more of a huge diff once the selection gets within a screen of the
+// bottom of what's been rendered so far
+func (gui *Gui) linesToRender(state *lineByLinePanelState) int {
+	total := len(state.PatchParser.PatchLines)
+	limit := gui.hugeDiffLines()
+	if limit <= 0 || total <= limit {
+		return total
+	}
+
+	_, viewHeight := gui.getMainView().Size()
+	for state.RenderedLineCount < state.LastLineIdx+viewHeight && state.RenderedLineCount < total {
+		state.RenderedLineCount += limit
+	}
+	if state.RenderedLineCount > total {
+		state.RenderedLineCount = total
+	}
+	return state.RenderedLineCount
+}
+
+// renderPartialPatch renders the first lineCount lines of a diff, followed by
+// a footer saying how much of it is left
+func (gui *Gui) renderPartialPatch(patchParser *patch.PatchParser, lineCount int, firstLineIdx int, lastLineIdx int, includedLineIndices []int) string {
+	result := patchParser.RenderUpTo(lineCount, firstLineIdx, lastLineIdx, includedLineIndices)
+	remaining := len(patchParser.PatchLines) - lineCount
+	if remaining <= 0 {
+		return result
+	}
+
+	footer := gui.Tr.TemplateLocalize("MoreDiffLines", Teml{"count": fmt.Sprintf("%d", remaining)})
+	return result + "\n" + color.New(theme.CurrentPalette.Info).Sprint(footer)
+}
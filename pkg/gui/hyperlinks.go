@@ -0,0 +1,46 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// We can't emit OSC 8 hyperlinks because our views are drawn cell by cell, so
+// instead we make shas, issue references and URLs in the main view clickable
+// ourselves, and open them in the browser.
+
+// openLinkUnderCursor opens the link for whatever word is under the cursor in
+// the given view, if it has one
+func (gui *Gui) openLinkUnderCursor(v *gocui.View) error {
+	cx, cy := v.Cursor()
+	word, err := v.Word(cx, cy)
+	if err != nil {
+		return nil
+	}
+
+	link, ok := commands.NewHyperlinker(gui.GitCommand).GetLink(word)
+	if !ok {
+		return nil
+	}
+
+	return gui.OSCommand.OpenLink(link)
+}
+
+func (gui *Gui) handleOpenCommitInBrowser(g *gocui.Gui, v *gocui.View) error {
+	var commit *commands.Commit
+	if v.Context == "reflog-commits" {
+		commit = gui.getSelectedReflogCommit()
+	} else {
+		commit = gui.getSelectedCommit(g)
+	}
+	if commit == nil {
+		return nil
+	}
+
+	link, ok := commands.NewHyperlinker(gui.GitCommand).GetCommitLink(commit.Sha)
+	if !ok {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("UnsupportedGitService"))
+	}
+
+	return gui.OSCommand.OpenLink(link)
+}
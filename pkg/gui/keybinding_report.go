@@ -0,0 +1,162 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/spf13/viper"
+)
+
+// these views take text input, so keybindings on plain characters will never
+// fire in them
+var editableViews = []string{"commitMessage", "credentials", "confirmation", "search"}
+
+var defaultConfig *viper.Viper
+var defaultConfigOnce sync.Once
+
+// getDefaultConfig returns a viper holding only our default config, which we
+// fall back to when the user's config has an invalid keybinding
+func getDefaultConfig() *viper.Viper {
+	defaultConfigOnce.Do(func() {
+		defaultConfig = viper.New()
+		defaultConfig.SetConfigType("yaml")
+		_ = config.LoadDefaults(defaultConfig, config.GetDefaultConfig())
+		_ = config.LoadDefaults(defaultConfig, config.GetPlatformDefaultConfig())
+	})
+	return defaultConfig
+}
+
+// getKeybindingProblems returns a description of everything wrong with the
+// user's keybindings: keys we can't parse, names we don't recognise, and
+// bindings that can never fire because another binding gets there first
+func (gui *Gui) getKeybindingProblems() []string {
+	problems := []string{}
+
+	userConfig := gui.Config.GetUserConfig()
+	knownKeys := map[string]bool{}
+	for _, key := range getDefaultConfig().AllKeys() {
+		knownKeys[key] = true
+	}
+
+	for _, key := range userConfig.AllKeys() {
+		if !strings.HasPrefix(key, "keybinding.") {
+			continue
+		}
+		name := strings.TrimPrefix(key, "keybinding.")
+		if !knownKeys[key] && !gui.isMenuActionKey(key) && !gui.isProjectCommandKey(key) {
+			problems = append(problems, gui.Tr.TemplateLocalize("UnknownKeybindingName", Teml{"name": name}))
+			continue
+		}
+		if _, err := getKeyFromConfig(userConfig, name); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	bindings := gui.GetInitialKeybindings()
+
+	for i, binding := range bindings {
+		if binding.Key == nil {
+			continue
+		}
+
+		if binding.ViewName != "" && utils.IncludesString(editableViews, binding.ViewName) {
+			if _, isRune := binding.Key.(rune); isRune {
+				problems = append(problems, gui.Tr.TemplateLocalize("UnreachableKeybinding", Teml{
+					"key":         GetKeyDisplay(binding.Key),
+					"description": bindingDescription(binding),
+					"viewName":    binding.ViewName,
+				}))
+			}
+		}
+
+		// gocui runs the first binding that matches, so any later binding with the
+		// same key in the same view and context is dead
+		for _, earlierBinding := range bindings[:i] {
+			if !bindingsOverlap(earlierBinding, binding) {
+				continue
+			}
+			// we have some deliberately doubled-up bindings for mouse events which
+			// don't show up anywhere, so we only care if one of the two is user-facing
+			if earlierBinding.Description == "" && binding.Description == "" {
+				continue
+			}
+			viewName := binding.ViewName
+			if viewName == "" {
+				viewName = "global"
+			}
+			problems = append(problems, gui.Tr.TemplateLocalize("ConflictingKeybindings", Teml{
+				"key":                 GetKeyDisplay(binding.Key),
+				"viewName":            viewName,
+				"description":         bindingDescription(binding),
+				"existingDescription": bindingDescription(earlierBinding),
+			}))
+			break
+		}
+	}
+
+	return problems
+}
+
+func bindingsOverlap(a *Binding, b *Binding) bool {
+	if a.ViewName != b.ViewName || a.Key != b.Key || a.Modifier != b.Modifier {
+		return false
+	}
+	if len(a.Contexts) == 0 || len(b.Contexts) == 0 {
+		return true
+	}
+	for _, context := range a.Contexts {
+		if utils.IncludesString(b.Contexts, context) {
+			return true
+		}
+	}
+	return false
+}
+
+func bindingDescription(binding *Binding) string {
+	if binding.Description == "" {
+		return "?"
+	}
+	return binding.Description
+}
+
+func (gui *Gui) renderKeybindingReport() string {
+	problems := gui.getKeybindingProblems()
+	if len(problems) == 0 {
+		return utils.ColoredString(gui.Tr.SLocalize("NoKeybindingProblems"), theme.CurrentPalette.Added)
+	}
+
+	sort.Strings(problems)
+
+	return fmt.Sprintf(
+		"%s\n\n%s",
+		utils.ColoredString(gui.Tr.SLocalize("KeybindingProblemsTitle"), theme.CurrentPalette.Conflict),
+		"- "+strings.Join(problems, "\n- "),
+	)
+}
+
+func (gui *Gui) handleShowKeybindingReport(g *gocui.Gui, v *gocui.View) error {
+	gui.getMainView().Title = gui.Tr.SLocalize("KeybindingReportTitle")
+	return gui.newStringTask("main", gui.renderKeybindingReport())
+}
+
+// showKeybindingProblems is a startup popup task letting the user know their
+// keybindings need attention, so that problems don't go unnoticed
+func (gui *Gui) showKeybindingProblems(done chan struct{}) error {
+	onClose := func(g *gocui.Gui, v *gocui.View) error {
+		done <- struct{}{}
+		return nil
+	}
+
+	message := gui.Tr.TemplateLocalize("KeybindingProblemsPrompt", Teml{
+		"count": len(gui.getKeybindingProblems()),
+		"key":   gui.getKeyDisplay("status.showKeybindingReport"),
+	})
+
+	return gui.createConfirmationPanel(gui.g, nil, true, gui.Tr.SLocalize("KeybindingProblemsTitle"), message, onClose, onClose)
+}
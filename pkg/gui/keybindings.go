@@ -1,10 +1,13 @@
 package gui
 
 import (
+	"fmt"
 	"log"
 	"strings"
 
+	"github.com/go-errors/errors"
 	"github.com/jesseduffield/gocui"
+	"github.com/spf13/viper"
 )
 
 // Binding - a keybinding mapping a key and modifier to a handler. The keypress
@@ -173,23 +176,39 @@ func GetKeyDisplay(key interface{}) string {
 		keyInt = int(key)
 	}
 
-	return string(keyInt)
+	return string(rune(keyInt))
 }
 
 func (gui *Gui) getKey(name string) interface{} {
-	key := gui.Config.GetUserConfig().GetString("keybinding." + name)
+	key, err := getKeyFromConfig(gui.Config.GetUserConfig(), name)
+	if err == nil {
+		return key
+	}
+
+	// falling back to the default so that a typo in the user's config doesn't
+	// stop us from starting. The problem shows up in the keybinding report
+	key, defaultErr := getKeyFromConfig(getDefaultConfig(), name)
+	if defaultErr != nil {
+		log.Fatal(err)
+	}
+	return key
+}
+
+// getKeyFromConfig parses the key for the given keybinding name out of the
+// given config, returning an error rather than bailing so that we can validate
+// a config before we start using it
+func getKeyFromConfig(userConfig *viper.Viper, name string) (interface{}, error) {
+	key := userConfig.GetString("keybinding." + name)
 	if len(key) > 1 {
 		binding := keymap[strings.ToLower(key)]
 		if binding == nil {
-			log.Fatalf("Unrecognized key %s for keybinding %s", strings.ToLower(key), name)
-		} else {
-			return binding
+			return nil, fmt.Errorf("Unrecognized key %s for keybinding %s", strings.ToLower(key), name)
 		}
+		return binding, nil
 	} else if len(key) == 1 {
-		return []rune(key)[0]
+		return []rune(key)[0], nil
 	}
-	log.Fatal("Key empty for keybinding: " + strings.ToLower(name))
-	return nil
+	return nil, errors.New("Key empty for keybinding: " + strings.ToLower(name))
 }
 
 // GetInitialKeybindings is a function.
@@ -259,6 +278,97 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Modifier: gocui.ModNone,
 			Handler:  gui.scrollDownMain,
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.searchMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleOpenMainSearch,
+			Description: gui.Tr.SLocalize("searchMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.prevFileInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handlePrevFileInMain,
+			Description: gui.Tr.SLocalize("prevFileInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.nextFileInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleNextFileInMain,
+			Description: gui.Tr.SLocalize("nextFileInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.prevHunkInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handlePrevHunkInMain,
+			Description: gui.Tr.SLocalize("prevHunkInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.nextHunkInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleNextHunkInMain,
+			Description: gui.Tr.SLocalize("nextHunkInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.jumpToFileInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleJumpToFileInMain,
+			Description: gui.Tr.SLocalize("JumpToFileInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.toggleMarkInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleMarkInMain,
+			Description: gui.Tr.SLocalize("toggleMarkInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.jumpToMarkInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleJumpToMarkInMain,
+			Description: gui.Tr.SLocalize("JumpToMarkInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.toggleFoldInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleFoldInMain,
+			Description: gui.Tr.SLocalize("toggleFoldInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.foldAllInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleFoldAllInMain,
+			Description: gui.Tr.SLocalize("foldAllInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.unfoldAllInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleUnfoldAllInMain,
+			Description: gui.Tr.SLocalize("unfoldAllInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.toggleWrapInMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleWrapInMain,
+			Description: gui.Tr.SLocalize("toggleWrapInMain"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.compareRefs"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateComparison,
+			Description: gui.Tr.SLocalize("compareRefs"),
+		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey("universal.createRebaseOptionsMenu"),
@@ -294,6 +404,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleRefresh,
 			Description: gui.Tr.SLocalize("refresh"),
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.forceRefresh"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleForceRefresh,
+			Description: gui.Tr.SLocalize("forceRefresh"),
+		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey("universal.optionMenu"),
@@ -355,6 +472,97 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateRecentReposMenu,
 			Description: gui.Tr.SLocalize("SwitchRepo"),
 		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.showKeybindingReport"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleShowKeybindingReport,
+			Description: gui.Tr.SLocalize("showKeybindingReport"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.toggleTutorial"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleTutorial,
+			Description: gui.Tr.SLocalize("toggleTutorial"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.toggleSandbox"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleSandbox,
+			Description: gui.Tr.SLocalize("toggleSandbox"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.toggleSparseIndex"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleSparseIndex,
+			Description: gui.Tr.SLocalize("toggleSparseIndex"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.toggleOfflineMode"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleOfflineMode,
+			Description: gui.Tr.SLocalize("toggleOfflineMode"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.testSigning"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleTestSigning,
+			Description: gui.Tr.SLocalize("testSigning"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.viewStatusSettings"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateStatusSettingsMenu,
+			Description: gui.Tr.SLocalize("viewStatusSettings"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.createSnapshot"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateSnapshot,
+			Description: gui.Tr.SLocalize("createSnapshot"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.viewSnapshots"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleViewSnapshots,
+			Description: gui.Tr.SLocalize("viewSnapshots"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.viewAutoSaves"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleViewAutoSaves,
+			Description: gui.Tr.SLocalize("viewAutoSaves"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.switchPairingDriver"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreatePairingMenu,
+			Description: gui.Tr.SLocalize("switchPairingDriver"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.viewRepoConfigTrust"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateRepoConfigTrustMenu,
+			Description: gui.Tr.SLocalize("viewRepoConfigTrust"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.toggleGitTrace"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleGitTrace,
+			Description: gui.Tr.SLocalize("toggleGitTrace"),
+		},
 		{
 			ViewName:    "files",
 			Key:         gui.getKey("files.commitChanges"),
@@ -387,14 +595,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "files",
 			Key:         gui.getKey("universal.select"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleFilePress,
+			Handler:     gui.abortIfChangedExternally(gui.handleFilePress),
 			Description: gui.Tr.SLocalize("toggleStaged"),
 		},
 		{
 			ViewName:    "files",
 			Key:         gui.getKey("universal.remove"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCreateDiscardMenu,
+			Handler:     gui.abortIfChangedExternally(gui.handleCreateDiscardMenu),
 			Description: gui.Tr.SLocalize("viewDiscardOptions"),
 		},
 		{
@@ -467,6 +675,69 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleGitFetch,
 			Description: gui.Tr.SLocalize("fetch"),
 		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.restoreDiscardedFile"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateRestoreBackupMenu,
+			Description: gui.Tr.SLocalize("RestoreDiscardedFile"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.filterFiles"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleFilterFiles,
+			Description: gui.Tr.SLocalize("FilterFiles"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.stageByPattern"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleStageByPattern,
+			Description: gui.Tr.SLocalize("StageByPattern"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.viewDirectoryOptions"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateDirectoryOptionsMenu,
+			Description: gui.Tr.SLocalize("ViewDirectoryOptions"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.viewIntroducedTodos"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleViewIntroducedTodos,
+			Description: gui.Tr.SLocalize("viewIntroducedTodos"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.diffAgainstRef"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleDiffFileAgainstRef,
+			Description: gui.Tr.SLocalize("DiffFileAgainstRef"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.viewSubmodulePins"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleFileSubmodulePins,
+			Description: gui.Tr.SLocalize("ViewSubmodulePins"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.viewWhitespaceOptions"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateWhitespaceOptionsMenu,
+			Description: gui.Tr.SLocalize("viewWhitespaceOptions"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.formatAndStage"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleFormatAndStage,
+			Description: gui.Tr.SLocalize("formatAndStage"),
+		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey("universal.executeCustomCommand"),
@@ -474,6 +745,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCustomCommand,
 			Description: gui.Tr.SLocalize("executeCustomCommand"),
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.viewCustomCommandLog"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleViewCustomCommandLog,
+			Description: gui.Tr.SLocalize("viewCustomCommandLog"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.viewProjectCommands"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateProjectCommandsMenu,
+			Description: gui.Tr.SLocalize("viewProjectCommands"),
+		},
 		{
 			ViewName:    "files",
 			Key:         gui.getKey("commits.viewResetOptions"),
@@ -486,7 +771,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"local-branches"},
 			Key:         gui.getKey("universal.select"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleBranchPress,
+			Handler:     gui.abortIfChangedExternally(gui.handleBranchPress),
 			Description: gui.Tr.SLocalize("checkout"),
 		},
 		{
@@ -510,7 +795,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"local-branches"},
 			Key:         gui.getKey("branches.forceCheckoutBranch"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleForceCheckout,
+			Handler:     gui.abortIfChangedExternally(gui.handleForceCheckout),
 			Description: gui.Tr.SLocalize("forceCheckout"),
 		},
 		{
@@ -526,7 +811,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"local-branches"},
 			Key:         gui.getKey("universal.remove"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleDeleteBranch,
+			Handler:     gui.abortIfChangedExternally(gui.handleDeleteBranch),
 			Description: gui.Tr.SLocalize("deleteBranch"),
 		},
 		{
@@ -534,7 +819,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"local-branches"},
 			Key:         gui.getKey("branches.rebaseBranch"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleRebaseOntoLocalBranch,
+			Handler:     gui.abortIfChangedExternally(gui.handleRebaseOntoLocalBranch),
 			Description: gui.Tr.SLocalize("rebaseBranch"),
 		},
 		{
@@ -542,7 +827,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"local-branches"},
 			Key:         gui.getKey("branches.mergeIntoCurrentBranch"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleMerge,
+			Handler:     gui.abortIfChangedExternally(gui.handleMerge),
 			Description: gui.Tr.SLocalize("mergeIntoCurrentBranch"),
 		},
 		{
@@ -553,12 +838,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateGitFlowMenu,
 			Description: gui.Tr.SLocalize("gitFlowOptions"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.viewStackOptions"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateStackMenu,
+			Description: gui.Tr.SLocalize("viewStackOptions"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{"local-branches"},
 			Key:         gui.getKey("branches.FastForward"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleFastForward,
+			Handler:     gui.abortIfChangedExternally(gui.handleFastForward),
 			Description: gui.Tr.SLocalize("FastForward"),
 		},
 		{
@@ -566,7 +859,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"local-branches"},
 			Key:         gui.getKey("commits.viewResetOptions"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCreateResetToBranchMenu,
+			Handler:     gui.abortIfChangedExternally(gui.handleCreateResetToBranchMenu),
 			Description: gui.Tr.SLocalize("viewResetOptions"),
 		},
 		{
@@ -574,9 +867,41 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"local-branches"},
 			Key:         gui.getKey("branches.renameBranch"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleRenameBranch,
+			Handler:     gui.abortIfChangedExternally(gui.handleRenameBranch),
 			Description: gui.Tr.SLocalize("renameBranch"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.reviewInWorktree"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleReviewLocalBranch,
+			Description: gui.Tr.SLocalize("reviewInWorktree"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.squashBranch"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.abortIfChangedExternally(gui.handleSquashBranch),
+			Description: gui.Tr.SLocalize("squashBranch"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.viewCommitsAheadOfBase"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleViewCommitsAheadOfBase,
+			Description: gui.Tr.SLocalize("viewCommitsAheadOfBase"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.viewAncestryOptions"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleBranchAncestryOptions,
+			Description: gui.Tr.SLocalize("viewAncestryOptions"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{"tags"},
@@ -590,7 +915,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"tags"},
 			Key:         gui.getKey("universal.remove"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleDeleteTag,
+			Handler:     gui.abortIfChangedExternally(gui.handleDeleteTag),
 			Description: gui.Tr.SLocalize("deleteTag"),
 		},
 		{
@@ -614,7 +939,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"tags"},
 			Key:         gui.getKey("commits.viewResetOptions"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCreateResetToTagMenu,
+			Handler:     gui.abortIfChangedExternally(gui.handleCreateResetToTagMenu),
 			Description: gui.Tr.SLocalize("viewResetOptions"),
 		},
 		{
@@ -681,7 +1006,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.squashDown"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitSquashDown,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitSquashDown),
 			Description: gui.Tr.SLocalize("squashDown"),
 		},
 		{
@@ -689,7 +1014,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.renameCommit"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleRenameCommit,
+			Handler:     gui.abortIfChangedExternally(gui.handleRenameCommit),
 			Description: gui.Tr.SLocalize("renameCommit"),
 		},
 		{
@@ -697,15 +1022,23 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.renameCommitWithEditor"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleRenameCommitEditor,
+			Handler:     gui.abortIfChangedExternally(gui.handleRenameCommitEditor),
 			Description: gui.Tr.SLocalize("renameCommitEditor"),
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.rewordCommits"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.abortIfChangedExternally(gui.handleRewordCommits),
+			Description: gui.Tr.SLocalize("rewordCommits"),
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.viewResetOptions"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCreateCommitResetMenu,
+			Handler:     gui.abortIfChangedExternally(gui.handleCreateCommitResetMenu),
 			Description: gui.Tr.SLocalize("resetToThisCommit"),
 		},
 		{
@@ -713,7 +1046,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.markCommitAsFixup"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitFixup,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitFixup),
 			Description: gui.Tr.SLocalize("fixupCommit"),
 		},
 		{
@@ -737,7 +1070,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("universal.remove"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitDelete,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitDelete),
 			Description: gui.Tr.SLocalize("deleteCommit"),
 		},
 		{
@@ -745,7 +1078,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.moveDownCommit"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitMoveDown,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitMoveDown),
 			Description: gui.Tr.SLocalize("moveDownCommit"),
 		},
 		{
@@ -753,7 +1086,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.moveUpCommit"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitMoveUp,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitMoveUp),
 			Description: gui.Tr.SLocalize("moveUpCommit"),
 		},
 		{
@@ -761,7 +1094,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("universal.edit"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitEdit,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitEdit),
 			Description: gui.Tr.SLocalize("editCommit"),
 		},
 		{
@@ -769,7 +1102,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.amendToCommit"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitAmendTo,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitAmendTo),
 			Description: gui.Tr.SLocalize("amendToCommit"),
 		},
 		{
@@ -777,7 +1110,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.pickCommit"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitPick,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitPick),
 			Description: gui.Tr.SLocalize("pickCommit"),
 		},
 		{
@@ -785,7 +1118,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.revertCommit"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCommitRevert,
+			Handler:     gui.abortIfChangedExternally(gui.handleCommitRevert),
 			Description: gui.Tr.SLocalize("revertCommit"),
 		},
 		{
@@ -809,9 +1142,105 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.pasteCommits"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.HandlePasteCommits,
+			Handler:     gui.abortIfChangedExternally(gui.HandlePasteCommits),
 			Description: gui.Tr.SLocalize("pasteCommits"),
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.filterCommits"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleFilterCommits,
+			Description: gui.Tr.SLocalize("FilterCommits"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.filterByAuthor"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleFilterByAuthor,
+			Description: gui.Tr.SLocalize("FilterByAuthor"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.addTrailer"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleAddTrailerToCommit,
+			Description: gui.Tr.SLocalize("AddTrailerToCommit"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.viewAuthorOptions"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateCommitAuthorOptionsMenu,
+			Description: gui.Tr.SLocalize("ViewCommitAuthorOptions"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.viewAncestryOptions"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCommitAncestryOptions,
+			Description: gui.Tr.SLocalize("viewAncestryOptions"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.viewLogSettings"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateLogSettingsMenu,
+			Description: gui.Tr.SLocalize("viewLogSettings"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.runCommandAtCommit"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRunCommandAtCommit,
+			Description: gui.Tr.SLocalize("runCommandAtCommit"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.bisectRun"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleBisectRun,
+			Description: gui.Tr.SLocalize("bisectRun"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.planRebase"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.abortIfChangedExternally(gui.handleCreateRebasePlan),
+			Description: gui.Tr.SLocalize("planRebase"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.exportPatches"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleExportPatches,
+			Description: gui.Tr.SLocalize("ExportPatches"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.applyMailbox"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleApplyMailbox,
+			Description: gui.Tr.SLocalize("ApplyMailbox"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.insertRebaseStep"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateRebaseStepMenu,
+			Description: gui.Tr.SLocalize("InsertRebaseStep"),
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{"branch-commits"},
@@ -825,7 +1254,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"branch-commits"},
 			Key:         gui.getKey("commits.checkoutCommit"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCheckoutCommit,
+			Handler:     gui.abortIfChangedExternally(gui.handleCheckoutCommit),
 			Description: gui.Tr.SLocalize("checkoutCommit"),
 		},
 		{
@@ -852,12 +1281,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleResetCherryPick,
 			Description: gui.Tr.SLocalize("resetCherryPick"),
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits", "reflog-commits"},
+			Key:         gui.getKey("commits.openInBrowser"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleOpenCommitInBrowser,
+			Description: gui.Tr.SLocalize("openCommitInBrowser"),
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{"reflog-commits"},
 			Key:         gui.getKey("universal.select"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCheckoutReflogCommit,
+			Handler:     gui.abortIfChangedExternally(gui.handleCheckoutReflogCommit),
 			Description: gui.Tr.SLocalize("checkoutCommit"),
 		},
 		{
@@ -865,53 +1302,231 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"reflog-commits"},
 			Key:         gui.getKey("commits.viewResetOptions"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleCreateReflogResetMenu,
+			Handler:     gui.abortIfChangedExternally(gui.handleCreateReflogResetMenu),
 			Description: gui.Tr.SLocalize("viewResetOptions"),
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"reflog-commits"},
+			Key:         gui.getKey("reflogCommits.filterReflog"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleFilterReflog,
+			Description: gui.Tr.SLocalize("FilterReflog"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"reflog-commits"},
+			Key:         gui.getKey("universal.goInto"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleReflogGroup,
+			Description: gui.Tr.SLocalize("ToggleReflogGroup"),
+		},
 		{
 			ViewName:    "stash",
 			Key:         gui.getKey("universal.select"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleStashApply,
+			Handler:     gui.abortIfChangedExternally(gui.handleStashApply),
 			Description: gui.Tr.SLocalize("apply"),
 		},
 		{
 			ViewName:    "stash",
 			Key:         gui.getKey("stash.popStash"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleStashPop,
+			Handler:     gui.abortIfChangedExternally(gui.handleStashPop),
 			Description: gui.Tr.SLocalize("pop"),
 		},
+		{
+			ViewName:    "stash",
+			Key:         gui.getKey("stash.viewLostCommits"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleViewLostCommits,
+			Description: gui.Tr.SLocalize("viewLostCommits"),
+		},
 		{
 			ViewName:    "stash",
 			Key:         gui.getKey("universal.remove"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleStashDrop,
+			Handler:     gui.abortIfChangedExternally(gui.handleStashDrop),
 			Description: gui.Tr.SLocalize("drop"),
 		},
+		{
+			ViewName:    "stash",
+			Key:         gui.getKey("universal.goInto"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateStashFilesMenu,
+			Description: gui.Tr.SLocalize("viewStashFiles"),
+		},
 		{
 			ViewName: "commitMessage",
 			Key:      gocui.KeyEnter,
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleCommitConfirm,
+			Handler:  gui.wrappedEditorConfirm(gui.handleCommitConfirm, true),
 		},
 		{
 			ViewName: "commitMessage",
 			Key:      gocui.KeyEsc,
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleCommitClose,
+			Handler:  gui.wrappedEditorClose(gui.handleCommitClose),
+		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey("commitMessage.trailersMenu"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateTrailersMenu,
+			Description: gui.Tr.SLocalize("TrailersMenu"),
+		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey("commitMessage.commitAuthorship"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateCommitAuthorshipMenu,
+			Description: gui.Tr.SLocalize("CommitAuthorshipMenu"),
+		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey("commitMessage.commitAndPush"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.wrappedEditorConfirm(gui.handleCommitAndPush, false),
+			Description: gui.Tr.SLocalize("commitAndPush"),
+		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey("commitMessage.toggleWrap"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleToggleWrapInCommitMessage,
+			Description: gui.Tr.SLocalize("toggleWrapInCommitMessage"),
 		},
 		{
 			ViewName: "credentials",
 			Key:      gocui.KeyEnter,
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSubmitCredential,
+			Handler:  gui.wrappedEditorConfirm(gui.handleSubmitCredential, false),
 		},
 		{
 			ViewName: "credentials",
 			Key:      gocui.KeyEsc,
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleCloseCredentialsView,
+			Handler:  gui.wrappedEditorClose(gui.handleCloseCredentialsView),
+		},
+		{
+			ViewName:    "customCommandOutput",
+			Key:         gui.getKey("universal.return"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCloseCustomCommandOutput,
+			Description: gui.Tr.SLocalize("closeCustomCommandOutput"),
+		},
+		{
+			ViewName:    "customCommandOutput",
+			Key:         gui.getKey("customCommandOutput.cancel"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCancelCustomCommand,
+			Description: gui.Tr.SLocalize("cancelCustomCommand"),
+		},
+		{
+			ViewName: "customCommandOutput",
+			Key:      gui.getKey("universal.prevItem"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleScrollUpCustomCommandOutput,
+		},
+		{
+			ViewName: "customCommandOutput",
+			Key:      gui.getKey("universal.prevItem-alt"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleScrollUpCustomCommandOutput,
+		},
+		{
+			ViewName: "customCommandOutput",
+			Key:      gocui.MouseWheelUp,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleScrollUpCustomCommandOutput,
+		},
+		{
+			ViewName: "customCommandOutput",
+			Key:      gui.getKey("universal.nextItem"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleScrollDownCustomCommandOutput,
+		},
+		{
+			ViewName: "customCommandOutput",
+			Key:      gui.getKey("universal.nextItem-alt"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleScrollDownCustomCommandOutput,
+		},
+		{
+			ViewName: "customCommandOutput",
+			Key:      gocui.MouseWheelDown,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleScrollDownCustomCommandOutput,
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.pick"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRebasePlanAction("pick"),
+			Description: gui.Tr.SLocalize("rebasePlanPick"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.squash"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRebasePlanAction("squash"),
+			Description: gui.Tr.SLocalize("rebasePlanSquash"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.fixup"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRebasePlanAction("fixup"),
+			Description: gui.Tr.SLocalize("rebasePlanFixup"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.edit"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRebasePlanAction("edit"),
+			Description: gui.Tr.SLocalize("rebasePlanEdit"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.drop"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRebasePlanAction("drop"),
+			Description: gui.Tr.SLocalize("rebasePlanDrop"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.reword"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRebasePlanAction("reword"),
+			Description: gui.Tr.SLocalize("rebasePlanReword"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.moveDownStep"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRebasePlanMoveStep(1),
+			Description: gui.Tr.SLocalize("moveDownRebasePlanStep"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.moveUpStep"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleRebasePlanMoveStep(-1),
+			Description: gui.Tr.SLocalize("moveUpRebasePlanStep"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("rebasePlan.startRebase"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleStartRebasePlan,
+			Description: gui.Tr.SLocalize("startRebasePlan"),
+		},
+		{
+			ViewName:    "rebasePlan",
+			Key:         gui.getKey("universal.return"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCloseRebasePlan,
+			Description: gui.Tr.SLocalize("cancelRebasePlan"),
 		},
 		{
 			ViewName:    "menu",
@@ -947,6 +1562,27 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCheckoutCommitFile,
 			Description: gui.Tr.SLocalize("checkoutCommitFile"),
 		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey("commitFiles.checkoutCommitFileIntoWorktree"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCheckoutCommitFileIntoWorktree,
+			Description: gui.Tr.SLocalize("CheckoutCommitFileIntoWorktree"),
+		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey("commitFiles.viewSubmodulePins"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCommitFileSubmodulePins,
+			Description: gui.Tr.SLocalize("ViewSubmodulePins"),
+		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey("commitFiles.filterByPath"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleFilterByPath,
+			Description: gui.Tr.SLocalize("FilterByPath"),
+		},
 		{
 			ViewName:    "commitFiles",
 			Key:         gui.getKey("universal.remove"),
@@ -1019,6 +1655,46 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Modifier: gocui.ModNone,
 			Handler:  gui.handleMouseDownMain,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"normal"},
+			Key:         gui.getKey("universal.startSearch"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleOpenMainSearch,
+			Description: gui.Tr.SLocalize("startSearch"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"normal"},
+			Key:         gui.getKey("universal.nextMatch"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleNextMainSearchMatch,
+			Description: gui.Tr.SLocalize("nextMatch"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"normal"},
+			Key:         gui.getKey("universal.prevMatch"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handlePrevMainSearchMatch,
+			Description: gui.Tr.SLocalize("prevMatch"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"normal"},
+			Key:         gui.getKey("universal.return"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleEscapeMainSearch,
+			Description: gui.Tr.SLocalize("exitMainSearch"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"normal"},
+			Key:         gui.getKey("universal.goInto"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleEnterMain,
+			Description: gui.Tr.SLocalize("jumpToStatFile"),
+		},
 		{
 			ViewName: "secondary",
 			Contexts: []string{"staging"},
@@ -1039,7 +1715,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"staging"},
 			Key:         gui.getKey("universal.select"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleToggleStagedSelection,
+			Handler:     gui.abortIfChangedExternally(gui.handleToggleStagedSelection),
 			Description: gui.Tr.SLocalize("StageSelection"),
 		},
 		{
@@ -1047,7 +1723,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts:    []string{"staging"},
 			Key:         gui.getKey("universal.remove"),
 			Modifier:    gocui.ModNone,
-			Handler:     gui.handleResetSelection,
+			Handler:     gui.abortIfChangedExternally(gui.handleResetSelection),
 			Description: gui.Tr.SLocalize("ResetSelection"),
 		},
 		{
@@ -1189,6 +1865,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleToggleSelectHunk,
 			Description: gui.Tr.SLocalize("ToggleSelectHunk"),
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"staging"},
+			Key:         gui.getKey("main.editHunk"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleEditHunk,
+			Description: gui.Tr.SLocalize("EditHunk"),
+		},
 		{
 			ViewName: "main",
 			Contexts: []string{"patch-building", "staging"},
@@ -1418,6 +2102,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleSetBranchUpstream,
 			Description: gui.Tr.SLocalize("setUpstream"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"remote-branches"},
+			Key:         gui.getKey("branches.reviewInWorktree"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleReviewRemoteBranch,
+			Description: gui.Tr.SLocalize("reviewInWorktree"),
+		},
 		{
 			ViewName: "stash",
 			Key:      gocui.MouseLeft,
@@ -1469,10 +2161,12 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 		bindings = append(bindings, []*Binding{
 			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gui.getKey("universal.prevItem-alt"), Modifier: gocui.ModNone, Handler: listView.handlePrevLine},
 			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gui.getKey("universal.prevItem"), Modifier: gocui.ModNone, Handler: listView.handlePrevLine},
-			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gocui.MouseWheelUp, Modifier: gocui.ModNone, Handler: listView.handlePrevLine},
+			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gocui.MouseWheelUp, Modifier: gocui.ModNone, Handler: listView.handleScrollUp},
 			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gui.getKey("universal.nextItem-alt"), Modifier: gocui.ModNone, Handler: listView.handleNextLine},
 			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gui.getKey("universal.nextItem"), Modifier: gocui.ModNone, Handler: listView.handleNextLine},
-			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gocui.MouseWheelDown, Modifier: gocui.ModNone, Handler: listView.handleNextLine},
+			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gocui.MouseWheelDown, Modifier: gocui.ModNone, Handler: listView.handleScrollDown},
+			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gui.getKey("universal.prevPage"), Modifier: gocui.ModNone, Handler: listView.handlePrevPage},
+			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gui.getKey("universal.nextPage"), Modifier: gocui.ModNone, Handler: listView.handleNextPage},
 			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gocui.MouseLeft, Modifier: gocui.ModNone, Handler: listView.handleClick},
 		}...)
 
@@ -1489,16 +2183,15 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 		}
 	}
 
+	bindings = append(bindings, gui.getMenuActionBindings()...)
+	bindings = append(bindings, gui.getProjectCommandBindings(bindings)...)
+
 	return bindings
 }
 
 func (gui *Gui) keybindings(g *gocui.Gui) error {
-	bindings := gui.GetInitialKeybindings()
-
-	for _, binding := range bindings {
-		if err := g.SetKeybinding(binding.ViewName, binding.Contexts, binding.Key, binding.Modifier, binding.Handler); err != nil {
-			return err
-		}
+	if err := gui.setKeybindings(g, gui.GetInitialKeybindings()); err != nil {
+		return err
 	}
 
 	tabClickBindings := map[string]func(int) error{
@@ -1514,3 +2207,13 @@ func (gui *Gui) keybindings(g *gocui.Gui) error {
 
 	return nil
 }
+
+func (gui *Gui) setKeybindings(g *gocui.Gui, bindings []*Binding) error {
+	for _, binding := range bindings {
+		if err := g.SetKeybinding(binding.ViewName, binding.Contexts, binding.Key, binding.Modifier, gui.wrappedReadOnlyHandler(binding.Handler)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// confirmLargeFiles asks the user whether they really want to stage or commit
+// any of the files that are large or look like they belong in LFS before
+// going ahead, so that a huge binary doesn't end up in the history by
+// accident. We don't ask about the same file twice.
+func (gui *Gui) confirmLargeFiles(fileNames []string, onConfirm func() error) error {
+	largeFiles, err := gui.GitCommand.GetLargeFiles(fileNames)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	unconfirmed := []*commands.LargeFile{}
+	for _, largeFile := range largeFiles {
+		if !gui.State.ConfirmedLargeFiles[largeFile.Name] {
+			unconfirmed = append(unconfirmed, largeFile)
+		}
+	}
+	if len(unconfirmed) == 0 {
+		return onConfirm()
+	}
+
+	lines := make([]string, len(unconfirmed))
+	for i, largeFile := range unconfirmed {
+		lines[i] = fmt.Sprintf("%s (%s)", largeFile.Name, utils.FormatFileSize(largeFile.Size))
+		if largeFile.Pattern != "" {
+			lines[i] = fmt.Sprintf("%s (%s)", largeFile.Name, gui.Tr.TemplateLocalize("MatchesLargeFilePattern", Teml{"pattern": largeFile.Pattern}))
+		}
+	}
+	prompt := gui.Tr.TemplateLocalize("LargeFilesPrompt", Teml{"files": strings.Join(lines, "\n")})
+
+	return gui.createConfirmationPanel(gui.g, gui.g.CurrentView(), true, gui.Tr.SLocalize("LargeFilesTitle"), prompt, func(*gocui.Gui, *gocui.View) error {
+		if gui.State.ConfirmedLargeFiles == nil {
+			gui.State.ConfirmedLargeFiles = map[string]bool{}
+		}
+		for _, largeFile := range unconfirmed {
+			gui.State.ConfirmedLargeFiles[largeFile.Name] = true
+		}
+		// the confirmation closes once we return, so whatever comes next has
+		// to wait until then
+		gui.g.Update(func(*gocui.Gui) error {
+			return onConfirm()
+		})
+		return nil
+	}, nil)
+}
+
+func fileNames(files []*commands.File) []string {
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Name
+	}
+	return names
+}
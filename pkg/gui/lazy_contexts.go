@@ -0,0 +1,53 @@
+package gui
+
+// Tags, remotes and the reflog can be slow to load on large repos and none of
+// them are visible when lazygit starts, so we hold off on loading them until
+// the user first switches to their tab. After that they're refreshed along with
+// everything else.
+
+// getLazyContextKey returns the key we track loading against for the given
+// context, or an empty string if the context is always loaded up front
+func getLazyContextKey(context string) string {
+	switch context {
+	case "remotes", "remote-branches":
+		return "remotes"
+	case "tags":
+		return "tags"
+	case "reflog-commits":
+		return "reflog-commits"
+	}
+	return ""
+}
+
+func (gui *Gui) contextLoaded(context string) bool {
+	key := getLazyContextKey(context)
+	if key == "" {
+		return true
+	}
+
+	gui.State.LoadedContextsMutex.Lock()
+	defer gui.State.LoadedContextsMutex.Unlock()
+
+	return gui.State.LoadedContexts[key]
+}
+
+func (gui *Gui) markContextLoaded(context string) {
+	gui.State.LoadedContextsMutex.Lock()
+	defer gui.State.LoadedContextsMutex.Unlock()
+
+	gui.State.LoadedContexts[getLazyContextKey(context)] = true
+}
+
+// loadContext loads the model for a lazily loaded context. The refresh
+// functions render the view themselves if the context is the active one.
+func (gui *Gui) loadContext(context string) error {
+	switch getLazyContextKey(context) {
+	case "remotes":
+		return gui.refreshRemotes()
+	case "tags":
+		return gui.refreshTags()
+	case "reflog-commits":
+		return gui.refreshReflogCommits()
+	}
+	return nil
+}
@@ -2,7 +2,7 @@ package gui
 
 import (
 	"github.com/jesseduffield/gocui"
-	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 )
 
 // Currently there are two 'pseudo-panels' that make use of this 'pseudo-panel'.
@@ -24,7 +24,7 @@ const (
 func (gui *Gui) refreshLineByLinePanel(diff string, secondaryDiff string, secondaryFocused bool, selectedLineIdx int) (bool, error) {
 	state := gui.State.Panels.LineByLine
 
-	patchParser, err := commands.NewPatchParser(gui.Log, diff)
+	patchParser, err := patch.NewPatchParser(gui.Log, diff)
 	if err != nil {
 		return false, nil
 	}
@@ -69,6 +69,10 @@ func (gui *Gui) refreshLineByLinePanel(diff string, secondaryDiff string, second
 		Diff:             diff,
 		SecondaryFocused: secondaryFocused,
 	}
+	if state != nil {
+		// keep whatever we'd loaded of a huge diff before staging a line
+		gui.State.Panels.LineByLine.RenderedLineCount = state.RenderedLineCount
+	}
 
 	if err := gui.refreshMainView(); err != nil {
 		return false, err
@@ -82,13 +86,18 @@ func (gui *Gui) refreshLineByLinePanel(diff string, secondaryDiff string, second
 	secondaryView.Highlight = true
 	secondaryView.Wrap = false
 
-	secondaryPatchParser, err := commands.NewPatchParser(gui.Log, secondaryDiff)
+	secondaryPatchParser, err := patch.NewPatchParser(gui.Log, secondaryDiff)
 	if err != nil {
 		return false, nil
 	}
 
+	secondaryLineCount := len(secondaryPatchParser.PatchLines)
+	if limit := gui.hugeDiffLines(); limit > 0 && secondaryLineCount > limit {
+		secondaryLineCount = limit
+	}
+
 	gui.g.Update(func(*gocui.Gui) error {
-		gui.setViewContent(gui.g, gui.getSecondaryView(), secondaryPatchParser.Render(-1, -1, nil))
+		gui.setViewContent(gui.g, gui.getSecondaryView(), gui.renderPartialPatch(secondaryPatchParser, secondaryLineCount, -1, -1, nil))
 		return nil
 	})
 
@@ -117,7 +126,7 @@ func (gui *Gui) handleSelectNextHunk(g *gocui.Gui, v *gocui.View) error {
 	return gui.selectNewHunk(newHunk)
 }
 
-func (gui *Gui) selectNewHunk(newHunk *commands.PatchHunk) error {
+func (gui *Gui) selectNewHunk(newHunk *patch.PatchHunk) error {
 	state := gui.State.Panels.LineByLine
 	state.SelectedLineIdx = state.PatchParser.GetNextStageableLineIndex(newHunk.FirstLineIdx)
 	if state.SelectMode == HUNK {
@@ -235,7 +244,7 @@ func (gui *Gui) refreshMainView() error {
 		filename := gui.getSelectedCommitFileName()
 		includedLineIndices = gui.GitCommand.PatchManager.GetFileIncLineIndices(filename)
 	}
-	colorDiff := state.PatchParser.Render(state.FirstLineIdx, state.LastLineIdx, includedLineIndices)
+	colorDiff := gui.renderPartialPatch(state.PatchParser, gui.linesToRender(state), state.FirstLineIdx, state.LastLineIdx, includedLineIndices)
 
 	mainView := gui.getMainView()
 	mainView.Highlight = true
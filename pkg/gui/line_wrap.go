@@ -0,0 +1,71 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// Long lines can either be soft-wrapped, which is easier to read, or left
+// unwrapped, which keeps columns lined up. Which one the user wants depends on
+// what they're looking at, so we remember it separately for the main view and
+// for the commit message, across runs. The main view's staging, merging and
+// patch building contexts select individual lines, so those are never wrapped.
+
+// defaultLineWrap is what we do until the user says otherwise
+var defaultLineWrap = map[string]bool{
+	"main":          true,
+	"commitMessage": false,
+}
+
+func (gui *Gui) isLineWrapped(viewName string) bool {
+	if wrapped, ok := gui.Config.GetAppState().LineWrap[viewName]; ok {
+		return wrapped
+	}
+	return defaultLineWrap[viewName]
+}
+
+// applyMainViewLineWrap sets the main view back to the user's choice after it
+// has been selecting individual lines
+func (gui *Gui) applyMainViewLineWrap() {
+	gui.getMainView().Wrap = gui.isLineWrapped("main")
+}
+
+func (gui *Gui) handleToggleWrapInMain(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.MainContext != "normal" {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantWrapWhileSelectingLines"))
+	}
+
+	mainView := gui.getMainView()
+	// keep the same line at the top, wherever wrapping moves it to
+	_, oy := mainView.Origin()
+	topLineIdx := bufferLineIdx(mainView, oy)
+
+	if err := gui.toggleLineWrap(mainView); err != nil {
+		return err
+	}
+
+	return mainView.SetOrigin(0, viewLineIdx(mainView, topLineIdx))
+}
+
+func (gui *Gui) handleToggleWrapInCommitMessage(g *gocui.Gui, v *gocui.View) error {
+	return gui.toggleLineWrap(gui.getCommitMessageView())
+}
+
+func (gui *Gui) toggleLineWrap(v *gocui.View) error {
+	v.Wrap = !v.Wrap
+
+	appState := gui.Config.GetAppState()
+	if appState.LineWrap == nil {
+		appState.LineWrap = map[string]bool{}
+	}
+	appState.LineWrap[v.Name()] = v.Wrap
+	if err := gui.Config.SaveAppState(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	if v.Wrap {
+		gui.raiseToast(gui.Tr.SLocalize("LineWrapOn"))
+	} else {
+		gui.raiseToast(gui.Tr.SLocalize("LineWrapOff"))
+	}
+	return nil
+}
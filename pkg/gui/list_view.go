@@ -15,13 +15,64 @@ type listView struct {
 }
 
 func (lv *listView) handlePrevLine(g *gocui.Gui, v *gocui.View) error {
+	if lv.shouldWrap(-1) {
+		return lv.handleLineChange(lv.getItemsLength() - 1)
+	}
 	return lv.handleLineChange(-1)
 }
 
 func (lv *listView) handleNextLine(g *gocui.Gui, v *gocui.View) error {
+	if lv.shouldWrap(1) {
+		return lv.handleLineChange(-lv.getItemsLength())
+	}
+	return lv.handleLineChange(1)
+}
+
+// handleScrollUp and handleScrollDown are for the mouse wheel, which we never
+// want to wrap around the ends of the list
+func (lv *listView) handleScrollUp(g *gocui.Gui, v *gocui.View) error {
+	return lv.handleLineChange(-1)
+}
+
+func (lv *listView) handleScrollDown(g *gocui.Gui, v *gocui.View) error {
 	return lv.handleLineChange(1)
 }
 
+func (lv *listView) handlePrevPage(g *gocui.Gui, v *gocui.View) error {
+	return lv.handleLineChange(-lv.pageSize(v))
+}
+
+func (lv *listView) handleNextPage(g *gocui.Gui, v *gocui.View) error {
+	return lv.handleLineChange(lv.pageSize(v))
+}
+
+// shouldWrap tells us whether moving the selection by one in the given
+// direction should take us to the other end of the list, as it does with
+// gui.wrapListSelection when we're at the end we're moving towards
+func (lv *listView) shouldWrap(change int) bool {
+	if !lv.gui.Config.GetUserConfig().GetBool("gui.wrapListSelection") {
+		return false
+	}
+	selectedLine := *lv.getSelectedLineIdxPtr()
+	if change < 0 {
+		return selectedLine == 0
+	}
+	return selectedLine == lv.getItemsLength()-1
+}
+
+// pageSize is how many lines the page keys move the selection by: a whole
+// view's worth, or half of one if gui.pageScroll is 'half'
+func (lv *listView) pageSize(v *gocui.View) int {
+	_, height := v.Size()
+	if lv.gui.Config.GetUserConfig().GetString("gui.pageScroll") == "half" {
+		height /= 2
+	}
+	if height < 1 {
+		return 1
+	}
+	return height
+}
+
 func (lv *listView) handleLineChange(change int) error {
 	if !lv.gui.isPopupPanel(lv.viewName) && lv.gui.popupPanelFocused() {
 		return nil
@@ -85,7 +136,7 @@ func (gui *Gui) getListViews() []*listView {
 		},
 		{
 			viewName:                "files",
-			getItemsLength:          func() int { return len(gui.State.Files) },
+			getItemsLength:          func() int { return len(gui.visibleFiles()) },
 			getSelectedLineIdxPtr:   func() *int { return &gui.State.Panels.Files.SelectedLine },
 			handleFocus:             gui.focusAndSelectFile,
 			handleItemSelect:        gui.focusAndSelectFile,
@@ -138,7 +189,7 @@ func (gui *Gui) getListViews() []*listView {
 		{
 			viewName:                "commits",
 			context:                 "branch-commits",
-			getItemsLength:          func() int { return len(gui.State.Commits) },
+			getItemsLength:          func() int { return len(gui.visibleCommits()) },
 			getSelectedLineIdxPtr:   func() *int { return &gui.State.Panels.Commits.SelectedLine },
 			handleFocus:             gui.handleCommitSelect,
 			handleItemSelect:        gui.handleCommitSelect,
@@ -149,7 +200,7 @@ func (gui *Gui) getListViews() []*listView {
 		{
 			viewName:              "commits",
 			context:               "reflog-commits",
-			getItemsLength:        func() int { return len(gui.State.ReflogCommits) },
+			getItemsLength:        func() int { return len(gui.visibleReflogCommits()) },
 			getSelectedLineIdxPtr: func() *int { return &gui.State.Panels.ReflogCommits.SelectedLine },
 			handleFocus:           gui.handleReflogCommitSelect,
 			handleItemSelect:      gui.handleReflogCommitSelect,
@@ -174,5 +225,19 @@ func (gui *Gui) getListViews() []*listView {
 			gui:                   gui,
 			rendersToMainView:     true,
 		},
+		{
+			viewName: "rebasePlan",
+			getItemsLength: func() int {
+				if gui.State.RebasePlan == nil {
+					return 0
+				}
+				return len(gui.State.RebasePlan.Steps)
+			},
+			getSelectedLineIdxPtr: func() *int { return &gui.State.Panels.RebasePlan.SelectedLine },
+			handleFocus:           gui.handleRebasePlanStepSelect,
+			handleItemSelect:      gui.handleRebasePlanStepSelect,
+			gui:                   gui,
+			rendersToMainView:     false,
+		},
 	}
 }
@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// The log settings menu decides how much of the history the commits panel
+// shows, and in what order. Like the status settings, they're saved to the repo's git config so
+// that they stick for that repo.
+
+func (gui *Gui) handleCreateLogSettingsMenu(g *gocui.Gui, v *gocui.View) error {
+	settings := gui.GitCommand.GetLogSettings()
+
+	onOff := func(value bool) string {
+		if value {
+			return gui.Tr.SLocalize("on")
+		}
+		return gui.Tr.SLocalize("off")
+	}
+
+	save := func(set func(bool) error, value bool) func() error {
+		return func() error {
+			if err := set(value); err != nil {
+				return gui.createErrorPanel(gui.g, err.Error())
+			}
+			gui.State.Panels.Commits.SelectedLine = 0
+			return gui.refreshCommits(gui.g)
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("ShowFirstParentOnly"), onOff(settings.FirstParent)},
+			onPress:        save(gui.GitCommand.SetFirstParent, !settings.FirstParent),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("HideMergeCommits"), onOff(settings.NoMerges)},
+			onPress:        save(gui.GitCommand.SetNoMerges, !settings.NoMerges),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("CommitOrder"), gui.logOrderName(settings.Order)},
+			onPress: func() error {
+				// the menu closes once we return, so the next one has to wait until then
+				gui.g.Update(func(*gocui.Gui) error {
+					return gui.createLogOrderMenu(settings.Order)
+				})
+				return nil
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("LogSettingsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// createLogOrderMenu lets the user sort commits by topology rather than by
+// date, so that the commits of parallel branches don't end up interleaved
+func (gui *Gui) createLogOrderMenu(current string) error {
+	menuItems := make([]*menuItem, len(commands.LogOrders))
+	for i, order := range commands.LogOrders {
+		order := order
+		selected := ""
+		if order == current {
+			selected = "*"
+		}
+		menuItems[i] = &menuItem{
+			displayStrings: []string{selected, gui.logOrderName(order)},
+			onPress: func() error {
+				if err := gui.GitCommand.SetLogOrder(order); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				gui.State.Panels.Commits.SelectedLine = 0
+				return gui.refreshCommits(gui.g)
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("CommitOrderTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) logOrderName(order string) string {
+	switch order {
+	case "date":
+		return gui.Tr.SLocalize("DateOrder")
+	case "author-date":
+		return gui.Tr.SLocalize("AuthorDateOrder")
+	case "topo":
+		return gui.Tr.SLocalize("TopoOrder")
+	default:
+		return gui.Tr.SLocalize("DefaultOrder")
+	}
+}
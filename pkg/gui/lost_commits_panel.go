@@ -0,0 +1,114 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// lostCommitsLimit is how many of the newest lost commits we list, given that
+// every rebase leaves a few behind
+const lostCommitsLimit = 50
+
+// handleViewLostCommits lists the dropped stashes and the commits that have
+// fallen off every branch, so that whatever the user thinks they've lost can
+// be brought back
+func (gui *Gui) handleViewLostCommits(g *gocui.Gui, v *gocui.View) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("LookingForLostCommitsStatus"), func() error {
+		lostCommits, err := gui.GitCommand.GetLostCommits(lostCommitsLimit)
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		if len(lostCommits) == 0 {
+			return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoLostCommits"))
+		}
+
+		menuItems := make([]*menuItem, len(lostCommits))
+		for i, lostCommit := range lostCommits {
+			lostCommit := lostCommit
+			kind := gui.Tr.SLocalize("LostCommit")
+			if lostCommit.IsStash {
+				kind = utils.ColoredString(gui.Tr.SLocalize("LostStash"), color.FgMagenta)
+			}
+			menuItems[i] = &menuItem{
+				displayStrings: []string{
+					utils.ColoredString(lostCommit.ShortSha(), color.FgYellow),
+					lostCommit.Time.Format("2006-01-02 15:04"),
+					kind,
+					lostCommit.Name,
+				},
+				onPress: func() error {
+					gui.g.Update(func(*gocui.Gui) error {
+						return gui.createLostCommitMenu(lostCommit)
+					})
+					return nil
+				},
+			}
+		}
+
+		return gui.createMenu(gui.Tr.SLocalize("LostCommitsTitle"), menuItems, createMenuOptions{showCancel: true})
+	})
+}
+
+func (gui *Gui) createLostCommitMenu(lostCommit *commands.LostCommit) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("PreviewLostCommit"),
+			onPress: func() error {
+				return gui.startComparison(lostCommit.Sha+"^", lostCommit.Sha)
+			},
+		},
+	}
+
+	if lostCommit.IsStash {
+		menuItems = append(menuItems,
+			&menuItem{
+				displayString: gui.Tr.SLocalize("ApplyLostStash"),
+				onPress: func() error {
+					if err := gui.GitCommand.ApplyLostStash(lostCommit.Sha); err != nil {
+						return gui.createErrorPanel(gui.g, err.Error())
+					}
+					return gui.refreshFiles()
+				},
+			},
+			&menuItem{
+				displayString: gui.Tr.SLocalize("StoreLostStash"),
+				onPress: func() error {
+					if err := gui.GitCommand.StoreLostStash(lostCommit); err != nil {
+						return gui.createErrorPanel(gui.g, err.Error())
+					}
+					return gui.refreshStashEntries(gui.g)
+				},
+			},
+		)
+	} else {
+		menuItems = append(menuItems,
+			&menuItem{
+				displayString: gui.Tr.SLocalize("CherryPickLostCommit"),
+				onPress: func() error {
+					return gui.WithWaitingStatus(gui.Tr.SLocalize("CherryPickingStatus"), func() error {
+						err := gui.GitCommand.CherryPickCommits([]*commands.Commit{{Sha: lostCommit.Sha, Name: lostCommit.Name}})
+						return gui.handleGenericMergeCommandResult(err)
+					})
+				},
+			},
+			&menuItem{
+				displayString: gui.Tr.SLocalize("CreateBranchAtLostCommit"),
+				onPress: func() error {
+					gui.g.Update(func(*gocui.Gui) error {
+						return gui.createPromptPanel(gui.g, gui.getBranchesView(), gui.Tr.TemplateLocalize("NewBranchNameBranchOff", Teml{"branchName": lostCommit.ShortSha()}), "", func(g *gocui.Gui, v *gocui.View) error {
+							if err := gui.GitCommand.CreateBranchAt(gui.trimmedContent(v), lostCommit.Sha); err != nil {
+								return gui.createErrorPanel(gui.g, err.Error())
+							}
+							return gui.refreshSidePanels(gui.g)
+						})
+					})
+					return nil
+				},
+			},
+		)
+	}
+
+	return gui.createMenu(lostCommit.ShortSha()+" "+lostCommit.Name, menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,33 @@
+package gui
+
+import (
+	"os"
+	"time"
+)
+
+// Low bandwidth mode is for users on slow SSH or mosh connections. termbox
+// already only sends the cells that changed between frames, so what we cut
+// down on is the number of frames: spinners are drawn statically rather than
+// animated, and we poll for changes less often so that refreshes get batched
+// together.
+
+const lowBandwidthPollMultiplier = 3
+
+func (gui *Gui) lowBandwidthMode() bool {
+	switch gui.Config.GetUserConfig().GetString("gui.lowBandwidthMode") {
+	case "true":
+		return true
+	case "ssh":
+		return os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != ""
+	}
+	return false
+}
+
+// getPollInterval returns how often we should poll for something that we'd
+// normally poll for at the given interval
+func (gui *Gui) getPollInterval(interval time.Duration) time.Duration {
+	if gui.lowBandwidthMode() {
+		return interval * lowBandwidthPollMultiplier
+	}
+	return interval
+}
@@ -0,0 +1,70 @@
+package gui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// handleApplyMailbox asks for a mailbox, Maildir or directory of patches and
+// applies its patches as commits with `git am`
+func (gui *Gui) handleApplyMailbox(g *gocui.Gui, v *gocui.View) error {
+	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
+		return err
+	}
+
+	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("ApplyMailboxPrompt"), "patches", func(g *gocui.Gui, promptView *gocui.View) error {
+		path := gui.trimmedContent(promptView)
+		return gui.WithWaitingStatus(gui.Tr.SLocalize("ApplyingPatchesStatus"), func() error {
+			err := gui.GitCommand.ApplyMailbox(path)
+			return gui.handleGenericMergeCommandResult(err)
+		})
+	})
+}
+
+// getMailboxPatchesDisplay lists the patches of the `git am` in progress,
+// marking which have been applied and which one we're stopped at
+func (gui *Gui) getMailboxPatchesDisplay() string {
+	patches := gui.GitCommand.GetMailboxPatches()
+	applied := 0
+	lines := make([]string, len(patches))
+	for i, patch := range patches {
+		lines[i] = gui.getMailboxPatchDisplay(patch)
+		if patch.Status == "applied" {
+			applied++
+		}
+	}
+
+	summary := gui.Tr.TemplateLocalize("MailboxPatchesSummary", Teml{
+		"applied": strconv.Itoa(applied),
+		"total":   strconv.Itoa(len(patches)),
+	})
+	return summary + "\n\n" + strings.Join(lines, "\n")
+}
+
+func (gui *Gui) getMailboxPatchDisplay(patch *commands.MailboxPatch) string {
+	var marker string
+	var markerColor color.Attribute
+	switch patch.Status {
+	case "applied":
+		marker, markerColor = "✓", theme.CurrentPalette.Added
+	case "current":
+		marker, markerColor = ">", theme.CurrentPalette.Warning
+	default:
+		marker, markerColor = " ", theme.DefaultTextColor
+	}
+	status := utils.WithPadding(gui.Tr.SLocalize("MailboxPatch_"+patch.Status), 8)
+	return fmt.Sprintf(
+		"%s %04d %s %s",
+		utils.ColoredString(marker, markerColor),
+		patch.Number,
+		utils.ColoredString(status, markerColor),
+		patch.Subject,
+	)
+}
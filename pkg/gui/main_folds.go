@@ -0,0 +1,168 @@
+package gui
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// Files and hunks in the main panel's diff can be folded down to their
+// header. Folded lines are left out as the output is read, so to fold or
+// unfold something we run the command again, which keeps the lines of the
+// view lined up with what we know about the output.
+
+// mainTask is enough of the command shown in the main view to run it again
+type mainTask struct {
+	path          string
+	args          []string
+	env           []string
+	dir           string
+	pagingContext string
+}
+
+// mainFolds holds what's folded in the output of a command in the main view
+type mainFolds struct {
+	cmdStr string
+	// all is whether files are folded unless they've been unfolded
+	all   bool
+	files map[string]bool
+	hunks map[string]bool
+}
+
+func newMainFolds(cmdStr string, all bool) *mainFolds {
+	return &mainFolds{cmdStr: cmdStr, all: all, files: map[string]bool{}, hunks: map[string]bool{}}
+}
+
+func (f *mainFolds) isFileFolded(name string) bool {
+	if f == nil {
+		return false
+	}
+	if folded, ok := f.files[name]; ok {
+		return folded
+	}
+	return f.all
+}
+
+func (f *mainFolds) isHunkFolded(key string) bool {
+	return f != nil && f.hunks[key]
+}
+
+// copy returns a copy for a task to read from while the original changes
+func (f *mainFolds) copy() *mainFolds {
+	result := newMainFolds(f.cmdStr, f.all)
+	for name, folded := range f.files {
+		result.files[name] = folded
+	}
+	for key, folded := range f.hunks {
+		result.hunks[key] = folded
+	}
+	return result
+}
+
+// rememberMainTask remembers the command now being run in the main view and
+// returns what's folded in its output. Folds are forgotten once the main view
+// moves on to a different command.
+func (gui *Gui) rememberMainTask(cmd *exec.Cmd, pagingContext string) *mainFolds {
+	gui.State.MainTask = &mainTask{
+		path:          cmd.Path,
+		args:          append([]string{}, cmd.Args...),
+		env:           append([]string{}, cmd.Env...),
+		dir:           cmd.Dir,
+		pagingContext: pagingContext,
+	}
+
+	cmdStr := strings.Join(cmd.Args, " ")
+	if gui.State.MainFolds == nil || gui.State.MainFolds.cmdStr != cmdStr {
+		gui.State.MainFolds = newMainFolds(cmdStr, false)
+	}
+	return gui.State.MainFolds.copy()
+}
+
+func (gui *Gui) rerunMainTask() error {
+	task := gui.State.MainTask
+	if task == nil {
+		return nil
+	}
+
+	cmd := &exec.Cmd{Path: task.path, Args: task.args, Env: append([]string{}, task.env...), Dir: task.dir}
+	if task.pagingContext == "" {
+		return gui.newCmdTask("main", cmd)
+	}
+	return gui.newPtyTask("main", cmd, task.pagingContext)
+}
+
+// getFoldTarget returns the hunk or, failing that, the file that the given
+// line of output is in
+func (o *pagerOutput) getFoldTarget(lineIdx int) (boundaryKind, string, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	lastBefore := func(boundaries []int) int {
+		for i := len(boundaries) - 1; i >= 0; i-- {
+			if boundaries[i] <= lineIdx {
+				return i
+			}
+		}
+		return -1
+	}
+
+	fileIdx := lastBefore(o.boundaries[fileBoundary])
+	hunkIdx := lastBefore(o.boundaries[hunkBoundary])
+	if hunkIdx != -1 && (fileIdx == -1 || o.boundaries[hunkBoundary][hunkIdx] > o.boundaries[fileBoundary][fileIdx]) {
+		return hunkBoundary, o.hunkKeys[hunkIdx], true
+	}
+	if fileIdx != -1 {
+		return fileBoundary, o.fileNames[fileIdx], true
+	}
+	return 0, "", false
+}
+
+// handleToggleFoldInMain folds or unfolds the hunk, or file, at the top of the
+// main view
+func (gui *Gui) handleToggleFoldInMain(g *gocui.Gui, v *gocui.View) error {
+	mainView := gui.getMainView()
+	output, ok := gui.pagerOutputs[mainView.Name()]
+	if !ok || gui.State.MainTask == nil {
+		return nil
+	}
+
+	_, oy := mainView.Origin()
+	kind, key, ok := output.getFoldTarget(bufferLineIdx(mainView, oy))
+	if !ok {
+		return nil
+	}
+
+	folds := gui.State.MainFolds
+	gui.State.MainMarks = nil
+	if kind == hunkBoundary {
+		folds.hunks[key] = !folds.isHunkFolded(key)
+	} else {
+		folds.files[key] = !folds.isFileFolded(key)
+	}
+	return gui.rerunMainTask()
+}
+
+func (gui *Gui) handleFoldAllInMain(g *gocui.Gui, v *gocui.View) error {
+	return gui.setAllFoldedInMain(true)
+}
+
+func (gui *Gui) handleUnfoldAllInMain(g *gocui.Gui, v *gocui.View) error {
+	return gui.setAllFoldedInMain(false)
+}
+
+func (gui *Gui) setAllFoldedInMain(folded bool) error {
+	if gui.State.MainTask == nil {
+		return nil
+	}
+
+	gui.State.MainFolds = newMainFolds(gui.State.MainFolds.cmdStr, folded)
+	gui.State.MainMarks = nil
+	if folded {
+		// otherwise we could be left looking at a blank part of the view
+		if err := gui.getMainView().SetOrigin(0, 0); err != nil {
+			return err
+		}
+	}
+	return gui.rerunMainTask()
+}
@@ -0,0 +1,91 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Lines of a long diff or log in the main view can be marked while scrolling
+// through it, to jump back to later. Marks are by line of output, so like
+// folds they're forgotten once the main view moves on to a different command,
+// and because folding moves lines around, whenever something is folded.
+
+// mainMarks holds the marked lines in the output of a command in the main view
+type mainMarks struct {
+	cmdStr string
+	lines  []int
+}
+
+// getMainMarks returns the marks for the command being shown in the main view
+func (gui *Gui) getMainMarks() *mainMarks {
+	cmdStr := gui.State.MainFolds.cmdStr
+	if gui.State.MainMarks == nil || gui.State.MainMarks.cmdStr != cmdStr {
+		gui.State.MainMarks = &mainMarks{cmdStr: cmdStr}
+	}
+	return gui.State.MainMarks
+}
+
+// handleToggleMarkInMain marks the line at the top of the main view, or
+// unmarks it if it's already marked
+func (gui *Gui) handleToggleMarkInMain(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.MainTask == nil {
+		return nil
+	}
+
+	mainView := gui.getMainView()
+	_, oy := mainView.Origin()
+	lineIdx := bufferLineIdx(mainView, oy)
+	marks := gui.getMainMarks()
+
+	for i, markedLineIdx := range marks.lines {
+		if markedLineIdx == lineIdx {
+			marks.lines = append(marks.lines[:i], marks.lines[i+1:]...)
+			gui.raiseToast(gui.Tr.TemplateLocalize("UnmarkedLineInMain", Teml{"line": fmt.Sprintf("%d", lineIdx+1)}))
+			return nil
+		}
+	}
+
+	marks.lines = append(marks.lines, lineIdx)
+	sort.Ints(marks.lines)
+	gui.raiseToast(gui.Tr.TemplateLocalize("MarkedLineInMain", Teml{"line": fmt.Sprintf("%d", lineIdx+1)}))
+	return nil
+}
+
+// handleJumpToMarkInMain lists the marked lines of the main view to jump to
+func (gui *Gui) handleJumpToMarkInMain(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.MainTask == nil {
+		return nil
+	}
+
+	marks := gui.getMainMarks()
+	if len(marks.lines) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoMarksInMain"))
+	}
+
+	bufferLines := gui.getMainView().BufferLines()
+	menuItems := make([]*menuItem, len(marks.lines))
+	for i, lineIdx := range marks.lines {
+		lineIdx := lineIdx
+		text := ""
+		if lineIdx < len(bufferLines) {
+			text = strings.TrimSpace(bufferLines[lineIdx])
+		}
+		menuItems[i] = &menuItem{
+			displayStrings: []string{
+				utils.ColoredString(gui.Tr.TemplateLocalize("LineNumber", Teml{"line": fmt.Sprintf("%d", lineIdx+1)}), color.FgBlue),
+				text,
+			},
+			onPress: func() error {
+				gui.scrollMainToLine(lineIdx)
+				return nil
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("JumpToMarkInMain"), menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,171 @@
+package gui
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// gocui's own search only does plain text, so the main view gets a search of
+// its own that takes a regex. Like gocui's, it's case-insensitive unless the
+// search has an uppercase letter in it. The main view takes focus while
+// searching so that n/N and esc go to it; the line of the current match is
+// highlighted.
+
+// mainSearchMatch is where a match starts, by line of output and character
+type mainSearchMatch struct {
+	x int
+	y int
+}
+
+type mainSearchState struct {
+	regexp  *regexp.Regexp
+	matches []mainSearchMatch
+	index   int
+}
+
+func compileMainSearch(searchString string) (*regexp.Regexp, error) {
+	for _, r := range searchString {
+		if unicode.IsUpper(r) {
+			return regexp.Compile(searchString)
+		}
+	}
+	return regexp.Compile("(?i)" + searchString)
+}
+
+// findMainSearchMatches returns where the regex matches in the given lines,
+// with x counted in characters rather than bytes
+func findMainSearchMatches(re *regexp.Regexp, lines []string) []mainSearchMatch {
+	matches := []mainSearchMatch{}
+	for y, line := range lines {
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			matches = append(matches, mainSearchMatch{x: utf8.RuneCountInString(line[:loc[0]]), y: y})
+		}
+	}
+	return matches
+}
+
+func (gui *Gui) handleOpenMainSearch(g *gocui.Gui, v *gocui.View) error {
+	gui.State.Searching.isSearching = true
+	gui.State.Searching.view = gui.getMainView()
+	gui.renderString(gui.g, "search", "")
+	return gui.switchFocus(gui.g, v, gui.getSearchView())
+}
+
+// searchMain runs a search typed into the search prompt against the main
+// view, moving to the first match at or below the top of the view
+func (gui *Gui) searchMain(searchString string) error {
+	mainView := gui.getMainView()
+	if err := gui.switchFocus(gui.g, nil, mainView); err != nil {
+		return err
+	}
+
+	re, err := compileMainSearch(searchString)
+	if err != nil {
+		gui.State.Searching.isSearching = false
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	gui.State.MainSearch = &mainSearchState{regexp: re}
+	gui.updateMainSearchMatches()
+
+	_, oy := mainView.Origin()
+	top := bufferLineIdx(mainView, oy)
+	for i, match := range gui.State.MainSearch.matches {
+		if match.y >= top {
+			gui.State.MainSearch.index = i
+			break
+		}
+	}
+	return gui.selectMainSearchMatch()
+}
+
+// updateMainSearchMatches searches whatever has been read into the main view
+// so far, which will have grown if the user has scrolled since the last time
+func (gui *Gui) updateMainSearchMatches() {
+	mainSearch := gui.State.MainSearch
+	mainSearch.matches = findMainSearchMatches(mainSearch.regexp, gui.getMainView().BufferLines())
+	if mainSearch.index >= len(mainSearch.matches) {
+		mainSearch.index = 0
+	}
+}
+
+func (gui *Gui) selectMainSearchMatch() error {
+	mainSearch := gui.State.MainSearch
+	mainView := gui.getMainView()
+	onSelect := gui.onSelectItemWrapper(func(y int) error {
+		mainView.Highlight = true
+		return gui.focusMainViewLine(y)
+	})
+
+	if len(mainSearch.matches) == 0 {
+		mainView.Highlight = false
+		return onSelect(-1, -1, 0)
+	}
+	match := mainSearch.matches[mainSearch.index]
+	return onSelect(match.y, mainSearch.index, len(mainSearch.matches))
+}
+
+func (gui *Gui) handleNextMainSearchMatch(g *gocui.Gui, v *gocui.View) error {
+	return gui.changeMainSearchMatch(1)
+}
+
+func (gui *Gui) handlePrevMainSearchMatch(g *gocui.Gui, v *gocui.View) error {
+	return gui.changeMainSearchMatch(-1)
+}
+
+func (gui *Gui) changeMainSearchMatch(change int) error {
+	mainSearch := gui.State.MainSearch
+	if mainSearch == nil {
+		return nil
+	}
+
+	// the pager may have given us more lines since we last looked
+	gui.updateMainSearchMatches()
+	if len(mainSearch.matches) == 0 {
+		return gui.selectMainSearchMatch()
+	}
+
+	mainSearch.index = (mainSearch.index + change + len(mainSearch.matches)) % len(mainSearch.matches)
+	if change > 0 && mainSearch.index == len(mainSearch.matches)-1 {
+		// read ahead so that there's more to find by the time we get to the
+		// end of what we've got
+		if manager, ok := gui.viewBufferManagerMap["main"]; ok {
+			manager.RequestMoreLines(maxLinesReadForJump)
+		}
+	}
+	return gui.selectMainSearchMatch()
+}
+
+func (gui *Gui) handleEscapeMainSearch(g *gocui.Gui, v *gocui.View) error {
+	gui.endMainSearch()
+	return gui.returnFocus(g, v)
+}
+
+func (gui *Gui) endMainSearch() {
+	gui.State.MainSearch = nil
+	gui.State.Searching.isSearching = false
+	gui.State.Searching.view = nil
+}
+
+// focusMainViewLine puts the cursor on a line of output in the main view,
+// scrolling it to the middle of the view if it's off screen
+func (gui *Gui) focusMainViewLine(lineIdx int) error {
+	mainView := gui.getMainView()
+	_, height := mainView.Size()
+	ox, oy := mainView.Origin()
+
+	y := viewLineIdx(mainView, lineIdx)
+	if y < oy || y >= oy+height {
+		oy = y - height/2
+		if oy < 0 {
+			oy = 0
+		}
+		if err := mainView.SetOrigin(ox, oy); err != nil {
+			return err
+		}
+	}
+	return mainView.SetCursor(0, y-oy)
+}
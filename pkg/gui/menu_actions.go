@@ -0,0 +1,144 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// menuAction is an action that normally lives in a menu but which the user can
+// also bind directly to a key, via keybinding.menuActions.<id> in their config.
+// The ids are part of the config so they mustn't change once added.
+type menuAction struct {
+	id          string
+	viewName    string
+	contexts    []string
+	description string
+	handler     func() error
+}
+
+func (gui *Gui) getMenuActions() []*menuAction {
+	resetToSelectedCommit := func(strength string) func() error {
+		return func() error {
+			commit := gui.getSelectedCommit(gui.g)
+			if commit == nil {
+				return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoCommitsThisBranch"))
+			}
+			return gui.resetToRef(commit.Sha, strength)
+		}
+	}
+
+	mergeCommand := func(command string) func() error {
+		return func() error {
+			return gui.genericMergeCommand(command)
+		}
+	}
+
+	return []*menuAction{
+		{
+			id:          "stashStagedChanges",
+			viewName:    "files",
+			description: gui.Tr.SLocalize("stashStagedChanges"),
+			handler:     gui.handleStashStagedChanges,
+		},
+		{
+			id:          "discardAllChangesToAllFiles",
+			viewName:    "files",
+			description: gui.Tr.SLocalize("discardAllChangesToAllFiles"),
+			handler:     gui.handleResetAndClean,
+		},
+		{
+			id:          "discardAnyUnstagedChanges",
+			viewName:    "files",
+			description: gui.Tr.SLocalize("discardAnyUnstagedChanges"),
+			handler:     gui.handleDiscardAnyUnstagedFileChanges,
+		},
+		{
+			id:          "discardUntrackedFiles",
+			viewName:    "files",
+			description: gui.Tr.SLocalize("discardUntrackedFiles"),
+			handler:     gui.handleRemoveUntrackedFiles,
+		},
+		{
+			id:          "softResetToCommit",
+			viewName:    "commits",
+			contexts:    []string{"branch-commits"},
+			description: gui.Tr.SLocalize("softResetToCommit"),
+			handler:     resetToSelectedCommit("soft"),
+		},
+		{
+			id:          "mixedResetToCommit",
+			viewName:    "commits",
+			contexts:    []string{"branch-commits"},
+			description: gui.Tr.SLocalize("mixedResetToCommit"),
+			handler:     resetToSelectedCommit("mixed"),
+		},
+		{
+			id:          "hardResetToCommit",
+			viewName:    "commits",
+			contexts:    []string{"branch-commits"},
+			description: gui.Tr.SLocalize("hardResetToCommit"),
+			handler:     resetToSelectedCommit("hard"),
+		},
+		{
+			id:          "continueRebaseOrMerge",
+			description: gui.Tr.SLocalize("continueRebaseOrMerge"),
+			handler:     mergeCommand("continue"),
+		},
+		{
+			id:          "abortRebaseOrMerge",
+			description: gui.Tr.SLocalize("abortRebaseOrMerge"),
+			handler:     mergeCommand("abort"),
+		},
+		{
+			id:          "skipRebaseCommit",
+			description: gui.Tr.SLocalize("skipRebaseCommit"),
+			handler:     mergeCommand("skip"),
+		},
+		{
+			id:          "resetPatch",
+			description: gui.Tr.SLocalize("resetPatch"),
+			handler:     gui.handleResetPatch,
+		},
+	}
+}
+
+// getMenuActionBindings returns keybindings for whichever menu actions the user
+// has bound to a key. Menu actions are unbound by default.
+func (gui *Gui) getMenuActionBindings() []*Binding {
+	bindings := []*Binding{}
+	userConfig := gui.Config.GetUserConfig()
+
+	for _, action := range gui.getMenuActions() {
+		if userConfig.GetString("keybinding.menuActions."+action.id) == "" {
+			continue
+		}
+
+		key, err := getKeyFromConfig(userConfig, "menuActions."+action.id)
+		if err != nil {
+			// this will show up in the keybinding report
+			continue
+		}
+
+		handler := action.handler
+		bindings = append(bindings, &Binding{
+			ViewName:    action.viewName,
+			Contexts:    action.contexts,
+			Key:         key,
+			Modifier:    gocui.ModNone,
+			Handler:     func(*gocui.Gui, *gocui.View) error { return handler() },
+			Description: action.description,
+		})
+	}
+
+	return bindings
+}
+
+func (gui *Gui) isMenuActionKey(key string) bool {
+	for _, action := range gui.getMenuActions() {
+		if key == strings.ToLower("keybinding.menuActions."+action.id) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,7 +17,7 @@ type menuItem struct {
 // list panel functions
 
 func (gui *Gui) handleMenuSelect(g *gocui.Gui, v *gocui.View) error {
-	v.FocusPoint(0, gui.State.Panels.Menu.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.Menu.SelectedLine)
 	return nil
 }
 
@@ -85,7 +85,7 @@ func (gui *Gui) createMenu(title string, items []*menuItem, createMenuOptions cr
 	wrappedHandlePress := func(g *gocui.Gui, v *gocui.View) error {
 		selectedLine := gui.State.Panels.Menu.SelectedLine
 		if err := items[selectedLine].onPress(); err != nil {
-			return err
+			return gui.refuseSubProcessIfReadOnly(err)
 		}
 
 		if _, err := gui.g.View("menu"); err == nil {
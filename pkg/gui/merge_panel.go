@@ -54,7 +54,7 @@ func (gui *Gui) coloredConflictFile(content string, conflicts []commands.Conflic
 	for i, line := range utils.SplitLines(content) {
 		colourAttr := theme.DefaultTextColor
 		if i == conflict.Start || i == conflict.Middle || i == conflict.End {
-			colourAttr = color.FgRed
+			colourAttr = theme.CurrentPalette.Conflict
 		}
 		colour := color.New(colourAttr)
 		if hasFocus && conflictIndex < len(conflicts) && conflicts[conflictIndex] == conflict && gui.shouldHighlightLine(i, conflict, conflictTop) {
@@ -129,6 +129,16 @@ func (gui *Gui) resolveConflict(g *gocui.Gui, conflict commands.Conflict, pick s
 	return ioutil.WriteFile(gitFile.Name, []byte(output), 0644)
 }
 
+// checkMergeFileWritable stops us resolving conflicts in read-only mode,
+// given we write the resolution straight to the file
+func (gui *Gui) checkMergeFileWritable(g *gocui.Gui) error {
+	gitFile, err := gui.getSelectedFile(g)
+	if err != nil {
+		return err
+	}
+	return gui.OSCommand.CheckWritable(gitFile.Name)
+}
+
 func (gui *Gui) pushFileSnapshot(g *gocui.Gui) error {
 	gitFile, err := gui.getSelectedFile(g)
 	if err != nil {
@@ -160,6 +170,9 @@ func (gui *Gui) handlePopFileSnapshot(g *gocui.Gui, v *gocui.View) error {
 
 func (gui *Gui) handlePickHunk(g *gocui.Gui, v *gocui.View) error {
 	conflict := gui.State.Panels.Merging.Conflicts[gui.State.Panels.Merging.ConflictIndex]
+	if err := gui.checkMergeFileWritable(g); err != nil {
+		return gui.createErrorPanel(g, err.Error())
+	}
 	if err := gui.pushFileSnapshot(g); err != nil {
 		return err
 	}
@@ -184,6 +197,9 @@ func (gui *Gui) handlePickHunk(g *gocui.Gui, v *gocui.View) error {
 
 func (gui *Gui) handlePickBothHunks(g *gocui.Gui, v *gocui.View) error {
 	conflict := gui.State.Panels.Merging.Conflicts[gui.State.Panels.Merging.ConflictIndex]
+	if err := gui.checkMergeFileWritable(g); err != nil {
+		return gui.createErrorPanel(g, err.Error())
+	}
 	if err := gui.pushFileSnapshot(g); err != nil {
 		return err
 	}
@@ -281,6 +297,12 @@ func (gui *Gui) handleCompleteMerge() error {
 	}
 	// if we got conflicts after unstashing, we don't want to call any git
 	// commands to continue rebasing/merging here
+	if gui.State.WorkingTreeState == "unstashing" {
+		if !gui.anyFilesWithMergeConflicts() {
+			return gui.promptToFinishStashConflict()
+		}
+		return gui.handleEscapeMerge(gui.g, gui.getMainView())
+	}
 	if gui.State.WorkingTreeState == "normal" {
 		return gui.handleEscapeMerge(gui.g, gui.getMainView())
 	}
@@ -293,7 +315,5 @@ func (gui *Gui) handleCompleteMerge() error {
 
 // promptToContinue asks the user if they want to continue the rebase/merge that's in progress
 func (gui *Gui) promptToContinue() error {
-	return gui.createConfirmationPanel(gui.g, gui.getFilesView(), true, "continue", gui.Tr.SLocalize("ConflictsResolved"), func(g *gocui.Gui, v *gocui.View) error {
-		return gui.genericMergeCommand("continue")
-	}, nil)
+	return gui.confirmContinue(gui.Tr.SLocalize("ConflictsResolved"))
 }
@@ -0,0 +1,39 @@
+package gui
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// withNetworkRetries runs a fetch, pull or push, trying again with a growing
+// delay for as many times as the user's config allows if it fails for a
+// reason that might go away e.g. a timeout. While we wait to try again we say
+// so in the status bar. It returns once the last attempt is done.
+func (gui *Gui) withNetworkRetries(operation string, f func() error) error {
+	userConfig := gui.Config.GetUserConfig()
+	retries := userConfig.GetInt("git.retry.attempts")
+	delay := time.Duration(userConfig.GetInt("git.retry.delay")) * time.Second
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if attempt > retries || !commands.IsTransientNetworkError(err) {
+			return err
+		}
+
+		status := gui.Tr.TemplateLocalize("RetryingStatus", Teml{
+			"operation": operation,
+			"attempt":   strconv.Itoa(attempt),
+			"attempts":  strconv.Itoa(retries),
+		})
+		waited := make(chan struct{})
+		_ = gui.WithWaitingStatus(status, func() error {
+			time.Sleep(delay)
+			close(waited)
+			return nil
+		})
+		<-waited
+		delay *= 2
+	}
+}
@@ -0,0 +1,131 @@
+package gui
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// While offline we don't auto-fetch, and pushes and fetches the user asks for
+// get queued rather than run. The queue is run once we can reach the remote
+// again, or when the user goes back online.
+
+// connectivityCheckInterval is how often we check whether the remote is
+// reachable again while there are operations waiting
+const connectivityCheckInterval = 30 * time.Second
+
+type queuedNetworkOperation struct {
+	description string
+	run         func()
+}
+
+type offlineState struct {
+	queue []queuedNetworkOperation
+	// running is set while we're working through the queue so that we don't
+	// run it twice
+	running bool
+	mutex   sync.Mutex
+}
+
+func (gui *Gui) isOffline() bool {
+	return gui.State.Offline != nil
+}
+
+// queueIfOffline queues the operation if we're offline, returning whether it
+// did so. run is called from a goroutine and shouldn't return until the
+// operation is done, so that queued operations don't overlap.
+func (gui *Gui) queueIfOffline(description string, run func()) bool {
+	state := gui.State.Offline
+	if state == nil {
+		return false
+	}
+
+	state.mutex.Lock()
+	state.queue = append(state.queue, queuedNetworkOperation{description: description, run: run})
+	state.mutex.Unlock()
+
+	gui.raiseToast(gui.Tr.TemplateLocalize("QueuedWhileOffline", Teml{"operation": description}))
+	_ = gui.refreshStatus(gui.g)
+	return true
+}
+
+func (gui *Gui) handleToggleOfflineMode(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Offline
+	if state == nil {
+		gui.State.Offline = &offlineState{}
+		gui.raiseToast(gui.Tr.SLocalize("OfflineModeOn"))
+		return gui.refreshStatus(gui.g)
+	}
+
+	gui.State.Offline = nil
+	gui.raiseToast(gui.Tr.SLocalize("OfflineModeOff"))
+	go gui.runQueuedNetworkOperations(state)
+	return gui.refreshStatus(gui.g)
+}
+
+// runQueuedNetworkOperationsIfOnline runs on a timer and works through the
+// queue once the remote is reachable, staying in offline mode so that we
+// still don't auto-fetch
+func (gui *Gui) runQueuedNetworkOperationsIfOnline() error {
+	state := gui.State.Offline
+	if state == nil {
+		return nil
+	}
+
+	state.mutex.Lock()
+	waiting := len(state.queue) > 0 && !state.running
+	state.mutex.Unlock()
+	if !waiting || !gui.GitCommand.CanReachRemote("") {
+		return nil
+	}
+
+	gui.raiseToast(gui.Tr.SLocalize("ConnectivityRestored"))
+	gui.runQueuedNetworkOperations(state)
+	return nil
+}
+
+func (gui *Gui) runQueuedNetworkOperations(state *offlineState) {
+	state.mutex.Lock()
+	if state.running {
+		state.mutex.Unlock()
+		return
+	}
+	state.running = true
+	state.mutex.Unlock()
+
+	defer func() {
+		state.mutex.Lock()
+		state.running = false
+		state.mutex.Unlock()
+	}()
+
+	for {
+		state.mutex.Lock()
+		if len(state.queue) == 0 {
+			state.mutex.Unlock()
+			break
+		}
+		operation := state.queue[0]
+		state.queue = state.queue[1:]
+		state.mutex.Unlock()
+
+		gui.raiseToast(gui.Tr.TemplateLocalize("RunningQueuedOperation", Teml{"operation": operation.description}))
+		operation.run()
+		_ = gui.refreshStatus(gui.g)
+	}
+}
+
+// offlineStatus is what we show in the status panel while offline
+func (gui *Gui) offlineStatus() string {
+	state := gui.State.Offline
+	state.mutex.Lock()
+	queued := len(state.queue)
+	state.mutex.Unlock()
+
+	if queued == 0 {
+		return gui.Tr.SLocalize("offline")
+	}
+	return gui.Tr.TemplateLocalize("offlineWithQueue", Teml{"count": strconv.Itoa(queued)})
+}
@@ -0,0 +1,486 @@
+package gui
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/mattn/go-runewidth"
+)
+
+// Pagers like delta can emit OSC 8 hyperlinks, which gocui can't draw, and
+// don't keep git's 'diff --git' lines, which we'd otherwise use to find where
+// each file starts. So as a diff is read into the main view we strip out the
+// hyperlinks, remembering where they were so that clicking them still works,
+// and note which lines start a file or a hunk.
+
+var (
+	// OSC 8 sequences are ESC ]8;params;url followed by ST (ESC \) or BEL. An
+	// empty url closes the link.
+	osc8Regexp = regexp.MustCompile("\x1b]8;[^;\x07\x1b]*;([^\x07\x1b]*)(?:\x1b\\\\|\x07)")
+	// git starts each file with 'diff --git'. Delta replaces that with the file
+	// name, prefixed with a label when --navigate is on, e.g. 'Δ pkg/gui/gui.go'.
+	fileHeaderRegexp = regexp.MustCompile(`^(diff --git |diff --cc |Δ |added: |removed: |renamed: )`)
+	// likewise delta labels hunks with '•' when --navigate is on
+	hunkHeaderRegexp = regexp.MustCompile(`^(@@ |• )`)
+	// a line of the --stat summary above a diff, e.g. ' pkg/gui/gui.go | 12 ++--'
+	statLineRegexp = regexp.MustCompile(`^ (\S.*?) +\| +(\d+|Bin )`)
+)
+
+// boundaryKind is the kind of line we can jump to in the output
+type boundaryKind int
+
+const (
+	fileBoundary boundaryKind = iota
+	hunkBoundary
+)
+
+// outputLink is a hyperlink on a line of the output, covering the columns from
+// start up to but not including end
+type outputLink struct {
+	start int
+	end   int
+	url   string
+}
+
+// pagerOutput is what we know about the output of the current task in a view
+type pagerOutput struct {
+	mutex      sync.Mutex
+	lineCount  int
+	boundaries map[boundaryKind][]int
+	// fileNames holds the name of the file starting at each file boundary
+	fileNames []string
+	// hunkKeys holds the key of the hunk starting at each hunk boundary, which
+	// is how we remember which hunks are folded
+	hunkKeys []string
+	links    map[int][]outputLink
+	// folds says what to leave out of the output. The lines of a folded file
+	// or hunk are left out, leaving its header, marked with foldedMarker.
+	folds        *mainFolds
+	foldedMarker string
+	currentFile  string
+	fileFolded   bool
+	hunkFolded   bool
+	// statFiles holds the file named on each line of the --stat summary
+	statFiles map[int]string
+	// jumpPending is set when the user asked for the file or hunk after
+	// jumpFrom, or the file jumpFile, before we'd read that far, so that we
+	// jump there once we come to it
+	jumpPending bool
+	jumpKind    boundaryKind
+	jumpFrom    int
+	jumpFile    string
+	onJump      func(lineIdx int)
+}
+
+func newPagerOutput(folds *mainFolds, foldedMarker string) *pagerOutput {
+	return &pagerOutput{
+		boundaries:   map[boundaryKind][]int{},
+		links:        map[int][]outputLink{},
+		statFiles:    map[int]string{},
+		folds:        folds,
+		foldedMarker: foldedMarker,
+	}
+}
+
+// processLine records the file or hunk header and hyperlinks of the next line
+// of output, and returns the line without the hyperlinks, or nil if it's been
+// folded away
+func (o *pagerOutput) processLine(line []byte) []byte {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	lineIdx := o.lineCount
+	links := []outputLink{}
+
+	matches := osc8Regexp.FindAllSubmatchIndex(line, -1)
+	if len(matches) > 0 {
+		result := make([]byte, 0, len(line))
+		var openLink *outputLink
+		prev := 0
+		for _, match := range matches {
+			result = append(result, line[prev:match[0]]...)
+			prev = match[1]
+
+			column := utf8.RuneCountInString(utils.Decolorise(string(result)))
+			if openLink != nil {
+				openLink.end = column
+				links = append(links, *openLink)
+				openLink = nil
+			}
+			if url := string(line[match[2]:match[3]]); url != "" {
+				openLink = &outputLink{start: column, url: url}
+			}
+		}
+		line = append(result, line[prev:]...)
+	}
+
+	text := utils.Decolorise(string(line))
+	folded := false
+	if fileHeaderRegexp.MatchString(text) {
+		o.currentFile = fileNameFromHeader(text)
+		o.fileFolded = o.folds.isFileFolded(o.currentFile)
+		o.hunkFolded = false
+		folded = o.fileFolded
+		o.fileNames = append(o.fileNames, o.currentFile)
+		o.addBoundary(fileBoundary, lineIdx)
+	} else if o.fileFolded {
+		return nil
+	} else if hunkHeaderRegexp.MatchString(text) {
+		key := o.currentFile + "\x00" + text
+		o.hunkFolded = o.folds.isHunkFolded(key)
+		folded = o.hunkFolded
+		o.hunkKeys = append(o.hunkKeys, key)
+		o.addBoundary(hunkBoundary, lineIdx)
+	} else if o.hunkFolded {
+		return nil
+	} else if len(o.fileNames) == 0 {
+		if match := statLineRegexp.FindStringSubmatch(text); match != nil {
+			o.statFiles[lineIdx] = match[1]
+		}
+	}
+
+	if folded {
+		line = append(line, []byte(" "+o.foldedMarker)...)
+	}
+	if len(links) > 0 {
+		o.links[lineIdx] = links
+	}
+	o.lineCount++
+
+	return line
+}
+
+func (o *pagerOutput) addBoundary(kind boundaryKind, lineIdx int) {
+	o.boundaries[kind] = append(o.boundaries[kind], lineIdx)
+	if o.jumpPending && o.jumpKind == kind && lineIdx > o.jumpFrom &&
+		(o.jumpFile == "" || statPathMatches(o.jumpFile, o.currentFile)) {
+		o.jumpPending = false
+		if o.onJump != nil {
+			o.onJump(lineIdx)
+		}
+	}
+}
+
+// fileNameFromHeader returns the name of the file a file header is for. For a
+// rename that's the new name.
+func fileNameFromHeader(header string) string {
+	name := fileHeaderRegexp.ReplaceAllString(header, "")
+	if strings.HasPrefix(header, "diff --git ") {
+		if idx := strings.LastIndex(name, " b/"); idx != -1 {
+			return name[idx+len(" b/"):]
+		}
+	}
+	if idx := strings.LastIndex(name, "⟶ "); idx != -1 {
+		return strings.TrimSpace(name[idx+len("⟶ "):])
+	}
+	return strings.TrimSpace(name)
+}
+
+// getLink returns the hyperlink at the given position, if there is one
+func (o *pagerOutput) getLink(cx int, cy int) (string, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, link := range o.links[cy] {
+		if cx >= link.start && cx < link.end {
+			return link.url, true
+		}
+	}
+	return "", false
+}
+
+// getBoundary returns the line the next file or hunk starts on after the
+// given line, or the previous one before it if forward is false. If we haven't
+// read as far as the next one yet we say so, and jump there once we get to it.
+func (o *pagerOutput) getBoundary(kind boundaryKind, lineIdx int, forward bool, onJump func(lineIdx int)) (int, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	boundaries := o.boundaries[kind]
+	if forward {
+		for _, boundary := range boundaries {
+			if boundary > lineIdx {
+				return boundary, true
+			}
+		}
+		o.jumpPending = true
+		o.jumpKind = kind
+		o.jumpFrom = lineIdx
+		o.jumpFile = ""
+		o.onJump = onJump
+		return 0, false
+	}
+
+	for i := len(boundaries) - 1; i >= 0; i-- {
+		if boundaries[i] < lineIdx {
+			return boundaries[i], true
+		}
+	}
+	return 0, len(boundaries) > 0
+}
+
+// getStatFileDiff returns the line where the diff starts for the file named
+// on the given line of the --stat summary. If we haven't read as far as that
+// yet we say so, and jump there once we get to it.
+func (o *pagerOutput) getStatFileDiff(lineIdx int, onJump func(lineIdx int)) (int, bool, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	path, ok := o.statFiles[lineIdx]
+	if !ok {
+		return 0, false, false
+	}
+	for i, name := range o.fileNames {
+		if statPathMatches(path, name) {
+			return o.boundaries[fileBoundary][i], true, true
+		}
+	}
+	o.jumpPending = true
+	o.jumpKind = fileBoundary
+	o.jumpFrom = lineIdx
+	o.jumpFile = path
+	o.onJump = onJump
+	return 0, false, true
+}
+
+// statPathMatches tells us whether a path in a --stat summary is for the
+// given file. Git shortens long paths there to '.../' and the end of the path.
+func statPathMatches(path string, fileName string) bool {
+	if strings.HasPrefix(path, ".../") {
+		return strings.HasSuffix(fileName, path[len("..."):])
+	}
+	return path == fileName
+}
+
+// outputFile is a file in the output and the line it starts on
+type outputFile struct {
+	name    string
+	lineIdx int
+}
+
+// getFiles returns the files we've read so far
+func (o *pagerOutput) getFiles() []outputFile {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	files := make([]outputFile, len(o.fileNames))
+	for i, name := range o.fileNames {
+		files[i] = outputFile{name: name, lineIdx: o.boundaries[fileBoundary][i]}
+	}
+	return files
+}
+
+// newPagerOutput starts keeping track of the output of a new task in a view.
+// For the main view we also remember the task so that it can be run again to
+// fold or unfold part of its output.
+func (gui *Gui) newPagerOutput(viewName string, cmd *exec.Cmd, pagingContext string) *pagerOutput {
+	var folds *mainFolds
+	if viewName == "main" {
+		folds = gui.rememberMainTask(cmd, pagingContext)
+	}
+	output := newPagerOutput(folds, utils.ColoredString(gui.Tr.SLocalize("FoldedMarker"), color.FgBlue))
+	gui.pagerOutputs[viewName] = output
+	return output
+}
+
+// clearPagerOutput forgets the output of the last task in a view, for when
+// something other than a command's output is shown there
+func (gui *Gui) clearPagerOutput(viewName string) {
+	delete(gui.pagerOutputs, viewName)
+	if viewName == "main" {
+		gui.State.MainTask = nil
+	}
+}
+
+// getLinkInOutput returns the hyperlink a pager emitted at the given position
+// in a view, if there is one
+func (gui *Gui) getLinkInOutput(v *gocui.View, cx int, cy int) (string, bool) {
+	output, ok := gui.pagerOutputs[v.Name()]
+	if !ok {
+		return "", false
+	}
+	ox, oy := v.Origin()
+	return output.getLink(cx+ox, cy+oy)
+}
+
+// jumpInMain scrolls the main view to the start of the next or previous file
+// or hunk in the diff it's showing
+func (gui *Gui) jumpInMain(kind boundaryKind, forward bool) error {
+	mainView := gui.getMainView()
+	output, ok := gui.pagerOutputs[mainView.Name()]
+	if !ok {
+		return nil
+	}
+
+	_, oy := mainView.Origin()
+	lineIdx, found := output.getBoundary(kind, bufferLineIdx(mainView, oy), forward, gui.scrollMainToLine)
+	if found {
+		gui.scrollMainToLine(lineIdx)
+	} else if forward {
+		// keep reading until we come to the next one, or the end
+		if manager, ok := gui.viewBufferManagerMap[mainView.Name()]; ok {
+			manager.RequestMoreLines(maxLinesReadForJump)
+		}
+	}
+	return nil
+}
+
+// jumpToStatFileInMain scrolls the main view to the diff of the file named on
+// the given line of the view, if it's a line of the --stat summary. It returns
+// false if it's not.
+func (gui *Gui) jumpToStatFileInMain(viewLineIdx int) bool {
+	mainView := gui.getMainView()
+	output, ok := gui.pagerOutputs[mainView.Name()]
+	if !ok {
+		return false
+	}
+
+	lineIdx, found, isStatLine := output.getStatFileDiff(bufferLineIdx(mainView, viewLineIdx), gui.scrollMainToLine)
+	if found {
+		gui.scrollMainToLine(lineIdx)
+	} else if isStatLine {
+		if manager, ok := gui.viewBufferManagerMap[mainView.Name()]; ok {
+			manager.RequestMoreLines(maxLinesReadForJump)
+		}
+	}
+	return isStatLine
+}
+
+func (gui *Gui) handleEnterMain(g *gocui.Gui, v *gocui.View) error {
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+	gui.jumpToStatFileInMain(cy + oy)
+	return nil
+}
+
+// scrollMainToLine scrolls the main view so that the given line of output is
+// at the top
+func (gui *Gui) scrollMainToLine(lineIdx int) {
+	mainView := gui.getMainView()
+	_, height := mainView.Size()
+	gui.g.Update(func(g *gocui.Gui) error {
+		ox, _ := mainView.Origin()
+		if manager, ok := gui.viewBufferManagerMap[mainView.Name()]; ok {
+			// make sure there's a screenful to see
+			manager.RequestLinesUpTo(lineIdx + height)
+		}
+		return mainView.SetOrigin(ox, viewLineIdx(mainView, lineIdx))
+	})
+}
+
+// maxLinesReadForJump is how far we'll read ahead looking for the next file
+// or hunk, so that a huge diff doesn't get read all at once
+const maxLinesReadForJump = 5000
+
+func (gui *Gui) handleNextFileInMain(g *gocui.Gui, v *gocui.View) error {
+	return gui.jumpInMain(fileBoundary, true)
+}
+
+func (gui *Gui) handlePrevFileInMain(g *gocui.Gui, v *gocui.View) error {
+	return gui.jumpInMain(fileBoundary, false)
+}
+
+func (gui *Gui) handleNextHunkInMain(g *gocui.Gui, v *gocui.View) error {
+	return gui.jumpInMain(hunkBoundary, true)
+}
+
+func (gui *Gui) handlePrevHunkInMain(g *gocui.Gui, v *gocui.View) error {
+	return gui.jumpInMain(hunkBoundary, false)
+}
+
+// handleJumpToFileInMain lists the files in the main panel's diff to jump to.
+// Only the files we've read so far can be listed, so we read ahead for next
+// time.
+func (gui *Gui) handleJumpToFileInMain(g *gocui.Gui, v *gocui.View) error {
+	mainView := gui.getMainView()
+	output, ok := gui.pagerOutputs[mainView.Name()]
+	if !ok {
+		return nil
+	}
+	if manager, ok := gui.viewBufferManagerMap[mainView.Name()]; ok {
+		manager.RequestMoreLines(maxLinesReadForJump)
+	}
+
+	files := output.getFiles()
+	if len(files) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoFilesInMain"))
+	}
+
+	menuItems := make([]*menuItem, len(files))
+	for i, file := range files {
+		file := file
+		menuItems[i] = &menuItem{
+			displayStrings: []string{
+				fmt.Sprintf("%d", i+1),
+				file.name,
+				utils.ColoredString(gui.Tr.TemplateLocalize("LineNumber", Teml{"line": fmt.Sprintf("%d", file.lineIdx+1)}), color.FgBlue),
+			},
+			onPress: func() error {
+				gui.scrollMainToLine(file.lineIdx)
+				return nil
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("JumpToFileInMain"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// The main view wraps its lines, so a line of output can take up more than one
+// line of the view. These convert between the two, working out the wrapping
+// the same way gocui does.
+
+func wrappedLineCount(line string, width int) int {
+	count := 1
+	n := 0
+	for _, r := range line {
+		rw := runewidth.RuneWidth(r)
+		n += rw
+		if n > width {
+			n = rw
+			count++
+		}
+	}
+	return count
+}
+
+// viewLineIdx returns the line of the view that a line of output starts on
+func viewLineIdx(v *gocui.View, lineIdx int) int {
+	width, _ := v.Size()
+	if !v.Wrap || width <= 0 {
+		return lineIdx
+	}
+
+	result := 0
+	for i, line := range v.BufferLines() {
+		if i >= lineIdx {
+			return result
+		}
+		result += wrappedLineCount(line, width)
+	}
+	return result
+}
+
+// bufferLineIdx returns the line of output shown on a line of the view
+func bufferLineIdx(v *gocui.View, viewLineIdx int) int {
+	width, _ := v.Size()
+	if !v.Wrap || width <= 0 {
+		return viewLineIdx
+	}
+
+	viewLines := 0
+	lines := v.BufferLines()
+	for i, line := range lines {
+		viewLines += wrappedLineCount(line, width)
+		if viewLines > viewLineIdx {
+			return i
+		}
+	}
+	return len(lines)
+}
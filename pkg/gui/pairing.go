@@ -0,0 +1,67 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// getPairingDriver returns who's driving, if anyone. We remember the driver
+// between runs, but only go with them while they're still in git.commit.pairs
+// so that taking someone out of the rotation stops crediting them.
+func (gui *Gui) getPairingDriver() string {
+	driver := gui.Config.GetAppState().PairingDriver
+	if driver == "" || !utils.IncludesString(gui.GitCommand.GetPairs(), driver) {
+		return ""
+	}
+	return driver
+}
+
+func (gui *Gui) setPairingDriver(driver string) error {
+	gui.Config.GetAppState().PairingDriver = driver
+	if err := gui.Config.SaveAppState(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	return gui.refreshStatus(gui.g)
+}
+
+// handleCreatePairingMenu lets the user pass the keyboard to the next person
+// in the rotation, or go back to working alone
+func (gui *Gui) handleCreatePairingMenu(g *gocui.Gui, v *gocui.View) error {
+	pairs := gui.GitCommand.GetPairs()
+	if len(pairs) == 0 {
+		return gui.createErrorPanel(g, gui.Tr.SLocalize("NoPairsConfigured"))
+	}
+
+	current := gui.getPairingDriver()
+	marker := func(identity string) string {
+		if identity == current {
+			return color.New(color.FgGreen).Sprint("*")
+		}
+		return " "
+	}
+
+	menuItems := make([]*menuItem, 0, len(pairs)+1)
+	for _, pair := range pairs {
+		identity := pair
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{
+				marker(identity),
+				commands.IdentityName(identity),
+				color.New(color.FgYellow).Sprint(identity),
+			},
+			onPress: func() error {
+				return gui.setPairingDriver(identity)
+			},
+		})
+	}
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{marker(""), gui.Tr.SLocalize("NoPairingDriver"), ""},
+		onPress: func() error {
+			return gui.setPairingDriver("")
+		},
+	})
+
+	return gui.createMenu(gui.Tr.SLocalize("PairingDriverTitle"), menuItems, createMenuOptions{showCancel: true})
+}
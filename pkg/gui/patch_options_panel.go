@@ -3,7 +3,9 @@ package gui
 import (
 	"fmt"
 
+	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 func (gui *Gui) handleCreatePatchOptionsMenu(g *gocui.Gui, v *gocui.View) error {
@@ -16,6 +18,10 @@ func (gui *Gui) handleCreatePatchOptionsMenu(g *gocui.Gui, v *gocui.View) error
 			displayString: fmt.Sprintf("remove patch from original commit (%s)", gui.GitCommand.PatchManager.CommitSha),
 			onPress:       gui.handleDeletePatchFromCommit,
 		},
+		{
+			displayString: "move patch into a different commit...",
+			onPress:       gui.handleCreateMovePatchCommitPicker,
+		},
 		{
 			displayString: "pull patch out into index",
 			onPress:       gui.handlePullPatchIntoWorkingTree,
@@ -85,6 +91,39 @@ func (gui *Gui) handleDeletePatchFromCommit() error {
 }
 
 func (gui *Gui) handleMovePatchToSelectedCommit() error {
+	return gui.movePatchToCommit(gui.selectedCommitIndex())
+}
+
+// handleCreateMovePatchCommitPicker lets the user pick which commit to move the
+// patch into without having to go and select it in the commits panel first
+func (gui *Gui) handleCreateMovePatchCommitPicker() error {
+	sourceIndex := gui.getPatchCommitIndex()
+
+	menuItems := []*menuItem{}
+	// we can't rebase onto the last commit we know about, so it can't be a
+	// destination
+	for i := 0; i < len(gui.State.Commits)-1; i++ {
+		commit := gui.State.Commits[i]
+		if i == sourceIndex || commit.Status == "rebasing" {
+			continue
+		}
+
+		index := i // never close over loop variables
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{
+				utils.ColoredString(commit.ShortSha(), color.FgYellow),
+				commit.Name,
+			},
+			onPress: func() error {
+				return gui.movePatchToCommit(index)
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("MovePatchToCommitTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) movePatchToCommit(destinationIndex int) error {
 	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
 		return err
 	}
@@ -95,7 +134,7 @@ func (gui *Gui) handleMovePatchToSelectedCommit() error {
 
 	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
 		commitIndex := gui.getPatchCommitIndex()
-		err := gui.GitCommand.MovePatchToSelectedCommit(gui.State.Commits, commitIndex, gui.State.Panels.Commits.SelectedLine, gui.GitCommand.PatchManager)
+		err := gui.GitCommand.MovePatchToSelectedCommit(gui.State.Commits, commitIndex, destinationIndex, gui.GitCommand.PatchManager)
 		return gui.handleGenericMergeCommandResult(err)
 	})
 }
@@ -24,17 +24,21 @@ func GetBranchListDisplayStrings(branches []*commands.Branch, fullDescription bo
 func getBranchDisplayStrings(b *commands.Branch, fullDescription bool) []string {
 	displayName := utils.ColoredString(b.Name, GetBranchColor(b.Name))
 	if b.Pushables != "" && b.Pullables != "" && b.Pushables != "?" && b.Pullables != "?" {
-		trackColor := color.FgYellow
+		trackColor := theme.CurrentPalette.Warning
 		if b.Pushables == "0" && b.Pullables == "0" {
-			trackColor = color.FgGreen
+			trackColor = theme.CurrentPalette.Added
 		}
 		track := utils.ColoredString(fmt.Sprintf("↑%s↓%s", b.Pushables, b.Pullables), trackColor)
 		displayName = fmt.Sprintf("%s %s", displayName, track)
 	}
 
-	recencyColor := color.FgCyan
+	if b.StackParent != "" {
+		displayName = fmt.Sprintf("%s %s", displayName, utils.ColoredString("↳ "+b.StackParent, theme.CurrentPalette.Info))
+	}
+
+	recencyColor := theme.CurrentPalette.Info
 	if b.Recency == "  *" {
-		recencyColor = color.FgGreen
+		recencyColor = theme.CurrentPalette.Added
 	}
 
 	if fullDescription {
@@ -50,11 +54,11 @@ func GetBranchColor(name string) color.Attribute {
 
 	switch branchType {
 	case "feature":
-		return color.FgGreen
+		return theme.CurrentPalette.Added
 	case "bugfix":
-		return color.FgYellow
+		return theme.CurrentPalette.Warning
 	case "hotfix":
-		return color.FgRed
+		return theme.CurrentPalette.Removed
 	default:
 		return theme.DefaultTextColor
 	}
@@ -18,8 +18,8 @@ func GetCommitFileListDisplayStrings(branches []*commands.CommitFile) [][]string
 
 // getCommitFileDisplayStrings returns the display string of branch
 func getCommitFileDisplayStrings(f *commands.CommitFile) []string {
-	yellow := color.New(color.FgYellow)
-	green := color.New(color.FgGreen)
+	yellow := color.New(theme.CurrentPalette.Warning)
+	green := color.New(theme.CurrentPalette.Added)
 	defaultColor := color.New(theme.DefaultTextColor)
 
 	var colour *color.Color
@@ -1,6 +1,7 @@
 package presentation
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/fatih/color"
@@ -9,10 +10,10 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetCommitListDisplayStrings(commits []*commands.Commit, fullDescription bool) [][]string {
+func GetCommitListDisplayStrings(commits []*commands.Commit, fullDescription bool, showStats bool, showDecorations bool) [][]string {
 	lines := make([][]string, len(commits))
 
-	var displayFunc func(*commands.Commit) []string
+	var displayFunc func(*commands.Commit, bool) []string
 	if fullDescription {
 		displayFunc = getFullDescriptionDisplayStringsForCommit
 	} else {
@@ -20,18 +21,22 @@ func GetCommitListDisplayStrings(commits []*commands.Commit, fullDescription boo
 	}
 
 	for i := range commits {
-		lines[i] = displayFunc(commits[i])
+		lines[i] = displayFunc(commits[i], showDecorations)
+		if showStats {
+			// straight after the sha
+			lines[i] = append([]string{lines[i][0], statString(commits[i])}, lines[i][1:]...)
+		}
 	}
 
 	return lines
 }
 
-func getFullDescriptionDisplayStringsForCommit(c *commands.Commit) []string {
-	red := color.New(color.FgRed)
-	yellow := color.New(color.FgYellow)
-	green := color.New(color.FgGreen)
+func getFullDescriptionDisplayStringsForCommit(c *commands.Commit, showDecorations bool) []string {
+	red := color.New(theme.CurrentPalette.Removed)
+	yellow := color.New(theme.CurrentPalette.Warning)
+	green := color.New(theme.CurrentPalette.Added)
 	blue := color.New(color.FgBlue)
-	cyan := color.New(color.FgCyan)
+	cyan := color.New(theme.CurrentPalette.Info)
 	defaultColor := color.New(theme.DefaultTextColor)
 	magenta := color.New(color.FgMagenta)
 
@@ -48,7 +53,7 @@ func getFullDescriptionDisplayStringsForCommit(c *commands.Commit) []string {
 		shaColor = yellow
 	case "merged":
 		shaColor = green
-	case "rebasing":
+	case "rebasing", "applying":
 		shaColor = blue
 	case "reflog":
 		shaColor = blue
@@ -67,6 +72,8 @@ func getFullDescriptionDisplayStringsForCommit(c *commands.Commit) []string {
 	secondColumnString := blue.Sprint(truncatedDate)
 	if c.Action != "" {
 		secondColumnString = cyan.Sprint(c.Action)
+	} else if showDecorations && len(c.Decorations) > 0 {
+		tagString = decorationsString(c.Decorations) + " "
 	} else if c.ExtraInfo != "" {
 		tagColor := color.New(color.FgMagenta, color.Bold)
 		tagString = utils.ColoredStringDirect(c.ExtraInfo, tagColor) + " "
@@ -74,15 +81,15 @@ func getFullDescriptionDisplayStringsForCommit(c *commands.Commit) []string {
 
 	truncatedAuthor := utils.TruncateWithEllipsis(c.Author, 17)
 
-	return []string{shaColor.Sprint(c.Sha[:8]), secondColumnString, yellow.Sprint(truncatedAuthor), tagString + defaultColor.Sprint(c.Name)}
+	return []string{shaColor.Sprint(c.ShortSha()), secondColumnString, yellow.Sprint(truncatedAuthor), tagString + nameColor(c).Sprint(c.Name) + stepCountString(c)}
 }
 
-func getDisplayStringsForCommit(c *commands.Commit) []string {
-	red := color.New(color.FgRed)
-	yellow := color.New(color.FgYellow)
-	green := color.New(color.FgGreen)
+func getDisplayStringsForCommit(c *commands.Commit, showDecorations bool) []string {
+	red := color.New(theme.CurrentPalette.Removed)
+	yellow := color.New(theme.CurrentPalette.Warning)
+	green := color.New(theme.CurrentPalette.Added)
 	blue := color.New(color.FgBlue)
-	cyan := color.New(color.FgCyan)
+	cyan := color.New(theme.CurrentPalette.Info)
 	defaultColor := color.New(theme.DefaultTextColor)
 	magenta := color.New(color.FgMagenta)
 
@@ -99,7 +106,7 @@ func getDisplayStringsForCommit(c *commands.Commit) []string {
 		shaColor = yellow
 	case "merged":
 		shaColor = green
-	case "rebasing":
+	case "rebasing", "applying":
 		shaColor = blue
 	case "reflog":
 		shaColor = blue
@@ -117,10 +124,72 @@ func getDisplayStringsForCommit(c *commands.Commit) []string {
 	tagString := ""
 	if c.Action != "" {
 		actionString = cyan.Sprint(utils.WithPadding(c.Action, 7)) + " "
+	} else if showDecorations && len(c.Decorations) > 0 {
+		tagString = decorationsString(c.Decorations) + " "
 	} else if len(c.Tags) > 0 {
 		tagColor := color.New(color.FgMagenta, color.Bold)
 		tagString = utils.ColoredStringDirect(strings.Join(c.Tags, " "), tagColor) + " "
 	}
 
-	return []string{shaColor.Sprint(c.Sha[:8]), actionString + tagString + defaultColor.Sprint(c.Name)}
+	return []string{shaColor.Sprint(c.ShortSha()), actionString + tagString + nameColor(c).Sprint(c.Name) + stepCountString(c)}
+}
+
+// decorationsString shows the refs pointing at a commit the way tig does, so
+// that they can be told apart without relying on color: [branch],
+// {remote/branch} and <tag>
+func decorationsString(decorations []commands.Decoration) string {
+	pills := make([]string, len(decorations))
+	for i, d := range decorations {
+		switch d.Kind {
+		case "head":
+			pills[i] = color.New(theme.CurrentPalette.Info, color.Bold).Sprint("[" + headDecorationName(d) + "]")
+		case "branch":
+			pills[i] = color.New(theme.CurrentPalette.Added, color.Bold).Sprint("[" + d.Name + "]")
+		case "remote":
+			pills[i] = color.New(theme.CurrentPalette.Removed, color.Bold).Sprint("{" + d.Name + "}")
+		case "tag":
+			pills[i] = color.New(theme.CurrentPalette.Warning, color.Bold).Sprint("<" + d.Name + ">")
+		default:
+			pills[i] = color.New(color.FgMagenta, color.Bold).Sprint("(" + d.Name + ")")
+		}
+	}
+	return strings.Join(pills, " ")
+}
+
+func headDecorationName(d commands.Decoration) string {
+	if d.Name == "HEAD" {
+		return "HEAD"
+	}
+	return "HEAD -> " + d.Name
+}
+
+// nameColor shows an exec that stopped a rebase in red so that it stands out
+func nameColor(c *commands.Commit) *color.Color {
+	if c.Status == "stopped" {
+		return color.New(theme.CurrentPalette.Removed)
+	}
+	return color.New(theme.DefaultTextColor)
+}
+
+// stepCountString marks a collapsed run of rebase steps in the reflog
+func stepCountString(c *commands.Commit) string {
+	if c.StepCount == 0 {
+		return ""
+	}
+	return color.New(theme.CurrentPalette.Info).Sprintf(" (+%d steps)", c.StepCount-1)
+}
+
+// statString shows how many lines and files a commit changes, or nothing if
+// we don't know yet
+func statString(c *commands.Commit) string {
+	if c.Stat == nil {
+		return ""
+	}
+	files := fmt.Sprintf("%d files", c.Stat.Files)
+	if c.Stat.Files == 1 {
+		files = "1 file"
+	}
+	return color.New(theme.CurrentPalette.Added).Sprintf("+%d", c.Stat.Insertions) + " " +
+		color.New(theme.CurrentPalette.Removed).Sprintf("-%d", c.Stat.Deletions) + " " +
+		files
 }
@@ -3,13 +3,17 @@ package presentation
 import (
 	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/theme"
 )
 
-func GetFileListDisplayStrings(files []*commands.File) [][]string {
+func GetFileListDisplayStrings(files []*commands.File, whitespaceOnlyLabel string) [][]string {
 	lines := make([][]string, len(files))
 
 	for i := range files {
 		lines[i] = getFileDisplayStrings(files[i])
+		if files[i].WhitespaceOnly {
+			lines[i][0] += " " + color.New(theme.CurrentPalette.Info).Sprint("("+whitespaceOnlyLabel+")")
+		}
 	}
 
 	return lines
@@ -19,8 +23,11 @@ func GetFileListDisplayStrings(files []*commands.File) [][]string {
 func getFileDisplayStrings(f *commands.File) []string {
 	// potentially inefficient to be instantiating these color
 	// objects with each render
-	red := color.New(color.FgRed)
-	green := color.New(color.FgGreen)
+	red := color.New(theme.CurrentPalette.Removed)
+	green := color.New(theme.CurrentPalette.Added)
+	if f.HasMergeConflicts {
+		return []string{color.New(theme.CurrentPalette.Conflict).Sprint(f.DisplayString)}
+	}
 	if !f.Tracked && !f.HasStagedChanges {
 		return []string{red.Sprint(f.DisplayString)}
 	}
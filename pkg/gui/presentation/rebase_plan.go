@@ -0,0 +1,31 @@
+package presentation
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetRebasePlanDisplayStrings shows each step of a planned rebase as its
+// action, the commit's sha and the commit's name, or its new name if it's
+// being reworded
+func GetRebasePlanDisplayStrings(steps []*commands.RebasePlanStep) [][]string {
+	cyan := color.New(theme.CurrentPalette.Info)
+	yellow := color.New(theme.CurrentPalette.Warning)
+	defaultColor := color.New(theme.DefaultTextColor)
+
+	lines := make([][]string, len(steps))
+	for i, step := range steps {
+		name := defaultColor.Sprint(step.Commit.Name)
+		if step.Action == "reword" {
+			name = yellow.Sprint(strings.SplitN(step.Message, "\n", 2)[0])
+		} else if step.Action == "drop" {
+			name = color.New(theme.DefaultTextColor, color.CrossedOut).Sprint(step.Commit.Name)
+		}
+		lines[i] = []string{cyan.Sprint(utils.WithPadding(step.Action, 7)), step.Commit.ShortSha(), name}
+	}
+	return lines
+}
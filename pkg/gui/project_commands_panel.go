@@ -0,0 +1,261 @@
+package gui
+
+import (
+	"context"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+)
+
+// Project commands are the commands from projectCommands, like running the
+// tests or the linter. They run in the background while the user gets on with
+// things, with how they went shown in the status bar and their output a
+// keypress away in the main panel.
+
+// projectCommandRun is the latest run of a project command
+type projectCommandRun struct {
+	mutex   sync.Mutex
+	cmd     *exec.Cmd
+	output  strings.Builder
+	running bool
+	failed  bool
+}
+
+func (r *projectCommandRun) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.output.Write(p)
+}
+
+// snapshot returns the output so far and whether the command is still going
+func (r *projectCommandRun) snapshot() (string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.output.String(), r.running
+}
+
+func (r *projectCommandRun) isRunning() bool {
+	_, running := r.snapshot()
+	return running
+}
+
+func (gui *Gui) handleCreateProjectCommandsMenu(g *gocui.Gui, v *gocui.View) error {
+	projectCommands := gui.OSCommand.GetProjectCommands()
+	if len(projectCommands) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoProjectCommands"))
+	}
+
+	menuItems := []*menuItem{}
+	for _, projectCommand := range projectCommands {
+		projectCommand := projectCommand
+		run := gui.State.ProjectCommandRuns[projectCommand.Name]
+		if run != nil && run.isRunning() {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{projectCommand.Name, gui.projectCommandStatus(run), gui.Tr.SLocalize("CancelProjectCommand")},
+				onPress: func() error {
+					return gui.cancelProjectCommand(run)
+				},
+			})
+		} else {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{projectCommand.Name, gui.projectCommandStatus(run), projectCommand.Command},
+				onPress: func() error {
+					return gui.runProjectCommand(projectCommand)
+				},
+			})
+		}
+		if run != nil {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{"", "", gui.Tr.TemplateLocalize("ShowProjectCommandOutput", Teml{"name": projectCommand.Name})},
+				onPress: func() error {
+					return gui.showProjectCommandOutput(projectCommand.Name, run)
+				},
+			})
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("ProjectCommandsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// runProjectCommand starts a project command in the background and shows its
+// output in the main panel as it comes in
+func (gui *Gui) runProjectCommand(projectCommand commands.ProjectCommand) error {
+	if err := gui.OSCommand.CheckReadOnly(projectCommand.Command); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	cmd := gui.OSCommand.RunCustomCommand(projectCommand.Command)
+	run := &projectCommandRun{cmd: cmd, running: true}
+	cmd.Stdout = run
+	cmd.Stderr = run
+	if err := cmd.Start(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	if gui.State.ProjectCommandRuns == nil {
+		gui.State.ProjectCommandRuns = map[string]*projectCommandRun{}
+	}
+	gui.State.ProjectCommandRuns[projectCommand.Name] = run
+
+	statusName := gui.Tr.TemplateLocalize("RunningProjectCommand", Teml{"name": projectCommand.Name})
+	if err := gui.WithWaitingStatus(statusName, func() error {
+		err := cmd.Wait()
+		run.mutex.Lock()
+		run.running = false
+		run.failed = err != nil
+		run.mutex.Unlock()
+
+		if err != nil {
+			gui.raiseToast(gui.Tr.TemplateLocalize("ProjectCommandFailed", Teml{"name": projectCommand.Name}))
+		} else {
+			gui.raiseToast(gui.Tr.TemplateLocalize("ProjectCommandPassed", Teml{"name": projectCommand.Name}))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return gui.showProjectCommandOutput(projectCommand.Name, run)
+}
+
+func (gui *Gui) cancelProjectCommand(run *projectCommandRun) error {
+	if !run.isRunning() {
+		return nil
+	}
+	if err := commands.Kill(run.cmd); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	return nil
+}
+
+// showProjectCommandOutput shows a project command's output in the main
+// panel, following along until it finishes or something else is shown there
+func (gui *Gui) showProjectCommandOutput(name string, run *projectCommandRun) error {
+	mainView := gui.getMainView()
+	mainView.Title = gui.Tr.TemplateLocalize("ProjectCommandOutputTitle", Teml{"name": name})
+	gui.State.SplitMainPanel = false
+
+	manager := gui.getManager(mainView)
+	return manager.NewTask(func(ctx context.Context) error {
+		ticker := time.NewTicker(time.Millisecond * 200)
+		defer ticker.Stop()
+
+		renderedLength := -1
+		for {
+			output, running := run.snapshot()
+			if len(output) != renderedLength {
+				renderedLength = len(output)
+				gui.g.Update(func(*gocui.Gui) error {
+					gui.setViewContent(gui.g, mainView, output)
+					// keep the latest output in view
+					_, height := mainView.Size()
+					return mainView.SetOrigin(0, max(0, mainView.ViewLinesHeight()-height))
+				})
+			}
+			if !running {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// projectCommandStatus describes how the latest run of a project command went
+func (gui *Gui) projectCommandStatus(run *projectCommandRun) string {
+	if run == nil {
+		return ""
+	}
+	run.mutex.Lock()
+	defer run.mutex.Unlock()
+	switch {
+	case run.running:
+		return color.New(theme.CurrentPalette.Warning).Sprint(gui.Tr.SLocalize("ProjectCommandRunning"))
+	case run.failed:
+		return color.New(theme.CurrentPalette.Removed).Sprint(gui.Tr.SLocalize("ProjectCommandFailedStatus"))
+	default:
+		return color.New(theme.CurrentPalette.Added).Sprint(gui.Tr.SLocalize("ProjectCommandPassedStatus"))
+	}
+}
+
+// projectCommandsInformation sums up the project commands that have been run
+// for the status bar, e.g. 'test: passed lint: failed'
+func (gui *Gui) projectCommandsInformation() string {
+	names := make([]string, 0, len(gui.State.ProjectCommandRuns))
+	for name := range gui.State.ProjectCommandRuns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ": " + gui.projectCommandStatus(gui.State.ProjectCommandRuns[name])
+	}
+	return strings.Join(parts, " ")
+}
+
+// getProjectCommandBindings returns keybindings for whichever project commands
+// have been bound to a key, via keybinding.projectCommands.<name>. A project
+// command can't take a key that lazygit already binds in any view.
+func (gui *Gui) getProjectCommandBindings(taken []*Binding) []*Binding {
+	bindings := []*Binding{}
+	userConfig := gui.Config.GetUserConfig()
+
+	isTaken := func(key interface{}) bool {
+		for _, binding := range taken {
+			if binding.Key == key && binding.Modifier == gocui.ModNone {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, projectCommand := range gui.OSCommand.GetProjectCommands() {
+		if userConfig.GetString("keybinding.projectCommands."+projectCommand.Name) == "" {
+			continue
+		}
+
+		key, err := getKeyFromConfig(userConfig, "projectCommands."+projectCommand.Name)
+		if err != nil {
+			// this will show up in the keybinding report
+			continue
+		}
+		if isTaken(key) {
+			gui.Log.Warnf("not binding project command %s to %s, which is already bound", projectCommand.Name, GetKeyDisplay(key))
+			continue
+		}
+
+		projectCommand := projectCommand
+		bindings = append(bindings, &Binding{
+			ViewName: "",
+			Key:      key,
+			Modifier: gocui.ModNone,
+			Handler: func(*gocui.Gui, *gocui.View) error {
+				return gui.runProjectCommand(projectCommand)
+			},
+			Description: gui.Tr.TemplateLocalize("runProjectCommand", Teml{"name": projectCommand.Name}),
+		})
+	}
+
+	return bindings
+}
+
+func (gui *Gui) isProjectCommandKey(key string) bool {
+	for _, projectCommand := range gui.OSCommand.GetProjectCommands() {
+		if key == strings.ToLower("keybinding.projectCommands."+projectCommand.Name) {
+			return true
+		}
+	}
+	return false
+}
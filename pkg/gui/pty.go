@@ -3,8 +3,12 @@
 package gui
 
 import (
+	"context"
+	"io"
+	"os"
 	"os/exec"
 
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/pty"
 )
 
@@ -29,10 +33,10 @@ func (gui *Gui) onResize() error {
 // talking to a terminal. We typically write cmd outputs straight to a view,
 // which is just an io.Reader. the pty package lets us wrap a command in a
 // pseudo-terminal meaning we'll get the behaviour we want from the underlying
-// command.
-func (gui *Gui) newPtyTask(viewName string, cmd *exec.Cmd) error {
+// command. The paging context says which pager to use.
+func (gui *Gui) newPtyTask(viewName string, cmd *exec.Cmd, pagingContext string) error {
 	width, _ := gui.getMainView().Size()
-	pager := gui.GitCommand.GetPager(width)
+	pager := gui.GitCommand.GetPager(width, pagingContext)
 
 	if pager == "" {
 		// if we're not using a custom pager we don't need to use a pty
@@ -51,22 +55,34 @@ func (gui *Gui) newPtyTask(viewName string, cmd *exec.Cmd) error {
 
 	manager := gui.getManager(view)
 
-	ptmx, err := pty.Start(cmd)
-	if err != nil {
-		return err
+	var ptmx *os.File
+	start := func(ctx context.Context) (*exec.Cmd, io.Reader, error) {
+		ctxCmd := commands.CommandWithContext(ctx, cmd)
+		// pty.Start gives the command a session of its own, which makes it the
+		// leader of its own process group anyway
+		ctxCmd.SysProcAttr.Setpgid = false
+		var err error
+		ptmx, err = pty.Start(ctxCmd)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		gui.State.Ptmx = ptmx
+		if err := gui.onResize(); err != nil {
+			gui.Log.Error(err)
+		}
+		return ctxCmd, ptmx, nil
 	}
 
-	gui.State.Ptmx = ptmx
 	onClose := func() {
 		ptmx.Close()
-		gui.State.Ptmx = nil
-	}
-
-	if err := gui.onResize(); err != nil {
-		return err
+		// the next task may have its own by now
+		if gui.State.Ptmx == ptmx {
+			gui.State.Ptmx = nil
+		}
 	}
 
-	if err := manager.NewTask(manager.NewCmdTask(ptmx, cmd, height+oy+10, onClose)); err != nil {
+	if err := manager.NewTask(manager.NewCmdTask(start, height+oy+10, onClose, gui.newPagerOutput(viewName, cmd, pagingContext).processLine)); err != nil {
 		return err
 	}
 
@@ -0,0 +1,35 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// Most of read-only mode is enforced by the OSCommand, which won't run
+// anything that could change the repo. Subprocesses are the exception because
+// we hand those over to the terminal ourselves, so we check them here.
+
+// refuseSubProcessIfReadOnly is given what a handler returned, and if it's
+// asking us to run a subprocess that could change something while we're in
+// read-only mode, it shows a notice instead
+func (gui *Gui) refuseSubProcessIfReadOnly(err error) error {
+	if err != gui.Errors.ErrSubProcess || gui.SubProcess == nil || !gui.OSCommand.ReadOnly() {
+		return err
+	}
+	if commands.IsReadOnlyArgs(gui.SubProcess.Args) {
+		return err
+	}
+
+	command := strings.Join(gui.SubProcess.Args, " ")
+	gui.SubProcess = nil
+	gui.OnSubProcessDone = nil
+	return gui.createErrorPanel(gui.g, commands.NewReadOnlyCommandError(command).Error())
+}
+
+func (gui *Gui) wrappedReadOnlyHandler(handler func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		return gui.refuseSubProcessIfReadOnly(handler(g, v))
+	}
+}
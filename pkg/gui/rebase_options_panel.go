@@ -8,9 +8,13 @@ import (
 )
 
 func (gui *Gui) handleCreateRebaseOptionsMenu(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.WorkingTreeState == "unstashing" {
+		return gui.handleCreateStashConflictMenu()
+	}
+
 	options := []string{"continue", "abort"}
 
-	if gui.State.WorkingTreeState == "rebasing" {
+	if gui.State.WorkingTreeState == "rebasing" || gui.State.WorkingTreeState == "applying" || gui.State.WorkingTreeState == "reverting" {
 		options = append(options, "skip")
 	}
 
@@ -21,6 +25,10 @@ func (gui *Gui) handleCreateRebaseOptionsMenu(g *gocui.Gui, v *gocui.View) error
 		menuItems[i] = &menuItem{
 			displayString: option,
 			onPress: func() error {
+				// once conflicts are resolved we show how before going on
+				if option == "continue" && len(gui.State.ConflictedFileNames) > 0 && !gui.anyFilesWithMergeConflicts() {
+					return gui.confirmContinue(gui.Tr.SLocalize("ContinueWithResolutions"))
+				}
 				return gui.genericMergeCommand(option)
 			},
 		}
@@ -29,6 +37,12 @@ func (gui *Gui) handleCreateRebaseOptionsMenu(g *gocui.Gui, v *gocui.View) error
 	var title string
 	if gui.State.WorkingTreeState == "merging" {
 		title = gui.Tr.SLocalize("MergeOptionsTitle")
+	} else if gui.State.WorkingTreeState == "applying" {
+		title = gui.Tr.SLocalize("MailboxOptionsTitle")
+	} else if gui.State.WorkingTreeState == "reverting" {
+		title = gui.Tr.SLocalize("RevertOptionsTitle")
+	} else if command, ok := gui.GitCommand.RebaseStoppedAtExec(); ok {
+		title = gui.Tr.TemplateLocalize("RebaseStoppedAtExecTitle", Teml{"command": command})
 	} else {
 		title = gui.Tr.SLocalize("RebaseOptionsTitle")
 	}
@@ -39,11 +53,16 @@ func (gui *Gui) handleCreateRebaseOptionsMenu(g *gocui.Gui, v *gocui.View) error
 func (gui *Gui) genericMergeCommand(command string) error {
 	status := gui.State.WorkingTreeState
 
-	if status != "merging" && status != "rebasing" {
+	if status != "merging" && status != "rebasing" && status != "reverting" && status != "applying" {
 		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NotMergingOrRebasing"))
 	}
 
 	commandType := strings.Replace(status, "ing", "e", 1)
+	if status == "applying" {
+		commandType = "am"
+	} else if status == "reverting" {
+		commandType = "revert"
+	}
 	// we should end up with a command like 'git merge --continue'
 
 	// it's impossible for a rebase to require a commit so we'll use a subprocess only if it's a merge
@@ -67,14 +86,14 @@ func (gui *Gui) handleGenericMergeCommandResult(result error) error {
 		return err
 	}
 	if result == nil {
-		return nil
+		return gui.reportUpdatedRefs()
 	} else if result == gui.Errors.ErrSubProcess {
 		return result
 	} else if strings.Contains(result.Error(), "No changes - did you forget to use") {
 		return gui.genericMergeCommand("skip")
 	} else if strings.Contains(result.Error(), "The previous cherry-pick is now empty") {
 		return gui.genericMergeCommand("continue")
-	} else if strings.Contains(result.Error(), "When you have resolved this problem") || strings.Contains(result.Error(), "fix conflicts") || strings.Contains(result.Error(), "Resolve all conflicts manually") {
+	} else if strings.Contains(result.Error(), "When you have resolved this problem") || strings.Contains(result.Error(), "fix conflicts") || strings.Contains(result.Error(), "Resolve all conflicts manually") || strings.Contains(result.Error(), "After resolving the conflicts") {
 		return gui.createConfirmationPanel(gui.g, gui.getFilesView(), true, gui.Tr.SLocalize("FoundConflictsTitle"), gui.Tr.SLocalize("FoundConflicts"),
 			func(g *gocui.Gui, v *gocui.View) error {
 				return nil
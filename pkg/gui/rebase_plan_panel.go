@@ -0,0 +1,200 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// An interactive rebase can be planned in the rebasePlan popup before it's
+// started: each commit from HEAD down to the selected one is listed with what's
+// to be done with it, and the commits can be reordered. Once the plan is
+// started, git is handed the plan's todo through lazygit standing in as its
+// sequence editor, and from then on it's like any other rebase.
+
+func (gui *Gui) getRebasePlanView() *gocui.View {
+	v, _ := gui.g.View("rebasePlan")
+	return v
+}
+
+func (gui *Gui) getSelectedRebasePlanStep() *commands.RebasePlanStep {
+	plan := gui.State.RebasePlan
+	selectedLine := gui.State.Panels.RebasePlan.SelectedLine
+	if plan == nil || selectedLine < 0 || selectedLine >= len(plan.Steps) {
+		return nil
+	}
+	return plan.Steps[selectedLine]
+}
+
+func (gui *Gui) handleCreateRebasePlan(g *gocui.Gui, v *gocui.View) error {
+	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
+		return err
+	}
+	// rebasing only the commits we can see would drop the rest
+	if gui.isHistoryPartial() {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantMoveCommitsWhileFiltered"))
+	}
+	if gui.getSelectedCommit(g) == nil {
+		return nil
+	}
+
+	plan, err := gui.GitCommand.NewRebasePlan(gui.State.Commits, gui.selectedCommitIndex())
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	gui.State.RebasePlan = plan
+	// the selected commit is the oldest in the plan
+	gui.State.Panels.RebasePlan.SelectedLine = len(plan.Steps) - 1
+
+	if err := gui.renderRebasePlan(); err != nil {
+		return err
+	}
+	return gui.switchFocus(gui.g, v, gui.getRebasePlanView())
+}
+
+func (gui *Gui) renderRebasePlan() error {
+	plan := gui.State.RebasePlan
+	list := utils.RenderDisplayStrings(presentation.GetRebasePlanDisplayStrings(plan.Steps))
+
+	x0, y0, x1, y1 := gui.getConfirmationPanelDimensions(gui.g, false, list)
+	v, err := gui.g.SetView("rebasePlan", x0, y0, x1, y1, 0)
+	if err != nil {
+		if err.Error() != "unknown view" {
+			return err
+		}
+		v.FgColor = theme.GocuiDefaultTextColor
+		v.ContainsList = true
+	}
+	base := &commands.Commit{Sha: plan.BaseSha}
+	v.Title = gui.Tr.TemplateLocalize("RebasePlanTitle", Teml{"sha": base.ShortSha()})
+	v.Clear()
+	fmt.Fprint(v, list)
+	gui.focusPoint(v, gui.State.Panels.RebasePlan.SelectedLine)
+	return nil
+}
+
+func (gui *Gui) handleRebasePlanStepSelect(g *gocui.Gui, v *gocui.View) error {
+	gui.focusPoint(v, gui.State.Panels.RebasePlan.SelectedLine)
+	return nil
+}
+
+func (gui *Gui) renderRebasePlanOptions() error {
+	actions := []string{"pick", "squash", "fixup", "edit", "drop", "reword"}
+	keys := make([]string, len(actions))
+	for i, action := range actions {
+		keys[i] = gui.getKeyDisplay("rebasePlan." + action)
+	}
+
+	optionsMap := map[string]string{
+		strings.Join(keys, "/"): strings.Join(actions, "/"),
+		fmt.Sprintf("%s/%s", gui.getKeyDisplay("rebasePlan.moveUpStep"), gui.getKeyDisplay("rebasePlan.moveDownStep")): gui.Tr.SLocalize("moveRebasePlanStep"),
+		gui.getKeyDisplay("rebasePlan.startRebase"): gui.Tr.SLocalize("startRebasePlan"),
+		gui.getKeyDisplay("universal.return"):       gui.Tr.SLocalize("cancel"),
+	}
+	return gui.renderOptionsMap(optionsMap)
+}
+
+func (gui *Gui) handleRebasePlanAction(action string) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		step := gui.getSelectedRebasePlanStep()
+		if step == nil {
+			return nil
+		}
+		if action == "reword" {
+			return gui.promptForRebasePlanMessage(step)
+		}
+
+		step.Action = action
+		step.Message = ""
+		return gui.renderRebasePlan()
+	}
+}
+
+func (gui *Gui) promptForRebasePlanMessage(step *commands.RebasePlanStep) error {
+	original, err := gui.GitCommand.GetCommitMessage(step.Commit.Sha)
+	if err != nil {
+		return gui.createRebasePlanErrorPanel(err.Error())
+	}
+	message := original
+	if step.Action == "reword" {
+		message = step.Message
+	}
+
+	title := gui.Tr.TemplateLocalize("RebasePlanRewordPrompt", Teml{"sha": step.Commit.ShortSha()})
+	handleConfirm := func(g *gocui.Gui, promptView *gocui.View) error {
+		// an empty message leaves the step as it was, and the commit's own
+		// message means there's nothing to reword
+		if newMessage := gui.trimmedContent(promptView); newMessage == original {
+			step.Action = "pick"
+			step.Message = ""
+		} else if newMessage != "" {
+			step.Action = "reword"
+			step.Message = newMessage
+		}
+		if err := gui.renderRebasePlan(); err != nil {
+			return err
+		}
+		return gui.focusRebasePlan(g, promptView)
+	}
+	return gui.createPopupPanel(gui.g, gui.getRebasePlanView(), title, message, false, false, true, handleConfirm, gui.focusRebasePlan)
+}
+
+// createRebasePlanErrorPanel is createErrorPanel for errors raised over the
+// plan, which we want to get back to once the error's been read
+func (gui *Gui) createRebasePlanErrorPanel(message string) error {
+	coloredMessage := color.New(theme.CurrentPalette.Conflict).Sprint(strings.TrimSpace(message))
+	return gui.createPopupPanel(gui.g, gui.getRebasePlanView(), gui.Tr.SLocalize("Error"), coloredMessage, false, false, false, gui.focusRebasePlan, gui.focusRebasePlan)
+}
+
+// focusRebasePlan gives focus back to the plan once a popup over it has closed.
+// Popups aren't meant to stack, so returnFocus would skip past the plan
+func (gui *Gui) focusRebasePlan(g *gocui.Gui, v *gocui.View) error {
+	gui.g.Update(func(g *gocui.Gui) error {
+		planView, err := g.View("rebasePlan")
+		if err != nil {
+			return nil
+		}
+		return gui.switchFocus(g, nil, planView)
+	})
+	return nil
+}
+
+func (gui *Gui) handleRebasePlanMoveStep(change int) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		selectedLine := &gui.State.Panels.RebasePlan.SelectedLine
+		if !gui.State.RebasePlan.MoveStep(*selectedLine, change) {
+			return nil
+		}
+		*selectedLine += change
+		return gui.renderRebasePlan()
+	}
+}
+
+func (gui *Gui) handleStartRebasePlan(g *gocui.Gui, v *gocui.View) error {
+	plan := gui.State.RebasePlan
+	if err := gui.GitCommand.ValidateRebasePlan(plan); err != nil {
+		return gui.createRebasePlanErrorPanel(err.Error())
+	}
+
+	if err := gui.handleCloseRebasePlan(g, v); err != nil {
+		return err
+	}
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+		err := gui.GitCommand.RunRebasePlan(plan)
+		return gui.handleGenericMergeCommandResult(err)
+	})
+}
+
+func (gui *Gui) handleCloseRebasePlan(g *gocui.Gui, v *gocui.View) error {
+	gui.State.RebasePlan = nil
+	if err := gui.returnFocus(g, v); err != nil {
+		return err
+	}
+	return g.DeleteView("rebasePlan")
+}
@@ -0,0 +1,81 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// handleCreateRebaseStepMenu offers to run a command after the selected commit
+// (or after each commit from there up to HEAD), or, mid-rebase, to add an exec
+// or break line to the todo after the selected todo item
+func (gui *Gui) handleCreateRebaseStepMenu(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedCommit(g)
+	if commit == nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoCommitsThisBranch"))
+	}
+	index := gui.selectedCommitIndex()
+
+	var menuItems []*menuItem
+	if commit.Status == "rebasing" {
+		insert := func(line string) error {
+			if err := gui.GitCommand.InsertRebaseTodoAfter(index, line); err != nil {
+				return gui.createErrorPanel(gui.g, err.Error())
+			}
+			return gui.refreshCommits(gui.g)
+		}
+
+		menuItems = []*menuItem{
+			{
+				displayString: gui.Tr.SLocalize("InsertExecAfterTodo"),
+				onPress: func() error {
+					return gui.promptForRebaseExec(func(command string) error {
+						return insert("exec " + command)
+					})
+				},
+			},
+			{
+				displayString: gui.Tr.SLocalize("InsertBreakAfterTodo"),
+				onPress: func() error {
+					return insert("break")
+				},
+			},
+		}
+	} else {
+		if gui.State.WorkingTreeState != "normal" {
+			return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CantRebaseWithExecMidRebase"))
+		}
+
+		rebaseWithExec := func(afterEach bool) func() error {
+			return func() error {
+				return gui.promptForRebaseExec(func(command string) error {
+					return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+						err := gui.GitCommand.RebaseWithExec(gui.State.Commits, index, command, afterEach)
+						return gui.handleGenericMergeCommandResult(err)
+					})
+				})
+			}
+		}
+
+		menuItems = []*menuItem{
+			{
+				displayString: gui.Tr.SLocalize("ExecAfterCommit"),
+				onPress:       rebaseWithExec(false),
+			},
+			{
+				displayString: gui.Tr.SLocalize("ExecAfterEachCommit"),
+				onPress:       rebaseWithExec(true),
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("RebaseStepsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) promptForRebaseExec(f func(command string) error) error {
+	return gui.createPromptPanel(gui.g, gui.getCommitsView(), gui.Tr.SLocalize("RebaseExecPrompt"), "", func(g *gocui.Gui, v *gocui.View) error {
+		command := gui.trimmedContent(v)
+		if command == "" {
+			return nil
+		}
+		return f(command)
+	})
+}
@@ -24,15 +24,7 @@ func (gui *Gui) handleCreateRecentReposMenu(g *gocui.Gui, v *gocui.View) error {
 				yellow.Sprint(innerPath),
 			},
 			onPress: func() error {
-				if err := os.Chdir(innerPath); err != nil {
-					return err
-				}
-				newGitCommand, err := commands.NewGitCommand(gui.Log, gui.OSCommand, gui.Tr, gui.Config)
-				if err != nil {
-					return err
-				}
-				gui.GitCommand = newGitCommand
-				return gui.Errors.ErrSwitchRepo
+				return gui.switchToRepo(innerPath)
 			},
 		}
 	}
@@ -40,9 +32,39 @@ func (gui *Gui) handleCreateRecentReposMenu(g *gocui.Gui, v *gocui.View) error {
 	return gui.createMenu(gui.Tr.SLocalize("RecentRepos"), menuItems, createMenuOptions{showCancel: true})
 }
 
+// switchToRepo changes directory to the given repo and returns the error that
+// gets our main loop to restart in it
+func (gui *Gui) switchToRepo(path string) error {
+	if err := os.Chdir(path); err != nil {
+		return err
+	}
+	newGitCommand, err := commands.NewGitCommand(gui.Log, gui.OSCommand, gui.Tr, gui.Config)
+	if err != nil {
+		return err
+	}
+	gui.GitCommand = newGitCommand
+
+	// the new repo may have its own conditional config
+	repoContext, err := newGitCommand.GetRepoContext()
+	if err != nil {
+		return err
+	}
+	if err := gui.Config.SetRepoContext(repoContext); err != nil {
+		return err
+	}
+
+	return gui.Errors.ErrSwitchRepo
+}
+
 // updateRecentRepoList registers the fact that we opened lazygit in this repo,
 // so that we can open the same repo via the 'recent repos' menu
 func (gui *Gui) updateRecentRepoList() error {
+	// the tutorial, sandbox and review worktree repos are deleted when we're
+	// done with them
+	if gui.State.Tutorial != nil || gui.State.Sandbox != nil || gui.inReviewWorktree() {
+		return nil
+	}
+
 	recentRepos := gui.Config.GetAppState().RecentRepos
 	currentRepo, err := os.Getwd()
 	if err != nil {
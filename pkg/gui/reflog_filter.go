@@ -0,0 +1,66 @@
+package gui
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// visibleReflogCommits returns the reflog entries that get through the reflog
+// filter, with runs of rebase steps collapsed unless they've been expanded
+func (gui *Gui) visibleReflogCommits() []*commands.Commit {
+	commits := gui.State.ReflogFilter.Filter(gui.State.ReflogCommits)
+	return commands.GroupReflogCommits(commits, gui.State.ExpandedReflogGroups)
+}
+
+// getReflogTabTitle shows the reflog filter in the reflog's tab, so that it's
+// obvious why entries are missing
+func (gui *Gui) getReflogTabTitle() string {
+	if gui.State.ReflogFilter.IsEmpty() {
+		return gui.Tr.SLocalize("ReflogTitle")
+	}
+
+	return gui.Tr.TemplateLocalize("FilteredReflogTitle", Teml{
+		"filter": gui.State.ReflogFilterText,
+		"count":  strconv.Itoa(len(gui.State.ReflogFilter.Filter(gui.State.ReflogCommits))),
+		"total":  strconv.Itoa(len(gui.State.ReflogCommits)),
+	})
+}
+
+func (gui *Gui) handleFilterReflog(g *gocui.Gui, v *gocui.View) error {
+	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("FilterReflogPrompt"), gui.State.ReflogFilterText, func(g *gocui.Gui, promptView *gocui.View) error {
+		text := gui.trimmedContent(promptView)
+		reflogFilter, err := commands.NewReflogFilter(text, time.Now())
+		if err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+
+		gui.State.ReflogFilter = reflogFilter
+		gui.State.ReflogFilterText = text
+		gui.State.Panels.ReflogCommits.SelectedLine = 0
+		return gui.renderReflogCommitsWithSelection()
+	})
+}
+
+// handleToggleReflogGroup expands a collapsed run of rebase steps, or collapses
+// it again when pressed on the run's newest entry
+func (gui *Gui) handleToggleReflogGroup(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedReflogCommit()
+	if commit == nil {
+		return nil
+	}
+
+	if gui.State.ExpandedReflogGroups[commit.Sha] {
+		delete(gui.State.ExpandedReflogGroups, commit.Sha)
+	} else if commit.StepCount > 0 {
+		if gui.State.ExpandedReflogGroups == nil {
+			gui.State.ExpandedReflogGroups = map[string]bool{}
+		}
+		gui.State.ExpandedReflogGroups[commit.Sha] = true
+	} else {
+		return nil
+	}
+	return gui.renderReflogCommitsWithSelection()
+}
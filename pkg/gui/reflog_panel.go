@@ -10,11 +10,12 @@ import (
 
 func (gui *Gui) getSelectedReflogCommit() *commands.Commit {
 	selectedLine := gui.State.Panels.ReflogCommits.SelectedLine
-	if selectedLine == -1 || len(gui.State.ReflogCommits) == 0 {
+	commits := gui.visibleReflogCommits()
+	if selectedLine == -1 || len(commits) == 0 {
 		return nil
 	}
 
-	return gui.State.ReflogCommits[selectedLine]
+	return commits[selectedLine]
 }
 
 func (gui *Gui) handleReflogCommitSelect(g *gocui.Gui, v *gocui.View) error {
@@ -34,12 +35,13 @@ func (gui *Gui) handleReflogCommitSelect(g *gocui.Gui, v *gocui.View) error {
 	if commit == nil {
 		return gui.newStringTask("main", "No reflog history")
 	}
-	v.FocusPoint(0, gui.State.Panels.ReflogCommits.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.ReflogCommits.SelectedLine)
 
+	width, _ := gui.getMainView().Size()
 	cmd := gui.OSCommand.ExecutableFromString(
-		gui.GitCommand.ShowCmdStr(commit.Sha),
+		gui.GitCommand.ShowCmdStr(commit.Sha, commands.PagingContextReflog, width),
 	)
-	if err := gui.newPtyTask("main", cmd); err != nil {
+	if err := gui.newPtyTask("main", cmd, commands.PagingContextReflog); err != nil {
 		gui.Log.Error(err)
 	}
 
@@ -52,20 +54,25 @@ func (gui *Gui) refreshReflogCommits() error {
 		return gui.createErrorPanel(gui.g, err.Error())
 	}
 
-	gui.State.ReflogCommits = commits
-
-	if gui.getCommitsView().Context == "reflog-commits" {
-		return gui.renderReflogCommitsWithSelection()
-	}
+	gui.g.Update(func(g *gocui.Gui) error {
+		gui.State.ReflogCommits = commits
+		gui.markContextLoaded("reflog-commits")
 
+		if gui.getCommitsView().Context == "reflog-commits" {
+			return gui.renderReflogCommitsWithSelection()
+		}
+		return nil
+	})
 	return nil
 }
 
 func (gui *Gui) renderReflogCommitsWithSelection() error {
 	commitsView := gui.getCommitsView()
 
-	gui.refreshSelectedLine(&gui.State.Panels.ReflogCommits.SelectedLine, len(gui.State.ReflogCommits))
-	displayStrings := presentation.GetCommitListDisplayStrings(gui.State.ReflogCommits, gui.State.ScreenMode != SCREEN_NORMAL)
+	commits := gui.visibleReflogCommits()
+	gui.refreshSelectedLine(&gui.State.Panels.ReflogCommits.SelectedLine, len(commits))
+	commitsView.Tabs[1] = gui.getReflogTabTitle()
+	displayStrings := presentation.GetCommitListDisplayStrings(commits, gui.State.ScreenMode != SCREEN_NORMAL, false, false)
 	gui.renderDisplayStrings(commitsView, displayStrings)
 	if gui.g.CurrentView() == commitsView && commitsView.Context == "reflog-commits" {
 		if err := gui.handleReflogCommitSelect(gui.g, commitsView); err != nil {
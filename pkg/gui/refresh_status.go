@@ -0,0 +1,117 @@
+package gui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// refreshTickInterval is how often we redraw so that the 'last refreshed'
+// times in the panel titles don't go stale while nothing else is happening
+const refreshTickInterval = time.Second * 5
+
+// refreshTimes records when each side panel's model was last loaded and
+// whether a fetch is in flight, so that the user can tell how fresh what
+// they're looking at is
+type refreshTimes struct {
+	mutex         sync.Mutex
+	lastRefreshed map[string]time.Time
+	fetching      bool
+}
+
+func newRefreshTimes() *refreshTimes {
+	return &refreshTimes{lastRefreshed: map[string]time.Time{}}
+}
+
+// recordRefresh notes that the given side view's model has just been loaded
+func (gui *Gui) recordRefresh(viewName string) {
+	times := gui.refreshTimes
+	times.mutex.Lock()
+	times.lastRefreshed[viewName] = time.Now()
+	times.mutex.Unlock()
+}
+
+func (gui *Gui) setFetching(fetching bool) {
+	times := gui.refreshTimes
+	times.mutex.Lock()
+	times.fetching = fetching
+	times.mutex.Unlock()
+}
+
+func (gui *Gui) showLastRefreshed() bool {
+	return gui.Config.GetUserConfig().GetBool("gui.showLastRefreshed")
+}
+
+// formatSince gives a duration the way we show it in a panel's title i.e. in
+// whole seconds, minutes or hours
+func formatSince(duration time.Duration) string {
+	switch {
+	case duration < time.Minute:
+		return fmt.Sprintf("%ds", int(duration.Seconds()))
+	case duration < time.Hour:
+		return fmt.Sprintf("%dm", int(duration.Minutes()))
+	default:
+		return fmt.Sprintf("%dh", int(duration.Hours()))
+	}
+}
+
+// renderLastRefreshed puts how long ago each side panel was refreshed in its
+// subtitle, and says so in the branches panel while we're fetching
+func (gui *Gui) renderLastRefreshed() {
+	if !gui.showLastRefreshed() {
+		return
+	}
+
+	times := gui.refreshTimes
+	times.mutex.Lock()
+	defer times.mutex.Unlock()
+
+	for _, viewName := range []string{"files", "branches", "commits", "stash"} {
+		view, err := gui.g.View(viewName)
+		if err != nil {
+			continue
+		}
+
+		subtitle := ""
+		if refreshedAt, ok := times.lastRefreshed[viewName]; ok {
+			subtitle = gui.Tr.TemplateLocalize("RefreshedAgo", Teml{"duration": formatSince(time.Since(refreshedAt))})
+		}
+		if viewName == "branches" && times.fetching {
+			subtitle = gui.Tr.SLocalize("FetchingStatus")
+		}
+		view.Subtitle = subtitle
+	}
+}
+
+// startRefreshTicker redraws every so often so that the times rendered by
+// renderLastRefreshed keep counting up
+func (gui *Gui) startRefreshTicker() {
+	if !gui.showLastRefreshed() {
+		return
+	}
+
+	gui.goEvery(gui.getPollInterval(refreshTickInterval), gui.stopChan, func() error {
+		gui.g.Update(func(*gocui.Gui) error { return nil })
+		return nil
+	})
+}
+
+// handleForceRefresh reloads everything, including what we'd normally hold on
+// to, for when some other tool has changed the repo behind our back in a way
+// that a regular refresh wouldn't pick up
+func (gui *Gui) handleForceRefresh(g *gocui.Gui, v *gocui.View) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("RefreshingStatus"), func() error {
+		// git trusts the modification times it has cached in the index, which
+		// some tools preserve when they rewrite files
+		if err := gui.GitCommand.RefreshIndex(); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+
+		gui.State.CommitStats = nil
+		gui.refreshSigningStatus()
+
+		return gui.refreshSidePanels(gui.g)
+	})
+}
@@ -38,7 +38,7 @@ func (gui *Gui) handleRemoteBranchSelect(g *gocui.Gui, v *gocui.View) error {
 		return gui.newStringTask("main", "No branches for this remote")
 	}
 
-	v.FocusPoint(0, gui.State.Panels.RemoteBranches.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.RemoteBranches.SelectedLine)
 
 	branchName := fmt.Sprintf("%s/%s", remote.Name, remoteBranch.Name)
 
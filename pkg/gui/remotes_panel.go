@@ -39,7 +39,7 @@ func (gui *Gui) handleRemoteSelect(g *gocui.Gui, v *gocui.View) error {
 	if remote == nil {
 		return gui.newStringTask("main", "No remotes")
 	}
-	v.FocusPoint(0, gui.State.Panels.Remotes.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.Remotes.SelectedLine)
 
 	return gui.newStringTask("main", fmt.Sprintf("%s\nUrls:\n%s", utils.ColoredString(remote.Name, color.FgGreen), strings.Join(remote.Urls, "\n")))
 }
@@ -52,26 +52,30 @@ func (gui *Gui) refreshRemotes() error {
 		return gui.createErrorPanel(gui.g, err.Error())
 	}
 
-	gui.State.Remotes = remotes
-
-	// we need to ensure our selected remote branches aren't now outdated
-	if prevSelectedRemote != nil && gui.State.RemoteBranches != nil {
-		// find remote now
-		for _, remote := range remotes {
-			if remote.Name == prevSelectedRemote.Name {
-				gui.State.RemoteBranches = remote.Branches
+	gui.g.Update(func(g *gocui.Gui) error {
+		gui.State.Remotes = remotes
+		gui.markContextLoaded("remotes")
+
+		// we need to ensure our selected remote branches aren't now outdated
+		if prevSelectedRemote != nil && gui.State.RemoteBranches != nil {
+			// find remote now
+			for _, remote := range remotes {
+				if remote.Name == prevSelectedRemote.Name {
+					gui.State.RemoteBranches = remote.Branches
+				}
 			}
 		}
-	}
 
-	// TODO: see if this works for deleting remote branches
-	switch gui.getBranchesView().Context {
-	case "remotes":
-		return gui.renderRemotesWithSelection()
-	case "remote-branches":
-		return gui.renderRemoteBranchesWithSelection()
-	}
+		// TODO: see if this works for deleting remote branches
+		switch gui.getBranchesView().Context {
+		case "remotes":
+			return gui.renderRemotesWithSelection()
+		case "remote-branches":
+			return gui.renderRemoteBranchesWithSelection()
+		}
 
+		return nil
+	})
 	return nil
 }
 
@@ -185,11 +189,23 @@ func (gui *Gui) handleFetchRemote(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	return gui.WithWaitingStatus(gui.Tr.SLocalize("FetchingRemoteStatus"), func() error {
-		if err := gui.GitCommand.FetchRemote(remote.Name); err != nil {
+	description := gui.Tr.TemplateLocalize("FetchRemoteOperation", Teml{"remote": remote.Name})
+	fetchRemote := func() error {
+		if err := gui.withNetworkRetries(description, func() error {
+			return gui.GitCommand.FetchRemote(remote.Name)
+		}); err != nil {
 			return err
 		}
 
 		return gui.refreshRemotes()
-	})
+	}
+	if gui.queueIfOffline(description, func() {
+		if err := fetchRemote(); err != nil {
+			_ = gui.createErrorPanel(gui.g, err.Error())
+		}
+	}) {
+		return nil
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("FetchingRemoteStatus"), fetchRemote)
 }
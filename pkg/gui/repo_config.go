@@ -0,0 +1,169 @@
+package gui
+
+import (
+	"os"
+	"sort"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/config"
+)
+
+// A repo's own config (see config.RepoConfigFileName) can add project commands
+// that run on the user's machine, so we don't load it until the user has said
+// they trust it. We ask once per session when we open a repo whose config file
+// is new to us or has changed since it was trusted. From the status panel the
+// user can trust or revoke trust in the repo's config, say never to load it,
+// and revoke what they've said about other repos' configs.
+
+func (gui *Gui) shouldPromptRepoConfigTrust() bool {
+	path := gui.Config.GetRepoConfigPath()
+	if gui.State.RepoConfigsAskedAbout[path] || !repoConfigExists(path) {
+		return false
+	}
+	trust := gui.Config.GetRepoConfigTrust()
+	return trust == config.RepoConfigUnknown || trust == config.RepoConfigChanged
+}
+
+func repoConfigExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// promptRepoConfigTrust is a startup popup task for repos with their own config
+func (gui *Gui) promptRepoConfigTrust(done chan struct{}) error {
+	path := gui.Config.GetRepoConfigPath()
+	if gui.State.RepoConfigsAskedAbout == nil {
+		gui.State.RepoConfigsAskedAbout = map[string]bool{}
+	}
+	gui.State.RepoConfigsAskedAbout[path] = true
+
+	onConfirm := func(g *gocui.Gui, v *gocui.View) error {
+		done <- struct{}{}
+		return gui.trustRepoConfig()
+	}
+	onClose := func(g *gocui.Gui, v *gocui.View) error {
+		done <- struct{}{}
+		return nil
+	}
+
+	promptID := "TrustRepoConfigPrompt"
+	if gui.Config.GetRepoConfigTrust() == config.RepoConfigChanged {
+		promptID = "TrustChangedRepoConfigPrompt"
+	}
+	prompt := gui.Tr.TemplateLocalize(promptID, Teml{
+		"path":     path,
+		"trustKey": gui.getKeyDisplay("status.viewRepoConfigTrust"),
+	})
+	return gui.createConfirmationPanel(gui.g, nil, true, gui.Tr.SLocalize("TrustRepoConfigTitle"), prompt, onConfirm, onClose)
+}
+
+func (gui *Gui) trustRepoConfig() error {
+	if err := gui.Config.TrustRepoConfig(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	gui.reloadUserConfigAfterPopup()
+	return nil
+}
+
+// reloadUserConfigAfterPopup reloads the config to pick up a change in which
+// repo config is loaded. Reloading resets the keybindings, which would clobber
+// the popup's own, so it waits until the popup is closed
+func (gui *Gui) reloadUserConfigAfterPopup() {
+	gui.g.Update(func(*gocui.Gui) error {
+		return gui.reloadUserConfig()
+	})
+}
+
+func (gui *Gui) handleCreateRepoConfigTrustMenu(g *gocui.Gui, v *gocui.View) error {
+	repoConfigPath := gui.Config.GetRepoConfigPath()
+	menuItems := []*menuItem{}
+
+	if repoConfigExists(repoConfigPath) {
+		trust := gui.Config.GetRepoConfigTrust()
+		status := gui.repoConfigTrustName(trust)
+		if trust == config.RepoConfigTrusted {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{repoConfigPath, status, gui.Tr.SLocalize("RevokeRepoConfigTrust")},
+				onPress: func() error {
+					return gui.forgetRepoConfig(repoConfigPath)
+				},
+			})
+		} else {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{repoConfigPath, status, gui.Tr.SLocalize("TrustRepoConfig")},
+				onPress:        gui.trustRepoConfig,
+			})
+		}
+		if trust != config.RepoConfigDenied {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{repoConfigPath, "", gui.Tr.SLocalize("DenyRepoConfig")},
+				onPress: func() error {
+					if err := gui.Config.DenyRepoConfig(); err != nil {
+						return gui.createErrorPanel(gui.g, err.Error())
+					}
+					if trust == config.RepoConfigTrusted {
+						gui.reloadUserConfigAfterPopup()
+					}
+					return nil
+				},
+			})
+		}
+	}
+
+	// the other repos' configs the user has said something about
+	appState := gui.Config.GetAppState()
+	paths := []string{}
+	for path := range appState.TrustedRepoConfigHashes {
+		paths = append(paths, path)
+	}
+	paths = append(paths, appState.DeniedRepoConfigs...)
+	sort.Strings(paths)
+	for _, path := range paths {
+		if path == repoConfigPath {
+			continue
+		}
+		path := path
+		status := gui.Tr.SLocalize("RepoConfigTrusted")
+		if _, ok := appState.TrustedRepoConfigHashes[path]; !ok {
+			status = gui.Tr.SLocalize("RepoConfigDenied")
+		}
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{path, status, gui.Tr.SLocalize("ForgetRepoConfig")},
+			onPress: func() error {
+				return gui.forgetRepoConfig(path)
+			},
+		})
+	}
+
+	if len(menuItems) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoRepoConfigs"))
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("RepoConfigTrustTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// forgetRepoConfig revokes whatever the user said about a repo config file,
+// unloading it if it's the current repo's
+func (gui *Gui) forgetRepoConfig(path string) error {
+	wasLoaded := path == gui.Config.GetRepoConfigPath() && gui.Config.GetRepoConfigTrust() == config.RepoConfigTrusted
+	if err := gui.Config.ForgetRepoConfig(path); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if wasLoaded {
+		gui.reloadUserConfigAfterPopup()
+	}
+	return nil
+}
+
+func (gui *Gui) repoConfigTrustName(trust config.RepoConfigTrust) string {
+	switch trust {
+	case config.RepoConfigTrusted:
+		return gui.Tr.SLocalize("RepoConfigTrusted")
+	case config.RepoConfigChanged:
+		return gui.Tr.SLocalize("RepoConfigChanged")
+	case config.RepoConfigDenied:
+		return gui.Tr.SLocalize("RepoConfigDenied")
+	default:
+		return gui.Tr.SLocalize("RepoConfigNotTrusted")
+	}
+}
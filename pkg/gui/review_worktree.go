@@ -0,0 +1,139 @@
+package gui
+
+import (
+	"os"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// A review worktree is a throwaway worktree with a branch checked out in it,
+// so that the user can look a branch over without disturbing their own working
+// tree. They can review it in lazygit, which switches over to the worktree, or
+// in their editor. Either way the worktree is deleted when they're done or
+// when they quit.
+
+type reviewWorktreeState struct {
+	Worktree *commands.ReviewWorktree
+	// RepoDir is the directory of the repo the worktree belongs to
+	RepoDir string
+}
+
+func (gui *Gui) handleReviewLocalBranch(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.ReviewWorktree != nil {
+		return gui.createLeaveReviewWorktreeMenu()
+	}
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	return gui.createReviewWorktreeMenu(branch.Name)
+}
+
+func (gui *Gui) handleReviewRemoteBranch(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.ReviewWorktree != nil {
+		return gui.createLeaveReviewWorktreeMenu()
+	}
+	remoteBranch := gui.getSelectedRemoteBranch()
+	if remoteBranch == nil {
+		return nil
+	}
+	return gui.createReviewWorktreeMenu(remoteBranch.RemoteName + "/" + remoteBranch.Name)
+}
+
+func (gui *Gui) createReviewWorktreeMenu(ref string) error {
+	if gui.State.Tutorial != nil || gui.State.Sandbox != nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("ReviewWorktreeUnavailable"))
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("ReviewInLazygit"),
+			onPress: func() error {
+				worktree, err := gui.createReviewWorktree(ref)
+				if err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				return gui.switchToRepo(worktree.Path)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("ReviewInEditor"),
+			onPress: func() error {
+				worktree, err := gui.createReviewWorktree(ref)
+				if err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				// plenty of editors return straight away, so we hold on to the
+				// worktree until the user says they're done with it
+				return gui.editFile(worktree.Path)
+			},
+		},
+	}
+
+	title := gui.Tr.TemplateLocalize("ReviewWorktreeTitle", Teml{"ref": ref})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) createReviewWorktree(ref string) (*commands.ReviewWorktree, error) {
+	repoDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	worktree, err := gui.GitCommand.CreateReviewWorktree(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	gui.State.ReviewWorktree = &reviewWorktreeState{
+		Worktree: worktree,
+		RepoDir:  repoDir,
+	}
+	return worktree, nil
+}
+
+func (gui *Gui) createLeaveReviewWorktreeMenu() error {
+	title := gui.Tr.SLocalize("LeaveReviewWorktree")
+	prompt := gui.Tr.TemplateLocalize("LeaveReviewWorktreePrompt", Teml{"ref": gui.State.ReviewWorktree.Worktree.Ref})
+	return gui.createConfirmationPanel(gui.g, gui.g.CurrentView(), true, title, prompt, func(*gocui.Gui, *gocui.View) error {
+		inWorktree := gui.inReviewWorktree()
+		repoDir := gui.State.ReviewWorktree.RepoDir
+		if err := gui.cleanUpReviewWorktree(); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		if inWorktree {
+			return gui.switchToRepo(repoDir)
+		}
+		return gui.refreshSidePanels(gui.g)
+	}, nil)
+}
+
+// inReviewWorktree tells us whether we've switched over to the review worktree
+// as opposed to the user reviewing it in their editor
+func (gui *Gui) inReviewWorktree() bool {
+	if gui.State.ReviewWorktree == nil {
+		return false
+	}
+	dir, err := os.Getwd()
+	return err == nil && dir == gui.State.ReviewWorktree.Worktree.Path
+}
+
+// cleanUpReviewWorktree takes us back to the repo and deletes the worktree
+func (gui *Gui) cleanUpReviewWorktree() error {
+	if gui.State.ReviewWorktree == nil {
+		return nil
+	}
+
+	state := gui.State.ReviewWorktree
+	gui.State.ReviewWorktree = nil
+
+	if err := os.Chdir(state.RepoDir); err != nil {
+		return err
+	}
+	gitCommand, err := commands.NewGitCommand(gui.Log, gui.OSCommand, gui.Tr, gui.Config)
+	if err != nil {
+		return err
+	}
+	return gitCommand.RemoveReviewWorktree(state.Worktree)
+}
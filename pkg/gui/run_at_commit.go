@@ -0,0 +1,120 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// Running a command at a commit checks the commit out into a temporary
+// worktree and runs the command there, e.g. to see whether the tests passed
+// back then, without touching the user's own working tree. The output is
+// streamed into the customCommandOutput popup and the worktree is deleted once
+// the command is done.
+
+func (gui *Gui) handleRunCommandAtCommit(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedCommit(g)
+	if commit == nil {
+		return nil
+	}
+
+	sha := commit.ShortSha()
+	title := gui.Tr.TemplateLocalize("RunAtCommitTitle", Teml{"sha": sha})
+	promptTitle := gui.Tr.TemplateLocalize("RunAtCommitPrompt", Teml{"sha": sha})
+	return gui.pickCommand(v, title, promptTitle, func(command string) error {
+		return gui.runCommandAtCommit(commit, command)
+	})
+}
+
+// pickCommand asks which command to run, offering the project commands before
+// prompting for one
+func (gui *Gui) pickCommand(v *gocui.View, title string, promptTitle string, onPick func(command string) error) error {
+	projectCommands := gui.OSCommand.GetProjectCommands()
+	if len(projectCommands) == 0 {
+		return gui.createCommandPrompt(v, promptTitle, onPick)
+	}
+
+	menuItems := make([]*menuItem, 0, len(projectCommands)+1)
+	for _, projectCommand := range projectCommands {
+		command := projectCommand.Command
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{projectCommand.Name, command},
+			onPress: func() error {
+				return onPick(command)
+			},
+		})
+	}
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{gui.Tr.SLocalize("RunAtCommitOtherCommand"), ""},
+		onPress: func() error {
+			// the menu closes once we return, so the prompt has to wait until then
+			gui.g.Update(func(*gocui.Gui) error {
+				return gui.createCommandPrompt(v, promptTitle, onPick)
+			})
+			return nil
+		},
+	})
+
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) createCommandPrompt(v *gocui.View, title string, onPick func(command string) error) error {
+	return gui.createPromptPanel(gui.g, v, title, "", func(g *gocui.Gui, v *gocui.View) error {
+		command := gui.trimmedContent(v)
+		if command == "" {
+			return nil
+		}
+		return onPick(command)
+	})
+}
+
+// runCommandAtCommit checks the commit out into a temporary worktree and
+// streams the command's output into the popup as it runs there
+func (gui *Gui) runCommandAtCommit(commit *commands.Commit, command string) error {
+	if gui.customCommandRunning() {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("CustomCommandAlreadyRunning"))
+	}
+	if err := gui.OSCommand.CheckReadOnly(command); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("CreatingWorktreeStatus"), func() error {
+		worktree, err := gui.GitCommand.CreateReviewWorktree(commit.Sha)
+		if err != nil {
+			return err
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			gui.State.RunAtCommitWorktrees = append(gui.State.RunAtCommitWorktrees, worktree)
+			title := gui.Tr.TemplateLocalize("RunAtCommitOutputTitle", Teml{"command": command, "sha": commit.ShortSha()})
+			return gui.streamCommand(title, gui.GitCommand.RunInWorktree(worktree, command), func(error) error {
+				if err := gui.removeRunAtCommitWorktree(worktree); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				return nil
+			})
+		})
+		return nil
+	})
+}
+
+func (gui *Gui) removeRunAtCommitWorktree(worktree *commands.ReviewWorktree) error {
+	worktrees := gui.State.RunAtCommitWorktrees
+	for i, w := range worktrees {
+		if w == worktree {
+			gui.State.RunAtCommitWorktrees = append(worktrees[:i:i], worktrees[i+1:]...)
+			return gui.GitCommand.RemoveReviewWorktree(worktree)
+		}
+	}
+	return nil
+}
+
+// cleanUpRunAtCommitWorktrees deletes the worktrees of commands still running
+// when we quit
+func (gui *Gui) cleanUpRunAtCommitWorktrees() error {
+	for len(gui.State.RunAtCommitWorktrees) > 0 {
+		if err := gui.removeRunAtCommitWorktree(gui.State.RunAtCommitWorktrees[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package gui
+
+import (
+	"os"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// The sandbox lets the user rehearse something risky like a rebase on a copy of
+// the repo. When they leave they can either throw the copy away or have the
+// current branch updated to match it.
+
+type sandboxState struct {
+	Sandbox *commands.Sandbox
+	// ReturnDir is the real repo's directory
+	ReturnDir string
+}
+
+func (gui *Gui) handleToggleSandbox(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.Sandbox != nil {
+		return gui.createLeaveSandboxMenu()
+	}
+
+	if gui.State.Tutorial != nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("SandboxUnavailableInTutorial"))
+	}
+
+	returnDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	sandbox, err := gui.GitCommand.CreateSandbox()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	gui.State.Sandbox = &sandboxState{
+		Sandbox:   sandbox,
+		ReturnDir: returnDir,
+	}
+
+	return gui.switchToRepo(sandbox.Path)
+}
+
+func (gui *Gui) createLeaveSandboxMenu() error {
+	branchName := gui.State.Sandbox.Sandbox.BranchName
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.TemplateLocalize("ApplySandbox", Teml{"branchName": branchName}),
+			onPress: func() error {
+				prompt := gui.Tr.TemplateLocalize("ApplySandboxPrompt", Teml{"branchName": branchName})
+				return gui.createConfirmationPanel(gui.g, gui.getStatusView(), true, gui.Tr.SLocalize("ApplySandbox"), prompt, func(*gocui.Gui, *gocui.View) error {
+					return gui.leaveSandbox(true)
+				}, nil)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("DiscardSandbox"),
+			onPress: func() error {
+				return gui.leaveSandbox(false)
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("LeaveSandbox"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) leaveSandbox(apply bool) error {
+	sandbox := gui.State.Sandbox.Sandbox
+	returnDir := gui.State.Sandbox.ReturnDir
+
+	if apply {
+		if err := os.Chdir(returnDir); err != nil {
+			return err
+		}
+		if err := gui.GitCommand.ApplySandbox(sandbox); err != nil {
+			// staying in the sandbox so that the user doesn't lose their work
+			if err := os.Chdir(sandbox.Path); err != nil {
+				return err
+			}
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+	}
+
+	if err := gui.cleanUpSandbox(); err != nil {
+		return err
+	}
+
+	return gui.switchToRepo(returnDir)
+}
+
+// cleanUpSandbox takes us back to the real repo and deletes the sandbox
+func (gui *Gui) cleanUpSandbox() error {
+	if gui.State.Sandbox == nil {
+		return nil
+	}
+
+	state := gui.State.Sandbox
+	gui.State.Sandbox = nil
+
+	if err := os.Chdir(state.ReturnDir); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(state.Sandbox.Path)
+}
@@ -21,6 +21,9 @@ func (gui *Gui) handleOpenSearch(g *gocui.Gui, v *gocui.View) error {
 
 func (gui *Gui) handleSearch(g *gocui.Gui, v *gocui.View) error {
 	gui.State.Searching.searchString = gui.getSearchView().Buffer()
+	if gui.State.Searching.view == gui.getMainView() {
+		return gui.searchMain(gui.State.Searching.searchString)
+	}
 	if err := gui.switchFocus(gui.g, nil, gui.State.Searching.view); err != nil {
 		return err
 	}
@@ -0,0 +1,57 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// runPreCommitChecks makes sure the user knows about anything in the staged
+// changes that they probably don't want to commit before going ahead
+func (gui *Gui) runPreCommitChecks(onConfirm func() error) error {
+	return gui.confirmLargeFiles(fileNames(gui.stagedFiles()), func() error {
+		return gui.confirmNoSecrets(onConfirm)
+	})
+}
+
+// confirmNoSecrets scans the staged changes for things like API keys and
+// passwords if the user has turned secret scanning on, and if it finds any
+// only goes ahead if the user says to
+func (gui *Gui) confirmNoSecrets(onConfirm func() error) error {
+	userConfig := gui.Config.GetUserConfig()
+	if !userConfig.GetBool("git.secretScanning.enabled") {
+		return onConfirm()
+	}
+
+	matches, err := gui.GitCommand.ScanStagedChanges()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	findings := []string{}
+	for _, match := range matches {
+		line := strings.Replace(match.Line, match.Match, utils.ColoredString(match.Match, theme.CurrentPalette.Removed), 1)
+		findings = append(findings, fmt.Sprintf("%s:%d (%s): %s", match.FileName, match.LineNumber, match.Rule, line))
+	}
+	if command := userConfig.GetString("git.secretScanning.command"); command != "" {
+		if output, found := gui.GitCommand.RunSecretScanner(command); found {
+			findings = append(findings, output)
+		}
+	}
+	if len(findings) == 0 {
+		return onConfirm()
+	}
+
+	prompt := gui.Tr.TemplateLocalize("PossibleSecretsPrompt", Teml{"findings": strings.Join(findings, "\n")})
+	return gui.createConfirmationPanel(gui.g, gui.g.CurrentView(), true, gui.Tr.SLocalize("PossibleSecretsTitle"), prompt, func(*gocui.Gui, *gocui.View) error {
+		// the confirmation closes once we return, so whatever comes next has
+		// to wait until then
+		gui.g.Update(func(*gocui.Gui) error {
+			return onConfirm()
+		})
+		return nil
+	}, nil)
+}
@@ -0,0 +1,89 @@
+package gui
+
+import (
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) handleCreateSnapshot(g *gocui.Gui, v *gocui.View) error {
+	name := time.Now().Format("2006-01-02-150405")
+	if branch := gui.getCheckedOutBranch(); branch != nil {
+		name = branch.Name + "-" + name
+	}
+
+	return gui.createPromptPanel(g, v, gui.Tr.SLocalize("SnapshotName"), name, func(g *gocui.Gui, promptView *gocui.View) error {
+		if err := gui.GitCommand.CreateSnapshot(gui.trimmedContent(promptView)); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		gui.raiseToast(gui.Tr.SLocalize("SnapshotCreated"))
+		return nil
+	})
+}
+
+func (gui *Gui) handleViewSnapshots(g *gocui.Gui, v *gocui.View) error {
+	snapshots, err := gui.GitCommand.GetSnapshots()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if len(snapshots) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoSnapshots"))
+	}
+
+	menuItems := make([]*menuItem, len(snapshots))
+	for i, snapshot := range snapshots {
+		snapshot := snapshot
+		changes := ""
+		if snapshot.WorktreeSha != "" {
+			changes = utils.ColoredString(gui.Tr.SLocalize("SnapshotHasChanges"), theme.CurrentPalette.Info)
+		}
+		menuItems[i] = &menuItem{
+			displayStrings: []string{snapshot.HeadSha[:8], snapshot.Name, changes},
+			onPress: func() error {
+				gui.g.Update(func(*gocui.Gui) error {
+					return gui.createSnapshotMenu(snapshot)
+				})
+				return nil
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("Snapshots"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) createSnapshotMenu(snapshot *commands.Snapshot) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("RestoreSnapshot"),
+			onPress: func() error {
+				prompt := gui.Tr.TemplateLocalize("RestoreSnapshotPrompt", Teml{"name": snapshot.Name})
+				return gui.createConfirmationPanel(gui.g, gui.getStatusView(), true, gui.Tr.SLocalize("RestoreSnapshot"), prompt, func(*gocui.Gui, *gocui.View) error {
+					if err := gui.GitCommand.RestoreSnapshot(snapshot); err != nil {
+						return gui.createErrorPanel(gui.g, err.Error())
+					}
+					return gui.refreshSidePanels(gui.g)
+				}, nil)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("DiffAgainstSnapshot"),
+			onPress: func() error {
+				return gui.startComparison(snapshot.Ref(), "HEAD")
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("DeleteSnapshot"),
+			onPress: func() error {
+				if err := gui.GitCommand.DeleteSnapshot(snapshot); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				return nil
+			},
+		},
+	}
+
+	return gui.createMenu(snapshot.Name, menuItems, createMenuOptions{showCancel: true})
+}
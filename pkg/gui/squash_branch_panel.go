@@ -0,0 +1,64 @@
+package gui
+
+import (
+	"strconv"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// handleSquashBranch squashes every commit on the checked out branch since it
+// diverged from its base branch into one, with a message made from theirs
+// that can be reworded afterwards
+func (gui *Gui) handleSquashBranch(g *gocui.Gui, v *gocui.View) error {
+	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
+		return err
+	}
+
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	if checkedOut := gui.getCheckedOutBranch(); checkedOut == nil || branch.Name != checkedOut.Name {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("SquashBranchNotCheckedOut"))
+	}
+	// anything staged would end up in the squashed commit
+	if len(gui.stagedFiles()) > 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("SquashBranchWithStagedChanges"))
+	}
+
+	baseBranch := gui.GitCommand.GetBaseBranch(branch.Name)
+	if baseBranch == "" {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoBaseBranch"))
+	}
+
+	return gui.confirmSquashBranch(v, branch.Name, baseBranch)
+}
+
+// confirmSquashBranch shows the message we'd squash the checked out branch's
+// commits since baseBranch with, and squashes them if the user's happy with it
+func (gui *Gui) confirmSquashBranch(v *gocui.View, branchName string, baseBranch string) error {
+	message, count, err := gui.GitCommand.GetBranchSquashMessage(baseBranch)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if count < 2 {
+		return gui.createErrorPanel(gui.g, gui.Tr.TemplateLocalize("NothingToSquash", Teml{"baseBranch": baseBranch}))
+	}
+
+	prompt := gui.Tr.TemplateLocalize("SureSquashBranch", Teml{
+		"count":      strconv.Itoa(count),
+		"branch":     branchName,
+		"baseBranch": baseBranch,
+		"message":    message,
+	})
+	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("SquashBranch"), prompt, func(g *gocui.Gui, v *gocui.View) error {
+		return gui.WithWaitingStatus(gui.Tr.SLocalize("SquashingStatus"), func() error {
+			ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.SquashBranch(baseBranch, message))
+			if !ok {
+				return err
+			}
+			gui.State.Panels.Commits.SelectedLine = 0
+			return gui.refreshSidePanels(gui.g)
+		})
+	}, nil)
+}
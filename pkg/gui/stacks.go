@@ -0,0 +1,166 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+func (gui *Gui) handleCreateStackMenu(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	checkedOutBranch := gui.getCheckedOutBranch()
+	stack := commands.GetStack(gui.State.Branches, branch.Name)
+
+	if err := gui.newStringTask("main", getStackDisplayString(stack)); err != nil {
+		return err
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.TemplateLocalize("NewStackedBranch", Teml{"branchName": branch.Name}),
+			onPress: func() error {
+				return gui.handleNewStackedBranch(branch.Name)
+			},
+		},
+	}
+
+	if checkedOutBranch.Name != branch.Name && checkedOutBranch.StackParent != branch.Name {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.TemplateLocalize("StackBranchOn", Teml{"branchName": checkedOutBranch.Name, "parentName": branch.Name}),
+			onPress: func() error {
+				if err := gui.GitCommand.SetStackParent(checkedOutBranch.Name, branch.Name); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				return gui.refreshSidePanels(gui.g)
+			},
+		})
+	}
+
+	if branch.StackParent != "" {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.TemplateLocalize("UnstackBranch", Teml{"branchName": branch.Name}),
+			onPress: func() error {
+				if err := gui.GitCommand.UnsetStackParent(branch.Name); err != nil {
+					return gui.createErrorPanel(gui.g, err.Error())
+				}
+				return gui.refreshSidePanels(gui.g)
+			},
+		})
+	}
+
+	if len(stack) > 1 {
+		menuItems = append(menuItems, []*menuItem{
+			{
+				displayString: gui.Tr.SLocalize("RestackBranches"),
+				onPress: func() error {
+					return gui.handleRestack(stack)
+				},
+			},
+			{
+				displayString: gui.Tr.SLocalize("PushStack"),
+				onPress: func() error {
+					return gui.pushStack(stack)
+				},
+			},
+		}...)
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("StackOptions"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleNewStackedBranch(parentName string) error {
+	title := gui.Tr.TemplateLocalize("NewBranchNameBranchOff", Teml{"branchName": parentName})
+	return gui.createPromptPanel(gui.g, gui.getBranchesView(), title, "", func(g *gocui.Gui, v *gocui.View) error {
+		name := gui.trimmedContent(v)
+		if err := gui.GitCommand.NewBranch(name, parentName); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		if err := gui.GitCommand.SetStackParent(name, parentName); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		gui.State.Panels.Branches.SelectedLine = 0
+		return gui.refreshSidePanels(gui.g)
+	})
+}
+
+// handleRestack moves every branch in the stack back on top of its parent,
+// which is what you want after amending or rebasing a branch lower down
+func (gui *Gui) handleRestack(stack []*commands.Branch) error {
+	checkedOutBranch := gui.getCheckedOutBranch().Name
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("RestackingStatus"), func() error {
+		err := gui.GitCommand.Restack(stack, checkedOutBranch)
+		return gui.handleGenericMergeCommandResult(err)
+	})
+}
+
+// pushStack force pushes every stacked branch, given that restacking rewrites
+// them all
+func (gui *Gui) pushStack(stack []*commands.Branch) error {
+	conf, err := gui.GitCommand.Repo.Config()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	v := gui.getBranchesView()
+	if err := gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("PushWait")); err != nil {
+		return err
+	}
+
+	go func() {
+		unamePassOpened := false
+		ask := func(passOrUname string) string {
+			unamePassOpened = true
+			return gui.waitForPassUname(gui.g, v, passOrUname)
+		}
+
+		var err error
+		for _, branch := range stack {
+			if branch.StackParent == "" {
+				// this is what the stack is built on, and it's not ours to push
+				continue
+			}
+
+			if branchConf, ok := conf.Branches[branch.Name]; ok && branchConf.Remote != "" {
+				err = gui.GitCommand.Push(branch.Name, true, "", fmt.Sprintf("%s %s", branchConf.Remote, branch.Name), ask)
+			} else {
+				err = gui.GitCommand.Push(branch.Name, true, "origin "+branch.Name, "", ask)
+			}
+			if err != nil {
+				break
+			}
+		}
+
+		gui.HandleCredentialsPopup(gui.g, unamePassOpened, err)
+	}()
+
+	return nil
+}
+
+// getStackDisplayString draws the stack as a tree, with each branch under its
+// parent
+func getStackDisplayString(stack []*commands.Branch) string {
+	if len(stack) == 0 {
+		return ""
+	}
+
+	lines := []string{}
+	var addChildren func(parentName string, indent string)
+	addChildren = func(parentName string, indent string) {
+		for _, branch := range stack {
+			if branch.StackParent == parentName {
+				lines = append(lines, indent+"└─ "+branch.Name)
+				addChildren(branch.Name, indent+"   ")
+			}
+		}
+	}
+
+	lines = append(lines, stack[0].Name)
+	addChildren(stack[0].Name, "")
+
+	return strings.Join(lines, "\n")
+}
@@ -4,7 +4,7 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/gocui"
-	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 )
 
 func (gui *Gui) refreshStagingPanel(forceSecondaryFocused bool, selectedLineIdx int) error {
@@ -96,9 +96,28 @@ func (gui *Gui) handleTogglePanel(g *gocui.Gui, v *gocui.View) error {
 func (gui *Gui) handleStagingEscape(g *gocui.Gui, v *gocui.View) error {
 	gui.handleEscapeLineByLinePanel()
 
+	if err := gui.removeIntentToAdd(); err != nil {
+		return err
+	}
+
 	return gui.switchFocus(gui.g, nil, gui.getFilesView())
 }
 
+// removeIntentToAdd undoes the `git add -N` from when we entered the staging
+// panel for an untracked file, if none of the file ended up being staged
+func (gui *Gui) removeIntentToAdd() error {
+	fileName := gui.State.IntentToAddFile
+	if fileName == "" {
+		return nil
+	}
+	gui.State.IntentToAddFile = ""
+
+	if err := gui.GitCommand.RemoveIntentToAdd(fileName); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	return gui.refreshFiles()
+}
+
 func (gui *Gui) handleToggleStagedSelection(g *gocui.Gui, v *gocui.View) error {
 	state := gui.State.Panels.LineByLine
 
@@ -130,9 +149,9 @@ func (gui *Gui) applySelection(reverse bool) error {
 		return err
 	}
 
-	patch := commands.ModifiedPatchForRange(gui.Log, file.Name, state.Diff, state.FirstLineIdx, state.LastLineIdx, reverse, false)
+	partialPatch := patch.ModifiedPatchForRange(gui.Log, file.Name, state.Diff, state.FirstLineIdx, state.LastLineIdx, reverse, false)
 
-	if patch == "" {
+	if partialPatch == "" {
 		return nil
 	}
 
@@ -142,7 +161,7 @@ func (gui *Gui) applySelection(reverse bool) error {
 	if !reverse || state.SecondaryFocused {
 		applyFlags = append(applyFlags, "cached")
 	}
-	err = gui.GitCommand.ApplyPatch(patch, applyFlags...)
+	err = gui.GitCommand.ApplyPatch(partialPatch, applyFlags...)
 	if err != nil {
 		return gui.createErrorPanel(gui.g, err.Error())
 	}
@@ -159,3 +178,44 @@ func (gui *Gui) applySelection(reverse bool) error {
 	}
 	return nil
 }
+
+// handleEditHunk opens the hunk under the cursor in the user's editor, for
+// when staging line by line isn't precise enough, e.g. to stage only part of a
+// changed line. Once the editor is closed we apply whatever is left.
+func (gui *Gui) handleEditHunk(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Panels.LineByLine
+
+	file, err := gui.getSelectedFile(gui.g)
+	if err != nil {
+		return err
+	}
+
+	hunk := state.PatchParser.GetHunkContainingLine(state.SelectedLineIdx, 0)
+	if hunk == nil {
+		return nil
+	}
+
+	hunkPatch := patch.ModifiedPatchForRange(gui.Log, file.Name, state.Diff, hunk.FirstLineIdx, hunk.LastLineIdx, false, false)
+	if hunkPatch == "" {
+		return nil
+	}
+
+	path, err := gui.GitCommand.CreateHunkEditFile(hunkPatch)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	sub, err := gui.OSCommand.EditFile(path)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	reverse := state.SecondaryFocused
+	gui.OnSubProcessDone = func() error {
+		_, err := gui.GitCommand.ApplyEditedHunk(path, reverse)
+		return err
+	}
+
+	_, err = gui.runSyncOrAsyncCommand(sub, nil)
+	return err
+}
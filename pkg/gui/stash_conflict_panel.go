@@ -0,0 +1,134 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// stashConflictState is the stash entry we were applying or popping when we
+// hit conflicts. Git doesn't keep track of this itself, so it's on us to
+// remember it until the user finishes or aborts.
+type stashConflictState struct {
+	Sha  string
+	Name string
+	Pop  bool
+}
+
+// handleStashConflict is for when applying or popping a stash entry has
+// failed. If it's because of conflicts we go into the 'unstashing' state,
+// otherwise we just show the error.
+func (gui *Gui) handleStashConflict(stashEntry *commands.StashEntry, stashSha string, pop bool, result error) error {
+	if err := gui.refreshStashEntries(gui.g); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if err := gui.refreshStateFiles(); err != nil {
+		return err
+	}
+	if !gui.anyFilesWithMergeConflicts() {
+		if err := gui.refreshFiles(); err != nil {
+			return err
+		}
+		return gui.createErrorPanel(gui.g, result.Error())
+	}
+
+	gui.State.StashConflict = &stashConflictState{
+		Sha:  stashSha,
+		Name: stashEntry.Name,
+		Pop:  pop,
+	}
+	if err := gui.refreshSidePanels(gui.g); err != nil {
+		return err
+	}
+	if err := gui.switchFocus(gui.g, gui.getStashView(), gui.getFilesView()); err != nil {
+		return err
+	}
+	return gui.handleCreateStashConflictMenu()
+}
+
+// handleCreateStashConflictMenu lists the files left with conflicts by the
+// stash, alongside the ways out of the 'unstashing' state
+func (gui *Gui) handleCreateStashConflictMenu() error {
+	stashConflict := gui.State.StashConflict
+	if stashConflict == nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NotUnstashing"))
+	}
+
+	menuItems := []*menuItem{}
+	for _, file := range gui.State.Files {
+		if !file.HasMergeConflicts {
+			continue
+		}
+		file := file
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{utils.ColoredString(file.ShortStatus, color.FgRed), file.Name},
+			onPress: func() error {
+				for i, visibleFile := range gui.visibleFiles() {
+					if visibleFile.Name == file.Name {
+						gui.State.Panels.Files.SelectedLine = i
+					}
+				}
+				return nil
+			},
+		})
+	}
+
+	finishKey := "FinishUnstashingKeepStash"
+	if stashConflict.Pop {
+		finishKey = "FinishUnstashingDropStash"
+	}
+	menuItems = append(menuItems,
+		&menuItem{
+			displayString: gui.Tr.SLocalize(finishKey),
+			onPress:       gui.finishStashConflict,
+		},
+		&menuItem{
+			displayString: gui.Tr.SLocalize("AbortUnstashing"),
+			onPress:       gui.abortStashConflict,
+		},
+	)
+
+	title := gui.Tr.TemplateLocalize("StashConflictTitle", Teml{"stashName": stashConflict.Name})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+// finishStashConflict is for once the user has resolved every conflict: it
+// unstages the stash's changes like a clean apply would, and drops the stash
+// entry if it was being popped
+func (gui *Gui) finishStashConflict() error {
+	if err := gui.refreshStateFiles(); err != nil {
+		return err
+	}
+	if gui.anyFilesWithMergeConflicts() {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("ResolveStashConflictsFirst"))
+	}
+
+	stashConflict := gui.State.StashConflict
+	err := gui.GitCommand.FinishStashApply(stashConflict.Sha, stashConflict.Pop)
+	return gui.endStashConflict(err)
+}
+
+func (gui *Gui) abortStashConflict() error {
+	err := gui.GitCommand.AbortStashApply(gui.State.StashConflict.Sha)
+	return gui.endStashConflict(err)
+}
+
+func (gui *Gui) endStashConflict(result error) error {
+	gui.State.StashConflict = nil
+	if err := gui.refreshSidePanels(gui.g); err != nil {
+		return err
+	}
+	if result != nil {
+		return gui.createErrorPanel(gui.g, result.Error())
+	}
+	return nil
+}
+
+// promptToFinishStashConflict asks the user if they want to finish applying
+// the stash now that there are no conflicts left
+func (gui *Gui) promptToFinishStashConflict() error {
+	return gui.createConfirmationPanel(gui.g, gui.getFilesView(), true, gui.Tr.SLocalize("FinishUnstashingTitle"), gui.Tr.SLocalize("StashConflictsResolved"), func(g *gocui.Gui, v *gocui.View) error {
+		return gui.finishStashConflict()
+	}, nil)
+}
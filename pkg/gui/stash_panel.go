@@ -34,12 +34,18 @@ func (gui *Gui) handleStashEntrySelect(g *gocui.Gui, v *gocui.View) error {
 	if stashEntry == nil {
 		return gui.newStringTask("main", gui.Tr.SLocalize("NoStashEntries"))
 	}
-	v.FocusPoint(0, gui.State.Panels.Stash.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.Stash.SelectedLine)
+
+	cmdStr := gui.GitCommand.ShowStashEntryCmdStr(stashEntry.Index)
+	if gui.State.Panels.Stash.ShowUntrackedFor == stashEntry.Index {
+		gui.getMainView().Title = gui.Tr.SLocalize("StashUntrackedFilesTitle")
+		cmdStr = gui.GitCommand.ShowStashUntrackedFilesCmdStr(stashEntry.Index)
+	} else {
+		gui.State.Panels.Stash.ShowUntrackedFor = -1
+	}
 
-	cmd := gui.OSCommand.ExecutableFromString(
-		gui.GitCommand.ShowStashEntryCmdStr(stashEntry.Index),
-	)
-	if err := gui.newPtyTask("main", cmd); err != nil {
+	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
+	if err := gui.newPtyTask("main", cmd, commands.PagingContextStash); err != nil {
 		gui.Log.Error(err)
 	}
 
@@ -47,8 +53,12 @@ func (gui *Gui) handleStashEntrySelect(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) refreshStashEntries(g *gocui.Gui) error {
+	gui.recordRefsFingerprint()
+	stashEntries := gui.GitCommand.GetStashEntries()
+	gui.recordRefresh("stash")
+
 	g.Update(func(g *gocui.Gui) error {
-		gui.State.StashEntries = gui.GitCommand.GetStashEntries()
+		gui.State.StashEntries = stashEntries
 
 		gui.refreshSelectedLine(&gui.State.Panels.Stash.SelectedLine, len(gui.State.StashEntries))
 
@@ -94,7 +104,16 @@ func (gui *Gui) stashDo(g *gocui.Gui, v *gocui.View, method string) error {
 		)
 		return gui.createErrorPanel(g, errorMessage)
 	}
+	// the stash entry's index can change by the time any conflicts are
+	// resolved, so we hold onto its sha instead
+	stashSha := ""
+	if method == "apply" || method == "pop" {
+		stashSha, _ = gui.GitCommand.GetStashSha(stashEntry.Index)
+	}
 	if err := gui.GitCommand.StashDo(stashEntry.Index, method); err != nil {
+		if stashSha != "" {
+			return gui.handleStashConflict(stashEntry, stashSha, method == "pop", err)
+		}
 		return gui.createErrorPanel(g, err.Error())
 	}
 	if err := gui.refreshStashEntries(g); err != nil {
@@ -122,3 +141,105 @@ func (gui *Gui) onStashPanelSearchSelect(selectedLine int) error {
 	gui.State.Panels.Stash.SelectedLine = selectedLine
 	return gui.handleStashEntrySelect(gui.g, gui.getStashView())
 }
+
+// handleCreateStashFilesMenu lists the files in a stash entry so that they can
+// be applied or popped one at a time
+func (gui *Gui) handleCreateStashFilesMenu(g *gocui.Gui, v *gocui.View) error {
+	stashEntry := gui.getSelectedStashEntry(v)
+	if stashEntry == nil {
+		return nil
+	}
+
+	stashFiles, err := gui.GitCommand.GetStashFiles(stashEntry.Index)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	menuItems := []*menuItem{}
+	if gui.GitCommand.StashHasUntrackedFiles(stashEntry.Index) {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("ViewStashUntrackedFiles"),
+			onPress: func() error {
+				gui.State.Panels.Stash.ShowUntrackedFor = stashEntry.Index
+				return nil
+			},
+		})
+	}
+
+	for _, stashFile := range stashFiles {
+		stashFile := stashFile
+		status := "M"
+		if stashFile.Untracked {
+			status = "??"
+		}
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{status, stashFile.Name},
+			onPress: func() error {
+				return gui.createStashFileMenu(stashEntry, stashFile)
+			},
+		})
+	}
+
+	return gui.createMenu(stashEntry.Name, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) createStashFileMenu(stashEntry *commands.StashEntry, stashFile *commands.StashFile) error {
+	do := func(f func(int, *commands.StashFile) error) func() error {
+		return func() error {
+			if err := f(stashEntry.Index, stashFile); err != nil {
+				return gui.createErrorPanel(gui.g, err.Error())
+			}
+			if err := gui.refreshStashEntries(gui.g); err != nil {
+				return err
+			}
+			return gui.refreshFiles()
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("ApplyStashFile"),
+			onPress:       do(gui.GitCommand.ApplyStashFile),
+		},
+		{
+			displayString: gui.Tr.SLocalize("PopStashFile"),
+			onPress:       do(gui.GitCommand.PopStashFile),
+		},
+		{
+			displayString: gui.Tr.SLocalize("ApplyStashFileHunks"),
+			onPress: func() error {
+				return gui.editStashFilePatch(stashEntry, stashFile)
+			},
+		},
+	}
+
+	return gui.createMenu(stashFile.Name, menuItems, createMenuOptions{showCancel: true})
+}
+
+// editStashFilePatch opens the stash's changes to a file in the user's editor,
+// where they can take out the hunks and lines they don't want before we apply
+// the rest to the working tree
+func (gui *Gui) editStashFilePatch(stashEntry *commands.StashEntry, stashFile *commands.StashFile) error {
+	patch, err := gui.GitCommand.GetStashFilePatch(stashEntry.Index, stashFile)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	path, err := gui.GitCommand.CreateHunkEditFile(patch)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	sub, err := gui.OSCommand.EditFile(path)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	gui.OnSubProcessDone = func() error {
+		_, err := gui.GitCommand.ApplyEditedPatch(path)
+		return err
+	}
+
+	_, err = gui.runSyncOrAsyncCommand(sub, nil)
+	return err
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -28,18 +30,18 @@ func (gui *Gui) refreshStatus(g *gocui.Gui) error {
 			return err
 		}
 
-		trackColor := color.FgYellow
+		trackColor := theme.CurrentPalette.Warning
 		if state.pushables == "0" && state.pullables == "0" {
-			trackColor = color.FgGreen
+			trackColor = theme.CurrentPalette.Added
 		} else if state.pushables == "?" && state.pullables == "?" {
-			trackColor = color.FgRed
+			trackColor = theme.CurrentPalette.Removed
 		}
 
 		status := utils.ColoredString(fmt.Sprintf("↑%s↓%s", state.pushables, state.pullables), trackColor)
 		branches := gui.State.Branches
 
 		if gui.State.WorkingTreeState != "normal" {
-			status += utils.ColoredString(fmt.Sprintf(" (%s)", gui.State.WorkingTreeState), color.FgYellow)
+			status += utils.ColoredString(fmt.Sprintf(" (%s)", gui.State.WorkingTreeState), theme.CurrentPalette.Warning)
 		}
 
 		if len(branches) > 0 {
@@ -49,6 +51,34 @@ func (gui *Gui) refreshStatus(g *gocui.Gui) error {
 			status += fmt.Sprintf(" %s → %s", repoName, name)
 		}
 
+		// these go last so that they don't throw off handleStatusClick
+		if gui.isOffline() {
+			status += utils.ColoredString(fmt.Sprintf(" (%s)", gui.offlineStatus()), theme.CurrentPalette.Warning)
+		}
+		if gui.OSCommand.ReadOnly() {
+			status += utils.ColoredString(fmt.Sprintf(" (%s)", gui.Tr.SLocalize("readOnly")), theme.CurrentPalette.Warning)
+		}
+		if gui.State.Sandbox != nil {
+			status += utils.ColoredString(fmt.Sprintf(" (%s)", gui.Tr.SLocalize("sandbox")), theme.CurrentPalette.Removed)
+		}
+		if review := gui.State.ReviewWorktree; review != nil {
+			status += utils.ColoredString(fmt.Sprintf(" (%s)", gui.Tr.TemplateLocalize("reviewing", Teml{"ref": review.Worktree.Ref})), theme.CurrentPalette.Info)
+		}
+		if gui.GitCommand.GetSparseCheckoutStatus().SparseIndex {
+			status += utils.ColoredString(fmt.Sprintf(" (%s)", gui.Tr.SLocalize("sparseIndex")), theme.CurrentPalette.Info)
+		}
+		if signing := gui.State.SigningStatus; signing != nil && signing.Enabled {
+			signingColor, signingId := theme.CurrentPalette.Added, "SigningReady"
+			if !signing.AgentReachable {
+				signingColor, signingId = theme.CurrentPalette.Removed, "SigningAgentUnreachable"
+			}
+			description := gui.Tr.TemplateLocalize(signingId, Teml{"signing": signing.Description()})
+			status += utils.ColoredString(fmt.Sprintf(" (%s)", description), signingColor)
+		}
+		if driver := gui.getPairingDriver(); driver != "" {
+			status += utils.ColoredString(fmt.Sprintf(" (%s)", gui.Tr.TemplateLocalize("drivingStatus", Teml{"name": commands.IdentityName(driver)})), theme.CurrentPalette.Info)
+		}
+
 		fmt.Fprint(v, status)
 		return nil
 	})
@@ -64,6 +94,36 @@ func cursorInSubstring(cx int, prefix string, substring string) bool {
 	return cx >= runeCount(prefix) && cx < runeCount(prefix+substring)
 }
 
+// handleToggleSparseIndex converts the index to or from a sparse index, which
+// can take a while in the big repos where it matters
+func (gui *Gui) handleToggleSparseIndex(g *gocui.Gui, v *gocui.View) error {
+	enable := !gui.GitCommand.GetSparseCheckoutStatus().SparseIndex
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("updatingIndexStatus"), func() error {
+		if err := gui.GitCommand.SetSparseIndex(enable); err != nil {
+			return gui.createErrorPanel(gui.g, err.Error())
+		}
+		return gui.refreshSidePanels(gui.g)
+	})
+}
+
+// refreshSigningStatus checks whether commits will be signed and the agent
+// is there to sign them, so that we can warn the user before a rebase fails
+// halfway through
+func (gui *Gui) refreshSigningStatus() {
+	status := gui.GitCommand.GetSigningStatus()
+	gui.State.SigningStatus = &status
+	gui.waitForIntro.Wait()
+	_ = gui.refreshStatus(gui.g)
+}
+
+// handleTestSigning signs a throwaway commit in a subprocess so that the user
+// can see any error from their signing program, and type in a passphrase if
+// it asks for one
+func (gui *Gui) handleTestSigning(g *gocui.Gui, v *gocui.View) error {
+	gui.SubProcess = gui.GitCommand.TestSigning()
+	return gui.Errors.ErrSubProcess
+}
+
 func (gui *Gui) handleCheckForUpdate(g *gocui.Gui, v *gocui.View) error {
 	gui.Updater.CheckForNewUpdate(gui.onUserUpdateCheckFinish, true)
 	return gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("CheckingForUpdates"))
@@ -77,7 +137,7 @@ func (gui *Gui) handleStatusClick(g *gocui.Gui, v *gocui.View) error {
 	repoName := utils.GetCurrentRepoName()
 	gui.Log.Warn(gui.State.WorkingTreeState)
 	switch gui.State.WorkingTreeState {
-	case "rebasing", "merging":
+	case "rebasing", "merging", "reverting", "unstashing", "applying":
 		workingTreeStatus := fmt.Sprintf("(%s)", gui.State.WorkingTreeState)
 		if cursorInSubstring(cx, upstreamStatus+" ", workingTreeStatus) {
 			return gui.handleCreateRebaseOptionsMenu(gui.g, v)
@@ -107,6 +167,11 @@ func (gui *Gui) handleStatusSelect(g *gocui.Gui, v *gocui.View) error {
 
 	gui.getMainView().Title = ""
 
+	if gui.State.WorkingTreeState == "applying" {
+		gui.getMainView().Title = gui.Tr.SLocalize("MailboxPatchesTitle")
+		return gui.newStringTask("main", gui.getMailboxPatchesDisplay())
+	}
+
 	magenta := color.New(color.FgMagenta)
 
 	dashboardString := strings.Join(
@@ -150,7 +215,18 @@ func (gui *Gui) updateWorkTreeState() error {
 		return err
 	}
 	if rebaseMode != "" {
-		gui.State.WorkingTreeState = "rebasing"
+		// `git am` uses the same directory as a non-interactive rebase
+		if gui.GitCommand.IsApplyingMailbox() {
+			gui.State.WorkingTreeState = "applying"
+		} else {
+			gui.State.WorkingTreeState = "rebasing"
+		}
+		return nil
+	}
+	// a revert that's stopped looks like a merge until its conflicts are
+	// resolved, and like nothing at all after that
+	if gui.GitCommand.IsReverting() {
+		gui.State.WorkingTreeState = "reverting"
 		return nil
 	}
 	merging, err := gui.GitCommand.IsInMergeState()
@@ -161,6 +237,10 @@ func (gui *Gui) updateWorkTreeState() error {
 		gui.State.WorkingTreeState = "merging"
 		return nil
 	}
+	if gui.State.StashConflict != nil {
+		gui.State.WorkingTreeState = "unstashing"
+		return nil
+	}
 	gui.State.WorkingTreeState = "normal"
 	return nil
 }
@@ -0,0 +1,71 @@
+package gui
+
+import (
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// The status settings menu lets users of giant repos tune how long refreshing
+// the files panel takes. Everything is saved to the repo's own git config, so
+// it sticks for that repo and applies to git on the command line too.
+
+func (gui *Gui) handleCreateStatusSettingsMenu(g *gocui.Gui, v *gocui.View) error {
+	settings := gui.GitCommand.GetStatusSettings()
+
+	setConfigValue := func(key string, value string) func() error {
+		return func() error {
+			if err := gui.GitCommand.SetLocalConfigValue(key, value); err != nil {
+				return gui.createErrorPanel(gui.g, err.Error())
+			}
+			return gui.refreshFiles()
+		}
+	}
+
+	onOff := func(value bool) string {
+		if value {
+			return gui.Tr.SLocalize("on")
+		}
+		return gui.Tr.SLocalize("off")
+	}
+
+	boolString := func(value bool) string {
+		if value {
+			return "true"
+		}
+		return "false"
+	}
+
+	nextUntrackedFilesMode := commands.UntrackedFilesModes[0]
+	for i, mode := range commands.UntrackedFilesModes {
+		if mode == settings.UntrackedFilesMode {
+			nextUntrackedFilesMode = commands.UntrackedFilesModes[(i+1)%len(commands.UntrackedFilesModes)]
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("UntrackedCache"), onOff(settings.UntrackedCache)},
+			onPress:        setConfigValue("core.untrackedCache", boolString(!settings.UntrackedCache)),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("ShowUntrackedFiles"), settings.UntrackedFilesMode},
+			onPress:        setConfigValue("status.showUntrackedFiles", nextUntrackedFilesMode),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("ManyFilesMode"), onOff(settings.ManyFiles)},
+			onPress:        setConfigValue("feature.manyFiles", boolString(!settings.ManyFiles)),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("RecurseSubmodules"), onOff(settings.RecurseSubmodules)},
+			onPress:        setConfigValue("lazygit.status.recurseSubmodules", boolString(!settings.RecurseSubmodules)),
+		},
+	}
+
+	title := gui.Tr.TemplateLocalize("StatusSettingsTitle", Teml{
+		"duration": gui.State.LastStatusDuration.Round(time.Millisecond).String(),
+	})
+
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
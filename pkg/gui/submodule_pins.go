@@ -0,0 +1,84 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// When a submodule's pin moves, its diff lists the submodule's commits between
+// the old and new pins. From the files and commit files panels we offer to
+// check out either pin in the submodule, e.g. to try the old version out
+// again.
+
+func (gui *Gui) handleFileSubmodulePins(g *gocui.Gui, v *gocui.View) error {
+	file, err := gui.getSelectedFile(g)
+	if err != nil {
+		if err != gui.Errors.ErrNoFiles {
+			return err
+		}
+		return nil
+	}
+
+	// the working tree's pin is whatever's checked out in the submodule
+	return gui.createSubmodulePinsMenu(file.Name, "HEAD", "")
+}
+
+func (gui *Gui) handleCommitFileSubmodulePins(g *gocui.Gui, v *gocui.View) error {
+	commitFile := gui.getSelectedCommitFile(g)
+	if commitFile == nil {
+		return nil
+	}
+
+	oldRef, newRef := commitFile.Sha+"^", commitFile.Sha
+	if comparison := gui.State.Comparison; comparison != nil {
+		oldRef, newRef = comparison.From, comparison.To
+	}
+	return gui.createSubmodulePinsMenu(commitFile.Name, oldRef, newRef)
+}
+
+// createSubmodulePinsMenu offers to check out the submodule at path as each of
+// the refs pins it, where an empty ref stands for the working tree
+func (gui *Gui) createSubmodulePinsMenu(path string, oldRef string, newRef string) error {
+	if !gui.GitCommand.IsSubmodule(path) {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NotASubmodule"))
+	}
+
+	pins := []struct {
+		label string
+		ref   string
+	}{
+		{gui.Tr.SLocalize("SubmoduleOldPin"), oldRef},
+		{gui.Tr.SLocalize("SubmoduleNewPin"), newRef},
+	}
+
+	menuItems := []*menuItem{}
+	for _, pin := range pins {
+		sha, err := gui.GitCommand.GetSubmodulePin(pin.ref, path)
+		if err != nil {
+			// the submodule was only just added, or has just been removed
+			continue
+		}
+		commit := &commands.Commit{Sha: sha}
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{pin.label, commit.ShortSha()},
+			onPress: func() error {
+				return gui.checkoutSubmodulePin(path, sha)
+			},
+		})
+	}
+	if len(menuItems) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoSubmodulePins"))
+	}
+
+	title := gui.Tr.TemplateLocalize("SubmodulePinsTitle", Teml{"path": path})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) checkoutSubmodulePin(path string, sha string) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("CheckingOutStatus"), func() error {
+		if err := gui.GitCommand.CheckoutSubmodulePin(path, sha); err != nil {
+			return err
+		}
+		return gui.refreshSidePanels(gui.g)
+	})
+}
@@ -0,0 +1,113 @@
+package gui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// When the files panel lists the files of the repo's submodules too (see
+// lazygit.status.recurseSubmodules), staging and committing a file has to
+// happen in the repo the file's in. These route each file to its repo.
+
+func (gui *Gui) stageFile(file *commands.File) error {
+	if file.Submodule == "" {
+		return gui.GitCommand.StageFile(file.Name)
+	}
+	split := strings.Split(file.NameInRepo(), " -> ") // in case of a renamed file we want the new filename
+	return gui.GitCommand.StageSubmoduleFiles(file.Submodule, split[len(split)-1:])
+}
+
+func (gui *Gui) unstageFile(file *commands.File) error {
+	if file.Submodule == "" {
+		return gui.GitCommand.UnStageFile(file.Name, file.Tracked)
+	}
+	return gui.GitCommand.UnStageSubmoduleFile(file)
+}
+
+// stageFiles stages the files with one command per repo they're in
+func (gui *Gui) stageFiles(files []*commands.File) error {
+	fileNamesByRepo := map[string][]string{}
+	for _, file := range files {
+		split := strings.Split(file.NameInRepo(), " -> ") // in case of a renamed file we want the new filename
+		fileNamesByRepo[file.Submodule] = append(fileNamesByRepo[file.Submodule], split[len(split)-1])
+	}
+
+	for submodule, fileNames := range fileNamesByRepo {
+		var err error
+		if submodule == "" {
+			err = gui.GitCommand.StageFiles(fileNames)
+		} else {
+			err = gui.GitCommand.StageSubmoduleFiles(submodule, fileNames)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stageAll stages everything in each submodule with files listed, and then
+// everything in the superproject
+func (gui *Gui) stageAll() error {
+	for _, submodule := range gui.listedSubmodules(false) {
+		if err := gui.GitCommand.StageAllInSubmodule(submodule); err != nil {
+			return err
+		}
+	}
+	return gui.GitCommand.StageAll()
+}
+
+func (gui *Gui) unstageAll() error {
+	for _, submodule := range gui.listedSubmodules(false) {
+		if err := gui.GitCommand.UnstageAllInSubmodule(submodule); err != nil {
+			return err
+		}
+	}
+	return gui.GitCommand.UnstageAll()
+}
+
+// listedSubmodules returns the submodules with files in the files panel, or
+// only those with staged files, innermost first
+func (gui *Gui) listedSubmodules(staged bool) []string {
+	seen := map[string]bool{}
+	submodules := []string{}
+	for _, file := range gui.State.Files {
+		if file.Submodule == "" || seen[file.Submodule] || (staged && !file.HasStagedChanges) {
+			continue
+		}
+		seen[file.Submodule] = true
+		submodules = append(submodules, file.Submodule)
+	}
+	// a submodule's path is longer than those of any submodules it's in
+	sort.SliceStable(submodules, func(i, j int) bool {
+		return len(submodules[i]) > len(submodules[j])
+	})
+	return submodules
+}
+
+// commitInSubmodules commits what's staged in each submodule with the given
+// message, returning whether there's anything left to commit in the
+// superproject
+func (gui *Gui) commitInSubmodules(message string, flags string) (bool, error) {
+	submodules := gui.listedSubmodules(true)
+	if len(submodules) == 0 {
+		return true, nil
+	}
+	for _, submodule := range submodules {
+		if err := gui.GitCommand.CommitInSubmodule(submodule, message, flags); err != nil {
+			return false, err
+		}
+	}
+
+	if gui.State.WorkingTreeState != "normal" {
+		return true, nil
+	}
+	for _, file := range gui.State.Files {
+		if file.Submodule == "" && file.HasStagedChanges {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -34,7 +34,7 @@ func (gui *Gui) handleTagSelect(g *gocui.Gui, v *gocui.View) error {
 	if tag == nil {
 		return gui.newStringTask("main", "No tags")
 	}
-	v.FocusPoint(0, gui.State.Panels.Tags.SelectedLine)
+	gui.focusPoint(v, gui.State.Panels.Tags.SelectedLine)
 
 	cmd := gui.OSCommand.ExecutableFromString(
 		gui.GitCommand.GetBranchGraphCmdStr(tag.Name),
@@ -52,12 +52,15 @@ func (gui *Gui) refreshTags() error {
 		return gui.createErrorPanel(gui.g, err.Error())
 	}
 
-	gui.State.Tags = tags
-
-	if gui.getBranchesView().Context == "tags" {
-		return gui.renderTagsWithSelection()
-	}
+	gui.g.Update(func(g *gocui.Gui) error {
+		gui.State.Tags = tags
+		gui.markContextLoaded("tags")
 
+		if gui.getBranchesView().Context == "tags" {
+			return gui.renderTagsWithSelection()
+		}
+		return nil
+	})
 	return nil
 }
 
@@ -128,10 +131,26 @@ func (gui *Gui) handlePushTag(g *gocui.Gui, v *gocui.View) error {
 	)
 
 	return gui.createPromptPanel(gui.g, v, title, "origin", func(g *gocui.Gui, v *gocui.View) error {
-		if err := gui.GitCommand.PushTag(v.Buffer(), tag.Name); err != nil {
-			return gui.createErrorPanel(gui.g, err.Error())
+		remoteName := v.Buffer()
+		description := gui.Tr.TemplateLocalize("PushTagOperation", Teml{"tagName": tag.Name})
+		pushTag := func() error {
+			if err := gui.withNetworkRetries(description, func() error {
+				return gui.GitCommand.PushTag(remoteName, tag.Name)
+			}); err != nil {
+				return err
+			}
+			return gui.refreshTags()
 		}
-		return gui.refreshTags()
+		if gui.queueIfOffline(description, func() {
+			if err := pushTag(); err != nil {
+				_ = gui.createErrorPanel(gui.g, err.Error())
+			}
+		}) {
+			return nil
+		}
+
+		// we may have to wait a while to retry, so this happens in the background
+		return gui.WithWaitingStatus(gui.Tr.SLocalize("PushWait"), pushTag)
 	})
 }
 
@@ -1,12 +1,18 @@
 package gui
 
 import (
+	"context"
+	"io"
 	"os/exec"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/tasks"
 )
 
+// newCmdTask shows the output of the unstarted cmd in the view. It's only
+// started once the view's last task has been stopped, and killed once the view
+// moves on to another.
 func (gui *Gui) newCmdTask(viewName string, cmd *exec.Cmd) error {
 	view, err := gui.g.View(viewName)
 	if err != nil {
@@ -18,24 +24,27 @@ func (gui *Gui) newCmdTask(viewName string, cmd *exec.Cmd) error {
 
 	manager := gui.getManager(view)
 
-	r, err := cmd.StdoutPipe()
-	if err != nil {
-		return err
-	}
-	cmd.Stderr = cmd.Stdout
-
-	if err := cmd.Start(); err != nil {
-		return err
+	start := func(ctx context.Context) (*exec.Cmd, io.Reader, error) {
+		ctxCmd := commands.CommandWithContext(ctx, cmd)
+		r, err := ctxCmd.StdoutPipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		ctxCmd.Stderr = ctxCmd.Stdout
+		if err := ctxCmd.Start(); err != nil {
+			return nil, nil, err
+		}
+		return ctxCmd, r, nil
 	}
 
-	if err := manager.NewTask(manager.NewCmdTask(r, cmd, height+oy+10, nil)); err != nil {
+	if err := manager.NewTask(manager.NewCmdTask(start, height+oy+10, nil, gui.newPagerOutput(viewName, cmd, "").processLine)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (gui *Gui) newTask(viewName string, f func(chan struct{}) error) error {
+func (gui *Gui) newTask(viewName string, f func(context.Context) error) error {
 	view, err := gui.g.View(viewName)
 	if err != nil {
 		return nil // swallowing for now
@@ -57,8 +66,10 @@ func (gui *Gui) newStringTask(viewName string, str string) error {
 	}
 
 	manager := gui.getManager(view)
+	gui.clearPagerOutput(viewName)
 
-	f := func(stop chan struct{}) error {
+	f := func(ctx context.Context) error {
+		view.Subtitle = ""
 		gui.renderString(gui.g, viewName, str)
 		return nil
 	}
@@ -78,12 +89,20 @@ func (gui *Gui) getManager(view *gocui.View) *tasks.ViewBufferManager {
 			view,
 			func() {
 				view.Clear()
+				view.Subtitle = ""
 			},
 			func() {
 				gui.g.Update(func(*gocui.Gui) error {
 					gui.Log.Warn("updating view")
 					return nil
 				})
+			},
+			func() {
+				// so it's clear that scrolling further won't turn up any more
+				gui.g.Update(func(*gocui.Gui) error {
+					view.Subtitle = gui.Tr.SLocalize("EndOfOutput")
+					return nil
+				})
 			})
 		gui.viewBufferManagerMap[view.Name()] = manager
 	}
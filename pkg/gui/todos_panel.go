@@ -0,0 +1,43 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// handleViewIntroducedTodos lists the TODOs and FIXMEs that the checked out
+// branch adds compared to its base branch, so that they can be cleaned up
+// before opening a pull request
+func (gui *Gui) handleViewIntroducedTodos(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getCheckedOutBranch()
+	if branch == nil {
+		return nil
+	}
+	baseBranch := gui.GitCommand.GetBaseBranch(branch.Name)
+	if baseBranch == "" {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoBaseBranch"))
+	}
+
+	todos, err := gui.GitCommand.GetIntroducedTodos(baseBranch)
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+	if len(todos) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.TemplateLocalize("NoIntroducedTodos", Teml{"baseBranch": baseBranch}))
+	}
+
+	menuItems := make([]*menuItem, len(todos))
+	for i, todo := range todos {
+		todo := todo
+		menuItems[i] = &menuItem{
+			displayStrings: []string{fmt.Sprintf("%s:%d", todo.FileName, todo.LineNumber), todo.Text},
+			onPress: func() error {
+				return gui.editFileAtLine(todo.FileName, todo.LineNumber)
+			},
+		}
+	}
+
+	title := gui.Tr.TemplateLocalize("IntroducedTodosTitle", Teml{"baseBranch": baseBranch})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,119 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// getTrailerOptions returns the trailers to offer: Signed-off-by for the
+// user, Reviewed-by for whoever they say, and any from their config
+func (gui *Gui) getTrailerOptions() ([]commands.Trailer, error) {
+	signOff, err := gui.GitCommand.GetSignOffTrailer()
+	if err != nil {
+		return nil, err
+	}
+	trailers := []commands.Trailer{signOff, {Key: "Reviewed-by"}}
+	return append(trailers, gui.GitCommand.GetConfiguredTrailers()...), nil
+}
+
+// withTrailerValue calls f with the trailer, first asking the user for its
+// value if it doesn't have one
+func (gui *Gui) withTrailerValue(v *gocui.View, trailer commands.Trailer, f func(commands.Trailer) error) error {
+	if trailer.Value != "" {
+		return f(trailer)
+	}
+
+	// the menu closes once we return, so the prompt has to wait until then
+	gui.g.Update(func(*gocui.Gui) error {
+		title := gui.Tr.TemplateLocalize("TrailerValuePrompt", Teml{"key": trailer.Key})
+		return gui.createPromptPanel(gui.g, v, title, "", func(g *gocui.Gui, promptView *gocui.View) error {
+			trailer.Value = gui.trimmedContent(promptView)
+			if trailer.Value == "" {
+				return nil
+			}
+			return f(trailer)
+		})
+	})
+	return nil
+}
+
+// handleCreateTrailersMenu lets the user toggle trailers at the end of the
+// commit message they're writing
+func (gui *Gui) handleCreateTrailersMenu(g *gocui.Gui, v *gocui.View) error {
+	trailers, err := gui.getTrailerOptions()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	message := gui.trimmedContent(v)
+	menuItems := make([]*menuItem, len(trailers))
+	for i, trailer := range trailers {
+		trailer := trailer
+		present := commands.HasTrailer(message, trailer)
+		onOff := gui.Tr.SLocalize("off")
+		if present {
+			onOff = gui.Tr.SLocalize("on")
+		}
+		menuItems[i] = &menuItem{
+			displayStrings: []string{strings.TrimSpace(trailer.String()), onOff},
+			onPress: func() error {
+				if present {
+					gui.setCommitMessage(v, commands.RemoveTrailer(message, trailer))
+					return nil
+				}
+				return gui.withTrailerValue(v, trailer, func(trailer commands.Trailer) error {
+					gui.setCommitMessage(v, commands.AddTrailer(gui.trimmedContent(v), trailer))
+					return nil
+				})
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("TrailersMenu"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// setCommitMessage replaces what's in the commit message panel, leaving the
+// cursor at the end
+func (gui *Gui) setCommitMessage(v *gocui.View, message string) {
+	v.Clear()
+	fmt.Fprint(v, message)
+	lines := strings.Split(message, "\n")
+	_ = v.SetOrigin(0, 0)
+	_ = v.SetCursor(len([]rune(lines[len(lines)-1])), len(lines)-1)
+	gui.RenderCommitLength()
+}
+
+// handleAddTrailerToCommit adds a trailer to the selected commit's message,
+// rebasing if it's not HEAD
+func (gui *Gui) handleAddTrailerToCommit(g *gocui.Gui, v *gocui.View) error {
+	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
+		return err
+	}
+
+	trailers, err := gui.getTrailerOptions()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	index := gui.selectedCommitIndex()
+	menuItems := make([]*menuItem, len(trailers))
+	for i, trailer := range trailers {
+		trailer := trailer
+		menuItems[i] = &menuItem{
+			displayString: strings.TrimSpace(trailer.String()),
+			onPress: func() error {
+				return gui.withTrailerValue(v, trailer, func(trailer commands.Trailer) error {
+					return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+						err := gui.GitCommand.AddTrailerToCommit(gui.State.Commits, index, trailer)
+						return gui.handleGenericMergeCommandResult(err)
+					})
+				})
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("AddTrailerToCommit"), menuItems, createMenuOptions{showCancel: true})
+}
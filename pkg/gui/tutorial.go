@@ -0,0 +1,228 @@
+package gui
+
+import (
+	"os"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// The tutorial walks the user through the basics in a throwaway repo that we
+// create in a temp directory and delete when they're done. Each step is
+// complete once the repo is in the state we asked for, so the user is free to
+// get there however they like.
+
+type tutorialState struct {
+	Repo *commands.TutorialRepo
+	// ReturnDir is the directory we were in before starting the tutorial
+	ReturnDir string
+	Step      int
+	// RenderedStep is the step whose instructions we last rendered
+	RenderedStep int
+}
+
+type tutorialStep struct {
+	instructions func() string
+	isDone       func() bool
+}
+
+func (gui *Gui) getTutorialSteps() []*tutorialStep {
+	initialCommitCount := 2
+	initialBranchCount := 2
+
+	commitsContainFeature := func() bool {
+		for _, commit := range gui.State.Commits {
+			if commit.Sha == gui.State.Tutorial.Repo.FeatureSha {
+				return true
+			}
+		}
+		return false
+	}
+
+	return []*tutorialStep{
+		{
+			instructions: func() string {
+				return gui.Tr.TemplateLocalize("TutorialStageStep", Teml{
+					"selectKey": gui.getKeyDisplay("universal.select"),
+				})
+			},
+			isDone: func() bool {
+				for _, file := range gui.State.Files {
+					if file.HasStagedChanges {
+						return true
+					}
+				}
+				return false
+			},
+		},
+		{
+			instructions: func() string {
+				return gui.Tr.TemplateLocalize("TutorialCommitStep", Teml{
+					"commitKey": gui.getKeyDisplay("files.commitChanges"),
+				})
+			},
+			isDone: func() bool { return len(gui.State.Commits) > initialCommitCount },
+		},
+		{
+			instructions: func() string {
+				return gui.Tr.TemplateLocalize("TutorialBranchStep", Teml{
+					"newKey": gui.getKeyDisplay("universal.new"),
+				})
+			},
+			isDone: func() bool { return len(gui.State.Branches) > initialBranchCount },
+		},
+		{
+			instructions: func() string {
+				return gui.Tr.TemplateLocalize("TutorialRebaseStep", Teml{
+					"rebaseKey": gui.getKeyDisplay("branches.rebaseBranch"),
+				})
+			},
+			isDone: commitsContainFeature,
+		},
+		{
+			instructions: func() string {
+				return gui.Tr.TemplateLocalize("TutorialUndoStep", Teml{
+					"nextTabKey": gui.getKeyDisplay("universal.nextTab"),
+					"resetKey":   gui.getKeyDisplay("commits.viewResetOptions"),
+				})
+			},
+			isDone: func() bool { return !commitsContainFeature() },
+		},
+		{
+			instructions: func() string {
+				return gui.Tr.TemplateLocalize("TutorialFinished", Teml{
+					"tutorialKey": gui.getKeyDisplay("status.toggleTutorial"),
+				})
+			},
+			isDone: func() bool { return false },
+		},
+	}
+}
+
+// layoutTutorial draws the current step's instructions over the bottom of the
+// main view, moving on to the next step once the current one is done
+func (gui *Gui) layoutTutorial(x0, y0, x1, y1 int) error {
+	if gui.State.Tutorial == nil {
+		_ = gui.g.DeleteView("tutorial")
+		return nil
+	}
+
+	steps := gui.getTutorialSteps()
+	if gui.State.Tutorial.Step < len(steps)-1 && steps[gui.State.Tutorial.Step].isDone() {
+		gui.State.Tutorial.Step++
+	}
+	step := steps[gui.State.Tutorial.Step]
+
+	v, err := gui.g.SetView("tutorial", x0, y0, x1, y1, 0)
+	if err != nil {
+		if err.Error() != "unknown view" {
+			return err
+		}
+		v.Wrap = true
+		v.FgColor = gocui.ColorYellow
+	}
+	v.Title = gui.Tr.TemplateLocalize("TutorialTitle", Teml{
+		"step":  gui.State.Tutorial.Step + 1,
+		"total": len(steps),
+	})
+
+	if gui.State.Tutorial.RenderedStep != gui.State.Tutorial.Step {
+		gui.setViewContent(gui.g, v, step.instructions())
+		gui.State.Tutorial.RenderedStep = gui.State.Tutorial.Step
+	}
+
+	// popups need to stay above the instructions
+	if !gui.popupPanelFocused() {
+		if _, err := gui.g.SetViewOnTop("tutorial"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (gui *Gui) handleToggleTutorial(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.Tutorial != nil {
+		return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("LeaveTutorialTitle"), gui.Tr.SLocalize("LeaveTutorialPrompt"), func(*gocui.Gui, *gocui.View) error {
+			return gui.endTutorial()
+		}, nil)
+	}
+
+	if gui.State.Sandbox != nil {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("TutorialUnavailableInSandbox"))
+	}
+
+	return gui.startTutorial()
+}
+
+func (gui *Gui) startTutorial() error {
+	returnDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	repo, err := gui.OSCommand.CreateTutorialRepo()
+	if err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	// we don't want anything left over from the user's repo counting towards
+	// the tutorial's steps before the tutorial repo has loaded
+	gui.State.Files = []*commands.File{}
+	gui.State.Commits = []*commands.Commit{}
+	gui.State.Branches = []*commands.Branch{}
+
+	gui.State.Tutorial = &tutorialState{
+		Repo:         repo,
+		ReturnDir:    returnDir,
+		RenderedStep: -1,
+	}
+
+	return gui.switchToRepo(repo.Path)
+}
+
+func (gui *Gui) endTutorial() error {
+	returnDir := gui.State.Tutorial.ReturnDir
+	if err := gui.cleanUpTutorial(); err != nil {
+		return err
+	}
+
+	return gui.switchToRepo(returnDir)
+}
+
+// cleanUpTutorial takes us back to where we were before the tutorial and
+// deletes the tutorial repo
+func (gui *Gui) cleanUpTutorial() error {
+	if gui.State.Tutorial == nil {
+		return nil
+	}
+
+	tutorial := gui.State.Tutorial
+	gui.State.Tutorial = nil
+
+	if err := os.Chdir(tutorial.ReturnDir); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(tutorial.Repo.Path)
+}
+
+// offerTutorial is a startup popup task for first time users
+func (gui *Gui) offerTutorial(done chan struct{}) error {
+	gui.State.TutorialOffered = true
+
+	onConfirm := func(g *gocui.Gui, v *gocui.View) error {
+		done <- struct{}{}
+		return gui.startTutorial()
+	}
+	onClose := func(g *gocui.Gui, v *gocui.View) error {
+		done <- struct{}{}
+		return nil
+	}
+
+	prompt := gui.Tr.TemplateLocalize("TutorialPrompt", Teml{
+		"tutorialKey": gui.getKeyDisplay("status.toggleTutorial"),
+	})
+
+	return gui.createConfirmationPanel(gui.g, nil, true, gui.Tr.SLocalize("TutorialPromptTitle"), prompt, onConfirm, onClose)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/gocui"
@@ -13,18 +14,43 @@ import (
 
 var cyclableViews = []string{"status", "files", "branches", "commits", "stash"}
 
+// refreshSidePanels loads each side panel's model concurrently, given they're
+// independent of one another and on large repos the git calls dominate.
 func (gui *Gui) refreshSidePanels(g *gocui.Gui) error {
-	if err := gui.refreshBranches(g); err != nil {
-		return err
+	// these only load their models concurrently: setting the gui state and
+	// rendering happens in gocui updates on the main loop
+	refreshFuncs := []func() error{
+		func() error { return gui.refreshBranches(g) },
+		func() error {
+			if gui.getFilesView() == nil {
+				return nil
+			}
+			gui.refreshFilesInUpdate(gui.loadStatusFiles())
+			return nil
+		},
+		func() error { return gui.refreshCommits(g) },
+		func() error { return gui.refreshStashEntries(g) },
 	}
-	if err := gui.refreshFiles(); err != nil {
-		return err
+
+	errs := make([]error, len(refreshFuncs))
+	wg := sync.WaitGroup{}
+	wg.Add(len(refreshFuncs))
+	for i, refreshFunc := range refreshFuncs {
+		i, refreshFunc := i, refreshFunc
+		go func() {
+			defer wg.Done()
+			errs[i] = refreshFunc()
+		}()
 	}
-	if err := gui.refreshCommits(g); err != nil {
-		return err
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
 	}
 
-	return gui.refreshStashEntries(g)
+	return nil
 }
 
 func (gui *Gui) nextView(g *gocui.Gui, v *gocui.View) error {
@@ -267,6 +293,11 @@ func (gui *Gui) getFilesView() *gocui.View {
 	return v
 }
 
+func (gui *Gui) getStatusView() *gocui.View {
+	v, _ := gui.g.View("status")
+	return v
+}
+
 func (gui *Gui) getCommitsView() *gocui.View {
 	v, _ := gui.g.View("commits")
 	return v
@@ -332,8 +363,13 @@ func (gui *Gui) resizeCurrentPopupPanel(g *gocui.Gui) error {
 func (gui *Gui) resizePopupPanel(g *gocui.Gui, v *gocui.View) error {
 	// If the confirmation panel is already displayed, just resize the width,
 	// otherwise continue
-	content := v.Buffer()
-	x0, y0, x1, y1 := gui.getConfirmationPanelDimensions(g, v.Wrap, content)
+	var x0, y0, x1, y1 int
+	if v.Name() == "customCommandOutput" {
+		// this one's as big as it is however much output there is
+		x0, y0, x1, y1 = gui.getCustomCommandOutputDimensions(g)
+	} else {
+		x0, y0, x1, y1 = gui.getConfirmationPanelDimensions(g, v.Wrap, v.Buffer())
+	}
 	vx0, vy0, vx1, vy1 := v.Dimensions()
 	if vx0 == x0 && vy0 == y0 && vx1 == x1 && vy1 == y1 {
 		return nil
@@ -357,6 +393,44 @@ func (gui *Gui) changeSelectedLine(line *int, total int, change int) {
 	}
 }
 
+// focusPoint scrolls a list view so that the selected line is in view, along
+// with gui.scrollOffMargin lines either side of it where there are any
+func (gui *Gui) focusPoint(v *gocui.View, selectedLine int) {
+	lineCount := v.LinesHeight()
+	if selectedLine < 0 || selectedLine > lineCount {
+		return
+	}
+	_, height := v.Size()
+	if height <= 0 {
+		v.FocusPoint(0, selectedLine)
+		return
+	}
+
+	margin := gui.Config.GetUserConfig().GetInt("gui.scrollOffMargin")
+	if margin > (height-1)/2 {
+		margin = (height - 1) / 2
+	}
+	if margin < 0 {
+		margin = 0
+	}
+
+	_, origin := v.Origin()
+	if selectedLine-margin < origin {
+		origin = selectedLine - margin
+	} else if selectedLine+margin > origin+height-1 {
+		origin = selectedLine + margin - (height - 1)
+	}
+	if origin > lineCount-height {
+		origin = lineCount - height
+	}
+	if origin < 0 {
+		origin = 0
+	}
+
+	_ = v.SetOrigin(0, origin)
+	_ = v.SetCursor(0, selectedLine-origin)
+}
+
 func (gui *Gui) refreshSelectedLine(line *int, total int) {
 	if *line == -1 && total > 0 {
 		*line = 0
@@ -379,16 +453,21 @@ func (gui *Gui) renderPanelOptions() error {
 	switch currentView.Name() {
 	case "menu":
 		return gui.renderMenuOptions()
+	case "rebasePlan":
+		return gui.renderRebasePlanOptions()
 	case "main":
 		if gui.State.MainContext == "merging" {
 			return gui.renderMergeOptions()
 		}
 	}
+	if gui.hintsBarEnabled() {
+		return gui.renderHintsBar()
+	}
 	return gui.renderGlobalOptions()
 }
 
 func (gui *Gui) isPopupPanel(viewName string) bool {
-	return viewName == "commitMessage" || viewName == "credentials" || viewName == "confirmation" || viewName == "menu"
+	return viewName == "commitMessage" || viewName == "credentials" || viewName == "confirmation" || viewName == "menu" || viewName == "customCommandOutput" || viewName == "rebasePlan"
 }
 
 func (gui *Gui) popupPanelFocused() bool {
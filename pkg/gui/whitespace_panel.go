@@ -0,0 +1,77 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// After an autoformatter has been let loose, the files it only reindented
+// drown out the ones with real changes. We mark those files in the files
+// panel, and this menu gets rid of them or leaves them out of staging.
+
+func (gui *Gui) markWhitespaceOnlyFiles() {
+	if !gui.Config.GetUserConfig().GetBool("gui.markWhitespaceOnlyFiles") {
+		return
+	}
+	if err := gui.GitCommand.MarkWhitespaceOnlyFiles(gui.State.Files); err != nil {
+		gui.Log.Error(err)
+	}
+}
+
+func (gui *Gui) handleCreateWhitespaceOptionsMenu(g *gocui.Gui, v *gocui.View) error {
+	whitespaceOnlyFiles := []*commands.File{}
+	otherFiles := []*commands.File{}
+	otherFileNames := []string{}
+	for _, file := range gui.State.Files {
+		if file.WhitespaceOnly {
+			whitespaceOnlyFiles = append(whitespaceOnlyFiles, file)
+		} else if file.HasUnstagedChanges {
+			otherFiles = append(otherFiles, file)
+			otherFileNames = append(otherFileNames, file.Name)
+		}
+	}
+	if len(whitespaceOnlyFiles) == 0 {
+		return gui.createErrorPanel(gui.g, gui.Tr.SLocalize("NoWhitespaceOnlyFiles"))
+	}
+
+	count := fmt.Sprintf("%d", len(whitespaceOnlyFiles))
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.TemplateLocalize("StageAllExceptWhitespaceOnly", Teml{"count": count}),
+			onPress: func() error {
+				if len(otherFileNames) == 0 {
+					return nil
+				}
+				return gui.confirmLargeFiles(otherFileNames, func() error {
+					return gui.refreshAfterStaging(gui.stageFiles(otherFiles))
+				})
+			},
+		},
+		{
+			displayString: gui.Tr.TemplateLocalize("DiscardWhitespaceOnlyChanges", Teml{"count": count}),
+			onPress: func() error {
+				fileNames := []string{}
+				for _, file := range whitespaceOnlyFiles {
+					if err := gui.backupFile(file); err != nil {
+						return gui.createErrorPanel(gui.g, err.Error())
+					}
+					if file.Submodule == "" {
+						fileNames = append(fileNames, file.Name)
+					} else if err := gui.GitCommand.DiscardUnstagedFileChanges(file); err != nil {
+						return gui.createErrorPanel(gui.g, err.Error())
+					}
+				}
+				if len(fileNames) > 0 {
+					if err := gui.GitCommand.DiscardUnstagedFilesChanges(fileNames); err != nil {
+						return gui.createErrorPanel(gui.g, err.Error())
+					}
+				}
+				return gui.refreshFiles()
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("WhitespaceOptionsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
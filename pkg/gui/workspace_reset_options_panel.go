@@ -3,10 +3,11 @@ package gui
 import (
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/theme"
 )
 
 func (gui *Gui) handleCreateResetMenu(g *gocui.Gui, v *gocui.View) error {
-	red := color.New(color.FgRed)
+	red := color.New(theme.CurrentPalette.Removed)
 
 	menuItems := []*menuItem{
 		{
@@ -14,39 +15,21 @@ func (gui *Gui) handleCreateResetMenu(g *gocui.Gui, v *gocui.View) error {
 				gui.Tr.SLocalize("discardAllChangesToAllFiles"),
 				red.Sprint("reset --hard HEAD && git clean -fd"),
 			},
-			onPress: func() error {
-				if err := gui.GitCommand.ResetAndClean(); err != nil {
-					return gui.createErrorPanel(gui.g, err.Error())
-				}
-
-				return gui.refreshFiles()
-			},
+			onPress: gui.handleResetAndClean,
 		},
 		{
 			displayStrings: []string{
 				gui.Tr.SLocalize("discardAnyUnstagedChanges"),
 				red.Sprint("git checkout -- ."),
 			},
-			onPress: func() error {
-				if err := gui.GitCommand.DiscardAnyUnstagedFileChanges(); err != nil {
-					return gui.createErrorPanel(gui.g, err.Error())
-				}
-
-				return gui.refreshFiles()
-			},
+			onPress: gui.handleDiscardAnyUnstagedFileChanges,
 		},
 		{
 			displayStrings: []string{
 				gui.Tr.SLocalize("discardUntrackedFiles"),
 				red.Sprint("git clean -fd"),
 			},
-			onPress: func() error {
-				if err := gui.GitCommand.RemoveUntrackedFiles(); err != nil {
-					return gui.createErrorPanel(gui.g, err.Error())
-				}
-
-				return gui.refreshFiles()
-			},
+			onPress: gui.handleRemoveUntrackedFiles,
 		},
 		{
 			displayStrings: []string{
@@ -91,3 +74,27 @@ func (gui *Gui) handleCreateResetMenu(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.createMenu("", menuItems, createMenuOptions{showCancel: true})
 }
+
+func (gui *Gui) handleResetAndClean() error {
+	if err := gui.GitCommand.ResetAndClean(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	return gui.refreshFiles()
+}
+
+func (gui *Gui) handleDiscardAnyUnstagedFileChanges() error {
+	if err := gui.GitCommand.DiscardAnyUnstagedFileChanges(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	return gui.refreshFiles()
+}
+
+func (gui *Gui) handleRemoveUntrackedFiles() error {
+	if err := gui.GitCommand.RemoveUntrackedFiles(); err != nil {
+		return gui.createErrorPanel(gui.g, err.Error())
+	}
+
+	return gui.refreshFiles()
+}
@@ -758,7 +758,7 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 			Other: "No files for this commit",
 		}, &i18n.Message{
 			ID:    "checkoutCommitFile",
-			Other: "checkout file",
+			Other: "restore file to this version and stage it",
 		}, &i18n.Message{
 			ID:    "discardOldFileChange",
 			Other: "discard this commit's changes to this file",
@@ -1041,6 +1041,1278 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "prevTab",
 			Other: "previous tab",
+		}, &i18n.Message{
+			ID:    "ConfigReloaded",
+			Other: "Config reloaded",
+		}, &i18n.Message{
+			ID:    "ConfigReloadFailed",
+			Other: "Could not reload config, keeping the existing config for now:\n\n{{.error}}",
+		}, &i18n.Message{
+			ID:    "showKeybindingReport",
+			Other: "show keybinding problems",
+		}, &i18n.Message{
+			ID:    "KeybindingReportTitle",
+			Other: "Keybinding Report",
+		}, &i18n.Message{
+			ID:    "KeybindingProblemsTitle",
+			Other: "Keybinding problems",
+		}, &i18n.Message{
+			ID:    "KeybindingProblemsPrompt",
+			Other: "Found {{.count}} problem(s) with your keybindings. Press {{.key}} in the status panel to see them.",
+		}, &i18n.Message{
+			ID:    "NoKeybindingProblems",
+			Other: "No problems found with your keybindings",
+		}, &i18n.Message{
+			ID:    "UnknownKeybindingName",
+			Other: "Unknown keybinding name '{{.name}}'",
+		}, &i18n.Message{
+			ID:    "UnreachableKeybinding",
+			Other: "'{{.key}}' ({{.description}}) can never fire in the {{.viewName}} view because it takes text input",
+		}, &i18n.Message{
+			ID:    "softResetToCommit",
+			Other: "soft reset to selected commit",
+		}, &i18n.Message{
+			ID:    "mixedResetToCommit",
+			Other: "mixed reset to selected commit",
+		}, &i18n.Message{
+			ID:    "hardResetToCommit",
+			Other: "hard reset to selected commit",
+		}, &i18n.Message{
+			ID:    "continueRebaseOrMerge",
+			Other: "continue rebase/merge",
+		}, &i18n.Message{
+			ID:    "abortRebaseOrMerge",
+			Other: "abort rebase/merge",
+		}, &i18n.Message{
+			ID:    "skipRebaseCommit",
+			Other: "skip current rebase commit",
+		}, &i18n.Message{
+			ID:    "resetPatch",
+			Other: "reset custom patch",
+		}, &i18n.Message{
+			ID:    "ConflictingKeybindings",
+			Other: "'{{.key}}' in {{.viewName}}: '{{.description}}' is shadowed by '{{.existingDescription}}'",
+		}, &i18n.Message{
+			ID:    "toggleTutorial",
+			Other: "start/leave tutorial",
+		}, &i18n.Message{
+			ID:    "TutorialTitle",
+			Other: "Tutorial ({{.step}}/{{.total}})",
+		}, &i18n.Message{
+			ID:    "TutorialPromptTitle",
+			Other: "Tutorial",
+		}, &i18n.Message{
+			ID:    "TutorialPrompt",
+			Other: "Looks like this is your first time using lazygit. Would you like a quick tutorial? It runs in a throwaway repo so your own repos won't be touched.\n\nYou can start or leave the tutorial at any time by pressing {{.tutorialKey}} in the status panel.",
+		}, &i18n.Message{
+			ID:    "TutorialStageStep",
+			Other: "This is a throwaway repo for you to practice on. Let's start with staging: select a file in the files panel and press {{.selectKey}} to stage it.",
+		}, &i18n.Message{
+			ID:    "TutorialCommitStep",
+			Other: "Nice! Now press {{.commitKey}} in the files panel to commit your staged changes.",
+		}, &i18n.Message{
+			ID:    "TutorialBranchStep",
+			Other: "Now let's make a branch. Go to the branches panel and press {{.newKey}} to create a new branch.",
+		}, &i18n.Message{
+			ID:    "TutorialRebaseStep",
+			Other: "Time to rebase. In the branches panel, select the 'feature' branch and press {{.rebaseKey}} to rebase your new branch onto it.",
+		}, &i18n.Message{
+			ID:    "TutorialUndoStep",
+			Other: "Finally, let's undo that rebase. In the commits panel press {{.nextTabKey}} to get to the reflog, select the entry from before the rebase, and press {{.resetKey}} to reset to it.",
+		}, &i18n.Message{
+			ID:    "TutorialFinished",
+			Other: "That's the tutorial done! Press {{.tutorialKey}} in the status panel to return to your repo. The tutorial repo will be deleted.",
+		}, &i18n.Message{
+			ID:    "LeaveTutorialTitle",
+			Other: "Leave tutorial",
+		}, &i18n.Message{
+			ID:    "LeaveTutorialPrompt",
+			Other: "Are you sure you want to leave the tutorial? The tutorial repo will be deleted.",
+		}, &i18n.Message{
+			ID:    "toggleSandbox",
+			Other: "enter/leave sandbox",
+		}, &i18n.Message{
+			ID:    "sandbox",
+			Other: "sandbox",
+		}, &i18n.Message{
+			ID:    "SandboxNeedsBranch",
+			Other: "You need to have a branch checked out to use the sandbox",
+		}, &i18n.Message{
+			ID:    "SandboxUnavailableInTutorial",
+			Other: "The sandbox isn't available during the tutorial",
+		}, &i18n.Message{
+			ID:    "TutorialUnavailableInSandbox",
+			Other: "Leave the sandbox before starting the tutorial",
+		}, &i18n.Message{
+			ID:    "LeaveSandbox",
+			Other: "Leave sandbox",
+		}, &i18n.Message{
+			ID:    "ApplySandbox",
+			Other: "update '{{.branchName}}' to match the sandbox",
+		}, &i18n.Message{
+			ID:    "ApplySandboxPrompt",
+			Other: "This will reset '{{.branchName}}' in your real repo to wherever it ended up in the sandbox. Changes to other branches in the sandbox will be discarded. Continue?",
+		}, &i18n.Message{
+			ID:    "DiscardSandbox",
+			Other: "discard the sandbox",
+		}, &i18n.Message{
+			ID:    "BranchMovedSinceSandbox",
+			Other: "'{{.branchName}}' has changed in your real repo since the sandbox was created, so the sandbox can't be applied",
+		}, &i18n.Message{
+			ID:    "openCommitInBrowser",
+			Other: "open commit in browser",
+		}, &i18n.Message{
+			ID:    "GitFlowNotEnabled",
+			Other: "git-flow hasn't been initialised in this repo. Run 'git flow init', or set git.flow.enabled to true in your config",
+		}, &i18n.Message{
+			ID:    "GitFlowFinishingStatus",
+			Other: "finishing",
+		}, &i18n.Message{
+			ID:    "StackOptions",
+			Other: "Stack options",
+		}, &i18n.Message{
+			ID:    "viewStackOptions",
+			Other: "view stacked branch options",
+		}, &i18n.Message{
+			ID:    "NewStackedBranch",
+			Other: "new branch stacked on '{{.branchName}}'",
+		}, &i18n.Message{
+			ID:    "StackBranchOn",
+			Other: "stack '{{.branchName}}' on '{{.parentName}}'",
+		}, &i18n.Message{
+			ID:    "UnstackBranch",
+			Other: "unstack '{{.branchName}}'",
+		}, &i18n.Message{
+			ID:    "RestackBranches",
+			Other: "restack: rebase each branch onto its parent",
+		}, &i18n.Message{
+			ID:    "PushStack",
+			Other: "force push every branch in the stack",
+		}, &i18n.Message{
+			ID:    "RestackingStatus",
+			Other: "restacking",
+		}, &i18n.Message{
+			ID:    "toggleSparseIndex",
+			Other: "toggle sparse index",
+		}, &i18n.Message{
+			ID:    "SparseIndexNeedsCone",
+			Other: "The sparse index only works with a cone-mode sparse checkout. Set one up with 'git sparse-checkout init --cone' first",
+		}, &i18n.Message{
+			ID:    "sparseIndex",
+			Other: "sparse index",
+		}, &i18n.Message{
+			ID:    "updatingIndexStatus",
+			Other: "updating index",
+		}, &i18n.Message{
+			ID:    "WritingCommitGraphStatus",
+			Other: "writing commit-graph",
+		}, &i18n.Message{
+			ID:    "viewStatusSettings",
+			Other: "view status performance settings",
+		}, &i18n.Message{
+			ID:    "StatusSettingsTitle",
+			Other: "Status performance (last refresh took {{.duration}})",
+		}, &i18n.Message{
+			ID:    "UntrackedCache",
+			Other: "untracked cache (core.untrackedCache)",
+		}, &i18n.Message{
+			ID:    "ShowUntrackedFiles",
+			Other: "show untracked files (status.showUntrackedFiles)",
+		}, &i18n.Message{
+			ID:    "ManyFilesMode",
+			Other: "many files mode (feature.manyFiles)",
+		}, &i18n.Message{
+			ID:    "on",
+			Other: "on",
+		}, &i18n.Message{
+			ID:    "off",
+			Other: "off",
+		}, &i18n.Message{
+			ID:    "MovePatchToCommitTitle",
+			Other: "Move patch into commit",
+		}, &i18n.Message{
+			ID:    "EditHunk",
+			Other: "edit hunk in editor",
+		}, &i18n.Message{
+			ID:    "EditHunkInstructions",
+			Other: "# Edit the hunk above, then save and close the file to apply it.\n# To leave a '-' line out, make it a ' ' line (context).\n# To leave a '+' line out, delete it.\n# Lines starting with # will be removed.\n# If you remove every change, nothing will be applied.",
+		}, &i18n.Message{
+			ID:    "RestoreDiscardedFile",
+			Other: "restore discarded file",
+		}, &i18n.Message{
+			ID:    "RestoreBackupTitle",
+			Other: "Restore discarded file",
+		}, &i18n.Message{
+			ID:    "NoBackups",
+			Other: "There are no backed up files to restore. Set gui.backupDiscardedFiles to true in your config to back files up before discarding their changes",
+		}, &i18n.Message{
+			ID:    "FilterFiles",
+			Other: "filter files",
+		}, &i18n.Message{
+			ID:    "FilterFilesPrompt",
+			Other: "Filter files (globs, is:staged, is:unstaged, is:untracked, is:conflicted):",
+		}, &i18n.Message{
+			ID:    "FilteredFilesTitle",
+			Other: "Files ({{.count}} of {{.total}} match '{{.filter}}')",
+		}, &i18n.Message{
+			ID:    "StageByPattern",
+			Other: "stage files by pattern",
+		}, &i18n.Message{
+			ID:    "StageByPatternPrompt",
+			Other: "Stage files matching (a glob, or a regex wrapped in slashes):",
+		}, &i18n.Message{
+			ID:    "StageByPatternConfirmation",
+			Other: "Stage these {{.count}} files?",
+		}, &i18n.Message{
+			ID:    "AndMoreFiles",
+			Other: "...and {{.count}} more",
+		}, &i18n.Message{
+			ID:    "NoFilesMatchPattern",
+			Other: "No unstaged files match that pattern",
+		}, &i18n.Message{
+			ID:    "viewStashFiles",
+			Other: "view stash files",
+		}, &i18n.Message{
+			ID:    "ViewStashUntrackedFiles",
+			Other: "view untracked files",
+		}, &i18n.Message{
+			ID:    "StashUntrackedFilesTitle",
+			Other: "Stash (untracked files)",
+		}, &i18n.Message{
+			ID:    "ApplyStashFile",
+			Other: "apply file",
+		}, &i18n.Message{
+			ID:    "PopStashFile",
+			Other: "pop file (apply it and take it out of the stash)",
+		}, &i18n.Message{
+			ID:    "ApplyStashFileHunks",
+			Other: "apply some of the file's changes (edit the patch in your editor)",
+		}, &i18n.Message{
+			ID:    "NotUnstashing",
+			Other: "You are not currently resolving conflicts from a stash",
+		}, &i18n.Message{
+			ID:    "StashConflictTitle",
+			Other: "Conflicts applying '{{.stashName}}'",
+		}, &i18n.Message{
+			ID:    "UnstashingFilesTitle",
+			Other: "(unstashing '{{.stashName}}')",
+		}, &i18n.Message{
+			ID:    "FinishUnstashingKeepStash",
+			Other: "finish: keep the stash entry",
+		}, &i18n.Message{
+			ID:    "FinishUnstashingDropStash",
+			Other: "finish: drop the stash entry",
+		}, &i18n.Message{
+			ID:    "AbortUnstashing",
+			Other: "abort and restore files",
+		}, &i18n.Message{
+			ID:    "ResolveStashConflictsFirst",
+			Other: "Resolve the stash's conflicts before finishing",
+		}, &i18n.Message{
+			ID:    "FinishUnstashingTitle",
+			Other: "Finish applying stash",
+		}, &i18n.Message{
+			ID:    "StashConflictsResolved",
+			Other: "All of the stash's conflicts are resolved. Finish applying it?",
+		}, &i18n.Message{
+			ID:    "ReflogTitle",
+			Other: "Reflog",
+		}, &i18n.Message{
+			ID:    "FilteredReflogTitle",
+			Other: "Reflog ({{.count}} of {{.total}} match '{{.filter}}')",
+		}, &i18n.Message{
+			ID:    "FilterReflog",
+			Other: "filter reflog",
+		}, &i18n.Message{
+			ID:    "FilterReflogPrompt",
+			Other: "Filter reflog (is:checkout, is:reset, is:rebase, is:commit, is:merge, is:pull, is:cherry-pick, since:2020-03-01, until:2w):",
+		}, &i18n.Message{
+			ID:    "ToggleReflogGroup",
+			Other: "expand/collapse rebase steps",
+		}, &i18n.Message{
+			ID:    "CommitsTabTitle",
+			Other: "Commits",
+		}, &i18n.Message{
+			ID:    "FilteredCommitsTitle",
+			Other: "Commits ({{.count}} of {{.total}} match '{{.filter}}')",
+		}, &i18n.Message{
+			ID:    "FilterCommits",
+			Other: "filter commits",
+		}, &i18n.Message{
+			ID:    "FilterCommitsPrompt",
+			Other: "Filter commits (author:name, path:dir/file, ahead:base-branch, words in the message):",
+		}, &i18n.Message{
+			ID:    "CantMoveCommitsWhileFiltered",
+			Other: "Commits can't be moved while the commits panel is filtered or hiding commits because of the log settings",
+		}, &i18n.Message{
+			ID:    "ExportPatches",
+			Other: "export copied commits as patches",
+		}, &i18n.Message{
+			ID:    "ExportPatchesPrompt",
+			Other: "Directory to write {{.count}} patch(es) to:",
+		}, &i18n.Message{
+			ID:    "CantChangeRebaseStep",
+			Other: "exec and break lines can only be removed from a rebase",
+		}, &i18n.Message{
+			ID:    "RebaseStoppedAtExecTitle",
+			Other: "Rebase options (stopped after 'exec {{.command}}')",
+		}, &i18n.Message{
+			ID:    "InsertRebaseStep",
+			Other: "run a command after commits / add exec or break to rebase",
+		}, &i18n.Message{
+			ID:    "RebaseStepsTitle",
+			Other: "Rebase steps",
+		}, &i18n.Message{
+			ID:    "InsertExecAfterTodo",
+			Other: "insert exec after this item",
+		}, &i18n.Message{
+			ID:    "InsertBreakAfterTodo",
+			Other: "insert break after this item",
+		}, &i18n.Message{
+			ID:    "ExecAfterCommit",
+			Other: "rebase, running a command after this commit",
+		}, &i18n.Message{
+			ID:    "ExecAfterEachCommit",
+			Other: "rebase, running a command after each commit from here",
+		}, &i18n.Message{
+			ID:    "RebaseExecPrompt",
+			Other: "Command to run (e.g. make test):",
+		}, &i18n.Message{
+			ID:    "CantRebaseWithExecMidRebase",
+			Other: "While rebasing or merging, exec and break lines can only be added after items in the rebase todo",
+		}, &i18n.Message{
+			ID:    "RebaseOntoTitle",
+			Other: "Rebase '{{.checkedOutBranch}}' onto '{{.selectedBranch}}'",
+		}, &i18n.Message{
+			ID:    "RebaseBranch",
+			Other: "rebase",
+		}, &i18n.Message{
+			ID:    "RebaseBranchUpdatingRefs",
+			Other: "rebase with --update-refs (also move branches stacked on top)",
+		}, &i18n.Message{
+			ID:    "UpdatedRefsTitle",
+			Other: "Branches updated",
+		}, &i18n.Message{
+			ID:    "UpdatedRefs",
+			Other: "The rebase also moved these branches:\n\n{{.branches}}",
+		}, &i18n.Message{
+			ID:    "ConflictsResolvedProgress",
+			Other: "conflicts: {{.resolved}} of {{.total}} files resolved",
+		}, &i18n.Message{
+			ID:    "ApplyingCommitProgress",
+			Other: "{{.action}} {{.current}}/{{.total}}: {{.commit}}",
+		}, &i18n.Message{
+			ID:    "ApplyingCommitQueued",
+			Other: "{{.action}} {{.commit}} ({{.queued}} more to come)",
+		}, &i18n.Message{
+			ID:    "ApplyingCommit",
+			Other: "{{.action}} {{.commit}}",
+		}, &i18n.Message{
+			ID:    "ConflictResolutionLine",
+			Other: "{{.file}}: +{{.oursAdded}} -{{.oursDeleted}} vs ours, +{{.theirsAdded}} -{{.theirsDeleted}} vs theirs",
+		}, &i18n.Message{
+			ID:    "ResolvedAsOurs",
+			Other: "(same as ours)",
+		}, &i18n.Message{
+			ID:    "ResolvedAsTheirs",
+			Other: "(same as theirs)",
+		}, &i18n.Message{
+			ID:    "ContinueWithResolutions",
+			Other: "Continue with these resolutions?",
+		}, &i18n.Message{
+			ID:    "NoConflictsToResolve",
+			Other: "There are no merge conflicts to resolve",
+		}, &i18n.Message{
+			ID:    "prevFileInMain",
+			Other: "jump to the previous file in the main panel",
+		}, &i18n.Message{
+			ID:    "nextFileInMain",
+			Other: "jump to the next file in the main panel",
+		}, &i18n.Message{
+			ID:    "searchMain",
+			Other: "search the main panel",
+		}, &i18n.Message{
+			ID:    "nextMatch",
+			Other: "next match",
+		}, &i18n.Message{
+			ID:    "prevMatch",
+			Other: "previous match",
+		}, &i18n.Message{
+			ID:    "exitMainSearch",
+			Other: "exit search",
+		}, &i18n.Message{
+			ID:    "prevHunkInMain",
+			Other: "jump to the previous hunk in the main panel",
+		}, &i18n.Message{
+			ID:    "nextHunkInMain",
+			Other: "jump to the next hunk in the main panel",
+		}, &i18n.Message{
+			ID:    "JumpToFileInMain",
+			Other: "jump to a file in the main panel",
+		}, &i18n.Message{
+			ID:    "NoFilesInMain",
+			Other: "There are no files in the main panel to jump to",
+		}, &i18n.Message{
+			ID:    "LineNumber",
+			Other: "line {{.line}}",
+		}, &i18n.Message{
+			ID:    "toggleFoldInMain",
+			Other: "fold/unfold the hunk or file at the top of the main panel",
+		}, &i18n.Message{
+			ID:    "foldAllInMain",
+			Other: "fold every file in the main panel",
+		}, &i18n.Message{
+			ID:    "unfoldAllInMain",
+			Other: "unfold everything in the main panel",
+		}, &i18n.Message{
+			ID:    "FoldedMarker",
+			Other: "(folded)",
+		}, &i18n.Message{
+			ID:    "jumpToStatFile",
+			Other: "jump to the diff of the file in the summary",
+		}, &i18n.Message{
+			ID:    "compareRefs",
+			Other: "compare two branches, tags or commits",
+		}, &i18n.Message{
+			ID:    "CompareFromPrompt",
+			Other: "Compare from (branch, tag or commit):",
+		}, &i18n.Message{
+			ID:    "CompareToPrompt",
+			Other: "Compare {{.from}} to (branch, tag or commit):",
+		}, &i18n.Message{
+			ID:    "NoRefsMatch",
+			Other: "No branches or tags match '{{.query}}'",
+		}, &i18n.Message{
+			ID:    "PickRefTitle",
+			Other: "Branches and tags matching '{{.query}}'",
+		}, &i18n.Message{
+			ID:    "ComparisonTitle",
+			Other: "Comparing {{.from}}..{{.to}}",
+		}, &i18n.Message{
+			ID:    "NotAvailableWhileComparing",
+			Other: "You can't do that while comparing refs. Press esc to go back to the commit's files",
+		}, &i18n.Message{
+			ID:    "ViewDirectoryOptions",
+			Other: "view options for the selected file's directories",
+		}, &i18n.Message{
+			ID:    "DirectoryOptionsTitle",
+			Other: "Directory",
+		}, &i18n.Message{
+			ID:    "ShowDirectoryLog",
+			Other: "show log for {{.dir}}",
+		}, &i18n.Message{
+			ID:    "DiffDirectoryAgainstRef",
+			Other: "diff {{.dir}} against a ref",
+		}, &i18n.Message{
+			ID:    "DiffDirectoryAgainstRefPrompt",
+			Other: "Diff {{.dir}} against (branch, tag or commit):",
+		}, &i18n.Message{
+			ID:    "DirectoryDiffTitle",
+			Other: "{{.dir}} against {{.ref}}",
+		}, &i18n.Message{
+			ID:    "FilterByAuthor",
+			Other: "only show commits by this commit's author",
+		}, &i18n.Message{
+			ID:    "FilterByPath",
+			Other: "only show commits touching this file",
+		}, &i18n.Message{
+			ID:    "TrailersMenu",
+			Other: "trailers",
+		}, &i18n.Message{
+			ID:    "AddTrailerToCommit",
+			Other: "add trailer to commit",
+		}, &i18n.Message{
+			ID:    "TrailerValuePrompt",
+			Other: "{{.key}}:",
+		}, &i18n.Message{
+			ID:    "rewordCommits",
+			Other: "reword copied commits (or the selected commit) in one rebase",
+		}, &i18n.Message{
+			ID:    "RewordCommitsPrompt",
+			Other: "Reword commit {{.sha}} ({{.number}} of {{.total}})",
+		}, &i18n.Message{
+			ID:    "testSigning",
+			Other: "test commit signing",
+		}, &i18n.Message{
+			ID:    "SigningReady",
+			Other: "signing: {{.signing}}",
+		}, &i18n.Message{
+			ID:    "SigningAgentUnreachable",
+			Other: "signing: {{.signing}}, agent unreachable",
+		}, &i18n.Message{
+			ID:    "toggleOfflineMode",
+			Other: "toggle offline mode",
+		}, &i18n.Message{
+			ID:    "OfflineModeOn",
+			Other: "Offline: pushes and fetches will be queued",
+		}, &i18n.Message{
+			ID:    "OfflineModeOff",
+			Other: "Back online",
+		}, &i18n.Message{
+			ID:    "ConnectivityRestored",
+			Other: "Remote reachable again, running queued operations",
+		}, &i18n.Message{
+			ID:    "QueuedWhileOffline",
+			Other: "Offline: queued {{.operation}}",
+		}, &i18n.Message{
+			ID:    "RunningQueuedOperation",
+			Other: "Running queued {{.operation}}",
+		}, &i18n.Message{
+			ID:    "offline",
+			Other: "offline",
+		}, &i18n.Message{
+			ID:    "offlineWithQueue",
+			Other: "offline, {{.count}} queued",
+		}, &i18n.Message{
+			ID:    "FetchRemoteOperation",
+			Other: "fetch from {{.remote}}",
+		}, &i18n.Message{
+			ID:    "PushOperation",
+			Other: "push of {{.branch}}",
+		}, &i18n.Message{
+			ID:    "PushTagOperation",
+			Other: "push of tag {{.tagName}}",
+		}, &i18n.Message{
+			ID:    "RetryingStatus",
+			Other: "Retrying {{.operation}} ({{.attempt}}/{{.attempts}})",
+		}, &i18n.Message{
+			ID:    "commitAndPush",
+			Other: "commit and push in the background",
+		}, &i18n.Message{
+			ID:    "PushedInBackground",
+			Other: "Pushed {{.branch}}",
+		}, &i18n.Message{
+			ID:    "NoUpstreamToPushTo",
+			Other: "Not pushing {{.branch}}: it has no upstream",
+		}, &i18n.Message{
+			ID:    "CherryPickPreviewTitle",
+			Other: "Cherry-pick {{.count}} commits onto {{.branch}}, oldest first",
+		}, &i18n.Message{
+			ID:    "CherryPickConfirm",
+			Other: "cherry-pick these commits (press a commit to drop it)",
+		}, &i18n.Message{
+			ID:    "ApplyMailbox",
+			Other: "apply patches from a mailbox (git am)",
+		}, &i18n.Message{
+			ID:    "ApplyMailboxPrompt",
+			Other: "Mailbox, Maildir or directory of patches to apply:",
+		}, &i18n.Message{
+			ID:    "ApplyingPatchesStatus",
+			Other: "applying patches",
+		}, &i18n.Message{
+			ID:    "MailboxOptionsTitle",
+			Other: "Applying patches",
+		}, &i18n.Message{
+			ID:    "MailboxPatchesTitle",
+			Other: "Patches",
+		}, &i18n.Message{
+			ID:    "MailboxPatchesSummary",
+			Other: "git am has applied {{.applied}} of {{.total}} patches",
+		}, &i18n.Message{
+			ID:    "MailboxPatch_applied",
+			Other: "applied",
+		}, &i18n.Message{
+			ID:    "MailboxPatch_current",
+			Other: "stopped",
+		}, &i18n.Message{
+			ID:    "MailboxPatch_pending",
+			Other: "pending",
+		}, &i18n.Message{
+			ID:    "reviewInWorktree",
+			Other: "review in a temporary worktree",
+		}, &i18n.Message{
+			ID:    "ReviewWorktreeTitle",
+			Other: "Review {{.ref}} in a temporary worktree",
+		}, &i18n.Message{
+			ID:    "ReviewInLazygit",
+			Other: "open it in lazygit",
+		}, &i18n.Message{
+			ID:    "ReviewInEditor",
+			Other: "open it in your editor",
+		}, &i18n.Message{
+			ID:    "ReviewWorktreeUnavailable",
+			Other: "You can't review a branch in a temporary worktree from the tutorial or sandbox",
+		}, &i18n.Message{
+			ID:    "LeaveReviewWorktree",
+			Other: "Finish reviewing",
+		}, &i18n.Message{
+			ID:    "LeaveReviewWorktreePrompt",
+			Other: "Finished reviewing {{.ref}}? The temporary worktree will be deleted.",
+		}, &i18n.Message{
+			ID:    "reviewing",
+			Other: "reviewing {{.ref}}",
+		}, &i18n.Message{
+			ID:    "createSnapshot",
+			Other: "snapshot the current branch and uncommitted changes",
+		}, &i18n.Message{
+			ID:    "viewSnapshots",
+			Other: "view snapshots",
+		}, &i18n.Message{
+			ID:    "SnapshotName",
+			Other: "Snapshot name:",
+		}, &i18n.Message{
+			ID:    "SnapshotCreated",
+			Other: "Snapshot created",
+		}, &i18n.Message{
+			ID:    "SnapshotAlreadyExists",
+			Other: "There's already a snapshot called {{.name}}",
+		}, &i18n.Message{
+			ID:    "NoSnapshots",
+			Other: "There are no snapshots",
+		}, &i18n.Message{
+			ID:    "Snapshots",
+			Other: "Snapshots",
+		}, &i18n.Message{
+			ID:    "SnapshotHasChanges",
+			Other: "+ uncommitted changes",
+		}, &i18n.Message{
+			ID:    "RestoreSnapshot",
+			Other: "restore snapshot",
+		}, &i18n.Message{
+			ID:    "RestoreSnapshotPrompt",
+			Other: "Are you sure you want to hard reset the checked out branch to {{.name}}? Any uncommitted changes will be lost.",
+		}, &i18n.Message{
+			ID:    "DiffAgainstSnapshot",
+			Other: "compare with HEAD",
+		}, &i18n.Message{
+			ID:    "DeleteSnapshot",
+			Other: "delete snapshot",
+		}, &i18n.Message{
+			ID:    "viewAutoSaves",
+			Other: "view autosaves of uncommitted changes",
+		}, &i18n.Message{
+			ID:    "NoAutoSaves",
+			Other: "There are no autosaves. Set git.autoSave.interval in your config to save your uncommitted changes every so often",
+		}, &i18n.Message{
+			ID:    "AutoSaves",
+			Other: "Autosaves",
+		}, &i18n.Message{
+			ID:    "RestoreAutoSave",
+			Other: "apply to working tree",
+		}, &i18n.Message{
+			ID:    "DiffAgainstAutoSave",
+			Other: "compare with HEAD",
+		}, &i18n.Message{
+			ID:    "viewIntroducedTodos",
+			Other: "list TODOs and FIXMEs added on this branch",
+		}, &i18n.Message{
+			ID:    "NoBaseBranch",
+			Other: "Couldn't work out which branch this one branched off. Set git.baseBranches in your config",
+		}, &i18n.Message{
+			ID:    "NoIntroducedTodos",
+			Other: "No TODOs or FIXMEs have been added since branching off {{.baseBranch}}",
+		}, &i18n.Message{
+			ID:    "IntroducedTodosTitle",
+			Other: "TODOs and FIXMEs added since {{.baseBranch}}",
+		}, &i18n.Message{
+			ID:    "LargeFilesTitle",
+			Other: "Large files",
+		}, &i18n.Message{
+			ID:    "LargeFilesPrompt",
+			Other: "These files are large or look like they belong in LFS:\n\n{{.files}}\n\nYou might want to track them with 'git lfs track' or add them to .gitignore. Continue anyway?",
+		}, &i18n.Message{
+			ID:    "MatchesLargeFilePattern",
+			Other: "matches {{.pattern}}",
+		}, &i18n.Message{
+			ID:    "PossibleSecretsTitle",
+			Other: "Possible secrets",
+		}, &i18n.Message{
+			ID:    "PossibleSecretsPrompt",
+			Other: "The staged changes look like they have secrets in them:\n\n{{.findings}}\n\nCommit anyway?",
+		}, &i18n.Message{
+			ID:    "SetCommitAuthor",
+			Other: "set author",
+		}, &i18n.Message{
+			ID:    "CommitAuthorPrompt",
+			Other: "Author (Name <email>):",
+		}, &i18n.Message{
+			ID:    "SetCommitDate",
+			Other: "set date",
+		}, &i18n.Message{
+			ID:    "CommitDatePrompt",
+			Other: "Date (e.g. 2006-01-02 15:04:05 -0700):",
+		}, &i18n.Message{
+			ID:    "UseStagedFilesModTime",
+			Other: "use the staged files' latest modification time as the date",
+		}, &i18n.Message{
+			ID:    "NoStagedFilesToTime",
+			Other: "None of the staged files are on disk to take a modification time from",
+		}, &i18n.Message{
+			ID:    "ClearCommitAuthorship",
+			Other: "commit as yourself, now",
+		}, &i18n.Message{
+			ID:    "CommitAuthorshipMenu",
+			Other: "Commit author and date",
+		}, &i18n.Message{
+			ID:    "CommitAuthorshipTitle",
+			Other: "as {{.overrides}}",
+		}, &i18n.Message{
+			ID:    "ResetCommitAuthor",
+			Other: "reset author to you and date to now",
+		}, &i18n.Message{
+			ID:    "CommitAuthorOptionsTitle",
+			Other: "Author of commit by {{.author}}",
+		}, &i18n.Message{
+			ID:    "ViewCommitAuthorOptions",
+			Other: "view author options",
+		}, &i18n.Message{
+			ID:    "SetAuthorOfCommitsSince",
+			Other: "change author of this commit and every commit after it",
+		}, &i18n.Message{
+			ID:    "SetAuthorOfCopiedCommits",
+			Other: "change author of the copied commits",
+		}, &i18n.Message{
+			ID:    "SetCommitsAuthor",
+			Other: "Change author",
+		}, &i18n.Message{
+			ID:    "SetCommitsAuthorPrompt",
+			Other: "This will rewrite {{.count}} commit(s) to be authored by {{.author}}. Every commit after the oldest of them gets a new sha, so the branch's history will be different to what anyone else has. Continue?",
+		}, &i18n.Message{
+			ID:    "SetPushedCommitsAuthorWarning",
+			Other: "WARNING: {{.count}} of these commits have already been pushed. You'll have to force push, and anyone who has pulled them will have to reset or rebase onto the rewritten history.",
+		}, &i18n.Message{
+			ID:    "CheckoutCommitFileIntoWorktree",
+			Other: "check out file at this version into the working tree",
+		}, &i18n.Message{
+			ID:    "RestoreCommitFileTitle",
+			Other: "Restore file",
+		}, &i18n.Message{
+			ID:    "RestoreCommitFilePrompt",
+			Other: "{{.file}} has unstaged changes which will be lost. Are you sure?",
+		}, &i18n.Message{
+			ID:    "DiffFileAgainstRef",
+			Other: "diff file against a ref",
+		}, &i18n.Message{
+			ID:    "DiffFileAgainstRefPrompt",
+			Other: "Diff {{.file}} against:",
+		}, &i18n.Message{
+			ID:    "FileRefDiffTitle",
+			Other: "Diff against {{.ref}}",
+		}, &i18n.Message{
+			ID:    "NoStagingWhileDiffingAgainstRef",
+			Other: "Can't stage lines while diffing against a ref. Press the same key again to go back to the usual diff first",
+		}, &i18n.Message{
+			ID:    "toggleMarkInMain",
+			Other: "mark/unmark the line at the top of the main panel",
+		}, &i18n.Message{
+			ID:    "JumpToMarkInMain",
+			Other: "Jump to a mark",
+		}, &i18n.Message{
+			ID:    "MarkedLineInMain",
+			Other: "Marked line {{.line}}",
+		}, &i18n.Message{
+			ID:    "UnmarkedLineInMain",
+			Other: "Unmarked line {{.line}}",
+		}, &i18n.Message{
+			ID:    "NoMarksInMain",
+			Other: "Nothing in the main panel is marked",
+		}, &i18n.Message{
+			ID:    "CustomCommandOutputTitle",
+			Other: "Where should the output go?",
+		}, &i18n.Message{
+			ID:    "CustomCommandOutput-terminal",
+			Other: "run it in the terminal",
+		}, &i18n.Message{
+			ID:    "CustomCommandOutput-popup",
+			Other: "stream the output into a popup, where it can be cancelled",
+		}, &i18n.Message{
+			ID:    "CustomCommandOutput-main",
+			Other: "stream the output into the main panel",
+		}, &i18n.Message{
+			ID:    "CustomCommandOutput-log",
+			Other: "run it in the background and keep the output in the custom command log",
+		}, &i18n.Message{
+			ID:    "CustomCommandOutput-none",
+			Other: "run it in the background and discard the output",
+		}, &i18n.Message{
+			ID:    "CustomCommandOutputMainTitle",
+			Other: "$ {{.command}}",
+		}, &i18n.Message{
+			ID:    "CustomCommandFinishedToast",
+			Other: "Finished: {{.command}}",
+		}, &i18n.Message{
+			ID:    "CustomCommandFailedToast",
+			Other: "Failed: {{.command}}",
+		}, &i18n.Message{
+			ID:    "NoCustomCommandLog",
+			Other: "No custom commands have been run with their output going to the log",
+		}, &i18n.Message{
+			ID:    "CustomCommandSucceeded",
+			Other: "succeeded",
+		}, &i18n.Message{
+			ID:    "CustomCommandFailed",
+			Other: "failed",
+		}, &i18n.Message{
+			ID:    "CustomCommandLogTitle",
+			Other: "Custom command log",
+		}, &i18n.Message{
+			ID:    "viewCustomCommandLog",
+			Other: "view custom command log",
+		}, &i18n.Message{
+			ID:    "CustomCommandAlreadyRunning",
+			Other: "A custom command is already streaming its output into a popup",
+		}, &i18n.Message{
+			ID:    "CustomCommandCancelled",
+			Other: "cancelled",
+		}, &i18n.Message{
+			ID:    "closeCustomCommandOutput",
+			Other: "close, cancelling the command if it's still running",
+		}, &i18n.Message{
+			ID:    "cancelCustomCommand",
+			Other: "cancel custom command",
+		}, &i18n.Message{
+			ID:    "RefreshingStatus",
+			Other: "refreshing",
+		}, &i18n.Message{
+			ID:    "FetchingStatus",
+			Other: "fetching",
+		}, &i18n.Message{
+			ID:    "RefreshedAgo",
+			Other: "{{.duration}} ago",
+		}, &i18n.Message{
+			ID:    "forceRefresh",
+			Other: "refresh everything, bypassing caches",
+		}, &i18n.Message{
+			ID:    "RepoChangedExternally",
+			Other: "The repository was changed by something else since it was last loaded, so nothing was done. It has been refreshed; check that you're still acting on what you meant to.",
+		}, &i18n.Message{
+			ID:    "readOnly",
+			Other: "read-only",
+		}, &i18n.Message{
+			ID:    "switchPairingDriver",
+			Other: "switch who's driving",
+		}, &i18n.Message{
+			ID:    "PairingDriverTitle",
+			Other: "Who's driving?",
+		}, &i18n.Message{
+			ID:    "NoPairingDriver",
+			Other: "nobody (work solo)",
+		}, &i18n.Message{
+			ID:    "NoPairsConfigured",
+			Other: "There is nobody to pair with. Add identities like 'Jane Doe <jane@example.com>' to git.commit.pairs in your config",
+		}, &i18n.Message{
+			ID:    "drivingStatus",
+			Other: "driving: {{.name}}",
+		}, &i18n.Message{
+			ID:    "RevertingStatus",
+			Other: "reverting",
+		}, &i18n.Message{
+			ID:    "RevertCommitsTitle",
+			Other: "Revert {{.count}} copied commits",
+		}, &i18n.Message{
+			ID:    "RevertCommitsSeparately",
+			Other: "revert each of the {{.count}} commits in its own commit",
+		}, &i18n.Message{
+			ID:    "RevertCommitsAsOne",
+			Other: "revert all {{.count}} commits in one commit",
+		}, &i18n.Message{
+			ID:    "RevertOptionsTitle",
+			Other: "Revert options",
+		}, &i18n.Message{
+			ID:    "squashBranch",
+			Other: "squash all commits since the base branch into one",
+		}, &i18n.Message{
+			ID:    "SquashBranch",
+			Other: "Squash branch",
+		}, &i18n.Message{
+			ID:    "SureSquashBranch",
+			Other: "Squash the {{.count}} commits on {{.branch}} since it branched off {{.baseBranch}} into one commit with this message? You can reword it afterwards.\n\n{{.message}}",
+		}, &i18n.Message{
+			ID:    "SquashBranchNotCheckedOut",
+			Other: "Check out the branch to squash it",
+		}, &i18n.Message{
+			ID:    "SquashBranchWithStagedChanges",
+			Other: "You have staged changes, which would end up in the squashed commit. Commit or unstage them first",
+		}, &i18n.Message{
+			ID:    "NothingToSquash",
+			Other: "There's nothing to squash: this branch has fewer than two commits since {{.baseBranch}}",
+		}, &i18n.Message{
+			ID:    "viewCommitsAheadOfBase",
+			Other: "only show the commits that aren't on the base branch",
+		}, &i18n.Message{
+			ID:    "ViewCommitsAheadNotCheckedOut",
+			Other: "Check out the branch to see its commits",
+		}, &i18n.Message{
+			ID:    "viewLostCommits",
+			Other: "recover dropped stashes and lost commits",
+		}, &i18n.Message{
+			ID:    "LostCommitsTitle",
+			Other: "Dropped stashes and lost commits",
+		}, &i18n.Message{
+			ID:    "LookingForLostCommitsStatus",
+			Other: "looking for lost commits",
+		}, &i18n.Message{
+			ID:    "NoLostCommits",
+			Other: "There are no dropped stashes or lost commits. Once git has garbage collected them they're gone for good",
+		}, &i18n.Message{
+			ID:    "LostCommit",
+			Other: "commit",
+		}, &i18n.Message{
+			ID:    "LostStash",
+			Other: "stash",
+		}, &i18n.Message{
+			ID:    "PreviewLostCommit",
+			Other: "show changes",
+		}, &i18n.Message{
+			ID:    "ApplyLostStash",
+			Other: "apply to the working tree",
+		}, &i18n.Message{
+			ID:    "StoreLostStash",
+			Other: "put back in the stash list",
+		}, &i18n.Message{
+			ID:    "CherryPickLostCommit",
+			Other: "cherry-pick onto the checked out branch",
+		}, &i18n.Message{
+			ID:    "CreateBranchAtLostCommit",
+			Other: "create a branch here",
+		}, &i18n.Message{
+			ID:    "viewAncestryOptions",
+			Other: "view ancestry options",
+		}, &i18n.Message{
+			ID:    "AncestryTitle",
+			Other: "Ancestry of {{.ref}}",
+		}, &i18n.Message{
+			ID:    "IsAncestorOf",
+			Other: "is {{.ref}} in the history of...",
+		}, &i18n.Message{
+			ID:    "HasAncestor",
+			Other: "is ... in the history of {{.ref}}",
+		}, &i18n.Message{
+			ID:    "MergeBaseWith",
+			Other: "where {{.ref}} diverged from... (merge base)",
+		}, &i18n.Message{
+			ID:    "IsAncestorOfPrompt",
+			Other: "Is {{.ref}} in the history of:",
+		}, &i18n.Message{
+			ID:    "HasAncestorPrompt",
+			Other: "Which commit should be in the history of {{.ref}}?",
+		}, &i18n.Message{
+			ID:    "MergeBaseWithPrompt",
+			Other: "Where did {{.ref}} diverge from:",
+		}, &i18n.Message{
+			ID:    "AncestryAnswerTitle",
+			Other: "Ancestry",
+		}, &i18n.Message{
+			ID:    "IsAncestor",
+			Other: "Yes: {{.ancestor}} is in the history of {{.descendant}}",
+		}, &i18n.Message{
+			ID:    "IsNotAncestor",
+			Other: "No: {{.ancestor}} is not in the history of {{.descendant}}",
+		}, &i18n.Message{
+			ID:    "MergeBaseIs",
+			Other: "{{.ref}} and {{.otherRef}} diverged at:\n\n{{.sha}} {{.name}}",
+		}, &i18n.Message{
+			ID:    "NoMergeBase",
+			Other: "{{.ref}} and {{.otherRef}} have no history in common",
+		}, &i18n.Message{
+			ID:    "viewLogSettings",
+			Other: "choose how much of the history to show, and in what order",
+		}, &i18n.Message{
+			ID:    "LogSettingsTitle",
+			Other: "Log settings for this repo",
+		}, &i18n.Message{
+			ID:    "ShowFirstParentOnly",
+			Other: "only follow the first parent of merges",
+		}, &i18n.Message{
+			ID:    "HideMergeCommits",
+			Other: "hide merge commits",
+		}, &i18n.Message{
+			ID:    "FirstParent",
+			Other: "first parent",
+		}, &i18n.Message{
+			ID:    "NoMerges",
+			Other: "no merges",
+		}, &i18n.Message{
+			ID:    "CommitOrder",
+			Other: "commit order",
+		}, &i18n.Message{
+			ID:    "CommitOrderTitle",
+			Other: "Sort commits by",
+		}, &i18n.Message{
+			ID:    "DefaultOrder",
+			Other: "commit date (git's default)",
+		}, &i18n.Message{
+			ID:    "DateOrder",
+			Other: "date order",
+		}, &i18n.Message{
+			ID:    "AuthorDateOrder",
+			Other: "author date order",
+		}, &i18n.Message{
+			ID:    "TopoOrder",
+			Other: "topo order",
+		}, &i18n.Message{
+			ID:    "toggleWrapInMain",
+			Other: "wrap/unwrap long lines in the main panel",
+		}, &i18n.Message{
+			ID:    "toggleWrapInCommitMessage",
+			Other: "wrap/unwrap long lines",
+		}, &i18n.Message{
+			ID:    "CantWrapWhileSelectingLines",
+			Other: "Lines can't be wrapped while selecting individual lines",
+		}, &i18n.Message{
+			ID:    "LineWrapOn",
+			Other: "Wrapping long lines",
+		}, &i18n.Message{
+			ID:    "LineWrapOff",
+			Other: "No longer wrapping long lines",
+		}, &i18n.Message{
+			ID:    "StageWholeFileWithoutRendering",
+			Other: "stage whole file without rendering it",
+		}, &i18n.Message{
+			ID:    "UnstageWholeFileWithoutRendering",
+			Other: "unstage whole file without rendering it",
+		}, &i18n.Message{
+			ID:    "OpenHugeDiffAnyway",
+			Other: "open in staging panel, a few lines at a time",
+		}, &i18n.Message{
+			ID:    "HugeDiffTitle",
+			Other: "This diff has {{.lines}} lines",
+		}, &i18n.Message{
+			ID:    "MoreDiffLines",
+			Other: "{{.count}} more lines, keep going down to load them",
+		}, &i18n.Message{
+			ID:    "WhitespaceOnly",
+			Other: "whitespace only",
+		}, &i18n.Message{
+			ID:    "viewWhitespaceOptions",
+			Other: "stage or discard around whitespace-only changes",
+		}, &i18n.Message{
+			ID:    "NoWhitespaceOnlyFiles",
+			Other: "No files have only whitespace changes",
+		}, &i18n.Message{
+			ID:    "StageAllExceptWhitespaceOnly",
+			Other: "stage all except the {{.count}} whitespace-only files",
+		}, &i18n.Message{
+			ID:    "DiscardWhitespaceOnlyChanges",
+			Other: "discard the changes to the {{.count}} whitespace-only files",
+		}, &i18n.Message{
+			ID:    "WhitespaceOptionsTitle",
+			Other: "Whitespace-only changes",
+		}, &i18n.Message{
+			ID:    "formatAndStage",
+			Other: "format file, then stage it",
+		}, &i18n.Message{
+			ID:    "CantFormatConflictedFile",
+			Other: "Resolve the file's conflicts before formatting it",
+		}, &i18n.Message{
+			ID:    "CantFormatDeletedFile",
+			Other: "There's nothing to format in a deleted file",
+		}, &i18n.Message{
+			ID:    "FormattingStatus",
+			Other: "formatting",
+		}, &i18n.Message{
+			ID:    "NoFormatterForFile",
+			Other: "None of the formatters in git.formatters match {{.file}}",
+		}, &i18n.Message{
+			ID:    "viewProjectCommands",
+			Other: "run project commands like the tests",
+		}, &i18n.Message{
+			ID:    "NoProjectCommands",
+			Other: "No project commands are configured. Add some under projectCommands in your config",
+		}, &i18n.Message{
+			ID:    "CancelProjectCommand",
+			Other: "cancel",
+		}, &i18n.Message{
+			ID:    "ShowProjectCommandOutput",
+			Other: "show output of {{.name}}",
+		}, &i18n.Message{
+			ID:    "ProjectCommandsTitle",
+			Other: "Project commands",
+		}, &i18n.Message{
+			ID:    "RunningProjectCommand",
+			Other: "running {{.name}}",
+		}, &i18n.Message{
+			ID:    "ProjectCommandFailed",
+			Other: "{{.name}} failed",
+		}, &i18n.Message{
+			ID:    "ProjectCommandPassed",
+			Other: "{{.name}} passed",
+		}, &i18n.Message{
+			ID:    "ProjectCommandOutputTitle",
+			Other: "Output of {{.name}}",
+		}, &i18n.Message{
+			ID:    "ProjectCommandRunning",
+			Other: "running",
+		}, &i18n.Message{
+			ID:    "ProjectCommandFailedStatus",
+			Other: "failed",
+		}, &i18n.Message{
+			ID:    "ProjectCommandPassedStatus",
+			Other: "passed",
+		}, &i18n.Message{
+			ID:    "runCommandAtCommit",
+			Other: "run command at this commit",
+		}, &i18n.Message{
+			ID:    "RunAtCommitTitle",
+			Other: "Run at {{.sha}}",
+		}, &i18n.Message{
+			ID:    "RunAtCommitOtherCommand",
+			Other: "other command...",
+		}, &i18n.Message{
+			ID:    "RunAtCommitPrompt",
+			Other: "Command to run at {{.sha}}:",
+		}, &i18n.Message{
+			ID:    "RunAtCommitOutputTitle",
+			Other: "{{.command}} at {{.sha}}",
+		}, &i18n.Message{
+			ID:    "CreatingWorktreeStatus",
+			Other: "creating worktree",
+		}, &i18n.Message{
+			ID:    "bisectRun",
+			Other: "find the commit since this one that broke a command (bisect run)",
+		}, &i18n.Message{
+			ID:    "BisectRunTitle",
+			Other: "Bisect from {{.sha}}",
+		}, &i18n.Message{
+			ID:    "BisectRunPrompt",
+			Other: "Command that fails on bad commits, from {{.sha}} (good) to HEAD (bad):",
+		}, &i18n.Message{
+			ID:    "BisectRunOutputTitle",
+			Other: "Bisecting {{.command}} from {{.sha}}",
+		}, &i18n.Message{
+			ID:    "BisectRunFoundTitle",
+			Other: "First bad commit",
+		}, &i18n.Message{
+			ID:    "BisectRunFound",
+			Other: "{{.sha}} {{.name}} is the first bad commit. Jump to it?",
+		}, &i18n.Message{
+			ID:    "CommitNotInCommitsPanel",
+			Other: "That commit isn't in the commits panel",
+		}, &i18n.Message{
+			ID:    "runProjectCommand",
+			Other: "run {{.name}}",
+		}, &i18n.Message{
+			ID:    "TrustRepoConfigTitle",
+			Other: "Trust this repo's config?",
+		}, &i18n.Message{
+			ID:    "TrustRepoConfigPrompt",
+			Other: "This repo has its own lazygit config in {{.path}}, which can add project commands and keybindings. Its commands will run on your machine, so only trust it if you trust the repo. Load it? You can change your mind with {{.trustKey}} in the status panel",
+		}, &i18n.Message{
+			ID:    "TrustChangedRepoConfigPrompt",
+			Other: "This repo's lazygit config in {{.path}} has changed since you trusted it. Its commands will run on your machine, so check what's changed before loading it. Load it? You can change your mind with {{.trustKey}} in the status panel",
+		}, &i18n.Message{
+			ID:    "viewRepoConfigTrust",
+			Other: "trust or revoke trust in repo config files",
+		}, &i18n.Message{
+			ID:    "RepoConfigTrustTitle",
+			Other: "Repo config trust",
+		}, &i18n.Message{
+			ID:    "NoRepoConfigs",
+			Other: "This repo has no .lazygit.yml, and you've not trusted or refused any other repo's",
+		}, &i18n.Message{
+			ID:    "TrustRepoConfig",
+			Other: "trust and load it",
+		}, &i18n.Message{
+			ID:    "RevokeRepoConfigTrust",
+			Other: "revoke trust and unload it",
+		}, &i18n.Message{
+			ID:    "DenyRepoConfig",
+			Other: "never load it, and stop asking",
+		}, &i18n.Message{
+			ID:    "ForgetRepoConfig",
+			Other: "forget, and ask again next time",
+		}, &i18n.Message{
+			ID:    "RepoConfigTrusted",
+			Other: "trusted",
+		}, &i18n.Message{
+			ID:    "RepoConfigChanged",
+			Other: "changed since trusted",
+		}, &i18n.Message{
+			ID:    "RepoConfigDenied",
+			Other: "never loaded",
+		}, &i18n.Message{
+			ID:    "RepoConfigNotTrusted",
+			Other: "not trusted",
+		}, &i18n.Message{
+			ID:    "toggleGitTrace",
+			Other: "toggle tracing git commands (GIT_TRACE)",
+		}, &i18n.Message{
+			ID:    "GitTraceOn",
+			Other: "Tracing git commands. See the trace with {{.logKey}}",
+		}, &i18n.Message{
+			ID:    "GitTraceOff",
+			Other: "Stopped tracing git commands",
+		}, &i18n.Message{
+			ID:    "GitTraceHeading",
+			Other: "--- git trace ---",
+		}, &i18n.Message{
+			ID:    "EndOfOutput",
+			Other: "end of output",
+		}, &i18n.Message{
+			ID:    "AutoFetchFailed",
+			Other: "Background fetch failed: {{.error}}",
+		}, &i18n.Message{
+			ID:    "ViewSubmodulePins",
+			Other: "check out submodule at old/new pin",
+		}, &i18n.Message{
+			ID:    "SubmodulePinsTitle",
+			Other: "Check out {{.path}} at",
+		}, &i18n.Message{
+			ID:    "SubmoduleOldPin",
+			Other: "old pin",
+		}, &i18n.Message{
+			ID:    "SubmoduleNewPin",
+			Other: "new pin",
+		}, &i18n.Message{
+			ID:    "NotASubmodule",
+			Other: "This isn't a submodule",
+		}, &i18n.Message{
+			ID:    "NoSubmodulePins",
+			Other: "Couldn't find where this submodule is pinned",
+		}, &i18n.Message{
+			ID:    "CheckingOutStatus",
+			Other: "checking out",
+		}, &i18n.Message{
+			ID:    "CannotSquashWithoutEarlierStep",
+			Other: "{{.sha}} can't be squashed or fixed up into an earlier commit, because there isn't one left in the plan",
+		}, &i18n.Message{
+			ID:    "planRebase",
+			Other: "plan an interactive rebase down to this commit",
+		}, &i18n.Message{
+			ID:    "RebasePlanTitle",
+			Other: "Interactive rebase onto {{.sha}}",
+		}, &i18n.Message{
+			ID:    "RebasePlanRewordPrompt",
+			Other: "New message for {{.sha}}",
+		}, &i18n.Message{
+			ID:    "rebasePlanPick",
+			Other: "pick the commit",
+		}, &i18n.Message{
+			ID:    "rebasePlanSquash",
+			Other: "squash the commit into the one before it",
+		}, &i18n.Message{
+			ID:    "rebasePlanFixup",
+			Other: "fixup the commit into the one before it, dropping its message",
+		}, &i18n.Message{
+			ID:    "rebasePlanEdit",
+			Other: "stop at the commit to edit it",
+		}, &i18n.Message{
+			ID:    "rebasePlanDrop",
+			Other: "drop the commit",
+		}, &i18n.Message{
+			ID:    "rebasePlanReword",
+			Other: "reword the commit",
+		}, &i18n.Message{
+			ID:    "moveDownRebasePlanStep",
+			Other: "move the commit down",
+		}, &i18n.Message{
+			ID:    "moveUpRebasePlanStep",
+			Other: "move the commit up",
+		}, &i18n.Message{
+			ID:    "moveRebasePlanStep",
+			Other: "move",
+		}, &i18n.Message{
+			ID:    "startRebasePlan",
+			Other: "start the rebase",
+		}, &i18n.Message{
+			ID:    "cancelRebasePlan",
+			Other: "cancel the rebase",
+		}, &i18n.Message{
+			ID:    "RecurseSubmodules",
+			Other: "List submodules' files too",
+		}, &i18n.Message{
+			ID:    "NoLineStagingInSubmodule",
+			Other: "Files in submodules can only be staged whole",
 		},
 	)
 }
@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -13,12 +14,12 @@ import (
 )
 
 type Task struct {
-	stop          chan struct{}
+	cancel        context.CancelFunc
 	stopped       bool
 	stopMutex     sync.Mutex
 	notifyStopped chan struct{}
 	Log           *logrus.Entry
-	f             func(chan struct{}) error
+	f             func(context.Context) error
 }
 
 type ViewBufferManager struct {
@@ -30,25 +31,90 @@ type ViewBufferManager struct {
 	newTaskId    int
 	readLines    chan int
 
+	// pagingMutex guards readLines along with how far the current task has got
+	// through its output
+	pagingMutex    sync.Mutex
+	linesRequested int
+	linesEmitted   int
+	eof            bool
+
 	// beforeStart is the function that is called before starting a new task
 	beforeStart func()
 	refreshView func()
+	// onEOF is called once a command task has written the last of its output
+	onEOF func()
+}
+
+func NewViewBufferManager(log *logrus.Entry, writer io.Writer, beforeStart func(), refreshView func(), onEOF func()) *ViewBufferManager {
+	return &ViewBufferManager{Log: log, writer: writer, beforeStart: beforeStart, refreshView: refreshView, onEOF: onEOF, readLines: make(chan int, 1024)}
+}
+
+// RequestMoreLines asks the current task for another count lines of output.
+// Once it's written the last of it there's nothing more to ask for, so this
+// does nothing.
+func (m *ViewBufferManager) RequestMoreLines(count int) {
+	m.pagingMutex.Lock()
+	defer m.pagingMutex.Unlock()
+	m.requestMoreLines(count)
 }
 
-func NewViewBufferManager(log *logrus.Entry, writer io.Writer, beforeStart func(), refreshView func()) *ViewBufferManager {
-	return &ViewBufferManager{Log: log, writer: writer, beforeStart: beforeStart, refreshView: refreshView, readLines: make(chan int, 1024)}
+// RequestLinesUpTo asks for however many more lines it takes for the first n
+// lines of output to be written, e.g. so there's enough to fill the view at
+// its scroll position. Lines already asked for aren't asked for again.
+func (m *ViewBufferManager) RequestLinesUpTo(n int) {
+	m.pagingMutex.Lock()
+	defer m.pagingMutex.Unlock()
+	m.requestMoreLines(n - m.linesRequested)
 }
 
-func (m *ViewBufferManager) ReadLines(n int) {
+func (m *ViewBufferManager) requestMoreLines(count int) {
+	if m.eof || count <= 0 {
+		return
+	}
+	m.linesRequested += count
+	readLines := m.readLines
 	go func() {
-		m.readLines <- n
+		readLines <- count
 	}()
 }
 
-func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, linesToRead int, onDone func()) func(chan struct{}) error {
-	return func(stop chan struct{}) error {
+// LinesEmitted returns how many lines of output the current task has written
+func (m *ViewBufferManager) LinesEmitted() int {
+	m.pagingMutex.Lock()
+	defer m.pagingMutex.Unlock()
+	return m.linesEmitted
+}
+
+// ReachedEOF tells us whether the current task has written all of its output
+func (m *ViewBufferManager) ReachedEOF() bool {
+	m.pagingMutex.Lock()
+	defer m.pagingMutex.Unlock()
+	return m.eof
+}
+
+// resetPaging forgets how far the last task got, for a new one to start from
+// the top
+func (m *ViewBufferManager) resetPaging() {
+	m.pagingMutex.Lock()
+	defer m.pagingMutex.Unlock()
+	m.linesRequested = 0
+	m.linesEmitted = 0
+	m.eof = false
+}
+
+// NewCmdTask returns a task that starts a command with start and writes its
+// output to the view as it's asked for. start should tie the command to the
+// context it's given, so that it dies with the task. If processLine isn't nil
+// each line goes through it first, and lines it returns nil for are left out.
+func (m *ViewBufferManager) NewCmdTask(start func(context.Context) (*exec.Cmd, io.Reader, error), linesToRead int, onDone func(), processLine func([]byte) []byte) func(context.Context) error {
+	return func(ctx context.Context) error {
+		cmd, r, err := start(ctx)
+		if err != nil {
+			return err
+		}
+
 		go func() {
-			<-stop
+			<-ctx.Done()
 			if err := commands.Kill(cmd); err != nil {
 				m.Log.Warn(err)
 			}
@@ -59,8 +125,11 @@ func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, linesToRead i
 
 		loadingMutex := sync.Mutex{}
 
+		m.pagingMutex.Lock()
 		// not sure if it's the right move to redefine this or not
 		m.readLines = make(chan int, 1024)
+		readLines := m.readLines
+		m.pagingMutex.Unlock()
 
 		done := make(chan struct{})
 
@@ -82,7 +151,7 @@ func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, linesToRead i
 						m.refreshView()
 					}
 					loadingMutex.Unlock()
-				case <-stop:
+				case <-ctx.Done():
 					return
 				}
 			}()
@@ -90,7 +159,7 @@ func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, linesToRead i
 		outer:
 			for {
 				select {
-				case linesToRead := <-m.readLines:
+				case linesToRead := <-readLines:
 					for i := 0; i < linesToRead; i++ {
 						ok := scanner.Scan()
 						loadingMutex.Lock()
@@ -101,19 +170,37 @@ func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, linesToRead i
 						loadingMutex.Unlock()
 
 						select {
-						case <-stop:
+						case <-ctx.Done():
 							m.refreshView()
 							break outer
 						default:
 						}
 						if !ok {
+							m.pagingMutex.Lock()
+							m.eof = true
+							m.pagingMutex.Unlock()
 							m.refreshView()
+							if m.onEOF != nil {
+								m.onEOF()
+							}
 							break outer
 						}
-						_, _ = m.writer.Write(append(scanner.Bytes(), []byte("\n")...))
+						line := scanner.Bytes()
+						if processLine != nil {
+							line = processLine(line)
+							if line == nil {
+								// a line left out doesn't count towards the lines we were asked for
+								i--
+								continue
+							}
+						}
+						_, _ = m.writer.Write(append(line, []byte("\n")...))
+						m.pagingMutex.Lock()
+						m.linesEmitted++
+						m.pagingMutex.Unlock()
 					}
 					m.refreshView()
-				case <-stop:
+				case <-ctx.Done():
 					m.refreshView()
 					break outer
 				}
@@ -132,7 +219,7 @@ func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, linesToRead i
 			close(done)
 		}()
 
-		m.readLines <- linesToRead
+		m.RequestMoreLines(linesToRead)
 
 		<-done
 
@@ -140,6 +227,58 @@ func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, linesToRead i
 	}
 }
 
+// NewStreamingCmdTask returns a task that starts a command with start and
+// writes all of its output to the view as it comes, rather than as it's asked
+// for, for commands whose output should be followed while they run. Stopping
+// the task kills the command. onDone is passed the command's error once it's
+// finished, or the error starting it.
+func (m *ViewBufferManager) NewStreamingCmdTask(start func(context.Context) (*exec.Cmd, io.ReadCloser, error), onDone func(error)) func(context.Context) error {
+	return func(ctx context.Context) error {
+		cmd, r, err := start(ctx)
+		if err != nil {
+			if onDone != nil {
+				onDone(err)
+			}
+			return nil
+		}
+
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				if err := commands.Kill(cmd); err != nil {
+					m.Log.Warn(err)
+				}
+				// anything the command started may still have the pipe open
+				_ = r.Close()
+			case <-done:
+			}
+		}()
+
+		m.beforeStart()
+		m.refreshView()
+
+		// refreshing the view for every line would swamp it with a chatty command
+		lastRefresh := time.Now()
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			_, _ = m.writer.Write(append(scanner.Bytes(), '\n'))
+			if time.Since(lastRefresh) > time.Millisecond*50 {
+				m.refreshView()
+				lastRefresh = time.Now()
+			}
+		}
+
+		err = cmd.Wait()
+		close(done)
+		m.refreshView()
+		if onDone != nil {
+			onDone(err)
+		}
+		return nil
+	}
+}
+
 // Close closes the task manager, killing whatever task may currently be running
 func (t *ViewBufferManager) Close() {
 	if t.currentTask == nil {
@@ -165,7 +304,10 @@ func (t *ViewBufferManager) Close() {
 // 1) command based, where the manager can be asked to read more lines,  but the command can be killed
 // 2) string based, where the manager can also be asked to read more lines
 
-func (m *ViewBufferManager) NewTask(f func(stop chan struct{}) error) error {
+// NewTask stops the current task and starts f in its place, once any tasks
+// started before it have had their turn. The context f is given is cancelled
+// when it's stopped in turn.
+func (m *ViewBufferManager) NewTask(f func(ctx context.Context) error) error {
 	go func() {
 		m.taskIDMutex.Lock()
 		m.newTaskId++
@@ -182,7 +324,7 @@ func (m *ViewBufferManager) NewTask(f func(stop chan struct{}) error) error {
 			return
 		}
 
-		stop := make(chan struct{})
+		ctx, cancel := context.WithCancel(context.Background())
 		notifyStopped := make(chan struct{})
 
 		if m.currentTask != nil {
@@ -191,15 +333,17 @@ func (m *ViewBufferManager) NewTask(f func(stop chan struct{}) error) error {
 			m.Log.Info("task stopped")
 		}
 
+		m.resetPaging()
+
 		m.currentTask = &Task{
-			stop:          stop,
+			cancel:        cancel,
 			notifyStopped: notifyStopped,
 			Log:           m.Log,
 			f:             f,
 		}
 
 		go func() {
-			if err := f(stop); err != nil {
+			if err := f(ctx); err != nil {
 				m.Log.Error(err) // might need an onError callback
 			}
 
@@ -217,8 +361,8 @@ func (t *Task) Stop() {
 	if t.stopped {
 		return
 	}
-	close(t.stop)
-	t.Log.Info("closed stop channel, waiting for notifyStopped message")
+	t.cancel()
+	t.Log.Info("cancelled context, waiting for notifyStopped message")
 	<-t.notifyStopped
 	t.Log.Info("received notifystopped message")
 	t.stopped = true
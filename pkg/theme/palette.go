@@ -0,0 +1,102 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Palette maps the things we convey with color onto actual colors, so that
+// users who can't tell red from green (or who just want more contrast) can
+// still tell added lines from removed lines, staged files from unstaged files,
+// and so on. Anything that carries meaning through its color should get that
+// color from here.
+type Palette struct {
+	Added    color.Attribute
+	Removed  color.Attribute
+	Conflict color.Attribute
+	Warning  color.Attribute
+	Info     color.Attribute
+	// IncludedLineBg is the background of lines included in a custom patch
+	IncludedLineBg color.Attribute
+	// SelectedLineBg overrides gui.theme.selectedLineBgColor when set
+	SelectedLineBg color.Attribute
+	// GitAdded and GitRemoved are passed to git as color.diff.new and
+	// color.diff.old so that the diffs git renders for us match. When empty we
+	// leave the user's git config alone.
+	GitAdded   string
+	GitRemoved string
+}
+
+// Palettes are the palettes the user can choose from via gui.theme.palette
+var Palettes = map[string]Palette{
+	"default": {
+		Added:          color.FgGreen,
+		Removed:        color.FgRed,
+		Conflict:       color.FgRed,
+		Warning:        color.FgYellow,
+		Info:           color.FgCyan,
+		IncludedLineBg: color.BgGreen,
+	},
+	// deuteranopes have trouble with green, so we use blue in its place
+	"deuteranopia": {
+		Added:          color.FgBlue,
+		Removed:        color.FgRed,
+		Conflict:       color.FgMagenta,
+		Warning:        color.FgYellow,
+		Info:           color.FgCyan,
+		IncludedLineBg: color.BgBlue,
+		GitAdded:       "blue",
+		GitRemoved:     "red",
+	},
+	// protanopes see red as dark and murky, so we avoid it altogether
+	"protanopia": {
+		Added:          color.FgBlue,
+		Removed:        color.FgYellow,
+		Conflict:       color.FgMagenta,
+		Warning:        color.FgHiWhite,
+		Info:           color.FgCyan,
+		IncludedLineBg: color.BgBlue,
+		GitAdded:       "blue",
+		GitRemoved:     "yellow",
+	},
+	"highContrast": {
+		Added:          color.FgHiGreen,
+		Removed:        color.FgHiRed,
+		Conflict:       color.FgHiMagenta,
+		Warning:        color.FgHiYellow,
+		Info:           color.FgHiCyan,
+		IncludedLineBg: color.BgHiGreen,
+		SelectedLineBg: color.ReverseVideo,
+		GitAdded:       "bold brightgreen",
+		GitRemoved:     "bold brightred",
+	},
+}
+
+// CurrentPalette is the palette chosen in the user's config
+var CurrentPalette = Palettes["default"]
+
+var originalGitConfigParameters = os.Getenv("GIT_CONFIG_PARAMETERS")
+
+// updateGitColors passes our palette's diff colors on to every git command we
+// run (and every git command those commands run) via the environment
+func updateGitColors() {
+	gitConfigParameters := []string{}
+	if originalGitConfigParameters != "" {
+		gitConfigParameters = append(gitConfigParameters, originalGitConfigParameters)
+	}
+	if CurrentPalette.GitAdded != "" {
+		gitConfigParameters = append(gitConfigParameters, fmt.Sprintf("'color.diff.new=%s'", CurrentPalette.GitAdded))
+	}
+	if CurrentPalette.GitRemoved != "" {
+		gitConfigParameters = append(gitConfigParameters, fmt.Sprintf("'color.diff.old=%s'", CurrentPalette.GitRemoved))
+	}
+
+	if len(gitConfigParameters) == 0 {
+		_ = os.Unsetenv("GIT_CONFIG_PARAMETERS")
+		return
+	}
+	_ = os.Setenv("GIT_CONFIG_PARAMETERS", strings.Join(gitConfigParameters, " "))
+}
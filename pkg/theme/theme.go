@@ -37,6 +37,16 @@ func UpdateTheme(userConfig *viper.Viper) {
 	OptionsColor = GetGocuiColor(userConfig.GetStringSlice("gui.theme.optionsTextColor"))
 	OptionsFgColor = GetFgColor(userConfig.GetStringSlice("gui.theme.optionsTextColor"))
 
+	palette, ok := Palettes[userConfig.GetString("gui.theme.palette")]
+	if !ok {
+		palette = Palettes["default"]
+	}
+	CurrentPalette = palette
+	if palette.SelectedLineBg != 0 {
+		SelectedLineBgColor = palette.SelectedLineBg
+	}
+	updateGitColors()
+
 	isLightTheme := userConfig.GetBool("gui.theme.lightTheme")
 	if isLightTheme {
 		DefaultTextColor = color.FgBlack
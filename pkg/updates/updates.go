@@ -52,13 +52,18 @@ func NewUpdater(log *logrus.Entry, config config.AppConfigurer, osCommand *comma
 }
 
 func (u *Updater) getLatestVersionNumber() (string, error) {
-	req, err := http.NewRequest("GET", PROJECT_URL+"/releases/latest", nil)
+	latestURL := PROJECT_URL + "/releases/latest"
+	req, err := http.NewRequest("GET", latestURL, nil)
 	if err != nil {
 		return "", err
 	}
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	client, err := u.OSCommand.NewVerifyingHTTPClient()
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -267,7 +272,11 @@ func (u *Updater) downloadAndInstall(rawUrl string) error {
 	defer out.Close()
 
 	// Get the data
-	resp, err := http.Get(rawUrl)
+	client, err := u.OSCommand.NewVerifyingHTTPClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.Get(rawUrl)
 	if err != nil {
 		return err
 	}
@@ -307,7 +316,12 @@ func (u *Updater) downloadAndInstall(rawUrl string) error {
 }
 
 func (u *Updater) verifyResourceFound(rawUrl string) bool {
-	resp, err := http.Head(rawUrl)
+	client, err := u.OSCommand.NewVerifyingHTTPClient()
+	if err != nil {
+		u.Log.Error(err)
+		return false
+	}
+	resp, err := client.Head(rawUrl)
 	if err != nil {
 		return false
 	}
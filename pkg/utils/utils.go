@@ -316,3 +316,79 @@ func TruncateWithEllipsis(str string, limit int) string {
 	remainingLength := limit - len(ellipsis)
 	return str[0:remainingLength] + "..."
 }
+
+// MatchesGlob tells us whether a path matches a glob pattern, where '*' and '?'
+// don't match across path separators but '**' does, so that 'src/**' matches
+// everything under src
+func MatchesGlob(pattern string, path string) bool {
+	var regexStr strings.Builder
+	regexStr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch char := pattern[i]; char {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				regexStr.WriteString(".*")
+				i++
+			} else {
+				regexStr.WriteString("[^/]*")
+			}
+		case '?':
+			regexStr.WriteString("[^/]")
+		default:
+			regexStr.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	regexStr.WriteString("$")
+
+	matched, err := regexp.MatchString(regexStr.String(), filepath.ToSlash(path))
+	return err == nil && matched
+}
+
+// FuzzyFilter returns the candidates that have the characters of the pattern
+// in order, ignoring case. Candidates with the pattern as a whole come first,
+// otherwise the order is kept.
+func FuzzyFilter(pattern string, candidates []string) []string {
+	pattern = strings.ToLower(pattern)
+	exact := []string{}
+	fuzzy := []string{}
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		if strings.Contains(lower, pattern) {
+			exact = append(exact, candidate)
+		} else if isSubsequence(pattern, lower) {
+			fuzzy = append(fuzzy, candidate)
+		}
+	}
+	return append(exact, fuzzy...)
+}
+
+func isSubsequence(pattern string, str string) bool {
+	remaining := []rune(pattern)
+	for _, r := range str {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
+
+// FormatFileSize returns a size in bytes in the largest unit that keeps it at
+// one or more, e.g. 1.5 MB
+func FormatFileSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	units := []string{"KB", "MB", "GB", "TB"}
+	unit := ""
+	for _, unit = range units {
+		value /= 1024
+		if value < 1024 {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f %s", value, unit)
+}
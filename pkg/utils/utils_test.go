@@ -413,3 +413,72 @@ func TestAsJson(t *testing.T) {
 	// no idea why this is returning empty hashes but it's works in the app ¯\_(ツ)_/¯
 	assert.EqualValues(t, "{}", output)
 }
+
+// TestMatchesGlob is a function.
+func TestMatchesGlob(t *testing.T) {
+	type scenario struct {
+		pattern  string
+		path     string
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/main.go", false},
+		{"**/*.go", "pkg/gui/gui.go", true},
+		{"src/**", "src/a/b/c.txt", true},
+		{"src/**", "lib/a.txt", false},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		{"/home/me/work/*", "/home/me/work/project", true},
+		{"/home/me/work/*", "/home/me/work/project/sub", false},
+		{"a+b.txt", "a+b.txt", true},
+	}
+
+	for _, s := range scenarios {
+		assert.EqualValues(t, s.expected, MatchesGlob(s.pattern, s.path), s.pattern+" "+s.path)
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	type scenario struct {
+		pattern  string
+		expected []string
+	}
+
+	candidates := []string{"master", "feature/login", "origin/master", "v1.0", "fix-lint"}
+
+	scenarios := []scenario{
+		{"master", []string{"master", "origin/master"}},
+		{"flg", []string{"feature/login"}},
+		{"li", []string{"fix-lint", "feature/login"}},
+		{"MAS", []string{"master", "origin/master"}},
+		{"omr", []string{"origin/master"}},
+		{"zzz", []string{}},
+		{"", candidates},
+	}
+
+	for _, s := range scenarios {
+		assert.EqualValues(t, s.expected, FuzzyFilter(s.pattern, candidates), s.pattern)
+	}
+}
+
+// TestFormatFileSize is a function.
+func TestFormatFileSize(t *testing.T) {
+	type scenario struct {
+		size     int64
+		expected string
+	}
+
+	scenarios := []scenario{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KB"},
+		{200 * 1024 * 1024, "200.0 MB"},
+		{3 * 1024 * 1024 * 1024 * 1024 * 1024, "3072.0 TB"},
+	}
+
+	for _, s := range scenarios {
+		assert.EqualValues(t, s.expected, FormatFileSize(s.size))
+	}
+}